@@ -0,0 +1,6 @@
+package request
+
+// RefreshTokenRequest 刷新令牌请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"` // 刷新令牌
+}
@@ -0,0 +1,52 @@
+package request
+
+import "org.thinkinai.com/recruit-center/internal/model"
+
+// CompanyRequest 创建/更新公司请求
+type CompanyRequest struct {
+	Name         string `json:"name" binding:"required,max=100"`                // 公司名称
+	ShortName    string `json:"shortName" binding:"omitempty,max=50"`           // 公司简称
+	Logo         string `json:"logo" binding:"omitempty,max=255"`               // 公司Logo
+	Industry     string `json:"industry" binding:"omitempty,max=50"`            // 所属行业
+	Scale        string `json:"scale" binding:"omitempty,max=50"`               // 公司规模
+	Website      string `json:"website" binding:"omitempty,max=255"`            // 公司官网
+	Address      string `json:"address" binding:"omitempty,max=255"`            // 公司地址
+	Description  string `json:"description"`                                    // 公司介绍
+	ContactEmail string `json:"contactEmail" binding:"omitempty,email,max=100"` // 联系邮箱
+	ContactPhone string `json:"contactPhone" binding:"omitempty,max=20"`        // 联系电话
+	CreditCode   string `json:"creditCode" binding:"omitempty,len=18"`          // 统一社会信用代码
+}
+
+// CompanyMemberAddRequest 添加公司成员请求
+type CompanyMemberAddRequest struct {
+	UserID uint   `json:"userId" binding:"required"`                            // 用户ID
+	Role   string `json:"role" binding:"required,oneof=owner recruiter viewer"` // 成员角色
+}
+
+// CompanyMemberRoleRequest 修改成员角色请求
+type CompanyMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner recruiter viewer"` // 成员角色
+}
+
+// CompanyVerifyRequest 公司认证审核请求
+type CompanyVerifyRequest struct {
+	Status int    `json:"status" binding:"oneof=0 1 2"`       // 认证状态 0: 待认证 1: 已认证 2: 认证驳回
+	Remark string `json:"remark" binding:"omitempty,max=255"` // 审核备注
+}
+
+// ToModel 转换为公司模型
+func (r *CompanyRequest) ToModel() *model.Company {
+	return &model.Company{
+		Name:         r.Name,
+		ShortName:    r.ShortName,
+		Logo:         r.Logo,
+		Industry:     r.Industry,
+		Scale:        r.Scale,
+		Website:      r.Website,
+		Address:      r.Address,
+		Description:  r.Description,
+		ContactEmail: r.ContactEmail,
+		ContactPhone: r.ContactPhone,
+		CreditCode:   r.CreditCode,
+	}
+}
@@ -0,0 +1,21 @@
+package request
+
+import "time"
+
+// InterviewRequest 安排或修改面试请求
+type InterviewRequest struct {
+	Round          int       `json:"round" binding:"omitempty,min=1,max=20"`                   // 面试轮次，为空时自动取下一轮
+	Title          string    `json:"title" binding:"omitempty,max=100"`                        // 面试标题
+	Mode           string    `json:"mode" binding:"required,oneof=onsite video phone"`         // 面试方式
+	StartTime      time.Time `json:"startTime" binding:"required"`                             // 开始时间
+	EndTime        time.Time `json:"endTime" binding:"required"`                               // 结束时间
+	Location       string    `json:"location" binding:"omitempty,max=255"`                     // 面试地点，现场面试必填
+	MeetingURL     string    `json:"meetingUrl" binding:"omitempty,url,max=500"`               // 视频会议链接，视频面试必填
+	Remark         string    `json:"remark" binding:"omitempty,max=500"`                       // 面试说明
+	InterviewerIDs []uint    `json:"interviewerIds" binding:"required,min=1,max=10,dive,gt=0"` // 面试官用户ID
+}
+
+// InterviewCancelRequest 取消面试请求
+type InterviewCancelRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=255"` // 取消原因
+}
@@ -1,24 +1,37 @@
 package request
 
 import (
+	"fmt"
 	"time"
 
 	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 // JobApplyRequest 职位申请请求
 type JobApplyRequest struct {
-	JobID    uint `json:"jobId" binding:"required"`
-	UserID   uint `json:"userId" binding:"required"`
-	ResumeID uint `json:"resumeId" binding:"required"`
+	JobID    uint                     `json:"jobId" binding:"required"`
+	UserID   uint                     `json:"userId" binding:"required"`
+	ResumeID uint                     `json:"resumeId" binding:"required"`
+	Answers  []ScreeningAnswerRequest `json:"answers" binding:"omitempty,max=20,dive"` // 职位筛选问题的回答
 }
 
+// JobApplyUpdateStatus 申请状态流转请求
 type JobApplyUpdateStatus struct {
 	Status enums.JobApplyEnum `json:"status" binding:"required"`
-	JobID  uint               `json:"jobId" binding:"required"`
-	UsID   uint               `json:"userId" binding:"required"`
-	Reason string             `json:"reason"`
+	JobID  uint               `json:"jobId"`
+	UsID   uint               `json:"userId"`
+	Reason string             `json:"reason" binding:"omitempty,max=255"` // 流转原因，如拒绝原因
+	Note   string             `json:"note" binding:"omitempty,max=2000"`  // 备注，记录在申请时间线中
+}
+
+// JobApplyBulkStatusRequest 批量流转申请状态请求
+type JobApplyBulkStatusRequest struct {
+	IDs    []uint             `json:"ids" binding:"required,min=1,max=200,dive,gt=0"` // 申请ID列表，单次最多200条
+	Status enums.JobApplyEnum `json:"status" binding:"required"`                      // 目标状态
+	Reason string             `json:"reason" binding:"omitempty,max=255"`             // 流转原因，如拒绝原因
+	Note   string             `json:"note" binding:"omitempty,max=2000"`              // 备注，记录在每条申请的时间线中
 }
 
 // NewJobApply 创建新的职位申请
@@ -35,33 +48,13 @@ func (ja *JobApplyRequest) NewJobApply() *model.JobApply {
 	}
 }
 
-// canTransitionTo 检查是否可以转换到目标状态
-func (ja *JobApplyUpdateStatus) canTransitionTo(targetStatus enums.JobApplyEnum) bool {
-	// 定义状态转换规则
-	transitions := map[enums.JobApplyEnum][]enums.JobApplyEnum{
-		enums.JobApplyPending: {
-			enums.JobApplyInProgress,
-			enums.JobApplyRejected,
-			enums.JobApplyWithdrawn,
-		},
-		enums.JobApplyInProgress: {
-			enums.JobApplyAccepted,
-			enums.JobApplyRejected,
-		},
-		// 已接受、已拒绝、已撤回状态为终态，不能再转换
-	}
-
-	allowedStatuses, exists := transitions[ja.Status]
-	if !exists {
-		return false
-	}
-
-	for _, allowedStatus := range allowedStatuses {
-		if allowedStatus == targetStatus {
-			return true
-		}
+// ScreeningAnswers 转换为筛选问题回答
+func (ja *JobApplyRequest) ScreeningAnswers() []model.ScreeningAnswer {
+	answers := make([]model.ScreeningAnswer, len(ja.Answers))
+	for i := range ja.Answers {
+		answers[i] = ja.Answers[i].ToModel()
 	}
-	return false
+	return answers
 }
 
 // 校验创建参数是否正确
@@ -71,3 +64,108 @@ func (ja *JobApplyRequest) Validate() bool {
 	}
 	return true
 }
+
+// JobApplyNoteRequest 添加或修改申请备注请求
+type JobApplyNoteRequest struct {
+	Content    string `json:"content" binding:"required,max=5000"`             // 备注内容
+	ParentID   *uint  `json:"parentId"`                                        // 回复的顶层备注，修改时忽略
+	MentionIDs []uint `json:"mentionIds" binding:"omitempty,max=20,dive,gt=0"` // 提及的公司成员，被提及的成员会收到通知
+}
+
+// ToModel 转换为申请备注
+func (r *JobApplyNoteRequest) ToModel() *model.JobApplyNote {
+	return &model.JobApplyNote{
+		Content:  r.Content,
+		ParentID: r.ParentID,
+		Mentions: r.MentionIDs,
+	}
+}
+
+// JobApplyTagsRequest 设置申请标签请求
+type JobApplyTagsRequest struct {
+	Tags []string `json:"tags" binding:"omitempty,max=20"` // 标签，整体替换，为空时清除全部标签
+}
+
+// JobApplyRatingRequest 设置申请评分请求
+type JobApplyRatingRequest struct {
+	Rating int `json:"rating" binding:"min=0,max=5"` // 评分 1-5，0 表示清除评分
+}
+
+// applyListDateLayout 申请列表日期筛选参数格式
+const applyListDateLayout = "2006-01-02"
+
+// JobApplyListRequest 公司侧申请列表的分页、筛选与排序参数
+type JobApplyListRequest struct {
+	Page          int      `form:"page,default=1" binding:"min=1"`            // 页码
+	Size          int      `form:"size,default=10" binding:"min=1,max=100"`   // 每页数量
+	Status        []int    `form:"status" binding:"omitempty,max=20"`         // 申请状态，可传多个，满足其一即可
+	JobID         uint     `form:"jobId"`                                     // 职位ID，仅公司申请列表使用
+	AppliedFrom   string   `form:"appliedFrom"`                               // 申请日期起(含)，格式 2006-01-02
+	AppliedTo     string   `form:"appliedTo"`                                 // 申请日期止(含)，格式 2006-01-02
+	MinExperience *int     `form:"minExperience" binding:"omitempty,min=0"`   // 简历最低工作年限
+	MaxExperience *int     `form:"maxExperience" binding:"omitempty,min=0"`   // 简历最高工作年限
+	ExpectedCity  string   `form:"expectedCity" binding:"omitempty,max=50"`   // 简历期望城市，模糊匹配
+	Degree        string   `form:"degree" binding:"omitempty,max=50"`         // 学历，匹配简历中任一教育经历
+	Tags          []string `form:"tag"`                                       // 标签，可传多个，须同时包含
+	MinRating     int      `form:"minRating" binding:"omitempty,min=1,max=5"` // 最低评分
+	MaxRating     int      `form:"maxRating" binding:"omitempty,min=1,max=5"` // 最高评分
+	Sort          string   `form:"sort"`                                      // 排序方式
+	Cursor        string   `form:"cursor"`                                    // 上一页返回的游标，指定时忽略页码
+	WithTotal     *bool    `form:"withTotal"`                                 // 是否统计总数，默认只在未指定游标时统计
+}
+
+// ToPage 转换为分页参数
+func (r *JobApplyListRequest) ToPage() pagination.Page {
+	return pagination.NewPage(r.Page, r.Size, r.Cursor, r.WithTotal)
+}
+
+// ToFilter 转换为申请列表筛选条件，校验日期、区间及排序方式
+func (r *JobApplyListRequest) ToFilter() (model.JobApplyFilter, error) {
+	filter := model.JobApplyFilter{
+		Statuses:      r.Status,
+		JobID:         r.JobID,
+		MinExperience: r.MinExperience,
+		MaxExperience: r.MaxExperience,
+		ExpectedCity:  r.ExpectedCity,
+		Degree:        r.Degree,
+		MinRating:     r.MinRating,
+		MaxRating:     r.MaxRating,
+		Sort:          model.JobApplySort(r.Sort),
+	}
+	if !filter.Sort.IsValid() {
+		return filter, fmt.Errorf("无效的排序方式: %s", r.Sort)
+	}
+	for _, status := range r.Status {
+		if !enums.JobApplyEnum(status).IsValid() {
+			return filter, fmt.Errorf("无效的申请状态: %d", status)
+		}
+	}
+	tags, err := model.NormalizeApplyTags(r.Tags)
+	if err != nil {
+		return filter, err
+	}
+	filter.Tags = tags
+
+	if r.AppliedFrom != "" {
+		if filter.AppliedFrom, err = time.ParseInLocation(applyListDateLayout, r.AppliedFrom, time.Local); err != nil {
+			return filter, fmt.Errorf("无效的申请日期: %s", r.AppliedFrom)
+		}
+	}
+	if r.AppliedTo != "" {
+		to, err := time.ParseInLocation(applyListDateLayout, r.AppliedTo, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("无效的申请日期: %s", r.AppliedTo)
+		}
+		filter.AppliedTo = to.AddDate(0, 0, 1)
+	}
+	if !filter.AppliedFrom.IsZero() && !filter.AppliedTo.IsZero() && !filter.AppliedFrom.Before(filter.AppliedTo) {
+		return filter, fmt.Errorf("申请日期起不能晚于止")
+	}
+	if r.MinExperience != nil && r.MaxExperience != nil && *r.MinExperience > *r.MaxExperience {
+		return filter, fmt.Errorf("最低工作年限不能高于最高工作年限")
+	}
+	if r.MinRating > 0 && r.MaxRating > 0 && r.MinRating > r.MaxRating {
+		return filter, fmt.Errorf("最低评分不能高于最高评分")
+	}
+	return filter, nil
+}
@@ -0,0 +1,329 @@
+package request
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// jobImportExcluded 不参与导入导出的字段：公司由接口路径指定，是否提交审核由导入参数指定
+var jobImportExcluded = map[string]bool{"companyId": true, "draft": true}
+
+// jobImportTimeLayouts 导入时支持的时间格式，不带时区的按服务器时区解析
+var jobImportTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// jobExportTimeLayout 导出时的时间格式
+const jobExportTimeLayout = "2006-01-02 15:04:05"
+
+// formulaPrefixes 表格软件会当作公式解析的单元格开头字符，导出时在前面加上 ' 转义
+const formulaPrefixes = "=+-@\t\r"
+
+// jobImportField 导入导出的列及其对应的 CreateJobRequest 字段
+type jobImportField struct {
+	column string
+	index  int
+}
+
+// jobImportFields 按 CreateJobRequest 的字段顺序排列的导入导出列
+var jobImportFields = func() []jobImportField {
+	t := reflect.TypeOf(CreateJobRequest{})
+	fields := make([]jobImportField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		column := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if column == "" || column == "-" || jobImportExcluded[column] {
+			continue
+		}
+		fields = append(fields, jobImportField{column: column, index: i})
+	}
+	return fields
+}()
+
+// JobImportColumns 职位导入导出的列名，与 CreateJobRequest 的 JSON 字段名一致
+func JobImportColumns() []string {
+	columns := make([]string, len(jobImportFields))
+	for i, f := range jobImportFields {
+		columns[i] = f.column
+	}
+	return columns
+}
+
+// JobImportFieldError 导入数据中单元格或整行的错误，Column 为空时表示整行的错误
+type JobImportFieldError struct {
+	Column  string
+	Message string
+}
+
+// JobImportRow 解析后的一行导入数据，Row 为表格中的行号(表头为第1行)
+type JobImportRow struct {
+	Row     int
+	Request CreateJobRequest
+	Errors  []JobImportFieldError
+}
+
+// ParseJobImport 按表头解析职位表格，逐行转换单元格并按 CreateJobRequest 的绑定规则校验，空行跳过
+// 多值字段(标签、福利)在单元格中以逗号分隔；表头有未知或重复的列时返回错误
+func ParseJobImport(table [][]string) ([]JobImportRow, error) {
+	if len(table) == 0 {
+		return nil, stderrors.New("文件中没有表头")
+	}
+	byColumn := make(map[string]jobImportField, len(jobImportFields))
+	for _, f := range jobImportFields {
+		byColumn[strings.ToLower(f.column)] = f
+	}
+	header := make([]*jobImportField, len(table[0]))
+	seen := make(map[string]bool)
+	for i, name := range table[0] {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		f, ok := byColumn[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("未知的列: %s", name)
+		}
+		if seen[f.column] {
+			return nil, fmt.Errorf("重复的列: %s", name)
+		}
+		seen[f.column] = true
+		header[i] = &f
+	}
+
+	var rows []JobImportRow
+	for i, record := range table[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		row := JobImportRow{Row: i + 2}
+		value := reflect.ValueOf(&row.Request).Elem()
+		for j, cell := range record {
+			if j >= len(header) || header[j] == nil {
+				continue
+			}
+			if err := setImportCell(value.Field(header[j].index), strings.TrimSpace(cell)); err != nil {
+				row.Errors = append(row.Errors, JobImportFieldError{Column: header[j].column, Message: err.Error()})
+			}
+		}
+		if len(row.Errors) == 0 {
+			row.Errors = bindingErrors(binding.Validator.ValidateStruct(&row.Request))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// NewJobExportRecord 将职位按导入导出的列转换为一行表格，可直接重新导入
+func NewJobExportRecord(job *model.Job) []string {
+	req := CreateJobRequest{
+		Name:          job.Name,
+		JobSkill:      job.JobSkill,
+		JobSalary:     job.JobSalary,
+		JobSalaryMax:  job.JobSalaryMax,
+		JobDescribe:   job.JobDescribe,
+		JobLocation:   job.JobLocation,
+		JobExpireTime: job.JobExpireTime,
+		PublishTime:   job.PublishTime,
+		JobType:       job.JobType,
+		JobCategory:   job.JobCategory,
+		JobExperience: job.JobExperience,
+		JobEducation:  job.JobEducation,
+		JobBenefit:    job.JobBenefit,
+		JobContact:    job.JobContact,
+		JobSource:     job.JobSource,
+		Tags:          job.Tags,
+		RemoteType:    int(job.RemoteType),
+		RemoteDesc:    job.RemoteDesc,
+		RemoteRatio:   job.RemoteRatio,
+		Benefits:      job.Benefits,
+		BenefitDesc:   job.BenefitDesc,
+		RegionCode:    firstNonEmpty(job.DistrictCode, job.CityCode, job.ProvinceCode),
+	}
+	value := reflect.ValueOf(req)
+	record := make([]string, len(jobImportFields))
+	for i, f := range jobImportFields {
+		record[i] = formatExportCell(value.Field(f.index))
+	}
+	return record
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// setImportCell 将单元格转换为字段类型，空单元格保留零值
+func setImportCell(field reflect.Value, cell string) error {
+	if cell == "" {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := setImportCell(elem.Elem(), cell); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if field.Type() == timeType {
+		for _, layout := range jobImportTimeLayouts {
+			if t, err := time.ParseInLocation(layout, cell, time.Local); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("时间格式错误，示例: %s", jobExportTimeLayout)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(unescapeFormula(cell))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, 64)
+		if err != nil || field.OverflowInt(n) {
+			return stderrors.New("须为整数")
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return stderrors.New("须为 true 或 false")
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		parts := strings.FieldsFunc(cell, func(r rune) bool { return r == ',' || r == '，' })
+		slice := reflect.MakeSlice(field.Type(), 0, len(parts))
+		for _, part := range parts {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setImportCell(elem, part); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		field.Set(slice)
+	default:
+		return fmt.Errorf("不支持的字段类型: %s", field.Type())
+	}
+	return nil
+}
+
+// formatExportCell 将字段值转换为单元格，格式与导入时一致
+func formatExportCell(field reflect.Value) string {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	if field.Type() == timeType {
+		t := field.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Local().Format(jobExportTimeLayout)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return escapeFormula(field.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	case reflect.Slice:
+		parts := make([]string, field.Len())
+		for i := range parts {
+			parts[i] = formatExportCell(field.Index(i))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(field.Interface())
+}
+
+// escapeFormula 以公式字符或 ' 开头的文本前加上 '，避免打开导出文件时被当作公式执行
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes+"'", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// unescapeFormula 去掉导出时加上的 '，使导出的文件重新导入后内容不变
+func unescapeFormula(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune(formulaPrefixes+"'", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
+
+// bindingErrors 将绑定校验错误转换为按列的错误
+func bindingErrors(err error) []JobImportFieldError {
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !stderrors.As(err, &validationErrors) {
+		return []JobImportFieldError{{Message: err.Error()}}
+	}
+	errs := make([]JobImportFieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		errs = append(errs, JobImportFieldError{Column: jobImportColumn(fe.StructField()), Message: bindingMessage(fe)})
+	}
+	return errs
+}
+
+// jobImportColumn 根据字段名获取列名，切片元素的字段名形如 Benefits[0]
+func jobImportColumn(structField string) string {
+	name, _, _ := strings.Cut(structField, "[")
+	if f, ok := reflect.TypeOf(CreateJobRequest{}).FieldByName(name); ok {
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	}
+	return name
+}
+
+// bindingMessage 绑定校验规则对应的错误提示
+func bindingMessage(fe validator.FieldError) string {
+	isText := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "不能为空"
+	case "min":
+		if isText {
+			return fmt.Sprintf("长度不能少于%s", fe.Param())
+		}
+		return fmt.Sprintf("不能小于%s", fe.Param())
+	case "max":
+		if isText {
+			return fmt.Sprintf("长度不能超过%s", fe.Param())
+		}
+		return fmt.Sprintf("不能大于%s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("取值须为 %s 之一", fe.Param())
+	case "gtefield":
+		return fmt.Sprintf("不能小于%s", jobImportColumn(fe.Param()))
+	}
+	return fmt.Sprintf("不满足%s校验规则", fe.Tag())
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+func importColumns(errs []JobImportFieldError) []string {
+	columns := make([]string, len(errs))
+	for i, e := range errs {
+		columns[i] = e.Column
+	}
+	return columns
+}
+
+func TestParseJobImport(t *testing.T) {
+	header := []string{"name", "JobSkill", "jobSalary", "jobSalaryMax", "jobDescribe", "jobLocation", "jobExpireTime",
+		"jobType", "jobCategory", "jobExperience", "jobEducation", "tags", "remoteType", "remoteRatio", "benefits", ""}
+	valid := []string{"Go工程师", "Go", "15000", "25000", "负责后端开发", "上海", "2030-12-31",
+		"1", "技术", "3-5年", "本科", "急招，双休", "2", "60", "1,2", "备注"}
+	table := [][]string{
+		header,
+		valid,
+		{"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", ""},
+		{"Go工程师", "Go", "2万", "25000", "负责后端开发", "上海", "明年", "1", "技术", "3-5年", "本科", "", "2", "60", "1"},
+		{"", "Go", "30000", "25000", "负责后端开发", "上海", "2030-12-31 18:00", "1", "技术", "3-5年", "本科", "", "5", "60", "1,11"},
+	}
+
+	rows, err := ParseJobImport(table)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 2, rows[0].Row)
+	assert.Empty(t, rows[0].Errors)
+	req := rows[0].Request
+	assert.Equal(t, 15000, req.JobSalary)
+	assert.Equal(t, []string{"急招", "双休"}, req.Tags)
+	assert.Equal(t, []model.JobBenefitType{1, 2}, req.Benefits)
+	assert.True(t, req.JobExpireTime.Equal(time.Date(2030, 12, 31, 0, 0, 0, 0, time.Local)))
+
+	// 单元格格式错误时不再做绑定校验
+	assert.Equal(t, 4, rows[1].Row)
+	assert.Equal(t, []string{"jobSalary", "jobExpireTime"}, importColumns(rows[1].Errors))
+
+	assert.Equal(t, 5, rows[2].Row)
+	assert.ElementsMatch(t, []string{"name", "jobSalaryMax", "remoteType", "benefits"}, importColumns(rows[2].Errors))
+
+	_, err = ParseJobImport([][]string{{"name", "salary"}})
+	assert.Error(t, err)
+	_, err = ParseJobImport([][]string{{"name", "Name"}})
+	assert.Error(t, err)
+}
+
+func TestJobExportRecord(t *testing.T) {
+	expire := time.Date(2030, 12, 31, 18, 0, 0, 0, time.Local)
+	job := &model.Job{
+		Name: "Go工程师", JobSkill: "Go", JobSalary: 15000, JobSalaryMax: 25000, JobDescribe: "负责后端开发",
+		JobLocation: "上海", JobExpireTime: expire, JobType: 1, JobCategory: "技术", JobExperience: "3-5年",
+		JobEducation: "本科", Tags: []string{"急招", "双休"}, RemoteType: model.Hybrid, RemoteRatio: 60,
+		Benefits: []model.JobBenefitType{1, 2}, GeoLocation: model.GeoLocation{ProvinceCode: "310000", CityCode: "310100"},
+	}
+	record := NewJobExportRecord(job)
+	require.Len(t, record, len(JobImportColumns()))
+
+	// 导出的数据可以原样导入
+	rows, err := ParseJobImport([][]string{JobImportColumns(), record})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Empty(t, rows[0].Errors)
+	assert.Equal(t, "310100", rows[0].Request.RegionCode)
+	assert.Nil(t, rows[0].Request.PublishTime)
+	assert.True(t, rows[0].Request.JobExpireTime.Equal(expire))
+	assert.Equal(t, job.Tags, rows[0].Request.Tags)
+	assert.Equal(t, job.Benefits, rows[0].Request.Benefits)
+}
+
+func TestJobExportEscapesFormulas(t *testing.T) {
+	job := &model.Job{
+		Name: "Go工程师", JobSkill: "'Go", JobSalary: 15000, JobSalaryMax: 25000, JobDescribe: `=HYPERLINK("http://example.com","点击")`,
+		JobLocation: "上海", JobExpireTime: time.Date(2030, 12, 31, 18, 0, 0, 0, time.Local), JobType: 1, JobContact: "+86 13800000000",
+		Tags: []string{"-急招", "@双休"}, GeoLocation: model.GeoLocation{CityCode: "310100"},
+	}
+	record := NewJobExportRecord(job)
+	columns := JobImportColumns()
+	cell := func(column string) string {
+		for i, c := range columns {
+			if c == column {
+				return record[i]
+			}
+		}
+		return ""
+	}
+
+	// 以公式字符或 ' 开头的文本导出时加上 '
+	assert.Equal(t, `'=HYPERLINK("http://example.com","点击")`, cell("jobDescribe"))
+	assert.Equal(t, "'+86 13800000000", cell("jobContact"))
+	assert.Equal(t, "''Go", cell("jobSkill"))
+	assert.Equal(t, "'-急招,'@双休", cell("tags"))
+	assert.Equal(t, "Go工程师", cell("name"))
+
+	// 重新导入后内容不变
+	rows, err := ParseJobImport([][]string{columns, record})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, job.JobDescribe, rows[0].Request.JobDescribe)
+	assert.Equal(t, job.JobContact, rows[0].Request.JobContact)
+	assert.Equal(t, job.JobSkill, rows[0].Request.JobSkill)
+	assert.Equal(t, job.Tags, rows[0].Request.Tags)
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+)
+
+// PipelineStageRequest 招聘流程阶段
+type PipelineStageRequest struct {
+	Status int    `json:"status" binding:"required"`       // 申请状态
+	Name   string `json:"name" binding:"omitempty,max=50"` // 阶段名称，为空时使用状态默认名称
+}
+
+// PipelineTransitionRequest 招聘流程状态流转
+type PipelineTransitionRequest struct {
+	From   int      `json:"from" binding:"required"`                                      // 起始状态
+	To     int      `json:"to" binding:"required"`                                        // 目标状态
+	Actors []string `json:"actors" binding:"required,min=1,dive,oneof=candidate company"` // 可触发流转的一方
+}
+
+// JobPipelineRequest 自定义职位招聘流程请求
+type JobPipelineRequest struct {
+	Stages      []PipelineStageRequest      `json:"stages" binding:"required,min=1,dive"`
+	Transitions []PipelineTransitionRequest `json:"transitions" binding:"dive"`
+}
+
+// ToModel 转换为招聘流程定义
+func (r *JobPipelineRequest) ToModel() *model.PipelineDefinition {
+	definition := &model.PipelineDefinition{
+		Stages:      make([]model.PipelineStage, len(r.Stages)),
+		Transitions: make([]model.PipelineTransition, len(r.Transitions)),
+	}
+	for i, s := range r.Stages {
+		definition.Stages[i] = model.PipelineStage{Status: enums.JobApplyEnum(s.Status), Name: s.Name}
+	}
+	for i, t := range r.Transitions {
+		actors := make([]model.PipelineActor, len(t.Actors))
+		for j, a := range t.Actors {
+			actors[j] = model.PipelineActor(a)
+		}
+		definition.Transitions[i] = model.PipelineTransition{
+			From:   enums.JobApplyEnum(t.From),
+			To:     enums.JobApplyEnum(t.To),
+			Actors: actors,
+		}
+	}
+	return definition
+}
@@ -2,6 +2,7 @@ package request
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"org.thinkinai.com/recruit-center/internal/model"
@@ -15,9 +16,8 @@ type CreateJobRequest struct {
 	// required: true
 	Name string `json:"name" binding:"required" example:"高级Go工程师"`
 
-	// 公司ID
-	// required: true
-	CompanyID uint `json:"companyId" binding:"required"`
+	// 公司ID，为空时使用当前用户所属公司
+	CompanyID uint `json:"companyId"`
 
 	// 职位技能要求
 	// required: true
@@ -44,6 +44,13 @@ type CreateJobRequest struct {
 	// example: 2024-12-31T23:59:59Z
 	JobExpireTime time.Time `json:"jobExpireTime" binding:"required"`
 
+	// 计划发布时间，审核通过时晚于当前时间的职位先保存为待发布，到时间后自动发布；为空时审核通过后立即发布
+	// example: 2024-06-01T09:00:00Z
+	PublishTime *time.Time `json:"publishTime"`
+
+	// 是否只保存为草稿，为 false 时创建后立即提交审核
+	Draft bool `json:"draft" example:"false"`
+
 	// 职位类型（全职/兼职）
 	// required: true
 	JobType int `json:"jobType" binding:"required" example:"1"`
@@ -85,10 +92,13 @@ type CreateJobRequest struct {
 
 	// 职位福利列表
 	// required: true
-	Benefits []model.JobBenefitType `json:"benefits" binding:"required,dive,oneof=1 2 3 4 5 6 7 8 9 10""`
+	Benefits []model.JobBenefitType `json:"benefits" binding:"required,dive,oneof=1 2 3 4 5 6 7 8 9 10"`
 
 	// 福利补充说明
 	BenefitDesc string `json:"benefitDesc" example:"额外提供商业医疗保险，每年体检一次"`
+
+	// 工作地点的行政区划代码，为空时从工作地点中解析
+	RegionCode string `json:"regionCode" binding:"max=20" example:"110105"`
 }
 
 // UpdateJobRequest 更新职位请求
@@ -102,6 +112,7 @@ type UpdateJobRequest struct {
 	JobDescribe   string        `json:"jobDescribe" binding:"max=2000"`
 	JobLocation   string        `json:"jobLocation" binding:"max=200"`
 	JobExpireTime time.Time     `json:"jobExpireTime,omitempty"`
+	PublishTime   *time.Time    `json:"publishTime,omitempty"` // 计划发布时间，仅待发布的职位可调整
 	JobType       enums.JobType `json:"jobType"`
 	JobCategory   string        `json:"jobCategory" binding:"max=50"`
 	JobExperience string        `json:"jobExperience" binding:"max=50"`
@@ -124,20 +135,79 @@ type UpdateJobRequest struct {
 
 	// 福利补充说明
 	BenefitDesc string `json:"benefitDesc,omitempty"`
+
+	// 工作地点的行政区划代码，为空时从工作地点中解析
+	RegionCode string `json:"regionCode,omitempty" binding:"max=20"`
 }
 
 // JobSearchRequest 职位搜索请求
-// @Description 职位搜索的请求参数
+// @Description 职位搜索的请求参数，分面筛选可传多个取值，同一分面满足其一即可，不同分面须同时满足
 type JobSearchRequest struct {
-	Keyword     string        `json:"keyword" form:"keyword" example:"Go开发"`
-	JobType     enums.JobType `json:"jobType" form:"jobType" example:"full_time"`
-	JobCategory string        `json:"jobCategory" form:"jobCategory" example:"技术"`
-	Location    string        `json:"location" form:"location" example:"北京"`
-	SalaryMin   int           `json:"salaryMin" form:"salaryMin" example:"10000"`
-	SalaryMax   int           `json:"salaryMax" form:"salaryMax" example:"30000"`
-	CompanyID   uint          `json:"companyId" form:"companyId" example:"1"`
-	Page        int           `json:"page" form:"page" binding:"min=1" example:"1"`
-	PageSize    int           `json:"pageSize" form:"pageSize" binding:"min=1,max=100" example:"10"`
+	Keyword     string   `json:"keyword" form:"keyword" binding:"max=100" example:"Go开发"`
+	JobType     []int    `json:"jobType" form:"jobType" binding:"omitempty,max=3,dive,min=1,max=3" example:"1"`
+	JobCategory []string `json:"jobCategory" form:"jobCategory" binding:"omitempty,max=20,dive,max=50" example:"技术"`
+	RemoteType  []int    `json:"remoteType" form:"remoteType" binding:"omitempty,max=4,dive,min=1,max=4" example:"2"`
+	Benefit     []int    `json:"benefit" form:"benefit" binding:"omitempty,max=10,dive,min=1,max=10" example:"1"`
+	Education   []string `json:"education" form:"education" binding:"omitempty,max=20,dive,max=50" example:"本科"`
+	Experience  []string `json:"experience" form:"experience" binding:"omitempty,max=20,dive,max=50" example:"3-5年"`
+	SalaryRange []string `json:"salaryRange" form:"salaryRange" binding:"omitempty,max=10" example:"10k-20k"`
+	Location    []string `json:"location" form:"location" binding:"omitempty,max=20,dive,max=100" example:"北京"`
+	SalaryMin   int      `json:"salaryMin" form:"salaryMin" binding:"min=0" example:"10000"`
+	SalaryMax   int      `json:"salaryMax" form:"salaryMax" binding:"min=0" example:"30000"`
+	CompanyID   uint     `json:"companyId" form:"companyId" example:"1"`
+	RegionCode  string   `json:"regionCode" form:"regionCode" binding:"max=20" example:"110105"`       // 行政区划代码，匹配该区划及其下级区划
+	Latitude    *float64 `json:"lat" form:"lat" binding:"omitempty,min=-90,max=90" example:"39.92"`    // 距离搜索中心点纬度
+	Longitude   *float64 `json:"lng" form:"lng" binding:"omitempty,min=-180,max=180" example:"116.44"` // 距离搜索中心点经度
+	RadiusKm    float64  `json:"radiusKm" form:"radiusKm" binding:"min=0,max=500" example:"10"`        // 距中心点的最大距离(公里)
+	Sort        string   `json:"sort" form:"sort" example:"distance"`                                  // 排序方式: 为空按相关度, newest 最新发布, distance 距离最近
+	Page        int      `json:"page" form:"page,default=1" binding:"min=1" example:"1"`
+	PageSize    int      `json:"pageSize" form:"pageSize,default=10" binding:"min=1,max=100" example:"10"`
+}
+
+// ToCondition 转换为职位搜索条件，校验薪资区间
+func (r *JobSearchRequest) ToCondition() (model.JobSearchCondition, error) {
+	cond := model.JobSearchCondition{
+		Keyword:       r.Keyword,
+		JobTypes:      r.JobType,
+		JobCategories: r.JobCategory,
+		Educations:    r.Education,
+		Experiences:   r.Experience,
+		SalaryRanges:  r.SalaryRange,
+		Locations:     r.Location,
+		SalaryMin:     r.SalaryMin,
+		SalaryMax:     r.SalaryMax,
+		CompanyID:     r.CompanyID,
+		RegionCode:    r.RegionCode,
+		RadiusKm:      r.RadiusKm,
+		Sort:          model.JobSearchSort(r.Sort),
+	}
+	for _, remoteType := range r.RemoteType {
+		cond.RemoteTypes = append(cond.RemoteTypes, model.RemoteType(remoteType))
+	}
+	for _, benefit := range r.Benefit {
+		cond.Benefits = append(cond.Benefits, model.JobBenefitType(benefit))
+	}
+	for _, key := range r.SalaryRange {
+		if _, ok := model.SalaryRangeByKey(key); !ok {
+			return cond, fmt.Errorf("无效的薪资区间: %s", key)
+		}
+	}
+	if r.SalaryMin > 0 && r.SalaryMax > 0 && r.SalaryMin > r.SalaryMax {
+		return cond, errors.New("最低薪资不能高于最高薪资")
+	}
+	if (r.Latitude == nil) != (r.Longitude == nil) {
+		return cond, errors.New("中心点经纬度须同时指定")
+	}
+	if r.Latitude != nil {
+		cond.Near = &model.GeoPoint{Latitude: *r.Latitude, Longitude: *r.Longitude}
+	}
+	if !cond.Sort.IsValid() {
+		return cond, fmt.Errorf("无效的排序方式: %s", r.Sort)
+	}
+	if cond.Near == nil && (cond.RadiusKm > 0 || cond.Sort == model.JobSearchSortDistance) {
+		return cond, errors.New("按距离搜索或排序须指定中心点经纬度")
+	}
+	return cond, nil
 }
 
 // JobListRequest 职位列表请求
@@ -166,6 +236,7 @@ func (r *CreateJobRequest) ToModel() *model.Job {
 		JobDescribe:   r.JobDescribe,
 		JobLocation:   r.JobLocation,
 		JobExpireTime: r.JobExpireTime,
+		PublishTime:   r.PublishTime,
 		JobType:       int(r.JobType),
 		JobCategory:   r.JobCategory,
 		JobExperience: r.JobExperience,
@@ -173,7 +244,7 @@ func (r *CreateJobRequest) ToModel() *model.Job {
 		JobBenefit:    r.JobBenefit,
 		JobContact:    r.JobContact,
 		JobSource:     r.JobSource,
-		Status:        int(enums.JobStatusNormal),
+		Status:        int(enums.JobStatusDraft),
 		RemoteType:    model.RemoteType(r.RemoteType),
 		RemoteDesc:    r.RemoteDesc,
 		RemoteRatio:   r.RemoteRatio,
@@ -199,6 +270,7 @@ func (r *UpdateJobRequest) ToModel() *model.Job {
 		JobDescribe:   r.JobDescribe,
 		JobLocation:   r.JobLocation,
 		JobExpireTime: r.JobExpireTime,
+		PublishTime:   r.PublishTime,
 		JobType:       int(r.JobType),
 		JobCategory:   r.JobCategory,
 		JobExperience: r.JobExperience,
@@ -224,31 +296,13 @@ func (r *UpdateJobRequest) ToModel() *model.Job {
 	return job
 }
 
-// ToConditions 将搜索请求转换为查询条件
-func (r *JobSearchRequest) ToConditions() map[string]interface{} {
-	conditions := make(map[string]interface{})
-
-	if r.Keyword != "" {
-		conditions["keyword"] = r.Keyword
-	}
-	if r.JobType != 0 {
-		conditions["job_type"] = r.JobType
-	}
-	if r.JobCategory != "" {
-		conditions["job_category"] = r.JobCategory
-	}
-	if r.Location != "" {
-		conditions["job_location"] = r.Location
-	}
-	if r.SalaryMin > 0 {
-		conditions["salary_min"] = r.SalaryMin
-	}
-	if r.SalaryMax > 0 {
-		conditions["salary_max"] = r.SalaryMax
-	}
-	if r.CompanyID > 0 {
-		conditions["company_id"] = r.CompanyID
-	}
+// JobRevisionDiffRequest 职位修订版本比较请求
+type JobRevisionDiffRequest struct {
+	From int `form:"from" binding:"required,min=1"` // 旧版本号，候选人可使用申请时的版本号
+	To   int `form:"to" binding:"omitempty,min=1"`  // 新版本号，为空时与当前版本比较
+}
 
-	return conditions
+// JobReviewRequest 审核职位请求
+type JobReviewRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=500"` // 审核意见，驳回时必填
 }
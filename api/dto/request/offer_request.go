@@ -0,0 +1,79 @@
+package request
+
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// OfferRequest 创建或修改Offer请求
+type OfferRequest struct {
+	TemplateID   *uint     `json:"templateId"`                                       // Offer函模板，为空时使用默认模板
+	Position     string    `json:"position" binding:"required,max=100"`              // 录用职位名称
+	Department   string    `json:"department" binding:"omitempty,max=100"`           // 所属部门
+	Currency     string    `json:"currency" binding:"omitempty,len=3"`               // 币种，默认CNY
+	BaseSalary   int64     `json:"baseSalary" binding:"required,min=1"`              // 月基本工资
+	SalaryMonths int       `json:"salaryMonths" binding:"omitempty,min=1,max=24"`    // 年薪月数，默认12
+	SigningBonus int64     `json:"signingBonus" binding:"omitempty,min=0"`           // 签字费
+	Benefits     string    `json:"benefits" binding:"omitempty,max=500"`             // 福利待遇
+	Location     string    `json:"location" binding:"omitempty,max=255"`             // 工作地点
+	StartDate    time.Time `json:"startDate" binding:"required"`                     // 入职日期
+	ExpireAt     time.Time `json:"expireAt" binding:"required"`                      // 候选人答复截止时间
+	Remark       string    `json:"remark" binding:"omitempty,max=500"`               // 备注，写入Offer函
+	ApproverIDs  []uint    `json:"approverIds" binding:"omitempty,max=5,dive,min=1"` // 审批人，按顺序依次审批，为空时无需审批
+}
+
+// ToModel 转换为Offer，审批链按审批人顺序生成
+func (r *OfferRequest) ToModel() *model.Offer {
+	offer := &model.Offer{
+		TemplateID:   r.TemplateID,
+		Position:     r.Position,
+		Department:   r.Department,
+		Currency:     r.Currency,
+		BaseSalary:   r.BaseSalary,
+		SalaryMonths: r.SalaryMonths,
+		SigningBonus: r.SigningBonus,
+		Benefits:     r.Benefits,
+		Location:     r.Location,
+		StartDate:    r.StartDate,
+		ExpireAt:     r.ExpireAt,
+		Remark:       r.Remark,
+		Approvals:    make([]model.OfferApproval, len(r.ApproverIDs)),
+	}
+	if offer.Currency == "" {
+		offer.Currency = "CNY"
+	}
+	if offer.SalaryMonths == 0 {
+		offer.SalaryMonths = 12
+	}
+	for i, id := range r.ApproverIDs {
+		offer.Approvals[i] = model.OfferApproval{Step: i + 1, ApproverID: id, Status: model.OfferApprovalPending}
+	}
+	return offer
+}
+
+// OfferApprovalRequest 审批Offer请求
+type OfferApprovalRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=500"` // 审批意见
+}
+
+// OfferDeclineRequest 候选人拒绝Offer请求
+type OfferDeclineRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=255"` // 拒绝原因
+}
+
+// OfferTemplateRequest 创建或修改Offer函模板请求
+type OfferTemplateRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`      // 模板名称
+	Title   string `json:"title" binding:"required,max=100"`     // Offer函标题
+	Content string `json:"content" binding:"required,max=10000"` // 模板内容，{{变量名}} 在生成时替换，每行一个段落
+}
+
+// ToModel 转换为Offer函模板
+func (r *OfferTemplateRequest) ToModel() *model.OfferTemplate {
+	return &model.OfferTemplate{
+		Name:    r.Name,
+		Title:   r.Title,
+		Content: r.Content,
+	}
+}
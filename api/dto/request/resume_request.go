@@ -16,6 +16,7 @@ type CreateResumeRequest struct {
 	Phone           string                  `json:"phone" binding:"required"`
 	Email           string                  `json:"email" binding:"required,email"`
 	Location        string                  `json:"location"`
+	RegionCode      string                  `json:"regionCode" binding:"max=20"` // 所在地的行政区划代码，为空时从所在地中解析
 	Experience      int                     `json:"experience"`
 	JobStatus       int                     `json:"jobStatus"`
 	ExpectedJob     string                  `json:"expectedJob"`
@@ -28,6 +28,7 @@ type UpdateResumeBasicRequest struct {
 	Phone        string    `json:"phone"`
 	Email        string    `json:"email"`
 	Location     string    `json:"location"`
+	RegionCode   string    `json:"regionCode" binding:"max=20"` // 所在地的行政区划代码，为空时从所在地中解析
 	Introduction string    `json:"introduction"`
 }
 
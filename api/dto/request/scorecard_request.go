@@ -0,0 +1,62 @@
+package request
+
+import "org.thinkinai.com/recruit-center/internal/model"
+
+// ScorecardCompetencyRequest 评分卡考察项
+type ScorecardCompetencyRequest struct {
+	Key         string `json:"key" binding:"required,max=50"`           // 考察项标识，职位内唯一
+	Name        string `json:"name" binding:"required,max=50"`          // 考察项名称
+	Description string `json:"description" binding:"omitempty,max=255"` // 评分说明
+	Scale       int    `json:"scale" binding:"required,min=2,max=10"`   // 评分档数，评分范围为 1 到 scale
+	Weight      int    `json:"weight" binding:"omitempty,min=0,max=10"` // 汇总权重，默认1
+}
+
+// ScorecardTemplateRequest 设置职位评分卡请求
+type ScorecardTemplateRequest struct {
+	Competencies    []ScorecardCompetencyRequest `json:"competencies" binding:"required,min=1,max=20,dive"`
+	RequireFeedback bool                         `json:"requireFeedback"` // 流转到面试通过或未通过前是否必须提交面试反馈
+}
+
+// ToModel 转换为评分卡定义
+func (r *ScorecardTemplateRequest) ToModel() *model.ScorecardDefinition {
+	definition := &model.ScorecardDefinition{
+		Competencies: make([]model.ScorecardCompetency, len(r.Competencies)),
+	}
+	for i, c := range r.Competencies {
+		definition.Competencies[i] = model.ScorecardCompetency{
+			Key:         c.Key,
+			Name:        c.Name,
+			Description: c.Description,
+			Scale:       c.Scale,
+			Weight:      c.Weight,
+		}
+	}
+	return definition
+}
+
+// CompetencyRatingRequest 考察项评分
+type CompetencyRatingRequest struct {
+	Key     string `json:"key" binding:"required"`              // 考察项标识
+	Score   int    `json:"score" binding:"required,min=1"`      // 评分
+	Comment string `json:"comment" binding:"omitempty,max=500"` // 评语
+}
+
+// InterviewFeedbackRequest 提交面试反馈请求
+type InterviewFeedbackRequest struct {
+	Ratings        []CompetencyRatingRequest `json:"ratings" binding:"dive"`                                              // 考察项评分，职位配置评分卡时每个考察项都须评分
+	Recommendation string                    `json:"recommendation" binding:"required,oneof=strong_no no yes strong_yes"` // 录用建议
+	Summary        string                    `json:"summary" binding:"omitempty,max=2000"`                                // 综合评价
+}
+
+// ToModel 转换为面试反馈
+func (r *InterviewFeedbackRequest) ToModel() *model.InterviewFeedback {
+	feedback := &model.InterviewFeedback{
+		Ratings:        make([]model.CompetencyRating, len(r.Ratings)),
+		Recommendation: model.FeedbackRecommendation(r.Recommendation),
+		Summary:        r.Summary,
+	}
+	for i, rating := range r.Ratings {
+		feedback.Ratings[i] = model.CompetencyRating{Key: rating.Key, Score: rating.Score, Comment: rating.Comment}
+	}
+	return feedback
+}
@@ -0,0 +1,74 @@
+package request
+
+import "org.thinkinai.com/recruit-center/internal/model"
+
+// KnockoutRuleRequest 淘汰规则，回答不满足条件时申请被自动拒绝
+type KnockoutRuleRequest struct {
+	Operator string   `json:"operator" binding:"required"`        // 比较方式: eq/gte/lte/in/includes_any/includes_all
+	Bool     *bool    `json:"bool"`                               // 是/否题的期望回答
+	Number   *float64 `json:"number"`                             // 数值题的比较值
+	Options  []string `json:"options" binding:"omitempty,max=20"` // 选择题的期望选项
+	Reason   string   `json:"reason" binding:"omitempty,max=255"` // 自动拒绝的原因，为空时使用默认原因
+}
+
+// ScreeningQuestionRequest 筛选问题
+type ScreeningQuestionRequest struct {
+	Key      string               `json:"key" binding:"required,max=50"`                   // 问题标识，职位内唯一
+	Text     string               `json:"text" binding:"required,max=255"`                 // 问题内容
+	Type     string               `json:"type" binding:"required"`                         // 问题类型: yes_no/single_choice/multi_choice/numeric/text
+	Options  []string             `json:"options" binding:"omitempty,max=20,dive,max=100"` // 选择题的选项
+	Required bool                 `json:"required"`                                        // 是否必答，设置了淘汰规则的问题必答
+	Knockout *KnockoutRuleRequest `json:"knockout"`                                        // 淘汰规则
+}
+
+// JobScreeningRequest 设置职位筛选问题请求
+type JobScreeningRequest struct {
+	Questions []ScreeningQuestionRequest `json:"questions" binding:"required,min=1,max=20,dive"`
+}
+
+// ToModel 转换为筛选问题定义
+func (r *JobScreeningRequest) ToModel() *model.ScreeningDefinition {
+	definition := &model.ScreeningDefinition{
+		Questions: make([]model.ScreeningQuestion, len(r.Questions)),
+	}
+	for i, q := range r.Questions {
+		question := model.ScreeningQuestion{
+			Key:      q.Key,
+			Text:     q.Text,
+			Type:     model.ScreeningQuestionType(q.Type),
+			Options:  q.Options,
+			Required: q.Required,
+		}
+		if q.Knockout != nil {
+			question.Knockout = &model.KnockoutRule{
+				Operator: model.KnockoutOperator(q.Knockout.Operator),
+				Bool:     q.Knockout.Bool,
+				Number:   q.Knockout.Number,
+				Options:  q.Knockout.Options,
+				Reason:   q.Knockout.Reason,
+			}
+		}
+		definition.Questions[i] = question
+	}
+	return definition
+}
+
+// ScreeningAnswerRequest 筛选问题的回答，按问题类型填写对应字段
+type ScreeningAnswerRequest struct {
+	Key     string   `json:"key" binding:"required"`             // 问题标识
+	Bool    *bool    `json:"bool"`                               // 是/否题的回答
+	Number  *float64 `json:"number"`                             // 数值题的回答
+	Text    string   `json:"text"`                               // 文本题的回答
+	Choices []string `json:"choices" binding:"omitempty,max=20"` // 选择题的回答
+}
+
+// ToModel 转换为筛选问题回答
+func (r *ScreeningAnswerRequest) ToModel() model.ScreeningAnswer {
+	return model.ScreeningAnswer{
+		Key:     r.Key,
+		Bool:    r.Bool,
+		Number:  r.Number,
+		Text:    r.Text,
+		Choices: r.Choices,
+	}
+}
@@ -0,0 +1,46 @@
+package request
+
+import "org.thinkinai.com/recruit-center/internal/model"
+
+// UserRegisterRequest 用户注册请求
+type UserRegisterRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50"` // 用户名
+	Email    string `json:"email" binding:"required,email,max=100"`   // 邮箱
+	Password string `json:"password" binding:"required,min=8,max=72"` // 密码，bcrypt最多支持72字节
+	Phone    string `json:"phone" binding:"omitempty,max=20"`         // 手机号
+	RealName string `json:"realName" binding:"omitempty,max=50"`      // 真实姓名
+	UserType int    `json:"userType" binding:"required,oneof=1 2 4"`  // 用户类型 1: 求职者 2: 招聘者 4: 企业用户
+}
+
+// UserLoginRequest 用户登录请求
+type UserLoginRequest struct {
+	Account  string `json:"account" binding:"required"`  // 用户名或邮箱
+	Password string `json:"password" binding:"required"` // 密码
+}
+
+// VerifyEmailRequest 邮箱验证请求
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"` // 邮箱验证令牌
+}
+
+// ForgotPasswordRequest 忘记密码请求
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"` // 注册邮箱
+}
+
+// ResetPasswordRequest 重置密码请求
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`                    // 重置密码令牌
+	NewPassword string `json:"newPassword" binding:"required,min=8,max=72"` // 新密码
+}
+
+// ToModel 转换为用户模型
+func (r *UserRegisterRequest) ToModel() *model.User {
+	return &model.User{
+		Username: r.Username,
+		Email:    r.Email,
+		Phone:    r.Phone,
+		RealName: r.RealName,
+		UserType: model.UserType(r.UserType),
+	}
+}
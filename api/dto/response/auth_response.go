@@ -0,0 +1,34 @@
+package response
+
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// TokenResponse 令牌响应
+type TokenResponse struct {
+	AccessToken      string    `json:"accessToken"`      // 访问令牌
+	RefreshToken     string    `json:"refreshToken"`     // 刷新令牌
+	TokenType        string    `json:"tokenType"`        // 令牌类型，固定为 Bearer
+	ExpiresIn        int64     `json:"expiresIn"`        // 访问令牌剩余有效秒数
+	ExpiresAt        time.Time `json:"expiresAt"`        // 访问令牌过期时间
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"` // 刷新令牌过期时间
+}
+
+// LogoutAllResponse 退出所有设备响应
+type LogoutAllResponse struct {
+	RevokedSessions int64 `json:"revokedSessions"` // 吊销的会话数量
+}
+
+// NewTokenResponse 从令牌对构建响应
+func NewTokenResponse(pair *utils.TokenPair) *TokenResponse {
+	return &TokenResponse{
+		AccessToken:      pair.AccessToken,
+		RefreshToken:     pair.RefreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(time.Until(pair.AccessExpiresAt).Seconds()),
+		ExpiresAt:        pair.AccessExpiresAt,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+	}
+}
@@ -11,12 +11,13 @@ type Response struct {
 
 // PageResponse 分页响应结构
 type PageResponse struct {
-	Code    errors.ErrorCode `json:"code"`           // 状态码
-	Message string           `json:"message"`        // 响应信息
-	Data    interface{}      `json:"data,omitempty"` // 响应数据
-	Total   int64            `json:"total"`          // 总记录数
-	Page    int              `json:"page"`           // 当前页码
-	Size    int              `json:"size"`           // 每页大小
+	Code       errors.ErrorCode `json:"code"`                 // 状态码
+	Message    string           `json:"message"`              // 响应信息
+	Data       interface{}      `json:"data,omitempty"`       // 响应数据
+	Total      *int64           `json:"total,omitempty"`      // 总记录数，未要求统计时不返回
+	Page       int              `json:"page"`                 // 当前页码，按游标分页时无意义
+	Size       int              `json:"size"`                 // 每页大小
+	NextCursor string           `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时不返回
 }
 
 // NewSuccess 创建成功响应
@@ -45,13 +46,14 @@ func NewErrorWithMsg(code errors.ErrorCode, message string) *Response {
 }
 
 // NewPage 创建分页响应
-func NewPage(data interface{}, total int64, page, size int) *PageResponse {
+func NewPage(data interface{}, total *int64, nextCursor string, page, size int) *PageResponse {
 	return &PageResponse{
-		Code:    errors.Success,
-		Message: errors.Success.String(),
-		Data:    data,
-		Total:   total,
-		Page:    page,
-		Size:    size,
+		Code:       errors.Success,
+		Message:    errors.Success.String(),
+		Data:       data,
+		Total:      total,
+		Page:       page,
+		Size:       size,
+		NextCursor: nextCursor,
 	}
 }
@@ -0,0 +1,80 @@
+package response
+
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// CompanyResponse 公司信息响应
+type CompanyResponse struct {
+	ID           uint       `json:"id"`           // 公司ID
+	Name         string     `json:"name"`         // 公司名称
+	ShortName    string     `json:"shortName"`    // 公司简称
+	Logo         string     `json:"logo"`         // 公司Logo
+	Industry     string     `json:"industry"`     // 所属行业
+	Scale        string     `json:"scale"`        // 公司规模
+	Website      string     `json:"website"`      // 公司官网
+	Address      string     `json:"address"`      // 公司地址
+	Description  string     `json:"description"`  // 公司介绍
+	ContactEmail string     `json:"contactEmail"` // 联系邮箱
+	ContactPhone string     `json:"contactPhone"` // 联系电话
+	VerifyStatus int        `json:"verifyStatus"` // 认证状态 0: 待认证 1: 已认证 2: 认证驳回
+	VerifiedAt   *time.Time `json:"verifiedAt"`   // 认证时间
+	Status       int        `json:"status"`       // 状态 1: 正常 2: 停用
+	CreateTime   time.Time  `json:"createTime"`   // 创建时间
+}
+
+// CompanyMemberResponse 公司成员响应
+type CompanyMemberResponse struct {
+	UserID     uint      `json:"userId"`     // 用户ID
+	Username   string    `json:"username"`   // 用户名
+	RealName   string    `json:"realName"`   // 真实姓名
+	Email      string    `json:"email"`      // 邮箱
+	Role       string    `json:"role"`       // 成员角色
+	InvitedBy  uint      `json:"invitedBy"`  // 邀请人
+	CreateTime time.Time `json:"createTime"` // 加入时间
+}
+
+// CompanyMembershipResponse 当前用户加入的公司
+type CompanyMembershipResponse struct {
+	Company *CompanyResponse `json:"company"` // 公司信息
+	Role    string           `json:"role"`    // 在该公司中的角色
+}
+
+// NewCompanyResponse 从模型转换为响应
+func NewCompanyResponse(c *model.Company) *CompanyResponse {
+	return &CompanyResponse{
+		ID:           c.ID,
+		Name:         c.Name,
+		ShortName:    c.ShortName,
+		Logo:         c.Logo,
+		Industry:     c.Industry,
+		Scale:        c.Scale,
+		Website:      c.Website,
+		Address:      c.Address,
+		Description:  c.Description,
+		ContactEmail: c.ContactEmail,
+		ContactPhone: c.ContactPhone,
+		VerifyStatus: int(c.VerifyStatus),
+		VerifiedAt:   c.VerifiedAt,
+		Status:       int(c.Status),
+		CreateTime:   c.CreateTime,
+	}
+}
+
+// NewCompanyMemberResponse 从成员记录与用户信息构建响应，用户不存在时只返回成员信息
+func NewCompanyMemberResponse(m *model.CompanyMember, u *model.User) *CompanyMemberResponse {
+	resp := &CompanyMemberResponse{
+		UserID:     m.UserID,
+		Role:       string(m.Role),
+		InvitedBy:  m.InvitedBy,
+		CreateTime: m.CreateTime,
+	}
+	if u != nil {
+		resp.Username = u.Username
+		resp.RealName = u.RealName
+		resp.Email = u.Email
+	}
+	return resp
+}
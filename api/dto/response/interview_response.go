@@ -0,0 +1,81 @@
+package response
+
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// InterviewerResponse 面试官
+type InterviewerResponse struct {
+	UserID uint   `json:"userId"` // 用户ID
+	Name   string `json:"name"`   // 姓名
+}
+
+// InterviewResponse 面试安排
+type InterviewResponse struct {
+	ID           uint                  `json:"id"`           // 面试ID
+	ApplyID      uint                  `json:"applyId"`      // 申请ID
+	JobID        uint                  `json:"jobId"`        // 职位ID
+	CompanyID    uint                  `json:"companyId"`    // 公司ID
+	CandidateID  uint                  `json:"candidateId"`  // 候选人用户ID
+	Round        int                   `json:"round"`        // 面试轮次
+	Title        string                `json:"title"`        // 面试标题
+	Mode         string                `json:"mode"`         // 面试方式 onsite/video/phone
+	StartTime    time.Time             `json:"startTime"`    // 开始时间
+	EndTime      time.Time             `json:"endTime"`      // 结束时间
+	Location     string                `json:"location"`     // 面试地点
+	MeetingURL   string                `json:"meetingUrl"`   // 视频会议链接
+	Status       int                   `json:"status"`       // 状态 1: 已安排 2: 已完成 3: 已取消
+	StatusName   string                `json:"statusName"`   // 状态名称
+	Sequence     int                   `json:"sequence"`     // 日历修订序号
+	Remark       string                `json:"remark"`       // 面试说明
+	CancelReason string                `json:"cancelReason"` // 取消原因
+	CancelledAt  *time.Time            `json:"cancelledAt"`  // 取消时间
+	Interviewers []InterviewerResponse `json:"interviewers"` // 面试官
+	CreatedBy    uint                  `json:"createdBy"`    // 安排人
+	CreateTime   time.Time             `json:"createTime"`   // 创建时间
+}
+
+// InterviewConflictResponse 与面试安排冲突的已有面试
+type InterviewConflictResponse struct {
+	InterviewID    uint      `json:"interviewId"`              // 冲突的面试ID
+	StartTime      time.Time `json:"startTime"`                // 开始时间
+	EndTime        time.Time `json:"endTime"`                  // 结束时间
+	InterviewerIDs []uint    `json:"interviewerIds,omitempty"` // 时间冲突的面试官
+	Candidate      bool      `json:"candidate"`                // 候选人是否时间冲突
+}
+
+// NewInterviewResponse 创建面试响应，users 用于填充面试官姓名
+func NewInterviewResponse(interview *model.Interview, users map[uint]*model.User) *InterviewResponse {
+	resp := &InterviewResponse{
+		ID:           interview.ID,
+		ApplyID:      interview.ApplyID,
+		JobID:        interview.JobID,
+		CompanyID:    interview.CompanyID,
+		CandidateID:  interview.CandidateID,
+		Round:        interview.Round,
+		Title:        interview.Title,
+		Mode:         string(interview.Mode),
+		StartTime:    interview.StartTime,
+		EndTime:      interview.EndTime,
+		Location:     interview.Location,
+		MeetingURL:   interview.MeetingURL,
+		Status:       int(interview.Status),
+		StatusName:   interview.Status.String(),
+		Sequence:     interview.Sequence,
+		Remark:       interview.Remark,
+		CancelReason: interview.CancelReason,
+		CancelledAt:  interview.CancelledAt,
+		Interviewers: make([]InterviewerResponse, len(interview.Interviewers)),
+		CreatedBy:    interview.CreatedBy,
+		CreateTime:   interview.CreateTime,
+	}
+	for i, v := range interview.Interviewers {
+		resp.Interviewers[i] = InterviewerResponse{UserID: v.UserID}
+		if u, ok := users[v.UserID]; ok {
+			resp.Interviewers[i].Name = u.DisplayName()
+		}
+	}
+	return resp
+}
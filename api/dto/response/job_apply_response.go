@@ -1,6 +1,13 @@
 package response
 
-import "time"
+import (
+	stderrors "errors"
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
 
 // JobApplyResponse 职位申请响应对象
 // @Description 职位申请响应对象
@@ -9,17 +16,37 @@ type JobApplyResponse struct {
 	JobID         uint   `json:"jobId"`         // 职位ID
 	UserID        uint   `json:"userId"`        // 用户ID
 	ResumeID      uint   `json:"resumeId"`      // 简历ID
-	Status        int    `json:"status"`        // 状态 1: 正常 0: 删除
-	ApplyProgress string `json:"applyProgress"` // 申请进度状态
+	Status        int    `json:"status"`        // 申请状态，取值见 enums.JobApplyEnum
+	ApplyProgress string `json:"applyProgress"` // 申请进度，即当前阶段名称
 	// enum: 待处理,进行中,已接受,已拒绝,已撤回,待面试,面试通过,面试不通过,已发送Offer,Offer已接受,Offer已拒绝
 	// example: 待面试
-	ApplyTime time.Time `json:"applyTime"` // 申请时间
+	ApplyTime        time.Time               `json:"applyTime"`                  // 申请时间
+	Reason           string                  `json:"reason,omitempty"`           // 拒绝原因，如未满足筛选问题的淘汰规则
+	ScreeningAnswers []model.ScreeningAnswer `json:"screeningAnswers,omitempty"` // 职位筛选问题的回答
+	JobRevision      int                     `json:"jobRevision"`                // 申请时职位的修订版本号，可与职位当前版本比较内容变化
+	Rating           int                     `json:"rating,omitempty"`           // 招聘方评分 1-5，仅公司侧列表返回
+	Tags             []string                `json:"tags,omitempty"`             // 招聘方标签，仅公司侧列表返回
+}
+
+// ApplyTagCountResponse 标签及使用次数
+type ApplyTagCountResponse struct {
+	Tag   string `json:"tag"`   // 标签
+	Count int64  `json:"count"` // 使用该标签的申请数
 }
 
 // JobApplyListResponse 职位申请列表响应
 type JobApplyListResponse struct {
-	Total   int64              `json:"total"`
-	Records []JobApplyResponse `json:"records"`
+	Total        *int64                     `json:"total,omitempty"` // 总数，未要求统计时不返回
+	Records      []JobApplyResponse         `json:"records"`
+	NextCursor   string                     `json:"nextCursor,omitempty"`   // 下一页的游标，没有下一页时不返回
+	StatusCounts []ApplyStatusCountResponse `json:"statusCounts,omitempty"` // 各状态的申请数，仅公司侧列表返回
+}
+
+// ApplyStatusCountResponse 申请状态及申请数，按除状态外的筛选条件统计
+type ApplyStatusCountResponse struct {
+	Status int    `json:"status"` // 申请状态
+	Name   string `json:"name"`   // 状态名称，职位列表取招聘流程中的阶段名称
+	Count  int64  `json:"count"`  // 申请数
 }
 
 // ToResponse 将 JobApply 转换为 JobApplyResponse
@@ -34,3 +61,245 @@ func (apply *JobApplyResponse) ToResponse() *JobApplyResponse {
 		ApplyTime:     apply.ApplyTime,
 	}
 }
+
+// JobApplyNextStatesResponse 申请当前阶段及可流转的后续状态
+type JobApplyNextStatesResponse struct {
+	ApplyID        uint                         `json:"applyId"`        // 申请ID
+	Status         int                          `json:"status"`         // 当前状态
+	StageName      string                       `json:"stageName"`      // 当前阶段名称
+	Terminal       bool                         `json:"terminal"`       // 是否为终态
+	CustomPipeline bool                         `json:"customPipeline"` // 职位是否使用自定义流程
+	Next           []PipelineTransitionResponse `json:"next"`           // 可流转的后续状态
+}
+
+// PipelineStageResponse 招聘流程阶段
+type PipelineStageResponse struct {
+	Status int    `json:"status"` // 申请状态
+	Name   string `json:"name"`   // 阶段名称
+}
+
+// PipelineTransitionResponse 招聘流程中的状态流转
+type PipelineTransitionResponse struct {
+	From   int      `json:"from"`   // 起始状态
+	To     int      `json:"to"`     // 目标状态
+	Name   string   `json:"name"`   // 目标阶段名称
+	Actors []string `json:"actors"` // 可触发流转的一方 candidate/company
+}
+
+// JobPipelineResponse 职位招聘流程
+type JobPipelineResponse struct {
+	JobID       uint                         `json:"jobId"`       // 职位ID
+	Custom      bool                         `json:"custom"`      // 是否为职位自定义流程
+	Stages      []PipelineStageResponse      `json:"stages"`      // 流程阶段
+	Transitions []PipelineTransitionResponse `json:"transitions"` // 允许的状态流转
+}
+
+// NewPipelineTransitionResponse 创建状态流转响应，目标阶段名称取自流程定义
+func NewPipelineTransitionResponse(p *model.PipelineDefinition, t model.PipelineTransition) PipelineTransitionResponse {
+	actors := make([]string, len(t.Actors))
+	for i, a := range t.Actors {
+		actors[i] = string(a)
+	}
+	return PipelineTransitionResponse{
+		From:   int(t.From),
+		To:     int(t.To),
+		Name:   stageName(p, int(t.To)),
+		Actors: actors,
+	}
+}
+
+// NewJobPipelineResponse 创建职位招聘流程响应
+func NewJobPipelineResponse(jobID uint, p *model.PipelineDefinition, custom bool) *JobPipelineResponse {
+	resp := &JobPipelineResponse{
+		JobID:       jobID,
+		Custom:      custom,
+		Stages:      make([]PipelineStageResponse, len(p.Stages)),
+		Transitions: make([]PipelineTransitionResponse, len(p.Transitions)),
+	}
+	for i, s := range p.Stages {
+		resp.Stages[i] = PipelineStageResponse{Status: int(s.Status), Name: s.DisplayName()}
+	}
+	for i, t := range p.Transitions {
+		resp.Transitions[i] = NewPipelineTransitionResponse(p, t)
+	}
+	return resp
+}
+
+// JobApplyEventResponse 申请状态流转事件
+type JobApplyEventResponse struct {
+	ID         uint      `json:"id"`         // 事件ID
+	ActorType  string    `json:"actorType"`  // 触发方 candidate/company/system
+	ActorID    uint      `json:"actorId"`    // 触发人用户ID
+	FromStatus int       `json:"fromStatus"` // 流转前状态，提交申请时为0
+	FromName   string    `json:"fromName"`   // 流转前阶段名称
+	ToStatus   int       `json:"toStatus"`   // 流转后状态
+	ToName     string    `json:"toName"`     // 流转后阶段名称
+	Reason     string    `json:"reason"`     // 流转原因
+	Note       string    `json:"note"`       // 备注
+	CreateTime time.Time `json:"createTime"` // 发生时间
+}
+
+// NewJobApplyEventResponse 创建流转事件响应，阶段名称取自职位的招聘流程
+func NewJobApplyEventResponse(p *model.PipelineDefinition, e *model.JobApplyEvent) JobApplyEventResponse {
+	return JobApplyEventResponse{
+		ID:         e.ID,
+		ActorType:  string(e.ActorType),
+		ActorID:    e.ActorID,
+		FromStatus: e.FromStatus,
+		FromName:   stageName(p, e.FromStatus),
+		ToStatus:   e.ToStatus,
+		ToName:     stageName(p, e.ToStatus),
+		Reason:     e.Reason,
+		Note:       e.Note,
+		CreateTime: e.CreateTime,
+	}
+}
+
+// stageName 获取状态在流程中的阶段名称，流程中已移除的状态使用默认名称
+func stageName(p *model.PipelineDefinition, status int) string {
+	if status == 0 {
+		return ""
+	}
+	if stage, ok := p.Stage(enums.JobApplyEnum(status)); ok {
+		return stage.DisplayName()
+	}
+	return enums.GetStatusText(status)
+}
+
+// JobApplyBulkStatusItem 批量流转中单条申请的处理结果
+type JobApplyBulkStatusItem struct {
+	ID      uint             `json:"id"`                // 申请ID
+	Success bool             `json:"success"`           // 是否流转成功
+	Code    errors.ErrorCode `json:"code,omitempty"`    // 失败时的错误码
+	Message string           `json:"message,omitempty"` // 失败原因
+}
+
+// Fail 记录失败原因，非业务错误统一视为服务器内部错误
+func (item *JobApplyBulkStatusItem) Fail(err error) {
+	item.Success = false
+	var bizErr *errors.Error
+	if stderrors.As(err, &bizErr) {
+		item.Code, item.Message = bizErr.Code, bizErr.Message
+		return
+	}
+	item.Code, item.Message = errors.InternalServerError, errors.InternalServerError.String()
+}
+
+// JobApplyBulkStatusResponse 批量流转申请状态结果
+type JobApplyBulkStatusResponse struct {
+	Total     int                      `json:"total"`     // 处理总数
+	Succeeded int                      `json:"succeeded"` // 成功数量
+	Failed    int                      `json:"failed"`    // 失败数量
+	Results   []JobApplyBulkStatusItem `json:"results"`   // 每条申请的处理结果，与请求顺序一致
+}
+
+// NewJobApplyBulkStatusResponse 汇总批量流转结果
+func NewJobApplyBulkStatusResponse(results []JobApplyBulkStatusItem) *JobApplyBulkStatusResponse {
+	resp := &JobApplyBulkStatusResponse{Total: len(results), Results: results}
+	for _, item := range results {
+		if item.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp
+}
+
+// ApplyNoteMentionResponse 备注中提及的成员
+type ApplyNoteMentionResponse struct {
+	UserID uint   `json:"userId"` // 用户ID
+	Name   string `json:"name"`   // 姓名
+}
+
+// JobApplyNoteResponse 申请备注，顶层备注附带回复
+type JobApplyNoteResponse struct {
+	ID         uint                       `json:"id"`         // 备注ID
+	ApplyID    uint                       `json:"applyId"`    // 申请ID
+	ParentID   *uint                      `json:"parentId"`   // 回复的顶层备注
+	AuthorID   uint                       `json:"authorId"`   // 作者ID
+	AuthorName string                     `json:"authorName"` // 作者姓名
+	Content    string                     `json:"content"`    // 备注内容
+	Mentions   []ApplyNoteMentionResponse `json:"mentions"`   // 提及的成员
+	Replies    []JobApplyNoteResponse     `json:"replies"`    // 回复，按时间正序
+	CreateTime time.Time                  `json:"createTime"` // 创建时间
+	UpdateTime time.Time                  `json:"updateTime"` // 更新时间
+}
+
+// NewJobApplyNoteResponse 创建申请备注响应，users 用于填充作者和被提及成员的姓名
+func NewJobApplyNoteResponse(note *model.JobApplyNote, users map[uint]*model.User) JobApplyNoteResponse {
+	resp := JobApplyNoteResponse{
+		ID:         note.ID,
+		ApplyID:    note.ApplyID,
+		ParentID:   note.ParentID,
+		AuthorID:   note.AuthorID,
+		Content:    note.Content,
+		Mentions:   make([]ApplyNoteMentionResponse, 0, len(note.Mentions)),
+		Replies:    []JobApplyNoteResponse{},
+		CreateTime: note.CreateTime,
+		UpdateTime: note.UpdateTime,
+	}
+	if u, ok := users[note.AuthorID]; ok {
+		resp.AuthorName = u.DisplayName()
+	}
+	for _, id := range note.Mentions {
+		mention := ApplyNoteMentionResponse{UserID: id}
+		if u, ok := users[id]; ok {
+			mention.Name = u.DisplayName()
+		}
+		resp.Mentions = append(resp.Mentions, mention)
+	}
+	return resp
+}
+
+// JobScreeningResponse 职位筛选问题
+type JobScreeningResponse struct {
+	JobID      uint                      `json:"jobId"`      // 职位ID
+	Configured bool                      `json:"configured"` // 职位是否配置了筛选问题
+	Questions  []model.ScreeningQuestion `json:"questions"`  // 筛选问题，申请人查看时不含淘汰规则
+}
+
+// NewJobScreeningResponse 创建职位筛选问题响应，withKnockout 为 false 时隐藏淘汰规则
+func NewJobScreeningResponse(jobID uint, d *model.ScreeningDefinition, configured, withKnockout bool) *JobScreeningResponse {
+	questions := d.Questions
+	if !withKnockout {
+		questions = d.PublicView().Questions
+	}
+	if questions == nil {
+		questions = []model.ScreeningQuestion{}
+	}
+	return &JobScreeningResponse{
+		JobID:      jobID,
+		Configured: configured,
+		Questions:  questions,
+	}
+}
+
+// BoardCardResponse 看板候选人卡片
+type BoardCardResponse struct {
+	ApplyID        uint      `json:"applyId"`        // 申请ID
+	UserID         uint      `json:"userId"`         // 候选人用户ID
+	ResumeID       uint      `json:"resumeId"`       // 简历ID
+	CandidateName  string    `json:"candidateName"`  // 简历姓名
+	CurrentTitle   string    `json:"currentTitle"`   // 最近一段工作经历的职位
+	StageEnteredAt time.Time `json:"stageEnteredAt"` // 进入当前阶段的时间
+	DaysInStage    int       `json:"daysInStage"`    // 在当前阶段停留的天数
+	ApplyTime      time.Time `json:"applyTime"`      // 申请时间
+	Rating         int       `json:"rating"`         // 招聘方评分，0 表示未评分
+	Tags           []string  `json:"tags"`           // 招聘方标签
+}
+
+// BoardStageResponse 看板阶段，包含阶段的申请总数及一页候选人卡片
+type BoardStageResponse struct {
+	Status     int                 `json:"status"`               // 申请状态
+	Name       string              `json:"name"`                 // 阶段名称
+	Count      int64               `json:"count"`                // 阶段的申请总数
+	Cards      []BoardCardResponse `json:"cards"`                // 候选人卡片，按申请时间倒序
+	NextCursor string              `json:"nextCursor,omitempty"` // 加载更多卡片的游标，为空表示没有更多
+}
+
+// JobBoardResponse 职位看板，按招聘流程的阶段顺序排列
+type JobBoardResponse struct {
+	JobID  uint                 `json:"jobId"`  // 职位ID
+	Stages []BoardStageResponse `json:"stages"` // 阶段列表
+}
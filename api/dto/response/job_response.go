@@ -19,7 +19,9 @@ type JobResponse struct {
 	JobDescribe   string                 `json:"jobDescribe"`                                                      // 职位描述
 	JobLocation   string                 `json:"jobLocation"`                                                      // 工作地点
 	JobExpireTime time.Time              `json:"jobExpireTime"`                                                    // 职位过期时间
-	Status        int                    `json:"status" example:"1" enums:"0,1"`                                   // 职位状态 0: 禁用 1: 正常
+	PublishTime   *time.Time             `json:"publishTime"`                                                      // 发布时间，待发布职位为计划发布时间
+	Revision      int                    `json:"revision"`                                                         // 当前修订版本号
+	Status        int                    `json:"status" example:"1" enums:"0,1,2,3,4,5"`                           // 职位状态 0: 已过期 1: 正常 2: 待发布 3: 草稿 4: 待审核 5: 审核驳回
 	JobType       int                    `json:"jobType" example:"1" enums:"1,2,3"`                                // 职位类型 1 全职 2 兼职 3 实习
 	RemoteType    int                    `json:"remoteType" example:"1" enums:"1,2,3,4"`                           // 远程办公类型
 	RemoteDesc    string                 `json:"remoteDesc"`                                                       // 远程办公描述
@@ -38,6 +40,8 @@ type JobResponse struct {
 	Benefits      []model.JobBenefitType `json:"benefits" `
 	BenefitDesc   string                 `json:"benefitDesc"`
 	BenefitTexts  []string               `json:"benefitTexts"`
+	// 结构化工作地点
+	GeoLocation model.GeoLocation `json:"geoLocation"`
 	// 是否已收藏
 	IsFavorited bool `json:"isFavorited"`
 	// 收藏时间
@@ -48,8 +52,58 @@ type JobResponse struct {
 
 // JobListResponse 职位列表响应
 type JobListResponse struct {
-	Total   int64         `json:"total"`   // 总数
-	Records []JobResponse `json:"records"` // 公司信息
+	Total      *int64        `json:"total,omitempty"`      // 总数，未要求统计时不返回
+	Records    []JobResponse `json:"records"`              // 公司信息
+	NextCursor string        `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时不返回
+}
+
+// JobHighlightResponse 搜索关键词高亮片段，关键词以 <em> 标记，未命中的字段为空
+type JobHighlightResponse struct {
+	Name        string `json:"name,omitempty"`        // 职位名称
+	JobSkill    string `json:"jobSkill,omitempty"`    // 技能要求
+	JobDescribe string `json:"jobDescribe,omitempty"` // 职位描述片段
+}
+
+// JobSearchRecord 职位搜索结果
+type JobSearchRecord struct {
+	JobResponse
+	Rank       float64              `json:"rank"`               // 关键词相关度，未指定关键词时为0
+	Distance   *float64             `json:"distance,omitempty"` // 距中心点的距离(公里)，指定中心点且职位有坐标时返回
+	Highlights JobHighlightResponse `json:"highlights"`         // 高亮片段
+}
+
+// JobFacetValueResponse 分面取值及职位数
+type JobFacetValueResponse struct {
+	Value    string `json:"value"`    // 取值，作为筛选参数回传
+	Name     string `json:"name"`     // 展示名称
+	Count    int64  `json:"count"`    // 在其他分面筛选条件下的职位数
+	Selected bool   `json:"selected"` // 是否已选中
+}
+
+// JobFacetResponse 职位搜索分面
+type JobFacetResponse struct {
+	Field  string                  `json:"field"`  // 分面标识，与筛选参数名一致
+	Name   string                  `json:"name"`   // 分面名称
+	Values []JobFacetValueResponse `json:"values"` // 取值列表
+}
+
+// JobSearchResponse 职位搜索响应
+type JobSearchResponse struct {
+	Total   int64              `json:"total"`   // 总数
+	Records []JobSearchRecord  `json:"records"` // 搜索结果，按指定方式排序
+	Facets  []JobFacetResponse `json:"facets"`  // 分面统计，基于当前筛选条件
+}
+
+// JobRecommendRecord 推荐职位
+type JobRecommendRecord struct {
+	JobResponse
+	Score   float64  `json:"score"`             // 匹配度，0-1
+	Reasons []string `json:"reasons,omitempty"` // 推荐理由，相似职位不返回
+}
+
+// JobRecommendResponse 推荐职位列表
+type JobRecommendResponse struct {
+	Records []JobRecommendRecord `json:"records"` // 按匹配度倒序
 }
 
 // 新增获取job是否激活的方法
@@ -68,6 +122,8 @@ func FromJob(job *model.Job) *JobResponse {
 		JobDescribe:   job.JobDescribe,
 		JobLocation:   job.JobLocation,
 		JobExpireTime: job.JobExpireTime,
+		PublishTime:   job.PublishTime,
+		Revision:      job.Revision,
 		Status:        job.Status,
 		JobType:       job.JobType,
 		JobCategory:   job.JobCategory,
@@ -82,6 +138,7 @@ func FromJob(job *model.Job) *JobResponse {
 		Tags:          job.Tags,
 		Benefits:      job.Benefits,
 		BenefitDesc:   job.BenefitDesc,
+		GeoLocation:   job.GeoLocation,
 	}
 
 	// 转换福利为文本描述
@@ -92,3 +149,93 @@ func FromJob(job *model.Job) *JobResponse {
 
 	return resp
 }
+
+// JobRevisionResponse 职位修订版本
+type JobRevisionResponse struct {
+	Revision     int                     `json:"revision"`               // 版本号
+	Action       model.JobRevisionAction `json:"action"`                 // 操作 create/update/restore
+	RestoredFrom int                     `json:"restoredFrom,omitempty"` // 恢复自的版本号
+	EditorID     uint                    `json:"editorId"`               // 操作人用户ID，升级前已存在的职位补存的版本为0
+	Current      bool                    `json:"current"`                // 是否为职位当前版本
+	CreateTime   time.Time               `json:"createTime"`             // 操作时间
+}
+
+// NewJobRevisionResponse 创建修订版本响应
+func NewJobRevisionResponse(r *model.JobRevision, current int) JobRevisionResponse {
+	return JobRevisionResponse{
+		Revision:     r.Revision,
+		Action:       r.Action,
+		RestoredFrom: r.RestoredFrom,
+		EditorID:     r.EditorID,
+		Current:      r.Revision == current,
+		CreateTime:   r.CreateTime,
+	}
+}
+
+// JobRevisionDiffResponse 两个修订版本之间的字段变化
+type JobRevisionDiffResponse struct {
+	JobID   uint                   `json:"jobId"`   // 职位ID
+	From    int                    `json:"from"`    // 旧版本号
+	To      int                    `json:"to"`      // 新版本号
+	Changes []model.JobFieldChange `json:"changes"` // 发生变化的字段，无变化时为空
+}
+
+// JobReviewResponse 职位审核记录
+type JobReviewResponse struct {
+	ID          uint                  `json:"id"`                   // 审核记录ID
+	JobID       uint                  `json:"jobId"`                // 职位ID
+	JobName     string                `json:"jobName,omitempty"`    // 职位名称，仅审核队列返回
+	CompanyID   uint                  `json:"companyId"`            // 公司ID
+	Revision    int                   `json:"revision"`             // 提交审核时职位的修订版本号
+	SubmitterID uint                  `json:"submitterId"`          // 提交人
+	Status      model.JobReviewStatus `json:"status"`               // 审核结果 pending/approved/rejected
+	Issues      []model.ContentIssue  `json:"issues"`               // 内容检查发现的问题
+	ReviewerID  uint                  `json:"reviewerId,omitempty"` // 审核人，自动审核为0
+	Reason      string                `json:"reason,omitempty"`     // 审核意见
+	ReviewTime  *time.Time            `json:"reviewTime,omitempty"` // 审核时间
+	CreateTime  time.Time             `json:"createTime"`           // 提交时间
+}
+
+// NewJobReviewResponse 创建审核记录响应
+func NewJobReviewResponse(r *model.JobReview) JobReviewResponse {
+	issues := r.Issues
+	if issues == nil {
+		issues = []model.ContentIssue{}
+	}
+	return JobReviewResponse{
+		ID:          r.ID,
+		JobID:       r.JobID,
+		CompanyID:   r.CompanyID,
+		Revision:    r.Revision,
+		SubmitterID: r.SubmitterID,
+		Status:      r.Status,
+		Issues:      issues,
+		ReviewerID:  r.ReviewerID,
+		Reason:      r.Reason,
+		ReviewTime:  r.ReviewTime,
+		CreateTime:  r.CreateTime,
+	}
+}
+
+// JobReviewListResponse 职位审核队列
+type JobReviewListResponse struct {
+	Total      *int64              `json:"total,omitempty"`      // 总数，未要求统计时不返回
+	Records    []JobReviewResponse `json:"records"`              // 审核记录，先提交的在前
+	NextCursor string              `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时不返回
+}
+
+// JobImportError 职位导入的错误，Row 为表格中的行号(表头为第1行)，Column 为空时表示整行的错误
+type JobImportError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// JobImportResponse 职位导入结果，存在任何错误时不导入任何职位
+type JobImportResponse struct {
+	DryRun   bool             `json:"dryRun"`   // 是否仅校验
+	Total    int              `json:"total"`    // 数据行数
+	Imported int              `json:"imported"` // 已导入的职位数
+	JobIDs   []uint           `json:"jobIds"`   // 已导入的职位ID，与数据行顺序一致
+	Errors   []JobImportError `json:"errors"`   // 各行的错误
+}
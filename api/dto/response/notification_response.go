@@ -19,8 +19,9 @@ type NotificationResponse struct {
 
 // NotificationListResponse 通知列表响应
 type NotificationListResponse struct {
-	Total   int64                  `json:"total"`
-	Records []NotificationResponse `json:"records"`
+	Total      *int64                 `json:"total,omitempty"` // 总数，未要求统计时不返回
+	Records    []NotificationResponse `json:"records"`
+	NextCursor string                 `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时不返回
 }
 
 // FromModel 从模型转换为响应
@@ -0,0 +1,124 @@
+package response
+
+import (
+	"fmt"
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// OfferApprovalResponse Offer审批节点
+type OfferApprovalResponse struct {
+	Step         int        `json:"step"`         // 审批顺序
+	ApproverID   uint       `json:"approverId"`   // 审批人用户ID
+	ApproverName string     `json:"approverName"` // 审批人姓名
+	Status       string     `json:"status"`       // 审批状态 pending/approved/rejected
+	Comment      string     `json:"comment"`      // 审批意见
+	DecidedAt    *time.Time `json:"decidedAt"`    // 审批时间
+}
+
+// OfferResponse Offer
+type OfferResponse struct {
+	ID            uint                    `json:"id"`            // Offer ID
+	ApplyID       uint                    `json:"applyId"`       // 申请ID
+	JobID         uint                    `json:"jobId"`         // 职位ID
+	CompanyID     uint                    `json:"companyId"`     // 公司ID
+	CandidateID   uint                    `json:"candidateId"`   // 候选人用户ID
+	TemplateID    *uint                   `json:"templateId"`    // Offer函模板ID
+	Position      string                  `json:"position"`      // 录用职位名称
+	Department    string                  `json:"department"`    // 所属部门
+	Currency      string                  `json:"currency"`      // 币种
+	BaseSalary    int64                   `json:"baseSalary"`    // 月基本工资
+	SalaryMonths  int                     `json:"salaryMonths"`  // 年薪月数
+	AnnualSalary  int64                   `json:"annualSalary"`  // 年薪
+	SigningBonus  int64                   `json:"signingBonus"`  // 签字费
+	Benefits      string                  `json:"benefits"`      // 福利待遇
+	Location      string                  `json:"location"`      // 工作地点
+	StartDate     time.Time               `json:"startDate"`     // 入职日期
+	ExpireAt      time.Time               `json:"expireAt"`      // 答复截止时间
+	Remark        string                  `json:"remark"`        // 备注
+	Status        int                     `json:"status"`        // 状态 1: 草稿 2: 审批中 3: 待发送 4: 待候选人确认 5: 已接受 6: 已拒绝 7: 已过期 8: 已撤回
+	StatusName    string                  `json:"statusName"`    // 状态名称
+	SentAt        *time.Time              `json:"sentAt"`        // 发送时间
+	RespondedAt   *time.Time              `json:"respondedAt"`   // 候选人答复时间
+	DeclineReason string                  `json:"declineReason"` // 拒绝原因
+	LetterURL     string                  `json:"letterUrl"`     // Offer函下载地址
+	Approvals     []OfferApprovalResponse `json:"approvals"`     // 审批链，候选人查看时为空
+	CreatedBy     uint                    `json:"createdBy"`     // 创建人
+	CreateTime    time.Time               `json:"createTime"`    // 创建时间
+}
+
+// OfferTemplateResponse Offer函模板
+type OfferTemplateResponse struct {
+	ID         uint      `json:"id"`         // 模板ID
+	CompanyID  uint      `json:"companyId"`  // 公司ID
+	Name       string    `json:"name"`       // 模板名称
+	Title      string    `json:"title"`      // Offer函标题
+	Content    string    `json:"content"`    // 模板内容
+	CreatedBy  uint      `json:"createdBy"`  // 创建人
+	UpdateTime time.Time `json:"updateTime"` // 更新时间
+}
+
+// NewOfferResponse 创建Offer响应
+// withApprovals 为 false 时不返回审批链，用于候选人查看；users 用于填充审批人姓名
+func NewOfferResponse(offer *model.Offer, users map[uint]*model.User, withApprovals bool) *OfferResponse {
+	resp := &OfferResponse{
+		ID:            offer.ID,
+		ApplyID:       offer.ApplyID,
+		JobID:         offer.JobID,
+		CompanyID:     offer.CompanyID,
+		CandidateID:   offer.CandidateID,
+		TemplateID:    offer.TemplateID,
+		Position:      offer.Position,
+		Department:    offer.Department,
+		Currency:      offer.Currency,
+		BaseSalary:    offer.BaseSalary,
+		SalaryMonths:  offer.SalaryMonths,
+		AnnualSalary:  offer.AnnualSalary(),
+		SigningBonus:  offer.SigningBonus,
+		Benefits:      offer.Benefits,
+		Location:      offer.Location,
+		StartDate:     offer.StartDate,
+		ExpireAt:      offer.ExpireAt,
+		Remark:        offer.Remark,
+		Status:        int(offer.Status),
+		StatusName:    offer.Status.String(),
+		SentAt:        offer.SentAt,
+		RespondedAt:   offer.RespondedAt,
+		DeclineReason: offer.DeclineReason,
+		LetterURL:     fmt.Sprintf("/api/v1/offers/%d/letter", offer.ID),
+		Approvals:     []OfferApprovalResponse{},
+		CreatedBy:     offer.CreatedBy,
+		CreateTime:    offer.CreateTime,
+	}
+	if !withApprovals {
+		return resp
+	}
+	for _, a := range offer.Approvals {
+		item := OfferApprovalResponse{
+			Step:       a.Step,
+			ApproverID: a.ApproverID,
+			Status:     string(a.Status),
+			Comment:    a.Comment,
+			DecidedAt:  a.DecidedAt,
+		}
+		if u, ok := users[a.ApproverID]; ok {
+			item.ApproverName = u.DisplayName()
+		}
+		resp.Approvals = append(resp.Approvals, item)
+	}
+	return resp
+}
+
+// NewOfferTemplateResponse 创建Offer函模板响应
+func NewOfferTemplateResponse(template *model.OfferTemplate) *OfferTemplateResponse {
+	return &OfferTemplateResponse{
+		ID:         template.ID,
+		CompanyID:  template.CompanyID,
+		Name:       template.Name,
+		Title:      template.Title,
+		Content:    template.Content,
+		CreatedBy:  template.CreatedBy,
+		UpdateTime: template.UpdateTime,
+	}
+}
@@ -1,6 +1,10 @@
 package response
 
-import "time"
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
 
 // ResumeResponse 简历响应对象
 type ResumeResponse struct {
@@ -24,6 +28,9 @@ type ResumeResponse struct {
 	WorkingStatus int       `json:"workingStatus"`
 	Status        int       `json:"status"`
 
+	// 结构化所在地
+	GeoLocation model.GeoLocation `json:"geoLocation"`
+
 	// 关联数据
 	Educations      []EducationResponse      `json:"educations"`
 	WorkExperiences []WorkExperienceResponse `json:"workExperiences"`
@@ -81,6 +88,7 @@ type AttachmentResponse struct {
 
 // ResumeListResponse 简历列表响应
 type ResumeListResponse struct {
-	Total   int64            `json:"total"`
-	Records []ResumeResponse `json:"records"`
+	Total      *int64           `json:"total,omitempty"` // 总数，未要求统计时不返回
+	Records    []ResumeResponse `json:"records"`
+	NextCursor string           `json:"nextCursor,omitempty"` // 下一页的游标，没有下一页时不返回
 }
@@ -0,0 +1,147 @@
+package response
+
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// ScorecardCompetencyResponse 评分卡考察项
+type ScorecardCompetencyResponse struct {
+	Key         string `json:"key"`         // 考察项标识
+	Name        string `json:"name"`        // 考察项名称
+	Description string `json:"description"` // 评分说明
+	Scale       int    `json:"scale"`       // 评分档数，评分范围为 1 到 scale
+	Weight      int    `json:"weight"`      // 汇总权重
+}
+
+// ScorecardTemplateResponse 职位的评分卡模板
+type ScorecardTemplateResponse struct {
+	JobID           uint                          `json:"jobId"`           // 职位ID
+	Custom          bool                          `json:"custom"`          // 是否已配置评分卡，未配置时反馈只记录录用建议
+	RequireFeedback bool                          `json:"requireFeedback"` // 流转到面试通过或未通过前是否必须提交面试反馈
+	Competencies    []ScorecardCompetencyResponse `json:"competencies"`    // 考察项
+}
+
+// CompetencyRatingResponse 考察项评分
+type CompetencyRatingResponse struct {
+	Key     string `json:"key"`     // 考察项标识
+	Name    string `json:"name"`    // 考察项名称，已从评分卡移除的考察项为空
+	Score   int    `json:"score"`   // 评分
+	Scale   int    `json:"scale"`   // 评分档数
+	Comment string `json:"comment"` // 评语
+}
+
+// InterviewFeedbackResponse 面试反馈
+type InterviewFeedbackResponse struct {
+	ID                 uint                       `json:"id"`                 // 反馈ID
+	InterviewID        uint                       `json:"interviewId"`        // 面试ID
+	ApplyID            uint                       `json:"applyId"`            // 申请ID
+	InterviewerID      uint                       `json:"interviewerId"`      // 面试官用户ID
+	InterviewerName    string                     `json:"interviewerName"`    // 面试官姓名
+	Ratings            []CompetencyRatingResponse `json:"ratings"`            // 考察项评分
+	Recommendation     string                     `json:"recommendation"`     // 录用建议 strong_no/no/yes/strong_yes
+	RecommendationText string                     `json:"recommendationText"` // 录用建议名称
+	Summary            string                     `json:"summary"`            // 综合评价
+	CreateTime         time.Time                  `json:"createTime"`         // 提交时间
+	UpdateTime         time.Time                  `json:"updateTime"`         // 更新时间
+}
+
+// PendingFeedbackResponse 尚未提交反馈的面试官
+type PendingFeedbackResponse struct {
+	InterviewID     uint   `json:"interviewId"`     // 面试ID
+	InterviewerID   uint   `json:"interviewerId"`   // 面试官用户ID
+	InterviewerName string `json:"interviewerName"` // 面试官姓名
+}
+
+// ApplyScorecardResponse 申请的面试反馈汇总
+type ApplyScorecardResponse struct {
+	ApplyID              uint                        `json:"applyId"`              // 申请ID
+	FeedbackCount        int                         `json:"feedbackCount"`        // 反馈数量
+	Recommendation       string                      `json:"recommendation"`       // 汇总建议，无反馈时为空，意见不一致时为 undecided
+	RecommendationText   string                      `json:"recommendationText"`   // 汇总建议名称
+	RecommendationScore  float64                     `json:"recommendationScore"`  // 录用建议平均分值，-2 到 2
+	RecommendationCounts map[string]int              `json:"recommendationCounts"` // 各录用建议的数量
+	OverallScore         float64                     `json:"overallScore"`         // 按权重汇总的综合得分，0 到 100
+	Competencies         []model.CompetencyScore     `json:"competencies"`         // 考察项汇总评分
+	RequireFeedback      bool                        `json:"requireFeedback"`      // 职位是否要求提交面试反馈
+	Pending              []PendingFeedbackResponse   `json:"pending"`              // 已完成面试中尚未提交反馈的面试官
+	Feedbacks            []InterviewFeedbackResponse `json:"feedbacks"`            // 反馈明细
+}
+
+// NewScorecardTemplateResponse 创建评分卡模板响应，template 为空表示职位未配置评分卡
+func NewScorecardTemplateResponse(jobID uint, template *model.ScorecardTemplate) *ScorecardTemplateResponse {
+	resp := &ScorecardTemplateResponse{
+		JobID:        jobID,
+		Competencies: []ScorecardCompetencyResponse{},
+	}
+	if template == nil {
+		return resp
+	}
+	resp.Custom = true
+	resp.RequireFeedback = template.RequireFeedback
+	for _, c := range template.Definition.Competencies {
+		resp.Competencies = append(resp.Competencies, ScorecardCompetencyResponse{
+			Key:         c.Key,
+			Name:        c.Name,
+			Description: c.Description,
+			Scale:       c.Scale,
+			Weight:      c.EffectiveWeight(),
+		})
+	}
+	return resp
+}
+
+// NewInterviewFeedbackResponse 创建面试反馈响应，definition 用于填充考察项名称，interviewer 用于填充面试官姓名
+func NewInterviewFeedbackResponse(feedback *model.InterviewFeedback, definition *model.ScorecardDefinition, interviewer *model.User) *InterviewFeedbackResponse {
+	resp := &InterviewFeedbackResponse{
+		ID:                 feedback.ID,
+		InterviewID:        feedback.InterviewID,
+		ApplyID:            feedback.ApplyID,
+		InterviewerID:      feedback.InterviewerID,
+		Ratings:            make([]CompetencyRatingResponse, len(feedback.Ratings)),
+		Recommendation:     string(feedback.Recommendation),
+		RecommendationText: feedback.Recommendation.String(),
+		Summary:            feedback.Summary,
+		CreateTime:         feedback.CreateTime,
+		UpdateTime:         feedback.UpdateTime,
+	}
+	if interviewer != nil {
+		resp.InterviewerName = interviewer.DisplayName()
+	}
+	for i, r := range feedback.Ratings {
+		resp.Ratings[i] = CompetencyRatingResponse{Key: r.Key, Score: r.Score, Comment: r.Comment}
+		if definition == nil {
+			continue
+		}
+		if c, ok := definition.Competency(r.Key); ok {
+			resp.Ratings[i].Name = c.Name
+			resp.Ratings[i].Scale = c.Scale
+		}
+	}
+	return resp
+}
+
+// NewApplyScorecardResponse 创建申请的面试反馈汇总响应
+func NewApplyScorecardResponse(applyID uint, summary model.FeedbackSummary, requireFeedback bool) *ApplyScorecardResponse {
+	resp := &ApplyScorecardResponse{
+		ApplyID:              applyID,
+		FeedbackCount:        summary.Count,
+		Recommendation:       string(summary.Recommendation),
+		RecommendationText:   summary.Recommendation.String(),
+		RecommendationScore:  summary.RecommendationScore,
+		RecommendationCounts: make(map[string]int, len(summary.RecommendationCounts)),
+		OverallScore:         summary.OverallScore,
+		Competencies:         summary.Competencies,
+		RequireFeedback:      requireFeedback,
+		Pending:              []PendingFeedbackResponse{},
+		Feedbacks:            []InterviewFeedbackResponse{},
+	}
+	if resp.Competencies == nil {
+		resp.Competencies = []model.CompetencyScore{}
+	}
+	for k, v := range summary.RecommendationCounts {
+		resp.RecommendationCounts[string(k)] = v
+	}
+	return resp
+}
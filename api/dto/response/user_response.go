@@ -0,0 +1,47 @@
+package response
+
+import (
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// UserResponse 用户信息响应
+type UserResponse struct {
+	ID            uint       `json:"id"`            // 用户ID
+	Username      string     `json:"username"`      // 用户名
+	Email         string     `json:"email"`         // 邮箱
+	Phone         string     `json:"phone"`         // 手机号
+	RealName      string     `json:"realName"`      // 真实姓名
+	Avatar        string     `json:"avatar"`        // 头像
+	UserType      int        `json:"userType"`      // 用户类型
+	Role          string     `json:"role"`          // 角色编码
+	CompanyID     uint       `json:"companyId"`     // 所属公司ID
+	EmailVerified bool       `json:"emailVerified"` // 邮箱是否已验证
+	LastLoginAt   *time.Time `json:"lastLoginAt"`   // 最后登录时间
+	CreateTime    time.Time  `json:"createTime"`    // 注册时间
+}
+
+// LoginResponse 登录响应
+type LoginResponse struct {
+	User  *UserResponse  `json:"user"`  // 用户信息
+	Token *TokenResponse `json:"token"` // 令牌信息
+}
+
+// NewUserResponse 从模型转换为响应
+func NewUserResponse(u *model.User) *UserResponse {
+	return &UserResponse{
+		ID:            u.ID,
+		Username:      u.Username,
+		Email:         u.Email,
+		Phone:         u.Phone,
+		RealName:      u.RealName,
+		Avatar:        u.Avatar,
+		UserType:      int(u.UserType),
+		Role:          u.UserType.Code(),
+		CompanyID:     u.CompanyID,
+		EmailVerified: u.EmailVerified,
+		LastLoginAt:   u.LastLoginAt,
+		CreateTime:    u.CreateTime,
+	}
+}
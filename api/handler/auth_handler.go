@@ -0,0 +1,116 @@
+package handler
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// AuthHandler 令牌管理处理器
+type AuthHandler struct {
+	authService *service.AuthService
+}
+
+// NewAuthHandler 创建令牌管理处理器
+func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+// Refresh 刷新令牌
+//
+//	@Summary		刷新令牌
+//	@Description	使用刷新令牌换取新的访问令牌与刷新令牌，旧令牌随即失效
+//	@Tags			认证管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.RefreshTokenRequest	true	"刷新令牌"
+//	@Success		200		{object}	response.Response{data=response.TokenResponse}
+//	@Router			/api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req request.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	pair, err := h.authService.Refresh(req.RefreshToken, tokenClient(c))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewTokenResponse(pair)))
+}
+
+// Logout 退出登录
+//
+//	@Summary		退出登录
+//	@Description	吊销当前访问令牌及其对应的刷新令牌
+//	@Tags			认证管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	userID := c.GetUint("userId")
+	tokenID := c.GetString("tokenId")
+	if tokenID == "" {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidToken))
+		return
+	}
+
+	if err := h.authService.Logout(userID, tokenID, c.GetTime("tokenExpiresAt")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// LogoutAll 退出所有设备
+//
+//	@Summary		退出所有设备
+//	@Description	吊销当前用户在所有设备上签发的令牌
+//	@Tags			认证管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Success		200				{object}	response.Response{data=response.LogoutAllResponse}
+//	@Router			/api/v1/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID := c.GetUint("userId")
+
+	count, err := h.authService.LogoutAll(userID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(&response.LogoutAllResponse{RevokedSessions: count}))
+}
+
+// tokenClient 获取签发令牌时记录的客户端信息
+func tokenClient(c *gin.Context) service.TokenClient {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	return service.TokenClient{IP: c.ClientIP(), UserAgent: userAgent}
+}
+
+// errorResponse 将服务层错误转换为响应，非业务错误统一返回服务器内部错误
+func errorResponse(err error) *response.Response {
+	var bizErr *errors.Error
+	if stderrors.As(err, &bizErr) {
+		resp := response.NewErrorWithMsg(bizErr.Code, bizErr.Message)
+		resp.Data = bizErr.Details
+		return resp
+	}
+	return response.NewError(errors.InternalServerError)
+}
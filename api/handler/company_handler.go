@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// CompanyHandler 公司管理处理器
+type CompanyHandler struct {
+	companyService *service.CompanyService
+	userService    *service.UserService
+}
+
+// NewCompanyHandler 创建公司管理处理器
+func NewCompanyHandler(companyService *service.CompanyService, userService *service.UserService) *CompanyHandler {
+	return &CompanyHandler{
+		companyService: companyService,
+		userService:    userService,
+	}
+}
+
+// Create 创建公司
+//
+//	@Summary		创建公司
+//	@Description	创建公司，创建人成为公司所有者
+//	@Tags			公司管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Bearer 用户令牌"
+//	@Param			request			body		request.CompanyRequest	true	"公司信息"
+//	@Success		200				{object}	response.Response{data=response.CompanyResponse}
+//	@Router			/api/v1/companies [post]
+func (h *CompanyHandler) Create(c *gin.Context) {
+	var req request.CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	company := req.ToModel()
+	if err := h.companyService.Create(company, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewCompanyResponse(company)))
+}
+
+// GetByID 获取公司信息
+//
+//	@Summary		获取公司信息
+//	@Description	获取指定公司的公开资料
+//	@Tags			公司管理
+//	@Produce		json
+//	@Param			companyId	path		int	true	"公司ID"
+//	@Success		200			{object}	response.Response{data=response.CompanyResponse}
+//	@Router			/api/v1/companies/{companyId} [get]
+func (h *CompanyHandler) GetByID(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+
+	company, err := h.companyService.GetByID(companyID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewCompanyResponse(company)))
+}
+
+// Update 更新公司资料
+//
+//	@Summary		更新公司资料
+//	@Description	更新公司资料，仅公司所有者可以操作
+//	@Tags			公司管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Bearer 用户令牌"
+//	@Param			companyId		path		int						true	"公司ID"
+//	@Param			request			body		request.CompanyRequest	true	"公司信息"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/companies/{companyId} [put]
+func (h *CompanyHandler) Update(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	var req request.CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	company := req.ToModel()
+	company.ID = companyID
+	if err := h.companyService.UpdateProfile(company, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// ListMine 获取当前用户加入的公司
+//
+//	@Summary		获取我的公司
+//	@Description	获取当前用户加入的公司及在各公司中的角色
+//	@Tags			公司管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Success		200				{object}	response.Response{data=[]response.CompanyMembershipResponse}
+//	@Router			/api/v1/companies/my [get]
+func (h *CompanyHandler) ListMine(c *gin.Context) {
+	members, companies, err := h.companyService.ListMemberships(c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	resp := make([]response.CompanyMembershipResponse, 0, len(members))
+	for _, m := range members {
+		company, ok := companies[m.CompanyID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, response.CompanyMembershipResponse{
+			Company: response.NewCompanyResponse(company),
+			Role:    string(m.Role),
+		})
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(resp))
+}
+
+// ListMembers 获取公司成员
+//
+//	@Summary		获取公司成员
+//	@Description	获取公司的成员列表及角色
+//	@Tags			公司管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Success		200				{object}	response.Response{data=[]response.CompanyMemberResponse}
+//	@Router			/api/v1/companies/{companyId}/members [get]
+func (h *CompanyHandler) ListMembers(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+
+	members, err := h.companyService.ListMembers(companyID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	userIDs := make([]uint, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+	}
+	users, err := h.userService.GetUserMap(userIDs)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	resp := make([]*response.CompanyMemberResponse, len(members))
+	for i := range members {
+		resp[i] = response.NewCompanyMemberResponse(&members[i], users[members[i].UserID])
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(resp))
+}
+
+// AddMember 添加公司成员
+//
+//	@Summary		添加公司成员
+//	@Description	将招聘者或企业用户加入公司，仅公司所有者可以操作
+//	@Tags			公司管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Bearer 用户令牌"
+//	@Param			companyId		path		int								true	"公司ID"
+//	@Param			request			body		request.CompanyMemberAddRequest	true	"成员信息"
+//	@Success		200				{object}	response.Response{data=response.CompanyMemberResponse}
+//	@Router			/api/v1/companies/{companyId}/members [post]
+func (h *CompanyHandler) AddMember(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	var req request.CompanyMemberAddRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	member, err := h.companyService.AddMember(companyID, c.GetUint("userId"), req.UserID, model.CompanyRole(req.Role))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewCompanyMemberResponse(member, nil)))
+}
+
+// UpdateMemberRole 修改成员角色
+//
+//	@Summary		修改成员角色
+//	@Description	修改公司成员的角色，仅公司所有者可以操作
+//	@Tags			公司管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"Bearer 用户令牌"
+//	@Param			companyId		path		int									true	"公司ID"
+//	@Param			userId			path		int									true	"成员用户ID"
+//	@Param			request			body		request.CompanyMemberRoleRequest	true	"成员角色"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/companies/{companyId}/members/{userId} [put]
+func (h *CompanyHandler) UpdateMemberRole(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	userID, ok := uintParam(c, "userId")
+	if !ok {
+		return
+	}
+	var req request.CompanyMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	if err := h.companyService.UpdateMemberRole(companyID, c.GetUint("userId"), userID, model.CompanyRole(req.Role)); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// RemoveMember 移除公司成员
+//
+//	@Summary		移除公司成员
+//	@Description	公司所有者可以移除成员，成员可以自行退出公司
+//	@Tags			公司管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Param			userId			path		int		true	"成员用户ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/companies/{companyId}/members/{userId} [delete]
+func (h *CompanyHandler) RemoveMember(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	userID, ok := uintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.companyService.RemoveMember(companyID, c.GetUint("userId"), userID); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// Verify 审核公司认证
+//
+//	@Summary		审核公司认证
+//	@Description	管理员审核公司认证信息
+//	@Tags			公司管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			companyId		path		int							true	"公司ID"
+//	@Param			request			body		request.CompanyVerifyRequest	true	"审核结果"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/companies/{companyId}/verify [put]
+func (h *CompanyHandler) Verify(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	var req request.CompanyVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	if err := h.companyService.Verify(companyID, model.CompanyVerifyStatus(req.Status), req.Remark); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// uintParam 解析无符号整数路径参数，解析失败时直接写入错误响应
+func uintParam(c *gin.Context, name string) (uint, bool) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || value == 0 {
+		c.JSON(http.StatusOK, response.NewError(errors.BadRequest))
+		return 0, false
+	}
+	return uint(value), true
+}
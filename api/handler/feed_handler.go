@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/feed"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// feedRecentItems RSS 和 Atom 订阅源只包含最新发布的职位数
+const feedRecentItems = 100
+
+// feedCacheControl 订阅源的缓存策略，过期后通过 ETag 条件请求重新验证
+const feedCacheControl = "public, max-age=300"
+
+// FeedHandler 职位订阅源处理器
+type FeedHandler struct {
+	feedService *service.JobFeedService
+}
+
+// NewFeedHandler 创建职位订阅源处理器
+func NewFeedHandler(feedService *service.JobFeedService) *FeedHandler {
+	return &FeedHandler{feedService: feedService}
+}
+
+// Indeed 获取 XML 职位源
+//
+//	@Summary		获取 XML 职位源
+//	@Description	以 Indeed 职位源格式输出全部已发布且未过期的职位，供职位聚合网站抓取，支持 ETag 条件请求
+//	@Tags			订阅源
+//	@Produce		xml
+//	@Param			companyId		query		int		false	"只输出该公司的职位"
+//	@Param			If-None-Match	header		string	false	"上次响应的 ETag，未变化时返回 304"
+//	@Success		200				{string}	string
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/feeds/jobs.xml [get]
+func (h *FeedHandler) Indeed(c *gin.Context) {
+	h.render(c, "application/xml; charset=utf-8", 0, feed.RenderIndeed)
+}
+
+// RSS 获取 RSS 订阅源
+//
+//	@Summary		获取 RSS 订阅源
+//	@Description	以 RSS 2.0 格式输出最新发布的职位，支持 ETag 条件请求
+//	@Tags			订阅源
+//	@Produce		xml
+//	@Param			companyId		query		int		false	"只输出该公司的职位"
+//	@Param			If-None-Match	header		string	false	"上次响应的 ETag，未变化时返回 304"
+//	@Success		200				{string}	string
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/feeds/jobs.rss [get]
+func (h *FeedHandler) RSS(c *gin.Context) {
+	h.render(c, "application/rss+xml; charset=utf-8", feedRecentItems, feed.RenderRSS)
+}
+
+// Atom 获取 Atom 订阅源
+//
+//	@Summary		获取 Atom 订阅源
+//	@Description	以 Atom 格式输出最新发布的职位，支持 ETag 条件请求
+//	@Tags			订阅源
+//	@Produce		xml
+//	@Param			companyId		query		int		false	"只输出该公司的职位"
+//	@Param			If-None-Match	header		string	false	"上次响应的 ETag，未变化时返回 304"
+//	@Success		200				{string}	string
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/feeds/jobs.atom [get]
+func (h *FeedHandler) Atom(c *gin.Context) {
+	h.render(c, "application/atom+xml; charset=utf-8", feedRecentItems, feed.RenderAtom)
+}
+
+// JobPosting 获取职位的结构化数据
+//
+//	@Summary		获取职位的结构化数据
+//	@Description	输出 schema.org JobPosting JSON-LD，供职位详情页嵌入以便搜索引擎识别，仅已发布且未过期的职位可以获取
+//	@Tags			订阅源
+//	@Produce		json
+//	@Param			id				path		int		true	"职位ID"
+//	@Param			If-None-Match	header		string	false	"上次响应的 ETag，未变化时返回 304"
+//	@Success		200				{object}	feed.JobPosting
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/jsonld [get]
+func (h *FeedHandler) JobPosting(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	item, err := h.feedService.Item(id)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	body, err := json.Marshal(feed.NewJobPosting(*item))
+	if err != nil {
+		logger.L.Error("生成职位结构化数据失败", zap.Error(err), zap.Uint("jobId", id))
+		c.JSON(http.StatusOK, response.NewError(errors.InternalServerError))
+		return
+	}
+	serveConditional(c, "application/ld+json; charset=utf-8", body, item.Job.UpdateTime)
+}
+
+// render 获取订阅源中的职位并按指定格式输出，limit 大于 0 时只输出最新的 limit 个职位
+func (h *FeedHandler) render(c *gin.Context, contentType string, limit int, renderer func(feed.Channel, []feed.Item) ([]byte, error)) {
+	var companyID uint
+	if v := c.Query("companyId"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.BadRequest))
+			return
+		}
+		companyID = uint(id)
+	}
+
+	items, err := h.feedService.Items(companyID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	ch := h.feedService.Channel(requestURL(c), items)
+	body, err := renderer(ch, items)
+	if err != nil {
+		logger.L.Error("生成职位订阅源失败", zap.Error(err), zap.String("path", c.FullPath()))
+		c.JSON(http.StatusOK, response.NewError(errors.InternalServerError))
+		return
+	}
+	serveConditional(c, contentType, body, ch.Updated)
+}
+
+// serveConditional 以内容摘要作为 ETag 输出响应，客户端缓存仍有效时返回 304
+// 请求带 If-None-Match 时只按 ETag 判断，否则按 If-Modified-Since 与最后修改时间判断
+func serveConditional(c *gin.Context, contentType string, body []byte, lastModified time.Time) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", feedCacheControl)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		if etagMatches(inm, etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	} else if ims, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.IsZero() &&
+		!lastModified.Truncate(time.Second).After(ims) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// etagMatches If-None-Match 中是否包含 etag，按弱比较忽略 W/ 前缀
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// requestURL 当前请求的完整地址，经反向代理时以 X-Forwarded-Proto 判断协议
+func requestURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host + c.Request.URL.RequestURI()
+}
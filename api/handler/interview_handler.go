@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+)
+
+// InterviewHandler 面试安排处理器
+type InterviewHandler struct {
+	interviewService *service.InterviewService
+}
+
+// NewInterviewHandler 创建面试安排处理器
+func NewInterviewHandler(interviewService *service.InterviewService) *InterviewHandler {
+	return &InterviewHandler{interviewService: interviewService}
+}
+
+// Schedule 安排面试
+//
+//	@Summary		安排面试
+//	@Description	为申请安排一轮面试，面试官须为公司成员，面试官或候选人时间冲突时返回冲突的面试
+//	@Tags			面试管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"申请ID"
+//	@Param			request			body		request.InterviewRequest	true	"面试安排"
+//	@Success		200				{object}	response.Response{data=response.InterviewResponse}
+//	@Failure		2015			{object}	response.Response{data=[]response.InterviewConflictResponse}
+//	@Router			/api/v1/applies/{id}/interviews [post]
+func (h *InterviewHandler) Schedule(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.InterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	interview, err := h.interviewService.Schedule(applyID, c.GetUint("userId"), interviewSchedule(&req))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(interview))
+}
+
+// ListByApply 获取申请的面试
+//
+//	@Summary		获取申请的面试
+//	@Description	获取申请的所有面试安排，候选人只能查看本人的申请
+//	@Tags			面试管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Success		200				{object}	response.Response{data=[]response.InterviewResponse}
+//	@Router			/api/v1/applies/{id}/interviews [get]
+func (h *InterviewHandler) ListByApply(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	interviews, err := h.interviewService.ListByApply(applyID, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(interviews))
+}
+
+// ListMine 获取我的面试日程
+//
+//	@Summary		获取我的面试日程
+//	@Description	获取当前用户作为面试官在时间范围内已安排的面试，默认为未来30天
+//	@Tags			面试管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			from			query		string	false	"开始时间 RFC3339"
+//	@Param			to				query		string	false	"结束时间 RFC3339"
+//	@Success		200				{object}	response.Response{data=[]response.InterviewResponse}
+//	@Router			/api/v1/interviews/my [get]
+func (h *InterviewHandler) ListMine(c *gin.Context) {
+	from, to := time.Now(), time.Now().AddDate(0, 0, 30)
+	var err error
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+			return
+		}
+	}
+
+	interviews, err := h.interviewService.ListByInterviewer(c.GetUint("userId"), from, to)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(interviews))
+}
+
+// GetByID 获取面试详情
+//
+//	@Summary		获取面试详情
+//	@Description	获取面试安排详情
+//	@Tags			面试管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"面试ID"
+//	@Success		200				{object}	response.Response{data=response.InterviewResponse}
+//	@Router			/api/v1/interviews/{id} [get]
+func (h *InterviewHandler) GetByID(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	interview, err := h.interviewService.Get(id, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(interview))
+}
+
+// Reschedule 修改面试
+//
+//	@Summary		修改面试
+//	@Description	修改面试时间、方式、地点或面试官，日历修订序号递增并重新通知候选人和面试官
+//	@Tags			面试管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"面试ID"
+//	@Param			request			body		request.InterviewRequest	true	"面试安排"
+//	@Success		200				{object}	response.Response{data=response.InterviewResponse}
+//	@Failure		2015			{object}	response.Response{data=[]response.InterviewConflictResponse}
+//	@Router			/api/v1/interviews/{id} [put]
+func (h *InterviewHandler) Reschedule(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.InterviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	interview, err := h.interviewService.Reschedule(id, c.GetUint("userId"), interviewSchedule(&req))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(interview))
+}
+
+// Cancel 取消面试
+//
+//	@Summary		取消面试
+//	@Description	取消已安排的面试，并向候选人和面试官发送取消通知
+//	@Tags			面试管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Bearer 用户令牌"
+//	@Param			id				path		int								true	"面试ID"
+//	@Param			request			body		request.InterviewCancelRequest	false	"取消原因"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/interviews/{id}/cancel [post]
+func (h *InterviewHandler) Cancel(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.InterviewCancelRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+			return
+		}
+	}
+
+	if err := h.interviewService.Cancel(id, c.GetUint("userId"), req.Reason); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// Complete 完成面试
+//
+//	@Summary		完成面试
+//	@Description	将已安排的面试标记为已完成
+//	@Tags			面试管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"面试ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/interviews/{id}/complete [post]
+func (h *InterviewHandler) Complete(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.interviewService.Complete(id, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// DownloadICS 下载面试日历文件
+//
+//	@Summary		下载面试日历文件
+//	@Description	下载 RFC 5545 格式的面试日历文件，改期后修订序号递增，取消后为取消事件
+//	@Tags			面试管理
+//	@Produce		text/calendar
+//	@Param			Authorization	header	string	true	"Bearer 用户令牌"
+//	@Param			id				path	int		true	"面试ID"
+//	@Success		200				{file}	file
+//	@Router			/api/v1/interviews/{id}/ics [get]
+func (h *InterviewHandler) DownloadICS(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	ics, err := h.interviewService.ICS(id, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%d.ics"`, id))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+}
+
+// interviewSchedule 将请求转换为面试安排内容
+func interviewSchedule(req *request.InterviewRequest) service.InterviewSchedule {
+	return service.InterviewSchedule{
+		Round:          req.Round,
+		Title:          req.Title,
+		Mode:           model.InterviewMode(req.Mode),
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		Location:       req.Location,
+		MeetingURL:     req.MeetingURL,
+		Remark:         req.Remark,
+		InterviewerIDs: req.InterviewerIDs,
+	}
+}
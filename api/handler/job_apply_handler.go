@@ -7,8 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"org.thinkinai.com/recruit-center/api/dto/request"
 	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/enums"
 	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 type JobApplyHandler struct {
@@ -23,7 +27,7 @@ func NewJobApplyHandler(service *service.JobApplyService, jobService *service.Jo
 // Create 创建职位申请
 //
 //	@Summary		创建职位申请
-//	@Description	创建一个新的职位申请
+//	@Description	创建一个新的职位申请，职位配置了筛选问题时须一并回答，未满足淘汰规则的申请会被自动拒绝
 //	@Tags			职位申请
 //	@Accept			json
 //	@Produce		json
@@ -46,8 +50,8 @@ func (h *JobApplyHandler) Create(c *gin.Context) {
 		return
 	}
 	apply := req.NewJobApply()
-	if err := h.jobApplyService.Create(apply); err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+	if err := h.jobApplyService.Create(apply, req.ScreeningAnswers()); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
@@ -100,6 +104,11 @@ func (h *JobApplyHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	if err := h.authorizeApply(c, uint(id)); err != nil {
+		c.JSON(http.StatusOK, errors.Wrap(err, errors.Forbidden))
+		return
+	}
+
 	apply, err := h.jobApplyService.GetByID(uint(id))
 	if err != nil {
 		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
@@ -122,108 +131,459 @@ func (h *JobApplyHandler) GetByID(c *gin.Context) {
 //	@Param			userId			path	int		true	"用户ID"
 //	@Param			page			query	integer	false	"页码 (默认值: 1)"		minimum(1)	default(1)
 //	@Param			size			query	integer	false	"每页数量 (默认值: 10)"	minimum(1)	maximum(100)	default(10)
+//	@Param			cursor			query	string	false	"上一页返回的游标，指定时忽略页码"
+//	@Param			withTotal		query	boolean	false	"是否统计总数，默认只在未指定游标时统计"
 //	@Success		0000			{object}	response.PageResponse{data=[]response.JobApplyResponse}	"成功"
 //	@Failure		2000			{object}	response.Response{}
 //	@Router			/api/v1/users/{userId}/applies [get]
 
 func (h *JobApplyHandler) ListByUser(c *gin.Context) {
-	userID, _ := strconv.Atoi(c.Param("userId"))
-	page, size := parsePageSize(c)
+	userID := c.GetUint("userId")
+	page := parsePage(c)
 
-	applies, err := h.jobApplyService.ListByUser(uint(userID), page, size)
+	applies, err := h.jobApplyService.ListByUser(userID, page)
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, response.NewSuccess(response.NewPage(applies, applies.Total, page, size)))
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewPage(applies, applies.Total, applies.NextCursor, page.Number, page.Size)))
 }
 
 // 根据公司信息，查询所有的职位申请记录
 // ListByCompany 获取公司职位申请记录
 //
 //	@Summary		获取公司职位申请记录
-//	@Description	分页获取指定公司的
-//	职位申请记录
+//	@Description	分页获取指定公司的职位申请记录，可按状态、职位、申请时间、简历信息、标签及评分筛选，返回各状态的申请数
 //	@Tags			职位申请
 //	@Accept			application/json
 //	@Produce		application/json
 //	@Security		Bearer
 //	@Param			Authorization	header		string						true	"Bearer 用户令牌"
 //	@Param			companyId		path	int		true	"公司ID"
-//	@Param			page			query	integer	false	"页码 (默认值: 1)"		minimum(1)	default(1)
-//	@Param			size			query	integer	false	"每页数量 (默认值: 10)"	minimum(1)	maximum(100)	default(10)
+//	@Param			request			query	request.JobApplyListRequest	false	"分页、筛选与排序参数"
 //	@Success		0000			{object}	response.PageResponse{data=[]response.JobApplyResponse}	"成功"
 //	@Failure		2000			{object}	response.Response{}
 //	@Router			/api/v1/companies/{companyId}/applies [get]
 func (h *JobApplyHandler) ListByCompany(c *gin.Context) {
 	companyID, _ := strconv.Atoi(c.Param("companyId"))
-	page, size := parsePageSize(c)
-	applies, err := h.jobApplyService.ListByCompanyID(uint(companyID), page, size)
+	req, filter, ok := applyListFilter(c)
+	if !ok {
+		return
+	}
+	page := req.ToPage()
+	applies, err := h.jobApplyService.ListByCompanyID(uint(companyID), filter, page)
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, response.NewSuccess(response.NewPage(applies, applies.Total, page, size)))
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewPage(applies, applies.Total, applies.NextCursor, page.Number, page.Size)))
 }
 
 // List 获取职位申请列表
 //
 //	@Summary		获取申请列表
-//	@Description	分页获取所有职位申请记录
+//	@Description	分页获取职位的申请记录，可按状态、申请时间、简历信息、标签及评分筛选，返回各阶段的申请数
 //	@Tags			职位申请
 //	@Accept			application/json
 //	@Produce		application/json
 //	@Param			Authorization	header		string						true	"Bearer 用户令牌"
-//	@Param			page			query		integer											false	"页码 (默认值: 1)"		minimum(1)	default(1)
-//	@Param			size			query		integer											false	"每页数量 (默认值: 10)"	minimum(1)	maximum(100)	default(10)
+//	@Param			id				path		int												true	"职位ID"
+//	@Param			request			query		request.JobApplyListRequest						false	"分页、筛选与排序参数"
 //	@Success		0000			{object}	response.PageResponse{data=[]response.JobApplyResponse}	"成功"
 //	@Failure		2000			{object}	response.Response{}								"错误"
 //	@Router			/api/v1/applies [get]
 func (h *JobApplyHandler) List(c *gin.Context) {
-	page, size := parsePageSize(c)
-	jobID := c.GetUint("jobId")
-	applies, err := h.jobApplyService.ListByJob(jobID, page, size)
+	req, filter, ok := applyListFilter(c)
+	if !ok {
+		return
+	}
+	page := req.ToPage()
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errors.BadRequest)
+		return
+	}
+	if userType, _ := middleware.CurrentUserType(c); userType != model.UserTypeAdmin {
+		if err := h.jobService.VerifyCompanyMember(uint(jobID), c.GetUint("userId")); err != nil {
+			c.JSON(http.StatusOK, errors.Wrap(err, errors.Forbidden))
+			return
+		}
+	}
+
+	applies, err := h.jobApplyService.ListByJob(uint(jobID), filter, page)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, response.NewSuccess(response.NewPage(applies, applies.Total, page, size)))
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewPage(applies, applies.Total, applies.NextCursor, page.Number, page.Size)))
 }
 
 // UpdateStatus 更新职位申请状态
 //
 //	@Summary		更新职位申请状态
-//	@Description	更新指定职位申请的
+//	@Description	按职位的招聘流程流转申请状态。企业侧以公司身份操作，求职者以候选人身份操作本人的申请(如撤回、接受或拒绝Offer)
 //	@Tags			职位申请
 //	@Accept			json
 //	@Produce		json
-//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
-//	@Param			id		path		int	true	"申请ID"
-//	@Param			status	body		string	true	"申请状态"
-//	@Success		0000	{object}	response.Response{data=string}
-//	@Failure		2000	{object}	response.Response{}
+//	@Param			Authorization	header		string							true	"Bearer 用户令牌"
+//	@Param			id				path		int								true	"申请ID"
+//	@Param			request			body		request.JobApplyUpdateStatus	true	"目标状态"
+//	@Success		0000			{object}	response.Response{data=string}
+//	@Failure		2000			{object}	response.Response{}
 //	@Router			/api/v1/applies/{id}/status [put]
 func (h *JobApplyHandler) UpdateStatus(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
 	var req request.JobApplyUpdateStatus
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusOK, errors.BadRequest)
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
 		return
 	}
-	companyID := c.GetUint("companyId")
-	if err := h.jobApplyService.UpdateStatus(req.JobID, companyID, req.Status); err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+	actor, ok := pipelineActor(c)
+	if !ok {
+		c.JSON(http.StatusOK, response.NewError(errors.Forbidden))
+		return
+	}
+	change := service.StatusChange{Status: req.Status, Reason: req.Reason, Note: req.Note}
+	if err := h.jobApplyService.Transition(id, c.GetUint("userId"), actor, change); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, response.NewSuccess(nil))
 }
 
+// BulkUpdateStatus 批量更新职位申请状态
+//
+//	@Summary		批量更新职位申请状态
+//	@Description	以公司身份将多条申请流转到同一状态，逐条校验权限与招聘流程，校验通过的申请在同一事务中更新，返回每条申请的处理结果
+//	@Tags			职位申请
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"Bearer 用户令牌"
+//	@Param			request			body		request.JobApplyBulkStatusRequest	true	"申请ID列表及目标状态"
+//	@Success		0000			{object}	response.Response{data=response.JobApplyBulkStatusResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/bulk-status [put]
+func (h *JobApplyHandler) BulkUpdateStatus(c *gin.Context) {
+	var req request.JobApplyBulkStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	change := service.StatusChange{Status: req.Status, Reason: req.Reason, Note: req.Note}
+	resp, err := h.jobApplyService.BulkTransition(req.IDs, c.GetUint("userId"), change)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(resp))
+}
+
+// NextStates 获取申请可流转的后续状态
+//
+//	@Summary		获取申请可流转的后续状态
+//	@Description	根据职位的招聘流程返回申请当前阶段及当前用户可触发的后续状态，管理员可查看所有流转
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Success		0000			{object}	response.Response{data=response.JobApplyNextStatesResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/next-states [get]
+func (h *JobApplyHandler) NextStates(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.authorizeApply(c, id); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	// 管理员不对应流程中的任何一方，返回所有流转
+	actor, _ := pipelineActor(c)
+	resp, err := h.jobApplyService.NextStates(id, actor)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(resp))
+}
+
+// Timeline 获取申请的状态流转时间线
+//
+//	@Summary		获取申请时间线
+//	@Description	获取申请从提交起的全部状态流转记录，包括触发方、流转原因和备注，候选人与公司成员均可查看
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Success		0000			{object}	response.Response{data=[]response.JobApplyEventResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/timeline [get]
+func (h *JobApplyHandler) Timeline(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.authorizeApply(c, id); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	events, err := h.jobApplyService.Timeline(id)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(events))
+}
+
+// SetTags 设置申请标签
+//
+//	@Summary		设置申请标签
+//	@Description	整体替换申请的标签，标签仅公司成员可见，不区分大小写去重，每条申请最多20个
+//	@Tags			职位申请
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"申请ID"
+//	@Param			request			body		request.JobApplyTagsRequest	true	"标签"
+//	@Success		0000			{object}	response.Response{data=[]string}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/tags [put]
+func (h *JobApplyHandler) SetTags(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobApplyTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	tags, err := h.jobApplyService.SetTags(id, c.GetUint("userId"), req.Tags)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(tags))
+}
+
+// SetRating 设置申请评分
+//
+//	@Summary		设置申请评分
+//	@Description	为申请打 1-5 星评分，0 表示清除评分，评分仅公司成员可见
+//	@Tags			职位申请
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Bearer 用户令牌"
+//	@Param			id				path		int								true	"申请ID"
+//	@Param			request			body		request.JobApplyRatingRequest	true	"评分"
+//	@Success		0000			{object}	response.Response{}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/rating [put]
+func (h *JobApplyHandler) SetRating(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobApplyRatingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	if err := h.jobApplyService.SetRating(id, c.GetUint("userId"), req.Rating); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// ListCompanyTags 获取公司使用过的申请标签
+//
+//	@Summary		获取公司申请标签
+//	@Description	获取公司申请中使用过的标签及使用次数，按使用次数倒序，用于列表筛选
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Success		0000			{object}	response.Response{data=[]response.ApplyTagCountResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/company/{companyId}/tags [get]
+func (h *JobApplyHandler) ListCompanyTags(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+
+	tags, err := h.jobApplyService.ListCompanyTags(companyID, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(tags))
+}
+
+// Board 获取职位看板
+//
+//	@Summary		获取职位看板
+//	@Description	按招聘流程的阶段返回职位的申请数及每个阶段的第一页候选人卡片，卡片包含姓名、当前职位和在当前阶段停留的天数
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Param			limit			query		int		false	"每个阶段的卡片数量"	minimum(1)	maximum(50)	default(10)
+//	@Success		200				{object}	response.Response{data=response.JobBoardResponse}
+//	@Router			/api/v1/applies/job/{id}/board [get]
+func (h *JobApplyHandler) Board(c *gin.Context) {
+	jobID, ok := h.boardJob(c)
+	if !ok {
+		return
+	}
+	limit, ok := boardLimit(c)
+	if !ok {
+		return
+	}
+
+	board, err := h.jobApplyService.Board(jobID, limit)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(board))
+}
+
+// BoardStage 加载看板阶段的更多卡片
+//
+//	@Summary		加载看板阶段的更多卡片
+//	@Description	使用阶段返回的游标加载下一页候选人卡片
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Param			status			path		int		true	"申请状态"
+//	@Param			cursor			query		string	false	"上一页返回的游标，为空时从第一页开始"
+//	@Param			limit			query		int		false	"卡片数量"	minimum(1)	maximum(50)	default(10)
+//	@Success		200				{object}	response.Response{data=response.BoardStageResponse}
+//	@Router			/api/v1/applies/job/{id}/board/{status} [get]
+func (h *JobApplyHandler) BoardStage(c *gin.Context) {
+	jobID, ok := h.boardJob(c)
+	if !ok {
+		return
+	}
+	status, ok := uintParam(c, "status")
+	if !ok {
+		return
+	}
+	limit, ok := boardLimit(c)
+	if !ok {
+		return
+	}
+
+	stage, err := h.jobApplyService.BoardStage(jobID, enums.JobApplyEnum(status), c.Query("cursor"), limit)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(stage))
+}
+
+// boardJob 解析职位ID并校验当前用户可以查看职位的申请，管理员不受限制
+func (h *JobApplyHandler) boardJob(c *gin.Context) (uint, bool) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return 0, false
+	}
+	if userType, _ := middleware.CurrentUserType(c); userType != model.UserTypeAdmin {
+		if err := h.jobService.VerifyCompanyMember(jobID, c.GetUint("userId")); err != nil {
+			c.JSON(http.StatusOK, errorResponse(err))
+			return 0, false
+		}
+	}
+	return jobID, true
+}
+
+// boardLimit 解析看板每个阶段的卡片数量
+func boardLimit(c *gin.Context) (int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(service.DefaultBoardCards)))
+	if err != nil || limit < 1 || limit > service.MaxBoardCards {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return 0, false
+	}
+	return limit, true
+}
+
+// applyListFilter 绑定公司侧申请列表的分页、筛选与排序参数，参数无效时直接返回错误响应
+func applyListFilter(c *gin.Context) (*request.JobApplyListRequest, model.JobApplyFilter, bool) {
+	var req request.JobApplyListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return nil, model.JobApplyFilter{}, false
+	}
+	filter, err := req.ToFilter()
+	if err != nil {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, err.Error()))
+		return nil, filter, false
+	}
+	return &req, filter, true
+}
+
+// pipelineActor 根据当前用户类型确定其在招聘流程中的身份
+func pipelineActor(c *gin.Context) (model.PipelineActor, bool) {
+	userType, _ := middleware.CurrentUserType(c)
+	switch {
+	case userType.IsCompanySide():
+		return model.PipelineActorCompany, true
+	case userType == model.UserTypeJobSeeker:
+		return model.PipelineActorCandidate, true
+	default:
+		return "", false
+	}
+}
+
+// authorizeApply 校验当前用户能否访问申请记录
+// 求职者只能访问本人的申请，企业侧用户只能访问本公司的申请，管理员不受限制
+func (h *JobApplyHandler) authorizeApply(c *gin.Context, applyID uint) error {
+	userType, _ := middleware.CurrentUserType(c)
+	switch {
+	case userType == model.UserTypeAdmin:
+		return nil
+	case userType.IsCompanySide():
+		return h.jobApplyService.VerifyApplyCompany(applyID, c.GetUint("userId"), model.CompanyReaders...)
+	default:
+		return h.jobApplyService.VerifyApplyOwner(applyID, c.GetUint("userId"))
+	}
+}
+
 // parsePageSize 解析分页参数
 func parsePageSize(c *gin.Context) (int, int) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
 	return page, size
 }
+
+// parsePage 解析页码及游标分页参数，cursor 为上一页返回的游标，withTotal 指定是否统计总数
+func parsePage(c *gin.Context) pagination.Page {
+	page, size := parsePageSize(c)
+	var withTotal *bool
+	if v, err := strconv.ParseBool(c.Query("withTotal")); err == nil {
+		withTotal = &v
+	}
+	return pagination.NewPage(page, size, c.Query("cursor"), withTotal)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// JobApplyNoteHandler 申请备注处理器
+type JobApplyNoteHandler struct {
+	noteService *service.JobApplyNoteService
+}
+
+// NewJobApplyNoteHandler 创建申请备注处理器
+func NewJobApplyNoteHandler(noteService *service.JobApplyNoteService) *JobApplyNoteHandler {
+	return &JobApplyNoteHandler{noteService: noteService}
+}
+
+// List 获取申请备注
+//
+//	@Summary		获取申请备注
+//	@Description	获取申请的私有备注，顶层备注按时间正序，回复挂在所属备注下，仅公司成员可见
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Success		0000			{object}	response.Response{data=[]response.JobApplyNoteResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/notes [get]
+func (h *JobApplyNoteHandler) List(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	notes, err := h.noteService.List(applyID, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(notes))
+}
+
+// Create 添加申请备注
+//
+//	@Summary		添加申请备注
+//	@Description	添加私有备注或回复顶层备注，可提及公司成员，被提及的成员会收到通知
+//	@Tags			职位申请
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"申请ID"
+//	@Param			request			body		request.JobApplyNoteRequest	true	"备注内容"
+//	@Success		0000			{object}	response.Response{data=response.JobApplyNoteResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/notes [post]
+func (h *JobApplyNoteHandler) Create(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobApplyNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	note, err := h.noteService.Create(applyID, c.GetUint("userId"), req.ToModel())
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(note))
+}
+
+// Update 修改申请备注
+//
+//	@Summary		修改申请备注
+//	@Description	修改自己的备注内容及提及的成员，新提及的成员会收到通知
+//	@Tags			职位申请
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"申请ID"
+//	@Param			noteId			path		int							true	"备注ID"
+//	@Param			request			body		request.JobApplyNoteRequest	true	"备注内容"
+//	@Success		0000			{object}	response.Response{data=response.JobApplyNoteResponse}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/notes/{noteId} [put]
+func (h *JobApplyNoteHandler) Update(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	noteID, ok := uintParam(c, "noteId")
+	if !ok {
+		return
+	}
+	var req request.JobApplyNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	note, err := h.noteService.Update(applyID, noteID, c.GetUint("userId"), req.Content, req.MentionIDs)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(note))
+}
+
+// Delete 删除申请备注
+//
+//	@Summary		删除申请备注
+//	@Description	作者或公司所有者可删除备注，删除顶层备注时回复一并删除
+//	@Tags			职位申请
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Param			noteId			path		int		true	"备注ID"
+//	@Success		0000			{object}	response.Response{}
+//	@Failure		2000			{object}	response.Response{}
+//	@Router			/api/v1/applies/{id}/notes/{noteId} [delete]
+func (h *JobApplyNoteHandler) Delete(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	noteID, ok := uintParam(c, "noteId")
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.Delete(applyID, noteID, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
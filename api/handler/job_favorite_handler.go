@@ -64,22 +64,23 @@ func (h *JobFavoriteHandler) RemoveFavorite(c *gin.Context) {
 
 // ListFavorites 获取收藏列表
 // @Summary 获取收藏列表
-// @Description 分页获取用户收藏的职位列表
+// @Description 按收藏时间倒序分页获取用户收藏的职位列表，支持页码分页和游标分页
 // @Tags 职位收藏
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer 用户令牌"
 // @Param page query int false "页码" minimum(1) default(1)
 // @Param size query int false "每页数量" minimum(1) maximum(100) default(10)
+// @Param cursor query string false "上一页返回的游标，指定时忽略页码"
+// @Param withTotal query bool false "是否统计总数，默认只在未指定游标时统计"
 // @Success 0000 {object} response.Response{data=response.JobListResponse}
 // @Router /api/v1/users/favorites [get]
 func (h *JobFavoriteHandler) ListFavorites(c *gin.Context) {
 	userID := c.GetUint("userId")
-	page, size := parsePageSize(c)
 
-	favorites, err := h.favoriteService.ListFavorites(userID, page, size)
+	favorites, err := h.favoriteService.ListFavorites(userID, parsePage(c))
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
@@ -1,28 +1,38 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	"org.thinkinai.com/recruit-center/api/dto/request"
 	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/enums"
 	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+	"org.thinkinai.com/recruit-center/pkg/utils"
 )
 
 type JobHandler struct {
-	jobService *service.JobService
+	jobService    *service.JobService
+	reviewService *service.JobReviewService
 }
 
-func NewJobHandler(jobService *service.JobService) *JobHandler {
-	return &JobHandler{jobService: jobService}
+func NewJobHandler(jobService *service.JobService, reviewService *service.JobReviewService) *JobHandler {
+	return &JobHandler{jobService: jobService, reviewService: reviewService}
 }
 
 // Create 创建职位
 //
 //	@Summary		创建职位
-//	@Description	创建新职位,需要提供职位相关信息
+//	@Description	创建新职位,需要提供职位相关信息，职位保存为草稿后立即提交审核，指定 draft 时只保存草稿
 //	@Tags			职位
 //	@Accept			json
 //	@Produce		json
@@ -39,16 +49,34 @@ func (h *JobHandler) Create(c *gin.Context) {
 		return
 	}
 
-	companyID := c.GetUint("companyId")
-	req.CompanyID = companyID
+	// 未指定公司时使用令牌中的所属公司，是否有权在该公司发布职位由服务层校验
+	if req.CompanyID == 0 {
+		req.CompanyID = c.GetUint("companyId")
+	}
 	job := req.ToModel()
 
-	if err := h.jobService.Create(job); err != nil {
+	userID := c.GetUint("userId")
+	if err := h.jobService.Create(job, req.RegionCode, userID); err != nil {
 		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
 		return
 	}
+	if req.Draft {
+		c.JSON(http.StatusOK, response.NewSuccess(h.jobService.ConvertToJobResponse(job, 0)))
+		return
+	}
 
-	c.JSON(http.StatusOK, response.NewSuccess(h.jobService.ConvertToJobResponse(job, 0)))
+	// 提交审核失败时职位保留为草稿，可稍后重新提交
+	if _, err := h.reviewService.Submit(job.ID, userID); err != nil {
+		logger.L.Warn("职位创建后提交审核失败", zap.Error(err), zap.Uint("jobId", job.ID))
+		c.JSON(http.StatusOK, response.NewSuccess(h.jobService.ConvertToJobResponse(job, 0)))
+		return
+	}
+	result, err := h.jobService.GetByID(job.ID, userID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(result))
 }
 
 // Update 更新职位
@@ -73,9 +101,7 @@ func (h *JobHandler) Update(c *gin.Context) {
 	}
 
 	job := req.ToModel()
-	companyID := c.GetUint("companyId")
-	job.CompanyID = companyID
-	if err := h.jobService.Update(job); err != nil {
+	if err := h.jobService.Update(job, req.RegionCode, c.GetUint("userId")); err != nil {
 		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
 		return
 	}
@@ -111,8 +137,7 @@ func (h *JobHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	companyID := c.GetUint("companyId")
-	if err := h.jobService.UpdateStatus(uint(id), status, companyID); err != nil {
+	if err := h.jobService.UpdateStatus(uint(id), status, c.GetUint("userId")); err != nil {
 		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
 		return
 	}
@@ -135,8 +160,7 @@ func (h *JobHandler) UpdateStatus(c *gin.Context) {
 //	@Router			/api/v1/jobs/{id} [delete]
 func (h *JobHandler) Delete(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	companyID := c.GetUint("companyId")
-	if err := h.jobService.Delete(uint(id), companyID); err != nil {
+	if err := h.jobService.Delete(uint(id), c.GetUint("userId")); err != nil {
 		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
 		return
 	}
@@ -147,7 +171,7 @@ func (h *JobHandler) Delete(c *gin.Context) {
 // GetByID 获取职位详情
 //
 //	@Summary		获取职位详情
-//	@Description	获取指定ID的职位详细信息
+//	@Description	获取指定ID的职位详细信息，未通过审核的职位仅所属公司成员和管理员可以查看
 //	@Tags			职位
 //	@Produce		json
 //	@Param			id		path		int	true	"职位ID"
@@ -162,6 +186,10 @@ func (h *JobHandler) GetByID(c *gin.Context) {
 		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
 		return
 	}
+	if !h.visible(c, job.ID, job.Status) {
+		c.JSON(http.StatusOK, response.NewError(errors.JobNotFound))
+		return
+	}
 
 	c.JSON(http.StatusOK, response.NewSuccess(job))
 }
@@ -169,74 +197,54 @@ func (h *JobHandler) GetByID(c *gin.Context) {
 // List 获取职位列表
 //
 //	@Summary		获取职位列表
-//	@Description	分页获取职位列表
+//	@Description	按发布时间倒序分页获取职位列表，支持页码分页和游标分页
 //	@Tags			职位
 //	@Produce		json
-//	@Param			page	query		int	false	"页码"	default(1)
-//	@Param			size	query		int	false	"每页数量"	default(10)
-//	@Success		0000	{object}	response.Response{data=response.JobListResponse}
-//	@Failure		2000	{object}	response.Response{}
+//	@Param			page		query		int		false	"页码"	default(1)
+//	@Param			size		query		int		false	"每页数量"	default(10)
+//	@Param			cursor		query		string	false	"上一页返回的游标，指定时忽略页码"
+//	@Param			withTotal	query		bool	false	"是否统计总数，默认只在未指定游标时统计"
+//	@Success		0000		{object}	response.Response{data=response.JobListResponse}
+//	@Failure		2000		{object}	response.Response{}
 //	@Router			/api/v1/jobs [get]
 func (h *JobHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.Query("page"))
-	size, _ := strconv.Atoi(c.Query("size"))
 	UserID := c.GetUint("userId")
-	result, err := h.jobService.List(page, size, UserID)
+	result, err := h.jobService.List(parsePage(c), UserID)
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, response.NewSuccess(result))
 }
 
-// SearchByKeyword 关键词搜索职位
+// Search 搜索职位
 //
-//	@Summary		关键词搜索职位
-//	@Description	根据关键词搜索职位信息
+//	@Summary		搜索职位
+//	@Description	按关键词全文检索有效职位，匹配职位名称、技能要求和职位描述并按相关度排序，返回关键词高亮片段
+//	@Description	可按职位类型、分类、办公方式、福利、学历、经验、薪资区间和地点多选筛选，同时返回各分面取值的职位数
+//	@Description	可按行政区划筛选，或按距中心点的距离筛选和排序
 //	@Tags			职位
 //	@Produce		json
-//	@Param			keyword	query		string												true	"搜索关键词"
-//	@Success		0000	{object}	response.Response{data=response.JobListResponse}	"成功"
-//	@Failure		2000	{object}	response.Response{}									"服务器内部错误"
+//	@Param			request	query		request.JobSearchRequest								false	"搜索条件"
+//	@Success		0000	{object}	response.Response{data=response.JobSearchResponse}	"成功"
+//	@Failure		2000	{object}	response.Response{}										"请求参数错误"
 //	@Router			/api/v1/jobs/search [get]
-func (h *JobHandler) SearchByKeyword(c *gin.Context) {
-	keyword := c.Query("keyword")
-
-	jobs, err := h.jobService.SearchByKeyword(keyword)
-	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+func (h *JobHandler) Search(c *gin.Context) {
+	var req request.JobSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
 		return
 	}
-
-	c.JSON(http.StatusOK, response.NewSuccess(jobs))
-}
-
-// SearchByCondition 多条件搜索职位
-//
-//	@Summary		多条件搜索职位
-//	@Description	根据多个条件筛选职位
-//	@Tags			职位
-//	@Produce		json
-//	@Param			page		query		integer												false	"页码"	default(1)
-//	@Param			size		query		integer												false	"每页数量"	default(10)
-//	@Param			conditions	body		object												true	"搜索条件"
-//	@Success		0000		{object}	response.Response{data=response.JobListResponse}	"成功"
-//	@Failure		2000		{object}	response.Response{}									"请求参数错误"
-//	@Router			/api/v1/jobs/search/condition [post]
-func (h *JobHandler) SearchByCondition(c *gin.Context) {
-	var conditions map[string]interface{}
-	if err := c.ShouldBindJSON(&conditions); err != nil {
-		c.JSON(http.StatusOK, response.NewError(errors.BadRequest))
+	cond, err := req.ToCondition()
+	if err != nil {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, err.Error()))
 		return
 	}
 
-	page, _ := strconv.Atoi(c.Query("page"))
-	size, _ := strconv.Atoi(c.Query("size"))
-
-	result, err := h.jobService.SearchByCondition(conditions, page, size)
+	result, err := h.jobService.Search(cond, req.Page, req.PageSize, c.GetUint("userId"))
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
@@ -289,3 +297,255 @@ func (h *JobHandler) SearchByCompany(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.NewSuccess(result))
 }
+
+// ListRevisions 获取职位修订版本列表
+//
+//	@Summary		获取职位修订版本列表
+//	@Description	按版本号倒序获取职位的修改历史，包括操作人和操作时间，仅职位所属公司成员和管理员可以查看
+//	@Tags			职位
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		0000			{object}	response.Response{data=[]response.JobRevisionResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/revisions [get]
+func (h *JobHandler) ListRevisions(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	if userType, _ := middleware.CurrentUserType(c); userType != model.UserTypeAdmin {
+		if err := h.jobService.VerifyCompanyMember(id, c.GetUint("userId")); err != nil {
+			c.JSON(http.StatusOK, errorResponse(err))
+			return
+		}
+	}
+
+	revisions, err := h.jobService.ListRevisions(id)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(revisions))
+}
+
+// DiffRevisions 比较职位修订版本
+//
+//	@Summary		比较职位修订版本
+//	@Description	按字段比较职位两个版本的内容，未指定新版本时与当前版本比较，候选人可据此查看申请后职位的变化；未发布的职位仅所属公司成员和管理员可以查看
+//	@Tags			职位
+//	@Produce		json
+//	@Param			id		path		int	true	"职位ID"
+//	@Param			from	query		int	true	"旧版本号"
+//	@Param			to		query		int	false	"新版本号，默认为当前版本"
+//	@Success		0000	{object}	response.Response{data=response.JobRevisionDiffResponse}
+//	@Failure		2000	{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/revisions/diff [get]
+func (h *JobHandler) DiffRevisions(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobRevisionDiffRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.BadRequest))
+		return
+	}
+	job, err := h.jobService.GetJob(id)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	if !h.visible(c, job.ID, job.Status) {
+		c.JSON(http.StatusOK, response.NewError(errors.JobNotFound))
+		return
+	}
+	diff, err := h.jobService.DiffRevisions(job, req.From, req.To)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(diff))
+}
+
+// RestoreRevision 恢复职位修订版本
+//
+//	@Summary		恢复职位修订版本
+//	@Description	将职位内容恢复到指定的历史版本，恢复操作会生成新版本，不改变职位状态和过期时间，仅公司所有者或招聘者可以操作
+//	@Tags			职位
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			id				path		int		true	"职位ID"
+//	@Param			revision		path		int		true	"要恢复的版本号"
+//	@Success		0000			{object}	response.Response{data=response.JobResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/revisions/{revision}/restore [post]
+func (h *JobHandler) RestoreRevision(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	revision, ok := uintParam(c, "revision")
+	if !ok {
+		return
+	}
+	job, err := h.jobService.RestoreRevision(id, int(revision), c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(job))
+}
+
+// visible 未发布的职位(包括待发布的职位)只对所属公司成员和管理员可见
+func (h *JobHandler) visible(c *gin.Context, jobID uint, status int) bool {
+	if enums.JobStatus(status).IsPublished() {
+		return true
+	}
+	if userType, _ := middleware.CurrentUserType(c); userType == model.UserTypeAdmin {
+		return true
+	}
+	return h.jobService.VerifyCompanyMember(jobID, c.GetUint("userId")) == nil
+}
+
+// 职位导入的限制
+const (
+	maxJobImportFileSize = 10 << 20 // 导入文件的最大字节数
+	maxJobImportRows     = 500      // 单次导入的最大数据行数
+)
+
+// Import 批量导入职位
+//
+//	@Summary		批量导入职位
+//	@Description	上传 CSV 或 XLSX 表格批量创建公司的职位，表头为 request.CreateJobRequest 的字段名(companyId、draft 除外)，多值字段以逗号分隔
+//	@Description	每行按创建职位的规则校验，任一行有错误时不导入任何职位并返回各行的错误；dryRun 时只校验不保存
+//	@Description	导入的职位在同一事务中创建，之后逐个提交审核，指定 draft 时只保存为草稿
+//	@Tags			职位
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Param			file			formData	file	true	"CSV 或 XLSX 文件"
+//	@Param			dryRun			formData	bool	false	"是否只校验不保存"
+//	@Param			draft			formData	bool	false	"是否只保存为草稿，不提交审核"
+//	@Success		0000			{object}	response.Response{data=response.JobImportResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/companies/{companyId}/jobs/import [post]
+func (h *JobHandler) Import(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+	format, ok := utils.TableFormatOf(file.Filename)
+	if !ok {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, "仅支持 CSV 和 XLSX 文件"))
+		return
+	}
+	if file.Size > maxJobImportFileSize {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, fmt.Sprintf("文件不能超过%dMB", maxJobImportFileSize>>20)))
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.PostForm("dryRun"))
+	draft, _ := strconv.ParseBool(c.PostForm("draft"))
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.FileUploadFailed, "无法读取文件"))
+		return
+	}
+	defer src.Close()
+	table, err := utils.ReadTable(src, format)
+	if err != nil {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, "文件解析失败: "+err.Error()))
+		return
+	}
+	rows, err := request.ParseJobImport(table)
+	if err != nil {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, err.Error()))
+		return
+	}
+	if len(rows) == 0 || len(rows) > maxJobImportRows {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, fmt.Sprintf("数据行数须在1到%d之间", maxJobImportRows)))
+		return
+	}
+
+	items := make([]service.JobImportItem, len(rows))
+	for i, row := range rows {
+		items[i] = service.JobImportItem{Row: row.Row, RegionCode: row.Request.RegionCode}
+		for _, e := range row.Errors {
+			items[i].Errors = append(items[i].Errors, response.JobImportError{Row: row.Row, Column: e.Column, Message: e.Message})
+		}
+		if len(row.Errors) == 0 {
+			items[i].Job = row.Request.ToModel()
+		}
+	}
+	userID := c.GetUint("userId")
+	result, err := h.jobService.Import(companyID, userID, items, dryRun)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	// 导入的职位已保存为草稿，提交审核失败的可稍后重新提交
+	if !draft {
+		for _, jobID := range result.JobIDs {
+			if _, err := h.reviewService.Submit(jobID, userID); err != nil {
+				logger.L.Warn("导入的职位提交审核失败", zap.Error(err), zap.Uint("jobId", jobID))
+			}
+		}
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(result))
+}
+
+// Export 导出公司职位
+//
+//	@Summary		导出公司职位
+//	@Description	将公司的全部职位(包括未发布和已过期的职位)导出为 CSV 或 XLSX，列与导入时一致，导出的文件可直接导入
+//	@Tags			职位
+//	@Produce		octet-stream
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Param			format			query		string	false	"文件格式 csv/xlsx"	default(csv)
+//	@Success		200				{file}		file
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/companies/{companyId}/jobs/export [get]
+func (h *JobHandler) Export(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", utils.TableCSV)
+	if !utils.IsTableFormat(format) {
+		c.JSON(http.StatusOK, response.NewErrorWithMsg(errors.InvalidParams, "仅支持 CSV 和 XLSX 格式"))
+		return
+	}
+
+	jobs, err := h.jobService.ListForExport(companyID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	table := make([][]string, 0, len(jobs)+1)
+	table = append(table, request.JobImportColumns())
+	for i := range jobs {
+		table = append(table, request.NewJobExportRecord(&jobs[i]))
+	}
+	var buf bytes.Buffer
+	if err := utils.WriteTable(&buf, format, table); err != nil {
+		logger.L.Error("导出职位失败", zap.Error(err), zap.Uint("companyId", companyID))
+		c.JSON(http.StatusOK, response.NewError(errors.InternalServerError))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="jobs-%d-%s.%s"`, companyID, time.Now().Format("20060102"), format))
+	c.Data(http.StatusOK, utils.TableContentType(format), buf.Bytes())
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+)
+
+// JobPipelineHandler 职位招聘流程处理器
+type JobPipelineHandler struct {
+	pipelineService *service.JobPipelineService
+	jobService      *service.JobService
+}
+
+// NewJobPipelineHandler 创建职位招聘流程处理器
+func NewJobPipelineHandler(pipelineService *service.JobPipelineService, jobService *service.JobService) *JobPipelineHandler {
+	return &JobPipelineHandler{
+		pipelineService: pipelineService,
+		jobService:      jobService,
+	}
+}
+
+// Get 获取职位招聘流程
+//
+//	@Summary		获取职位招聘流程
+//	@Description	获取职位生效的招聘流程，未自定义时返回默认流程
+//	@Tags			招聘流程
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		200				{object}	response.Response{data=response.JobPipelineResponse}
+//	@Router			/api/v1/jobs/{id}/pipeline [get]
+func (h *JobPipelineHandler) Get(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	if userType, _ := middleware.CurrentUserType(c); userType != model.UserTypeAdmin {
+		if err := h.jobService.VerifyCompanyMember(jobID, c.GetUint("userId")); err != nil {
+			c.JSON(http.StatusOK, errorResponse(err))
+			return
+		}
+	}
+
+	definition, custom, err := h.pipelineService.GetForJob(jobID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewJobPipelineResponse(jobID, definition, custom)))
+}
+
+// Save 自定义职位招聘流程
+//
+//	@Summary		自定义职位招聘流程
+//	@Description	设置职位的招聘阶段、允许的状态流转及每个流转的触发方，仅公司所有者或招聘者可以操作
+//	@Tags			招聘流程
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"职位ID"
+//	@Param			request			body		request.JobPipelineRequest	true	"招聘流程"
+//	@Success		200				{object}	response.Response{data=response.JobPipelineResponse}
+//	@Router			/api/v1/jobs/{id}/pipeline [put]
+func (h *JobPipelineHandler) Save(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobPipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	definition := req.ToModel()
+	if err := h.pipelineService.Save(jobID, c.GetUint("userId"), definition); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewJobPipelineResponse(jobID, definition, true)))
+}
+
+// Reset 恢复默认招聘流程
+//
+//	@Summary		恢复默认招聘流程
+//	@Description	删除职位自定义的招聘流程，恢复使用默认流程
+//	@Tags			招聘流程
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/pipeline [delete]
+func (h *JobPipelineHandler) Reset(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.pipelineService.Reset(jobID, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// JobRecommendHandler 职位推荐处理器
+type JobRecommendHandler struct {
+	recommendService *service.JobRecommendService
+}
+
+// NewJobRecommendHandler 创建职位推荐处理器
+func NewJobRecommendHandler(recommendService *service.JobRecommendService) *JobRecommendHandler {
+	return &JobRecommendHandler{recommendService: recommendService}
+}
+
+// Recommend 获取推荐职位
+//
+//	@Summary		获取推荐职位
+//	@Description	根据求职者简历中的期望职位、期望城市、技能和工作年限，以及收藏和投递过的职位推荐有效职位，按匹配度倒序，不包括已收藏和已投递的职位
+//	@Tags			职位推荐
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			limit			query		int		false	"数量"	minimum(1)	maximum(50)	default(20)
+//	@Success		0000			{object}	response.Response{data=response.JobRecommendResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/jobs/recommended [get]
+func (h *JobRecommendHandler) Recommend(c *gin.Context) {
+	limit, ok := recommendLimit(c)
+	if !ok {
+		return
+	}
+	resp, err := h.recommendService.Recommend(c.GetUint("userId"), limit)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(resp))
+}
+
+// Similar 获取相似职位
+//
+//	@Summary		获取相似职位
+//	@Description	按技能要求、标签、职位名称、分类和工作城市获取与指定职位相似的有效职位，供职位详情页展示
+//	@Tags			职位推荐
+//	@Produce		json
+//	@Param			id		path		int	true	"职位ID"
+//	@Param			limit	query		int	false	"数量"	minimum(1)	maximum(50)	default(20)
+//	@Success		0000	{object}	response.Response{data=response.JobRecommendResponse}
+//	@Failure		2000	{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/similar [get]
+func (h *JobRecommendHandler) Similar(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	limit, ok := recommendLimit(c)
+	if !ok {
+		return
+	}
+	resp, err := h.recommendService.Similar(id, c.GetUint("userId"), limit)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(resp))
+}
+
+// recommendLimit 解析推荐职位的数量
+func recommendLimit(c *gin.Context) (int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(service.DefaultRecommendLimit)))
+	if err != nil || limit < 1 || limit > service.MaxRecommendLimit {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return 0, false
+	}
+	return limit, true
+}
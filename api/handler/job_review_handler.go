@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+)
+
+// JobReviewHandler 职位审核处理器
+type JobReviewHandler struct {
+	reviewService *service.JobReviewService
+	jobService    *service.JobService
+}
+
+// NewJobReviewHandler 创建职位审核处理器
+func NewJobReviewHandler(reviewService *service.JobReviewService, jobService *service.JobService) *JobReviewHandler {
+	return &JobReviewHandler{reviewService: reviewService, jobService: jobService}
+}
+
+// Submit 提交职位审核
+//
+//	@Summary		提交职位审核
+//	@Description	将草稿或被驳回的职位提交审核，提交时检查敏感词、联系方式和薪资，检查结果供管理员参考，仅公司所有者或招聘者可以操作
+//	@Tags			职位审核
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		0000			{object}	response.Response{data=response.JobReviewResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/submit [post]
+func (h *JobReviewHandler) Submit(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	review, err := h.reviewService.Submit(id, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(review))
+}
+
+// ListByJob 获取职位的审核记录
+//
+//	@Summary		获取职位的审核记录
+//	@Description	按提交时间倒序获取职位的审核记录，包括内容检查结果和审核意见，仅职位所属公司成员和管理员可以查看
+//	@Tags			职位审核
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		0000			{object}	response.Response{data=[]response.JobReviewResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/reviews [get]
+func (h *JobReviewHandler) ListByJob(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	if userType, _ := middleware.CurrentUserType(c); userType != model.UserTypeAdmin {
+		if err := h.jobService.VerifyCompanyMember(id, c.GetUint("userId")); err != nil {
+			c.JSON(http.StatusOK, errorResponse(err))
+			return
+		}
+	}
+
+	reviews, err := h.reviewService.ListByJob(id)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(reviews))
+}
+
+// Queue 获取审核队列
+//
+//	@Summary		获取审核队列
+//	@Description	按提交时间正序分页获取职位审核记录，默认只返回待审核的记录，仅管理员可以查看
+//	@Tags			职位审核
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string	true	"Bearer JWT"
+//	@Param			status			query		string	false	"审核结果 pending/approved/rejected，all 表示不限"	default(pending)
+//	@Param			page			query		int		false	"页码"	default(1)
+//	@Param			size			query		int		false	"每页数量"	default(10)
+//	@Param			cursor			query		string	false	"上一页返回的游标，指定时忽略页码"
+//	@Param			withTotal		query		bool	false	"是否统计总数，默认只在未指定游标时统计"
+//	@Success		0000			{object}	response.Response{data=response.JobReviewListResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/job-reviews [get]
+func (h *JobReviewHandler) Queue(c *gin.Context) {
+	status := model.JobReviewStatus(c.DefaultQuery("status", string(model.JobReviewPending)))
+	if status == "all" {
+		status = ""
+	}
+	result, err := h.reviewService.List(status, parsePage(c))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(result))
+}
+
+// Approve 审核通过职位
+//
+//	@Summary		审核通过职位
+//	@Description	审核通过后职位立即发布，设置了计划发布时间的到时间后自动发布，仅管理员可以操作
+//	@Tags			职位审核
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string						true	"Bearer JWT"
+//	@Param			id				path		int							true	"审核记录ID"
+//	@Param			request			body		request.JobReviewRequest	false	"审核意见"
+//	@Success		0000			{object}	response.Response{data=response.JobReviewResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/job-reviews/{id}/approve [post]
+func (h *JobReviewHandler) Approve(c *gin.Context) {
+	h.decide(c, h.reviewService.Approve)
+}
+
+// Reject 驳回职位
+//
+//	@Summary		驳回职位
+//	@Description	驳回职位须填写原因，职位修改后可重新提交审核，仅管理员可以操作
+//	@Tags			职位审核
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			Authorization	header		string						true	"Bearer JWT"
+//	@Param			id				path		int							true	"审核记录ID"
+//	@Param			request			body		request.JobReviewRequest	true	"驳回原因"
+//	@Success		0000			{object}	response.Response{data=response.JobReviewResponse}
+//	@Failure		2000			{object}	response.Response
+//	@Router			/api/v1/job-reviews/{id}/reject [post]
+func (h *JobReviewHandler) Reject(c *gin.Context) {
+	h.decide(c, h.reviewService.Reject)
+}
+
+// decide 处理审核请求，请求体可为空
+func (h *JobReviewHandler) decide(c *gin.Context, fn func(reviewID, reviewerID uint, reason string) (*response.JobReviewResponse, error)) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobReviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+			return
+		}
+	}
+
+	review, err := fn(id, c.GetUint("userId"), req.Reason)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(review))
+}
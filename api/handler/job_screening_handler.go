@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+)
+
+// JobScreeningHandler 职位筛选问题处理器
+type JobScreeningHandler struct {
+	screeningService *service.JobScreeningService
+	jobService       *service.JobService
+}
+
+// NewJobScreeningHandler 创建职位筛选问题处理器
+func NewJobScreeningHandler(screeningService *service.JobScreeningService, jobService *service.JobService) *JobScreeningHandler {
+	return &JobScreeningHandler{
+		screeningService: screeningService,
+		jobService:       jobService,
+	}
+}
+
+// Get 获取职位筛选问题
+//
+//	@Summary		获取职位筛选问题
+//	@Description	获取申请职位时需回答的问题，仅职位所属公司成员和管理员可以看到淘汰规则
+//	@Tags			筛选问题
+//	@Produce		json
+//	@Param			id	path		int	true	"职位ID"
+//	@Success		200	{object}	response.Response{data=response.JobScreeningResponse}
+//	@Router			/api/v1/jobs/{id}/screening [get]
+func (h *JobScreeningHandler) Get(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	definition, configured, err := h.screeningService.GetForJob(jobID)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	withKnockout := false
+	if userType, ok := middleware.CurrentUserType(c); ok {
+		withKnockout = userType == model.UserTypeAdmin ||
+			h.jobService.VerifyCompanyMember(jobID, c.GetUint("userId")) == nil
+	}
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewJobScreeningResponse(jobID, definition, configured, withKnockout)))
+}
+
+// Save 设置职位筛选问题
+//
+//	@Summary		设置职位筛选问题
+//	@Description	设置申请职位时需回答的问题及淘汰规则，未满足淘汰规则的申请会被自动拒绝，仅公司所有者或招聘者可以操作
+//	@Tags			筛选问题
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"职位ID"
+//	@Param			request			body		request.JobScreeningRequest	true	"筛选问题"
+//	@Success		200				{object}	response.Response{data=response.JobScreeningResponse}
+//	@Router			/api/v1/jobs/{id}/screening [put]
+func (h *JobScreeningHandler) Save(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.JobScreeningRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	definition := req.ToModel()
+	if err := h.screeningService.Save(jobID, c.GetUint("userId"), definition); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewJobScreeningResponse(jobID, definition, true, true)))
+}
+
+// Delete 删除职位筛选问题
+//
+//	@Summary		删除职位筛选问题
+//	@Description	删除职位的筛选问题，之后的申请无需回答问题，已提交的回答保留
+//	@Tags			筛选问题
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/screening [delete]
+func (h *JobScreeningHandler) Delete(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.screeningService.Delete(jobID, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
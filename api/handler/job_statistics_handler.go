@@ -6,21 +6,29 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/internal/service"
 	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
 )
 
+// JobMemberVerifier 校验操作人是否为职位所属公司的成员，由 service.JobService 实现
+type JobMemberVerifier interface {
+	VerifyCompanyMember(jobID, userID uint) error
+}
+
 type JobStatisticsHandler struct {
 	statsService *service.JobStatisticsService
+	jobVerifier  JobMemberVerifier
 }
 
-func NewJobStatisticsHandler(service *service.JobStatisticsService) *JobStatisticsHandler {
-	return &JobStatisticsHandler{statsService: service}
+func NewJobStatisticsHandler(service *service.JobStatisticsService, jobVerifier JobMemberVerifier) *JobStatisticsHandler {
+	return &JobStatisticsHandler{statsService: service, jobVerifier: jobVerifier}
 }
 
 // GetJobStats 获取职位统计信息
 //	@Summary		获取职位统计信息
-//	@Description	获取指定职位的统计信息
+//	@Description	获取指定职位的统计信息，仅职位所属公司成员和管理员可以查看
 //	@Tags			职位统计
 //	@Accept			json
 //	@Produce		json
@@ -33,6 +41,12 @@ func (h *JobStatisticsHandler) GetJobStats(c *gin.Context) {
 		c.JSON(http.StatusOK, errors.BadRequest)
 		return
 	}
+	if userType, _ := middleware.CurrentUserType(c); userType != model.UserTypeAdmin {
+		if err := h.jobVerifier.VerifyCompanyMember(uint(jobID), c.GetUint("userId")); err != nil {
+			c.JSON(http.StatusOK, errorResponse(err))
+			return
+		}
+	}
 
 	stats, err := h.statsService.GetJobStatisticsByJobID(uint(jobID))
 	if err != nil {
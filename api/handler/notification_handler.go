@@ -21,31 +21,33 @@ func NewNotificationHandler(service *service.NotificationService) *NotificationH
 
 // List 获取通知列表
 //	@Summary		获取通知列表
-//	@Description	分页获取当前用户的通知列表
+//	@Description	按创建时间倒序分页获取当前用户的通知列表，支持页码分页和游标分页
 //	@Tags			通知管理
 //	@Accept			json
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Bearer 用户令牌"
 //	@Param			page			query		int		false	"页码 (默认值: 1)"		minimum(1)	default(1)
 //	@Param			size			query		int		false	"每页数量 (默认值: 10)"	minimum(1)	maximum(100)	default(10)
+//	@Param			cursor			query		string	false	"上一页返回的游标，指定时忽略页码"
+//	@Param			withTotal		query		bool	false	"是否统计总数，默认只在未指定游标时统计"
 //	@Success		200				{object}	response.Response{data=response.NotificationListResponse}
 //	@Router			/api/v1/notifications [get]
 func (h *NotificationHandler) List(c *gin.Context) {
 	userID := c.GetUint("userId")
-	page, size := parsePageSize(c)
 
-	notifications, total, err := h.notificationService.ListUserNotifications(userID, page, size)
+	result, err := h.notificationService.ListUserNotifications(userID, parsePage(c))
 	if err != nil {
-		c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
+		c.JSON(http.StatusOK, errorResponse(err))
 		return
 	}
 
 	resp := &response.NotificationListResponse{
-		Total:   total,
-		Records: make([]response.NotificationResponse, len(notifications)),
+		Total:      result.Total,
+		Records:    make([]response.NotificationResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
 	}
 
-	for i, n := range notifications {
+	for i, n := range result.Records {
 		resp.Records[i].FromModel(&n)
 	}
 
@@ -89,8 +91,9 @@ func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
 		return
 	}
 
+	userID := c.GetUint("userId")
 	for _, id := range req.NotificationIDs {
-		if err := h.notificationService.MarkAsRead(id); err != nil {
+		if err := h.notificationService.MarkUserNotificationAsRead(id, userID); err != nil {
 			c.JSON(http.StatusOK, errors.Wrap(err, errors.InternalServerError))
 			return
 		}
@@ -0,0 +1,498 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+)
+
+// OfferHandler Offer管理处理器
+type OfferHandler struct {
+	offerService *service.OfferService
+}
+
+// NewOfferHandler 创建Offer管理处理器
+func NewOfferHandler(offerService *service.OfferService) *OfferHandler {
+	return &OfferHandler{offerService: offerService}
+}
+
+// Create 创建Offer
+//
+//	@Summary		创建Offer
+//	@Description	为申请创建Offer草稿，可指定Offer函模板和按顺序审批的审批人，同一申请同时只能有一个进行中的Offer
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Bearer 用户令牌"
+//	@Param			id				path		int						true	"申请ID"
+//	@Param			request			body		request.OfferRequest	true	"Offer内容"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/applies/{id}/offers [post]
+func (h *OfferHandler) Create(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.OfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	offer, err := h.offerService.Create(applyID, c.GetUint("userId"), req.ToModel())
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// ListByApply 获取申请的Offer
+//
+//	@Summary		获取申请的Offer
+//	@Description	获取申请的所有Offer，候选人只能查看本人申请中已发送的Offer
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Success		200				{object}	response.Response{data=[]response.OfferResponse}
+//	@Router			/api/v1/applies/{id}/offers [get]
+func (h *OfferHandler) ListByApply(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	offers, err := h.offerService.ListByApply(applyID, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offers))
+}
+
+// ListPendingApproval 获取待我审批的Offer
+//
+//	@Summary		获取待我审批的Offer
+//	@Description	获取审批链中已轮到当前用户审批的Offer
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Success		200				{object}	response.Response{data=[]response.OfferResponse}
+//	@Router			/api/v1/offers/pending-approval [get]
+func (h *OfferHandler) ListPendingApproval(c *gin.Context) {
+	offers, err := h.offerService.ListPendingApproval(c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offers))
+}
+
+// GetByID 获取Offer详情
+//
+//	@Summary		获取Offer详情
+//	@Description	获取Offer详情，企业侧包含审批链，候选人只能查看已发送给本人的Offer
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"Offer ID"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/offers/{id} [get]
+func (h *OfferHandler) GetByID(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	offer, err := h.offerService.Get(id, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// Update 修改Offer
+//
+//	@Summary		修改Offer
+//	@Description	修改草稿状态的Offer，审批被驳回的Offer回到草稿后可修改
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Bearer 用户令牌"
+//	@Param			id				path		int						true	"Offer ID"
+//	@Param			request			body		request.OfferRequest	true	"Offer内容"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/offers/{id} [put]
+func (h *OfferHandler) Update(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.OfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	offer, err := h.offerService.Update(id, c.GetUint("userId"), req.ToModel())
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// Submit 提交Offer审批
+//
+//	@Summary		提交Offer审批
+//	@Description	提交草稿Offer，按审批链依次审批，没有审批人时直接进入待发送
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"Offer ID"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/offers/{id}/submit [post]
+func (h *OfferHandler) Submit(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	offer, err := h.offerService.Submit(id, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// Approve 审批通过Offer
+//
+//	@Summary		审批通过Offer
+//	@Description	当前审批人通过Offer，最后一位审批人通过后Offer进入待发送
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"Offer ID"
+//	@Param			request			body		request.OfferApprovalRequest	false	"审批意见"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/offers/{id}/approve [post]
+func (h *OfferHandler) Approve(c *gin.Context) {
+	h.decide(c, h.offerService.Approve)
+}
+
+// Reject 驳回Offer
+//
+//	@Summary		驳回Offer
+//	@Description	当前审批人驳回Offer，Offer回到草稿，修改后可重新提交审批
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"Offer ID"
+//	@Param			request			body		request.OfferApprovalRequest	false	"审批意见"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/offers/{id}/reject [post]
+func (h *OfferHandler) Reject(c *gin.Context) {
+	h.decide(c, h.offerService.Reject)
+}
+
+// decide 处理审批请求，请求体可为空
+func (h *OfferHandler) decide(c *gin.Context, fn func(id, userID uint, comment string) (*response.OfferResponse, error)) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.OfferApprovalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+			return
+		}
+	}
+
+	offer, err := fn(id, c.GetUint("userId"), req.Comment)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// Send 发送Offer
+//
+//	@Summary		发送Offer
+//	@Description	将审批通过的Offer发送给候选人，申请同步流转到「已发Offer」
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"Offer ID"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Router			/api/v1/offers/{id}/send [post]
+func (h *OfferHandler) Send(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	offer, err := h.offerService.Send(id, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// Withdraw 撤回Offer
+//
+//	@Summary		撤回Offer
+//	@Description	撤回尚未发送给候选人的Offer
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"Offer ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/offers/{id}/withdraw [post]
+func (h *OfferHandler) Withdraw(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.offerService.Withdraw(id, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// Accept 接受Offer
+//
+//	@Summary		接受Offer
+//	@Description	候选人在答复截止时间前接受Offer，申请流转到「已接受Offer」
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"Offer ID"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Failure		2021			{object}	response.Response
+//	@Router			/api/v1/offers/{id}/accept [post]
+func (h *OfferHandler) Accept(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	offer, err := h.offerService.Accept(id, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// Decline 拒绝Offer
+//
+//	@Summary		拒绝Offer
+//	@Description	候选人在答复截止时间前拒绝Offer，申请流转到「已拒绝Offer」
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			id				path		int							true	"Offer ID"
+//	@Param			request			body		request.OfferDeclineRequest	false	"拒绝原因"
+//	@Success		200				{object}	response.Response{data=response.OfferResponse}
+//	@Failure		2021			{object}	response.Response
+//	@Router			/api/v1/offers/{id}/decline [post]
+func (h *OfferHandler) Decline(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.OfferDeclineRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+			return
+		}
+	}
+
+	offer, err := h.offerService.Decline(id, c.GetUint("userId"), req.Reason)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(offer))
+}
+
+// DownloadLetter 下载Offer函
+//
+//	@Summary		下载Offer函
+//	@Description	按Offer函模板生成 Word 格式的Offer函，未指定模板时使用默认模板
+//	@Tags			Offer管理
+//	@Produce		application/vnd.openxmlformats-officedocument.wordprocessingml.document
+//	@Param			Authorization	header	string	true	"Bearer 用户令牌"
+//	@Param			id				path	int		true	"Offer ID"
+//	@Success		200				{file}	file
+//	@Router			/api/v1/offers/{id}/letter [get]
+func (h *OfferHandler) DownloadLetter(c *gin.Context) {
+	id, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	letter, err := h.offerService.Letter(id, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, letter.Filename))
+	c.Data(http.StatusOK, letter.ContentType, letter.Data)
+}
+
+// ListTemplates 获取公司的Offer函模板
+//
+//	@Summary		获取Offer函模板
+//	@Description	获取公司的所有Offer函模板
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Success		200				{object}	response.Response{data=[]response.OfferTemplateResponse}
+//	@Router			/api/v1/companies/{companyId}/offer-templates [get]
+func (h *OfferHandler) ListTemplates(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+
+	templates, err := h.offerService.ListTemplates(companyID, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(templates))
+}
+
+// CreateTemplate 创建Offer函模板
+//
+//	@Summary		创建Offer函模板
+//	@Description	创建Offer函模板，内容中的 {{变量名}} 在生成时替换，可用变量：candidateName、companyName、department、position、baseSalary、currency、salaryMonths、annualSalary、signingBonus、benefits、location、startDate、remark、expireAt、today
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			companyId		path		int							true	"公司ID"
+//	@Param			request			body		request.OfferTemplateRequest	true	"模板内容"
+//	@Success		200				{object}	response.Response{data=response.OfferTemplateResponse}
+//	@Router			/api/v1/companies/{companyId}/offer-templates [post]
+func (h *OfferHandler) CreateTemplate(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	var req request.OfferTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	template, err := h.offerService.CreateTemplate(companyID, c.GetUint("userId"), req.ToModel())
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(template))
+}
+
+// UpdateTemplate 修改Offer函模板
+//
+//	@Summary		修改Offer函模板
+//	@Description	修改Offer函模板的名称、标题和内容
+//	@Tags			Offer管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Bearer 用户令牌"
+//	@Param			companyId		path		int							true	"公司ID"
+//	@Param			templateId		path		int							true	"模板ID"
+//	@Param			request			body		request.OfferTemplateRequest	true	"模板内容"
+//	@Success		200				{object}	response.Response{data=response.OfferTemplateResponse}
+//	@Router			/api/v1/companies/{companyId}/offer-templates/{templateId} [put]
+func (h *OfferHandler) UpdateTemplate(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	templateID, ok := uintParam(c, "templateId")
+	if !ok {
+		return
+	}
+	var req request.OfferTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	template, err := h.offerService.UpdateTemplate(companyID, templateID, c.GetUint("userId"), req.ToModel())
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(template))
+}
+
+// DeleteTemplate 删除Offer函模板
+//
+//	@Summary		删除Offer函模板
+//	@Description	删除Offer函模板，使用该模板的Offer改用默认模板生成
+//	@Tags			Offer管理
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			companyId		path		int		true	"公司ID"
+//	@Param			templateId		path		int		true	"模板ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/companies/{companyId}/offer-templates/{templateId} [delete]
+func (h *OfferHandler) DeleteTemplate(c *gin.Context) {
+	companyID, ok := uintParam(c, "companyId")
+	if !ok {
+		return
+	}
+	templateID, ok := uintParam(c, "templateId")
+	if !ok {
+		return
+	}
+
+	if err := h.offerService.DeleteTemplate(companyID, templateID, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+)
+
+// ScorecardHandler 面试评分卡与反馈处理器
+type ScorecardHandler struct {
+	scorecardService *service.ScorecardService
+}
+
+// NewScorecardHandler 创建面试评分卡处理器
+func NewScorecardHandler(scorecardService *service.ScorecardService) *ScorecardHandler {
+	return &ScorecardHandler{scorecardService: scorecardService}
+}
+
+// GetTemplate 获取职位评分卡
+//
+//	@Summary		获取职位评分卡
+//	@Description	获取职位的面试评分卡模板，未配置时考察项为空
+//	@Tags			面试评价
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		200				{object}	response.Response{data=response.ScorecardTemplateResponse}
+//	@Router			/api/v1/jobs/{id}/scorecard [get]
+func (h *ScorecardHandler) GetTemplate(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	template, err := h.scorecardService.GetTemplate(jobID, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(template))
+}
+
+// SaveTemplate 设置职位评分卡
+//
+//	@Summary		设置职位评分卡
+//	@Description	设置职位的考察项及评分档数，可要求流转到面试通过或未通过前必须提交面试反馈，仅公司所有者或招聘者可以操作
+//	@Tags			面试评价
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"Bearer 用户令牌"
+//	@Param			id				path		int									true	"职位ID"
+//	@Param			request			body		request.ScorecardTemplateRequest	true	"评分卡"
+//	@Success		200				{object}	response.Response{data=response.ScorecardTemplateResponse}
+//	@Router			/api/v1/jobs/{id}/scorecard [put]
+func (h *ScorecardHandler) SaveTemplate(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.ScorecardTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	template, err := h.scorecardService.SaveTemplate(jobID, c.GetUint("userId"), req.ToModel(), req.RequireFeedback)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(template))
+}
+
+// DeleteTemplate 删除职位评分卡
+//
+//	@Summary		删除职位评分卡
+//	@Description	删除职位的评分卡模板，已提交的反馈保留
+//	@Tags			面试评价
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"职位ID"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/jobs/{id}/scorecard [delete]
+func (h *ScorecardHandler) DeleteTemplate(c *gin.Context) {
+	jobID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.scorecardService.DeleteTemplate(jobID, c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// SubmitFeedback 提交面试反馈
+//
+//	@Summary		提交面试反馈
+//	@Description	面试官按职位评分卡提交评分和录用建议，重复提交时覆盖之前的反馈
+//	@Tags			面试评价
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"Bearer 用户令牌"
+//	@Param			id				path		int									true	"面试ID"
+//	@Param			request			body		request.InterviewFeedbackRequest	true	"面试反馈"
+//	@Success		200				{object}	response.Response{data=response.InterviewFeedbackResponse}
+//	@Router			/api/v1/interviews/{id}/feedback [put]
+func (h *ScorecardHandler) SubmitFeedback(c *gin.Context) {
+	interviewID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.InterviewFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	feedback, err := h.scorecardService.SubmitFeedback(interviewID, c.GetUint("userId"), req.ToModel())
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(feedback))
+}
+
+// ListFeedback 获取面试反馈
+//
+//	@Summary		获取面试反馈
+//	@Description	获取一场面试所有面试官提交的反馈
+//	@Tags			面试评价
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"面试ID"
+//	@Success		200				{object}	response.Response{data=[]response.InterviewFeedbackResponse}
+//	@Router			/api/v1/interviews/{id}/feedback [get]
+func (h *ScorecardHandler) ListFeedback(c *gin.Context) {
+	interviewID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	feedbacks, err := h.scorecardService.ListByInterview(interviewID, c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(feedbacks))
+}
+
+// ApplySummary 获取申请的面试评价汇总
+//
+//	@Summary		获取申请的面试评价汇总
+//	@Description	汇总申请所有面试的反馈，返回综合录用建议、考察项平均分及尚未提交反馈的面试官
+//	@Tags			面试评价
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Param			id				path		int		true	"申请ID"
+//	@Success		200				{object}	response.Response{data=response.ApplyScorecardResponse}
+//	@Router			/api/v1/applies/{id}/scorecard [get]
+func (h *ScorecardHandler) ApplySummary(c *gin.Context) {
+	applyID, ok := uintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	userType, _ := middleware.CurrentUserType(c)
+	summary, err := h.scorecardService.ApplySummary(applyID, c.GetUint("userId"), userType)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(summary))
+}
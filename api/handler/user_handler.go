@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"org.thinkinai.com/recruit-center/api/dto/request"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/service"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// UserHandler 用户账号处理器
+type UserHandler struct {
+	userService *service.UserService
+}
+
+// NewUserHandler 创建用户账号处理器
+func NewUserHandler(userService *service.UserService) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// Register 用户注册
+//
+//	@Summary		用户注册
+//	@Description	注册求职者、招聘者或企业用户账号，注册后发送邮箱验证通知
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.UserRegisterRequest	true	"注册信息"
+//	@Success		200		{object}	response.Response{data=response.UserResponse}
+//	@Router			/api/v1/auth/register [post]
+func (h *UserHandler) Register(c *gin.Context) {
+	var req request.UserRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	user := req.ToModel()
+	if err := h.userService.Register(user, req.Password); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewUserResponse(user)))
+}
+
+// Login 用户登录
+//
+//	@Summary		用户登录
+//	@Description	使用用户名或邮箱和密码登录，返回访问令牌与刷新令牌
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.UserLoginRequest	true	"登录信息"
+//	@Success		200		{object}	response.Response{data=response.LoginResponse}
+//	@Router			/api/v1/auth/login [post]
+func (h *UserHandler) Login(c *gin.Context) {
+	var req request.UserLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	user, pair, err := h.userService.Login(req.Account, req.Password, tokenClient(c))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(&response.LoginResponse{
+		User:  response.NewUserResponse(user),
+		Token: response.NewTokenResponse(pair),
+	}))
+}
+
+// Me 获取当前用户信息
+//
+//	@Summary		获取当前用户信息
+//	@Description	获取当前登录用户的账号信息
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Success		200				{object}	response.Response{data=response.UserResponse}
+//	@Router			/api/v1/users/me [get]
+func (h *UserHandler) Me(c *gin.Context) {
+	user, err := h.userService.GetByID(c.GetUint("userId"))
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(response.NewUserResponse(user)))
+}
+
+// VerifyEmail 验证邮箱
+//
+//	@Summary		验证邮箱
+//	@Description	使用邮件中的验证令牌完成邮箱验证
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.VerifyEmailRequest	true	"验证令牌"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/auth/email/verify [post]
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	if err := h.userService.VerifyEmail(req.Token); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// ResendEmailVerification 重新发送邮箱验证
+//
+//	@Summary		重新发送邮箱验证
+//	@Description	为当前用户重新发送邮箱验证通知，之前的验证令牌失效
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Bearer 用户令牌"
+//	@Success		200				{object}	response.Response
+//	@Router			/api/v1/auth/email/resend [post]
+func (h *UserHandler) ResendEmailVerification(c *gin.Context) {
+	if err := h.userService.ResendEmailVerification(c.GetUint("userId")); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// ForgotPassword 忘记密码
+//
+//	@Summary		忘记密码
+//	@Description	向注册邮箱发送重置密码邮件，邮箱是否注册均返回成功
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ForgotPasswordRequest	true	"注册邮箱"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/auth/password/forgot [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	if err := h.userService.ForgotPassword(req.Email); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
+
+// ResetPassword 重置密码
+//
+//	@Summary		重置密码
+//	@Description	使用重置密码令牌设置新密码，成功后该用户所有设备需重新登录
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.ResetPasswordRequest	true	"重置密码信息"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/auth/password/reset [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusOK, response.NewError(errors.InvalidParams))
+		return
+	}
+
+	if err := h.userService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		c.JSON(http.StatusOK, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccess(nil))
+}
@@ -10,6 +10,7 @@ import (
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"org.thinkinai.com/recruit-center/api/handler"
+	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/pkg/config"
 	"org.thinkinai.com/recruit-center/pkg/middleware"
 )
@@ -18,7 +19,7 @@ import (
 const APIVersion = "v1"
 
 // SetupRouter 初始化路由配置
-func SetupRouter(jobHandler *handler.JobHandler, jobApplyHandler *handler.JobApplyHandler, resumeHandler *handler.ResumeHandler, notificationHandler *handler.NotificationHandler, jobStatsHandler *handler.JobStatisticsHandler, jobFavoriteHandler *handler.JobFavoriteHandler) *gin.Engine {
+func SetupRouter(jobHandler *handler.JobHandler, jobApplyHandler *handler.JobApplyHandler, resumeHandler *handler.ResumeHandler, notificationHandler *handler.NotificationHandler, jobStatsHandler *handler.JobStatisticsHandler, jobFavoriteHandler *handler.JobFavoriteHandler, authHandler *handler.AuthHandler, userHandler *handler.UserHandler, companyHandler *handler.CompanyHandler, pipelineHandler *handler.JobPipelineHandler, interviewHandler *handler.InterviewHandler, scorecardHandler *handler.ScorecardHandler, offerHandler *handler.OfferHandler, noteHandler *handler.JobApplyNoteHandler, screeningHandler *handler.JobScreeningHandler, reviewHandler *handler.JobReviewHandler, feedHandler *handler.FeedHandler, recommendHandler *handler.JobRecommendHandler) *gin.Engine {
 	if gin.Mode() != gin.ReleaseMode {
 		gin.SetMode(gin.DebugMode)
 	}
@@ -29,7 +30,7 @@ func SetupRouter(jobHandler *handler.JobHandler, jobApplyHandler *handler.JobApp
 
 	// 配置API路由
 	apiGroup := r.Group(fmt.Sprintf("/api/%s", APIVersion))
-	setupAPIRoutes(apiGroup, jobHandler, jobApplyHandler, resumeHandler, notificationHandler, jobStatsHandler, jobFavoriteHandler)
+	setupAPIRoutes(apiGroup, jobHandler, jobApplyHandler, resumeHandler, notificationHandler, jobStatsHandler, jobFavoriteHandler, authHandler, userHandler, companyHandler, pipelineHandler, interviewHandler, scorecardHandler, offerHandler, noteHandler, screeningHandler, reviewHandler, feedHandler, recommendHandler)
 
 	// 配置工具路由
 	setupToolRoutes(r)
@@ -54,12 +55,33 @@ func setupGlobalMiddleware(r *gin.Engine) {
 }
 
 // setupAPIRoutes 配置API路由
-func setupAPIRoutes(api *gin.RouterGroup, jobHandler *handler.JobHandler, jobApplyHandler *handler.JobApplyHandler, resumeHandler *handler.ResumeHandler, notificationHandler *handler.NotificationHandler, jobStatsHandler *handler.JobStatisticsHandler, jobFavoriteHandler *handler.JobFavoriteHandler) {
+func setupAPIRoutes(api *gin.RouterGroup, jobHandler *handler.JobHandler, jobApplyHandler *handler.JobApplyHandler, resumeHandler *handler.ResumeHandler, notificationHandler *handler.NotificationHandler, jobStatsHandler *handler.JobStatisticsHandler, jobFavoriteHandler *handler.JobFavoriteHandler, authHandler *handler.AuthHandler, userHandler *handler.UserHandler, companyHandler *handler.CompanyHandler, pipelineHandler *handler.JobPipelineHandler, interviewHandler *handler.InterviewHandler, scorecardHandler *handler.ScorecardHandler, offerHandler *handler.OfferHandler, noteHandler *handler.JobApplyNoteHandler, screeningHandler *handler.JobScreeningHandler, reviewHandler *handler.JobReviewHandler, feedHandler *handler.FeedHandler, recommendHandler *handler.JobRecommendHandler) {
+	// 认证相关路由
+	setupAuthRoutes(api.Group("/auth"), authHandler, userHandler)
+
+	// 用户相关路由
+	setupUserRoutes(api.Group("/users"), userHandler)
+
+	// 公司相关路由
+	setupCompanyRoutes(api.Group("/companies"), companyHandler, offerHandler, jobHandler)
+
 	// 职位相关路由
-	setupJobRoutes(api.Group("/jobs"), jobHandler, jobStatsHandler, jobFavoriteHandler)
+	setupJobRoutes(api.Group("/jobs"), jobHandler, jobStatsHandler, jobFavoriteHandler, pipelineHandler, scorecardHandler, screeningHandler, reviewHandler, feedHandler, recommendHandler)
+
+	// 职位审核相关路由
+	setupJobReviewRoutes(api.Group("/job-reviews"), reviewHandler)
 
 	// 申请相关路由
-	setupApplyRoutes(api.Group("/applies"), jobApplyHandler)
+	setupApplyRoutes(api.Group("/applies"), jobApplyHandler, interviewHandler, scorecardHandler, offerHandler, noteHandler)
+
+	// 面试相关路由
+	setupInterviewRoutes(api.Group("/interviews"), interviewHandler, scorecardHandler)
+
+	// Offer相关路由
+	setupOfferRoutes(api.Group("/offers"), offerHandler)
+
+	// 职位订阅源
+	setupFeedRoutes(api.Group("/feeds"), feedHandler)
 
 	// 简历相关路由
 	setupResumeRoutes(api.Group("/resumes"), resumeHandler)
@@ -67,74 +89,227 @@ func setupAPIRoutes(api *gin.RouterGroup, jobHandler *handler.JobHandler, jobApp
 	setupNotificationsRouter(api.Group("/notifications"), notificationHandler)
 }
 
+// 路由访问策略，均需挂载在 middleware.AuthRequired 之后
+var (
+	// jobSeekerOnly 仅求职者
+	jobSeekerOnly = []model.UserType{model.UserTypeJobSeeker}
+	// companySide 企业侧用户(企业用户、招聘者)
+	companySide = []model.UserType{model.UserTypeCompany, model.UserTypeRecruiter}
+	// companySideOrAdmin 企业侧用户及管理员
+	companySideOrAdmin = []model.UserType{model.UserTypeCompany, model.UserTypeRecruiter, model.UserTypeAdmin}
+	// applyParticipants 申请的参与方(求职者与企业侧用户)
+	applyParticipants = []model.UserType{model.UserTypeJobSeeker, model.UserTypeCompany, model.UserTypeRecruiter}
+	// adminOnly 仅管理员
+	adminOnly = []model.UserType{model.UserTypeAdmin}
+)
+
+// setupAuthRoutes 配置认证相关路由
+func setupAuthRoutes(auth *gin.RouterGroup, handler *handler.AuthHandler, userHandler *handler.UserHandler) {
+	auth.POST("/register", userHandler.Register)
+	auth.POST("/login", userHandler.Login)
+	auth.POST("/refresh", handler.Refresh)
+	auth.POST("/logout", middleware.AuthRequired(), handler.Logout)
+	auth.POST("/logout-all", middleware.AuthRequired(), handler.LogoutAll)
+	// 邮箱验证与找回密码
+	auth.POST("/email/verify", userHandler.VerifyEmail)
+	auth.POST("/email/resend", middleware.AuthRequired(), userHandler.ResendEmailVerification)
+	auth.POST("/password/forgot", userHandler.ForgotPassword)
+	auth.POST("/password/reset", userHandler.ResetPassword)
+}
+
+// setupUserRoutes 配置用户相关路由
+func setupUserRoutes(users *gin.RouterGroup, handler *handler.UserHandler) {
+	users.GET("/me", middleware.AuthRequired(), handler.Me)
+}
+
+// setupCompanyRoutes 配置公司相关路由
+// 成员角色(所有者/招聘者/观察者)的细粒度校验在服务层完成
+func setupCompanyRoutes(companies *gin.RouterGroup, handler *handler.CompanyHandler, offerHandler *handler.OfferHandler, jobHandler *handler.JobHandler) {
+	companies.POST("/", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Create)
+	companies.GET("/my", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.ListMine)
+	companies.GET("/:companyId", handler.GetByID)
+	companies.PUT("/:companyId", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), handler.Update)
+	companies.PUT("/:companyId/verify", middleware.AuthRequired(), middleware.RequireRole(adminOnly...), handler.Verify)
+
+	// 成员管理
+	companies.GET("/:companyId/members", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), middleware.RequireCompanyAccess("companyId"), handler.ListMembers)
+	companies.POST("/:companyId/members", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), handler.AddMember)
+	companies.PUT("/:companyId/members/:userId", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), handler.UpdateMemberRole)
+	companies.DELETE("/:companyId/members/:userId", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), handler.RemoveMember)
+
+	// Offer函模板
+	companies.GET("/:companyId/offer-templates", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), offerHandler.ListTemplates)
+	companies.POST("/:companyId/offer-templates", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), offerHandler.CreateTemplate)
+	companies.PUT("/:companyId/offer-templates/:templateId", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), offerHandler.UpdateTemplate)
+	companies.DELETE("/:companyId/offer-templates/:templateId", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), offerHandler.DeleteTemplate)
+
+	// 职位批量导入导出
+	companies.POST("/:companyId/jobs/import", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), jobHandler.Import)
+	companies.GET("/:companyId/jobs/export", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), middleware.RequireCompanyAccess("companyId"), jobHandler.Export)
+}
+
 // setupJobRoutes 配置职位相关路由
-func setupJobRoutes(jobs *gin.RouterGroup, handler *handler.JobHandler, jobStatsHandler *handler.JobStatisticsHandler, jobFavoriteHandler *handler.JobFavoriteHandler) {
-	jobs.POST("/", middleware.AuthRequired(), handler.Create)
-	jobs.PUT("/:id", middleware.AuthRequired(), handler.Update)
-	jobs.DELETE("/:id", middleware.AuthRequired(), handler.Delete)
-	jobs.GET("/:id", handler.GetByID)
-	jobs.GET("/", handler.List)
+func setupJobRoutes(jobs *gin.RouterGroup, handler *handler.JobHandler, jobStatsHandler *handler.JobStatisticsHandler, jobFavoriteHandler *handler.JobFavoriteHandler, pipelineHandler *handler.JobPipelineHandler, scorecardHandler *handler.ScorecardHandler, screeningHandler *handler.JobScreeningHandler, reviewHandler *handler.JobReviewHandler, feedHandler *handler.FeedHandler, recommendHandler *handler.JobRecommendHandler) {
+	jobs.POST("/", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Create)
+	jobs.PUT("/:id", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Update)
+	jobs.DELETE("/:id", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Delete)
+	jobs.GET("/:id", middleware.AuthOptional(), handler.GetByID)
+	jobs.GET("/", middleware.AuthOptional(), handler.List)
+	jobs.GET("/search", middleware.AuthOptional(), handler.Search)
+	// 供搜索引擎使用的结构化数据
+	jobs.GET("/:id/jsonld", feedHandler.JobPosting)
+	// 职位推荐
+	jobs.GET("/recommended", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), recommendHandler.Recommend)
+	jobs.GET("/:id/similar", middleware.AuthOptional(), recommendHandler.Similar)
 
 	// 职位统计相关路由
-	jobs.GET("/jobs/:jobId/statistics", jobStatsHandler.GetJobStats)
-	jobs.GET("/companies/:companyId/statistics", jobStatsHandler.GetCompanyStats)
+	jobs.GET("/jobs/:jobId/statistics", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), jobStatsHandler.GetJobStats)
+	jobs.GET("/companies/:companyId/statistics", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), middleware.RequireCompanyAccess("companyId"), jobStatsHandler.GetCompanyStats)
 	// 更新职位状态
-	jobs.PUT("/:id/status", middleware.AuthRequired(), handler.UpdateStatus)
+	jobs.PUT("/:id/status", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.UpdateStatus)
+	// 提交审核及审核记录
+	jobs.POST("/:id/submit", middleware.AuthRequired(), middleware.RequireRole(companySide...), reviewHandler.Submit)
+	jobs.GET("/:id/reviews", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), reviewHandler.ListByJob)
+	// 修订版本，比较内容变化对所有人开放，便于候选人查看申请后职位的变化
+	jobs.GET("/:id/revisions", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), handler.ListRevisions)
+	jobs.GET("/:id/revisions/diff", middleware.AuthOptional(), handler.DiffRevisions)
+	jobs.POST("/:id/revisions/:revision/restore", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.RestoreRevision)
+	// 职位招聘流程
+	jobs.GET("/:id/pipeline", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), pipelineHandler.Get)
+	jobs.PUT("/:id/pipeline", middleware.AuthRequired(), middleware.RequireRole(companySide...), pipelineHandler.Save)
+	jobs.DELETE("/:id/pipeline", middleware.AuthRequired(), middleware.RequireRole(companySide...), pipelineHandler.Reset)
+	// 面试评分卡
+	jobs.GET("/:id/scorecard", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), scorecardHandler.GetTemplate)
+	jobs.PUT("/:id/scorecard", middleware.AuthRequired(), middleware.RequireRole(companySide...), scorecardHandler.SaveTemplate)
+	jobs.DELETE("/:id/scorecard", middleware.AuthRequired(), middleware.RequireRole(companySide...), scorecardHandler.DeleteTemplate)
+	// 筛选问题
+	jobs.GET("/:id/screening", middleware.AuthOptional(), screeningHandler.Get)
+	jobs.PUT("/:id/screening", middleware.AuthRequired(), middleware.RequireRole(companySide...), screeningHandler.Save)
+	jobs.DELETE("/:id/screening", middleware.AuthRequired(), middleware.RequireRole(companySide...), screeningHandler.Delete)
 	// 根据公司搜索职位信息
 	jobs.GET("/companies/:companyId/search", handler.SearchByCompany) // 假设有搜索功能
 
 	// 收藏相关路由
-	jobs.POST("/favorite/:jobId", middleware.AuthRequired(), jobFavoriteHandler.AddFavorite)
-	jobs.DELETE("/favorite/:jobId", middleware.AuthRequired(), jobFavoriteHandler.RemoveFavorite)
+	jobs.POST("/favorite/:jobId", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), jobFavoriteHandler.AddFavorite)
+	jobs.DELETE("/favorite/:jobId", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), jobFavoriteHandler.RemoveFavorite)
 	//获取用户收藏的职位
-	jobs.GET("/favorites", middleware.AuthRequired(), jobFavoriteHandler.ListFavorites)
+	jobs.GET("/favorites", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), jobFavoriteHandler.ListFavorites)
 	//获取用户收藏职位的统计信息
-	jobs.GET("/favorites/stats", middleware.AuthRequired(), jobFavoriteHandler.GetUserStatistics)
+	jobs.GET("/favorites/stats", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), jobFavoriteHandler.GetUserStatistics)
+}
+
+// setupJobReviewRoutes 配置职位审核相关路由，审核队列仅管理员可以访问
+func setupJobReviewRoutes(reviews *gin.RouterGroup, handler *handler.JobReviewHandler) {
+	reviews.Use(middleware.AuthRequired(), middleware.RequireRole(adminOnly...))
+
+	reviews.GET("", handler.Queue)
+	reviews.POST("/:id/approve", handler.Approve)
+	reviews.POST("/:id/reject", handler.Reject)
 }
 
 // setupApplyRoutes 配置申请相关路由
-func setupApplyRoutes(applies *gin.RouterGroup, handler *handler.JobApplyHandler) {
-	applies.POST("/", middleware.AuthRequired(), handler.Create)
-	applies.GET("/my", middleware.AuthRequired(), handler.ListByUser)
-	applies.GET("/job/:id", handler.List)
-	applies.GET("/:id", handler.GetByID)
-	applies.DELETE("/:id", middleware.AuthRequired(), handler.Delete)
+func setupApplyRoutes(applies *gin.RouterGroup, handler *handler.JobApplyHandler, interviewHandler *handler.InterviewHandler, scorecardHandler *handler.ScorecardHandler, offerHandler *handler.OfferHandler, noteHandler *handler.JobApplyNoteHandler) {
+	applies.POST("/", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.Create)
+	applies.GET("/my", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.ListByUser)
+	applies.GET("/job/:id", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), handler.List)
+	applies.GET("/job/:id/board", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), handler.Board)
+	applies.GET("/job/:id/board/:status", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), handler.BoardStage)
+	applies.GET("/:id", middleware.AuthRequired(), handler.GetByID)
+	applies.DELETE("/:id", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.Delete)
 	//根据公司id查询职位申请信息
-	applies.GET("/company/:id", middleware.AuthRequired(), handler.ListByCompany)
-	applies.PUT("/:id/status", middleware.AuthRequired(), handler.UpdateStatus)
+	applies.GET("/company/:companyId", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), middleware.RequireCompanyAccess("companyId"), handler.ListByCompany)
+	applies.GET("/company/:companyId/tags", middleware.AuthRequired(), middleware.RequireRole(companySide...), middleware.RequireCompanyAccess("companyId"), handler.ListCompanyTags)
+	// 状态流转，企业侧以公司身份、求职者以候选人身份操作，具体流转规则由职位的招聘流程决定
+	applies.PUT("/:id/status", middleware.AuthRequired(), middleware.RequireRole(applyParticipants...), handler.UpdateStatus)
+	applies.PUT("/bulk-status", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.BulkUpdateStatus)
+	applies.GET("/:id/next-states", middleware.AuthRequired(), handler.NextStates)
+	applies.GET("/:id/timeline", middleware.AuthRequired(), handler.Timeline)
+	// 面试安排，候选人可查看本人申请的面试
+	applies.POST("/:id/interviews", middleware.AuthRequired(), middleware.RequireRole(companySide...), interviewHandler.Schedule)
+	applies.GET("/:id/interviews", middleware.AuthRequired(), interviewHandler.ListByApply)
+	applies.GET("/:id/scorecard", middleware.AuthRequired(), middleware.RequireRole(companySideOrAdmin...), scorecardHandler.ApplySummary)
+	// Offer，候选人可查看本人申请中已发送的Offer
+	applies.POST("/:id/offers", middleware.AuthRequired(), middleware.RequireRole(companySide...), offerHandler.Create)
+	applies.GET("/:id/offers", middleware.AuthRequired(), offerHandler.ListByApply)
+	// 招聘方协作：私有备注、标签与评分，仅公司成员可见
+	applies.GET("/:id/notes", middleware.AuthRequired(), middleware.RequireRole(companySide...), noteHandler.List)
+	applies.POST("/:id/notes", middleware.AuthRequired(), middleware.RequireRole(companySide...), noteHandler.Create)
+	applies.PUT("/:id/notes/:noteId", middleware.AuthRequired(), middleware.RequireRole(companySide...), noteHandler.Update)
+	applies.DELETE("/:id/notes/:noteId", middleware.AuthRequired(), middleware.RequireRole(companySide...), noteHandler.Delete)
+	applies.PUT("/:id/tags", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.SetTags)
+	applies.PUT("/:id/rating", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.SetRating)
+}
+
+// setupInterviewRoutes 配置面试相关路由
+// 面试所属公司的成员校验在服务层完成
+func setupInterviewRoutes(interviews *gin.RouterGroup, handler *handler.InterviewHandler, scorecardHandler *handler.ScorecardHandler) {
+	interviews.GET("/my", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.ListMine)
+	interviews.GET("/:id", middleware.AuthRequired(), handler.GetByID)
+	interviews.PUT("/:id", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Reschedule)
+	interviews.POST("/:id/cancel", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Cancel)
+	interviews.POST("/:id/complete", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Complete)
+	interviews.GET("/:id/ics", middleware.AuthRequired(), handler.DownloadICS)
+	// 面试反馈，仅该场面试的面试官可以提交
+	interviews.PUT("/:id/feedback", middleware.AuthRequired(), middleware.RequireRole(companySide...), scorecardHandler.SubmitFeedback)
+	interviews.GET("/:id/feedback", middleware.AuthRequired(), middleware.RequireRole(companySide...), scorecardHandler.ListFeedback)
+}
+
+// setupOfferRoutes 配置Offer相关路由
+// Offer所属公司的成员及审批人校验在服务层完成，接受和拒绝仅限Offer的候选人
+func setupOfferRoutes(offers *gin.RouterGroup, handler *handler.OfferHandler) {
+	offers.GET("/pending-approval", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.ListPendingApproval)
+	offers.GET("/:id", middleware.AuthRequired(), handler.GetByID)
+	offers.PUT("/:id", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Update)
+	offers.POST("/:id/submit", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Submit)
+	offers.POST("/:id/approve", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Approve)
+	offers.POST("/:id/reject", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Reject)
+	offers.POST("/:id/send", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Send)
+	offers.POST("/:id/withdraw", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.Withdraw)
+	offers.POST("/:id/accept", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.Accept)
+	offers.POST("/:id/decline", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.Decline)
+	offers.GET("/:id/letter", middleware.AuthRequired(), handler.DownloadLetter)
+}
+
+// setupFeedRoutes 配置职位订阅源路由，订阅源公开访问
+func setupFeedRoutes(feeds *gin.RouterGroup, handler *handler.FeedHandler) {
+	feeds.GET("/jobs.xml", handler.Indeed)
+	feeds.GET("/jobs.rss", handler.RSS)
+	feeds.GET("/jobs.atom", handler.Atom)
 }
 
 // setupResumeRoutes 配置简历相关路由
 func setupResumeRoutes(resumes *gin.RouterGroup, handler *handler.ResumeHandler) {
-	resumes.POST("/", middleware.AuthRequired(), handler.Create)
-	resumes.PUT("/:id", middleware.AuthRequired(), handler.Update)
-	resumes.GET("/my", middleware.AuthRequired(), handler.GetByUser)
+	resumes.POST("/", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.Create)
+	resumes.PUT("/:id", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.Update)
+	resumes.GET("/my", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.GetByUser)
 	// 添加文件上传路由
 	resumes.POST("/upload",
 		middleware.AuthRequired(),
+		middleware.RequireRole(jobSeekerOnly...),
 		middleware.FileUploadValidator(middleware.FileUploadConfig(config.GetConfig().FileUploadConfig)),
 		handler.UploadResume,
 	)
-	resumes.PUT("/access-status", middleware.AuthRequired(), handler.UpdateAccessStatus)
-	resumes.PUT("/working-status", middleware.AuthRequired(), handler.UpdateWorkingStatus)
-	resumes.GET("/:id", handler.GetByID)
+	resumes.PUT("/access-status", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.UpdateAccessStatus)
+	resumes.PUT("/working-status", middleware.AuthRequired(), middleware.RequireRole(jobSeekerOnly...), handler.UpdateWorkingStatus)
+	resumes.GET("/:id", middleware.AuthOptional(), handler.GetByID)
 	//格局share token获取简历
 	resumes.GET("/share/:token", handler.GetByShareToken)
 	//查看简历收藏相关信息
-	resumes.GET("/:id/view", middleware.AuthRequired(), handler.ViewResume)
+	resumes.GET("/:id/view", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.ViewResume)
 	//切换简历收藏状态
-	resumes.PUT("/:id/favorite", middleware.AuthRequired(), handler.ToggleFavorite)
+	resumes.PUT("/:id/favorite", middleware.AuthRequired(), middleware.RequireRole(companySide...), handler.ToggleFavorite)
 	//获取简历的统计信息
 	resumes.GET("/:id/stats", middleware.AuthRequired(), handler.GetStats)
 }
 
 // SetupNotificationsRouter 通知相关路由配置
 func setupNotificationsRouter(notifications *gin.RouterGroup, notificationHandler *handler.NotificationHandler) {
+	notifications.Use(middleware.AuthRequired())
 
-	notifications.GET("", notificationHandler.List)                        // 获取通知列表
-	notifications.GET("/unread/count", notificationHandler.GetUnreadCount) // 获取未读数量
-	notifications.POST("/read", notificationHandler.MarkAsRead)            // 标记已读
-	notifications.POST("/send", notificationHandler.Send)                  // 发送通知
+	notifications.GET("", notificationHandler.List)                                             // 获取通知列表
+	notifications.GET("/unread/count", notificationHandler.GetUnreadCount)                      // 获取未读数量
+	notifications.POST("/read", notificationHandler.MarkAsRead)                                 // 标记已读
+	notifications.POST("/send", middleware.RequireRole(adminOnly...), notificationHandler.Send) // 发送通知
 
 }
 
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/api/handler"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/config"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// 测试中使用的访问主体
+const (
+	anonymous      = "anonymous"
+	jobSeeker      = "job_seeker"
+	recruiter      = "recruiter"
+	companyUser    = "company"
+	admin          = "admin"
+	otherRecruiter = "other_recruiter" // 其他公司的招聘者
+)
+
+var allPrincipals = []string{anonymous, jobSeeker, recruiter, companyUser, admin, otherRecruiter}
+
+var (
+	everyone      = allPrincipals
+	authenticated = []string{jobSeeker, recruiter, companyUser, admin, otherRecruiter}
+	seekers       = []string{jobSeeker}
+	anyCompany    = []string{recruiter, companyUser, otherRecruiter}
+	anyCompanyAdm = []string{recruiter, companyUser, otherRecruiter, admin}
+	ownCompany    = []string{recruiter, companyUser}
+	ownCompanyAdm = []string{recruiter, companyUser, admin}
+	admins        = []string{admin}
+	participants  = []string{jobSeeker, recruiter, companyUser, otherRecruiter}
+)
+
+// deniedCodes 认证与鉴权失败时返回的错误码
+var deniedCodes = map[errors.ErrorCode]bool{
+	errors.Unauthorized:   true,
+	errors.Forbidden:      true,
+	errors.InvalidToken:   true,
+	errors.TokenExpired:   true,
+	errors.TokenMalformed: true,
+	// 处理器中校验职位所属公司失败
+	errors.JobNotBelongToCompany: true,
+}
+
+// fakeJobVerifier 职位均属于公司10，公司10的成员为用户2和3
+type fakeJobVerifier struct{}
+
+func (fakeJobVerifier) VerifyCompanyMember(_, userID uint) error {
+	if userID == 2 || userID == 3 {
+		return nil
+	}
+	return errors.New(errors.JobNotBelongToCompany)
+}
+
+// newPolicyTestRouter 使用空处理器构建完整路由，用于只验证访问策略
+// 请求通过策略后会进入处理器，因依赖为空而返回业务错误或被 Recovery 捕获
+func newPolicyTestRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	gin.DefaultErrorWriter = io.Discard
+	logger.L = zap.NewNop()
+
+	_, err := config.LoadConfig("../config/config.dev.yaml")
+	require.NoError(t, err)
+
+	return SetupRouter(&handler.JobHandler{}, &handler.JobApplyHandler{}, &handler.ResumeHandler{},
+		&handler.NotificationHandler{}, handler.NewJobStatisticsHandler(nil, fakeJobVerifier{}), &handler.JobFavoriteHandler{},
+		&handler.AuthHandler{}, &handler.UserHandler{}, &handler.CompanyHandler{}, &handler.JobPipelineHandler{}, &handler.InterviewHandler{}, &handler.ScorecardHandler{}, &handler.OfferHandler{}, &handler.JobApplyNoteHandler{}, &handler.JobScreeningHandler{}, &handler.JobReviewHandler{}, &handler.FeedHandler{}, &handler.JobRecommendHandler{})
+}
+
+// principalTokens 为每个访问主体签发令牌，公司ID为10，其他公司为20
+func principalTokens(t *testing.T) map[string]string {
+	issue := func(userID, companyID uint, userType model.UserType) string {
+		token, err := utils.GenerateCompanyToken(userID, companyID, userType.Code(), userType.Code())
+		require.NoError(t, err)
+		return token
+	}
+	return map[string]string{
+		jobSeeker:      issue(1, 0, model.UserTypeJobSeeker),
+		recruiter:      issue(2, 10, model.UserTypeRecruiter),
+		companyUser:    issue(3, 10, model.UserTypeCompany),
+		admin:          issue(4, 0, model.UserTypeAdmin),
+		otherRecruiter: issue(5, 20, model.UserTypeRecruiter),
+	}
+}
+
+// isDenied 判断请求是否被访问策略拦截
+func isDenied(w *httptest.ResponseRecorder) bool {
+	if w.Code != http.StatusOK {
+		return false
+	}
+	var body struct {
+		Code errors.ErrorCode `json:"code"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		return false
+	}
+	return deniedCodes[body.Code]
+}
+
+func TestRoutePolicies(t *testing.T) {
+	r := newPolicyTestRouter(t)
+	tokens := principalTokens(t)
+
+	tests := []struct {
+		method  string
+		path    string
+		allowed []string
+	}{
+		// 认证
+		{http.MethodPost, "/api/v1/auth/refresh", everyone},
+		{http.MethodPost, "/api/v1/auth/logout", authenticated},
+		{http.MethodPost, "/api/v1/auth/logout-all", authenticated},
+		{http.MethodPost, "/api/v1/auth/register", everyone},
+		{http.MethodPost, "/api/v1/auth/login", everyone},
+		{http.MethodPost, "/api/v1/auth/email/verify", everyone},
+		{http.MethodPost, "/api/v1/auth/email/resend", authenticated},
+		{http.MethodPost, "/api/v1/auth/password/forgot", everyone},
+		{http.MethodPost, "/api/v1/auth/password/reset", everyone},
+		{http.MethodGet, "/api/v1/users/me", authenticated},
+
+		// 公司
+		{http.MethodPost, "/api/v1/companies/", anyCompany},
+		{http.MethodGet, "/api/v1/companies/my", anyCompany},
+		{http.MethodGet, "/api/v1/companies/10", everyone},
+		{http.MethodPut, "/api/v1/companies/10", ownCompany},
+		{http.MethodPut, "/api/v1/companies/10/verify", admins},
+		{http.MethodGet, "/api/v1/companies/10/members", ownCompanyAdm},
+		{http.MethodPost, "/api/v1/companies/10/members", ownCompany},
+		{http.MethodPut, "/api/v1/companies/10/members/2", ownCompany},
+		{http.MethodDelete, "/api/v1/companies/10/members/2", ownCompany},
+		{http.MethodGet, "/api/v1/companies/10/offer-templates", ownCompany},
+		{http.MethodPost, "/api/v1/companies/10/offer-templates", ownCompany},
+		{http.MethodPut, "/api/v1/companies/10/offer-templates/1", ownCompany},
+		{http.MethodDelete, "/api/v1/companies/10/offer-templates/1", ownCompany},
+		{http.MethodPost, "/api/v1/companies/10/jobs/import", ownCompany},
+		{http.MethodGet, "/api/v1/companies/10/jobs/export", ownCompanyAdm},
+
+		// 职位
+		{http.MethodPost, "/api/v1/jobs/", anyCompany},
+		{http.MethodPut, "/api/v1/jobs/1", anyCompany},
+		{http.MethodDelete, "/api/v1/jobs/1", anyCompany},
+		{http.MethodGet, "/api/v1/jobs/1", everyone},
+		{http.MethodGet, "/api/v1/jobs/search", everyone},
+		{http.MethodGet, "/api/v1/jobs/", everyone},
+		{http.MethodGet, "/api/v1/jobs/jobs/1/statistics", ownCompanyAdm},
+		{http.MethodGet, "/api/v1/jobs/companies/10/statistics", ownCompanyAdm},
+		{http.MethodPut, "/api/v1/jobs/1/status", anyCompany},
+		{http.MethodGet, "/api/v1/jobs/1/revisions", anyCompanyAdm},
+		{http.MethodGet, "/api/v1/jobs/1/revisions/diff", everyone},
+		{http.MethodPost, "/api/v1/jobs/1/revisions/2/restore", anyCompany},
+		{http.MethodPost, "/api/v1/jobs/1/submit", anyCompany},
+		{http.MethodGet, "/api/v1/jobs/1/jsonld", everyone},
+		{http.MethodGet, "/api/v1/jobs/1/similar", everyone},
+		{http.MethodGet, "/api/v1/jobs/recommended", seekers},
+		{http.MethodGet, "/api/v1/feeds/jobs.xml", everyone},
+		{http.MethodGet, "/api/v1/feeds/jobs.rss", everyone},
+		{http.MethodGet, "/api/v1/feeds/jobs.atom", everyone},
+		{http.MethodGet, "/api/v1/jobs/1/reviews", anyCompanyAdm},
+		{http.MethodGet, "/api/v1/job-reviews", admins},
+		{http.MethodPost, "/api/v1/job-reviews/1/approve", admins},
+		{http.MethodPost, "/api/v1/job-reviews/1/reject", admins},
+		{http.MethodGet, "/api/v1/jobs/companies/10/search", everyone},
+		{http.MethodGet, "/api/v1/jobs/1/pipeline", anyCompanyAdm},
+		{http.MethodPut, "/api/v1/jobs/1/pipeline", anyCompany},
+		{http.MethodDelete, "/api/v1/jobs/1/pipeline", anyCompany},
+		{http.MethodGet, "/api/v1/jobs/1/scorecard", anyCompanyAdm},
+		{http.MethodPut, "/api/v1/jobs/1/scorecard", anyCompany},
+		{http.MethodDelete, "/api/v1/jobs/1/scorecard", anyCompany},
+		{http.MethodGet, "/api/v1/jobs/1/screening", everyone},
+		{http.MethodPut, "/api/v1/jobs/1/screening", anyCompany},
+		{http.MethodDelete, "/api/v1/jobs/1/screening", anyCompany},
+		{http.MethodPost, "/api/v1/jobs/favorite/1", seekers},
+		{http.MethodDelete, "/api/v1/jobs/favorite/1", seekers},
+		{http.MethodGet, "/api/v1/jobs/favorites", seekers},
+		{http.MethodGet, "/api/v1/jobs/favorites/stats", seekers},
+
+		// 职位申请
+		{http.MethodPost, "/api/v1/applies/", seekers},
+		{http.MethodGet, "/api/v1/applies/my", seekers},
+		{http.MethodGet, "/api/v1/applies/job/1", anyCompanyAdm},
+		{http.MethodGet, "/api/v1/applies/job/1/board", anyCompanyAdm},
+		{http.MethodGet, "/api/v1/applies/job/1/board/1", anyCompanyAdm},
+		{http.MethodGet, "/api/v1/applies/1", authenticated},
+		{http.MethodDelete, "/api/v1/applies/1", seekers},
+		{http.MethodGet, "/api/v1/applies/company/10", ownCompanyAdm},
+		{http.MethodGet, "/api/v1/applies/company/10/tags", ownCompany},
+		{http.MethodPut, "/api/v1/applies/1/status", participants},
+		{http.MethodPut, "/api/v1/applies/bulk-status", anyCompany},
+		{http.MethodGet, "/api/v1/applies/1/next-states", authenticated},
+		{http.MethodGet, "/api/v1/applies/1/timeline", authenticated},
+		{http.MethodPost, "/api/v1/applies/1/interviews", anyCompany},
+		{http.MethodGet, "/api/v1/applies/1/interviews", authenticated},
+		{http.MethodGet, "/api/v1/applies/1/scorecard", anyCompanyAdm},
+		{http.MethodPost, "/api/v1/applies/1/offers", anyCompany},
+		{http.MethodGet, "/api/v1/applies/1/offers", authenticated},
+		{http.MethodGet, "/api/v1/applies/1/notes", anyCompany},
+		{http.MethodPost, "/api/v1/applies/1/notes", anyCompany},
+		{http.MethodPut, "/api/v1/applies/1/notes/2", anyCompany},
+		{http.MethodDelete, "/api/v1/applies/1/notes/2", anyCompany},
+		{http.MethodPut, "/api/v1/applies/1/tags", anyCompany},
+		{http.MethodPut, "/api/v1/applies/1/rating", anyCompany},
+
+		// 面试
+		{http.MethodGet, "/api/v1/interviews/my", anyCompany},
+		{http.MethodGet, "/api/v1/interviews/1", authenticated},
+		{http.MethodPut, "/api/v1/interviews/1", anyCompany},
+		{http.MethodPost, "/api/v1/interviews/1/cancel", anyCompany},
+		{http.MethodPost, "/api/v1/interviews/1/complete", anyCompany},
+		{http.MethodGet, "/api/v1/interviews/1/ics", authenticated},
+		{http.MethodPut, "/api/v1/interviews/1/feedback", anyCompany},
+		{http.MethodGet, "/api/v1/interviews/1/feedback", anyCompany},
+
+		// Offer
+		{http.MethodGet, "/api/v1/offers/pending-approval", anyCompany},
+		{http.MethodGet, "/api/v1/offers/1", authenticated},
+		{http.MethodPut, "/api/v1/offers/1", anyCompany},
+		{http.MethodPost, "/api/v1/offers/1/submit", anyCompany},
+		{http.MethodPost, "/api/v1/offers/1/approve", anyCompany},
+		{http.MethodPost, "/api/v1/offers/1/reject", anyCompany},
+		{http.MethodPost, "/api/v1/offers/1/send", anyCompany},
+		{http.MethodPost, "/api/v1/offers/1/withdraw", anyCompany},
+		{http.MethodPost, "/api/v1/offers/1/accept", seekers},
+		{http.MethodPost, "/api/v1/offers/1/decline", seekers},
+		{http.MethodGet, "/api/v1/offers/1/letter", authenticated},
+
+		// 简历
+		{http.MethodPost, "/api/v1/resumes/", seekers},
+		{http.MethodPut, "/api/v1/resumes/1", seekers},
+		{http.MethodGet, "/api/v1/resumes/my", seekers},
+		{http.MethodPost, "/api/v1/resumes/upload", seekers},
+		{http.MethodPut, "/api/v1/resumes/access-status", seekers},
+		{http.MethodPut, "/api/v1/resumes/working-status", seekers},
+		{http.MethodGet, "/api/v1/resumes/1", everyone},
+		{http.MethodGet, "/api/v1/resumes/share/abc", everyone},
+		{http.MethodGet, "/api/v1/resumes/1/view", anyCompany},
+		{http.MethodPut, "/api/v1/resumes/1/favorite", anyCompany},
+		{http.MethodGet, "/api/v1/resumes/1/stats", authenticated},
+
+		// 通知
+		{http.MethodGet, "/api/v1/notifications", authenticated},
+		{http.MethodGet, "/api/v1/notifications/unread/count", authenticated},
+		{http.MethodPost, "/api/v1/notifications/read", authenticated},
+		{http.MethodPost, "/api/v1/notifications/send", admins},
+	}
+
+	for _, tt := range tests {
+		allowed := make(map[string]bool, len(tt.allowed))
+		for _, p := range tt.allowed {
+			allowed[p] = true
+		}
+
+		for _, principal := range allPrincipals {
+			t.Run(tt.method+" "+tt.path+" as "+principal, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.path, nil)
+				if token, ok := tokens[principal]; ok {
+					req.Header.Set("Authorization", "Bearer "+token)
+				}
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, !allowed[principal], isDenied(w), "body: %s", w.Body.String())
+			})
+		}
+	}
+}
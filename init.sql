@@ -49,10 +49,12 @@ INSERT INTO t_rc_notification_template (id, code, title, content, type, user_typ
 (4, 'interview_invite', '面试邀请', '您好！{{companyName}}邀请您参加{{jobName}}职位的面试，时间：{{interviewTime}}。', 3, '[1]', 3, true, NOW(), NOW()),
 (5, 'offer_send', 'Offer通知', '恭喜！{{companyName}}向您发送了{{jobName}}职位的Offer，请及时查看。', 2, '[1]', 1, true, NOW(), NOW()),
 (6, 'resume_view', '简历被查看', '您的简历被{{companyName}}查看了，快去看看吧！', 4, '[1]', 1, true, NOW(), NOW()),
-(7, 'job_expire_remind', '职位即将到期', '您发布的{{jobName}}职位将在3天后到期，请及时续期。', 4, '[2]', 1, true, NOW(), NOW()),
+(7, 'job_expire_remind', '职位即将到期', '您发布的{{jobName}}职位将在{{days}}天后（{{expireTime}}）到期，请及时续期。', 4, '[2]', 1, true, NOW(), NOW()),
 (8, 'system_maintain', '系统维护通知', '系统将于{{maintainTime}}进行维护，预计耗时{{duration}}。', 4, '[1,2,3,4]', 1, true, NOW(), NOW()),
 (9, 'new_job_recommend', '为您推荐新职位', '根据您的简历，为您推荐{{jobName}}职位，快来看看吧！', 1, '[1]', 1, true, NOW(), NOW()),
-(10, 'profile_complete', '完善简历信息', '完善您的简历信息，获得更多面试机会！', 4, '[1]', 1, true, NOW(), NOW());
+(10, 'profile_complete', '完善简历信息', '完善您的简历信息，获得更多面试机会！', 4, '[1]', 1, true, NOW(), NOW()),
+(11, 'user_email_verify', '验证您的邮箱', '{{username}}您好，您的邮箱验证令牌为：{{token}}，请在{{expiresAt}}前完成验证。如非本人操作，请忽略此邮件。', 4, '[1,2,3,4]', 2, true, NOW(), NOW()),
+(12, 'user_password_reset', '重置密码', '{{username}}您好，您的重置密码令牌为：{{token}}，请在{{expiresAt}}前完成重置。如非本人操作，请忽略此邮件，您的密码不会改变。', 4, '[1,2,3,4]', 2, true, NOW(), NOW());
 
 -- 插入简历数据
 INSERT INTO t_rc_resume (id, user_id, name, avatar, gender, birthday, phone, email, location, experience, job_status, expected_job, expected_city, expected_salary, introduction, skills, share_token, access_status, working_status, status, created_at, updated_at) VALUES
@@ -192,10 +194,12 @@ INSERT INTO t_rc_notification_template (id, code, title, content, type, user_typ
 (4, 'interview_invite', '面试邀请', '您好！{{companyName}}邀请您参加{{jobName}}职位的面试，时间：{{interviewTime}}。', 3, '[1]', 3, true, NOW(), NOW()),
 (5, 'offer_send', 'Offer通知', '恭喜！{{companyName}}向您发送了{{jobName}}职位的Offer，请及时查看。', 2, '[1]', 1, true, NOW(), NOW()),
 (6, 'resume_view', '简历被查看', '您的简历被{{companyName}}查看了，快去看看吧！', 4, '[1]', 1, true, NOW(), NOW()),
-(7, 'job_expire_remind', '职位即将到期', '您发布的{{jobName}}职位将在3天后到期，请及时续期。', 4, '[2]', 1, true, NOW(), NOW()),
+(7, 'job_expire_remind', '职位即将到期', '您发布的{{jobName}}职位将在{{days}}天后（{{expireTime}}）到期，请及时续期。', 4, '[2]', 1, true, NOW(), NOW()),
 (8, 'system_maintain', '系统维护通知', '系统将于{{maintainTime}}进行维护，预计耗时{{duration}}。', 4, '[1,2,3,4]', 1, true, NOW(), NOW()),
 (9, 'new_job_recommend', '为您推荐新职位', '根据您的简历，为您推荐{{jobName}}职位，快来看看吧！', 1, '[1]', 1, true, NOW(), NOW()),
-(10, 'profile_complete', '完善简历信息', '完善您的简历信息，获得更多面试机会！', 4, '[1]', 1, true, NOW(), NOW());
+(10, 'profile_complete', '完善简历信息', '完善您的简历信息，获得更多面试机会！', 4, '[1]', 1, true, NOW(), NOW()),
+(11, 'user_email_verify', '验证您的邮箱', '{{username}}您好，您的邮箱验证令牌为：{{token}}，请在{{expiresAt}}前完成验证。如非本人操作，请忽略此邮件。', 4, '[1,2,3,4]', 2, true, NOW(), NOW()),
+(12, 'user_password_reset', '重置密码', '{{username}}您好，您的重置密码令牌为：{{token}}，请在{{expiresAt}}前完成重置。如非本人操作，请忽略此邮件，您的密码不会改变。', 4, '[1,2,3,4]', 2, true, NOW(), NOW());
 
 -- 插入通知数据 (修正列名)
 INSERT INTO t_rc_notification (id, user_id, user_type, type, title, content, channels, template_id, variables, is_read, create_time, update_time) VALUES
@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// CompanyDAO 公司数据访问对象
+type CompanyDAO struct {
+	db *gorm.DB
+}
+
+// CompanyMemberDAO 公司成员数据访问对象
+type CompanyMemberDAO struct {
+	db *gorm.DB
+}
+
+// NewCompanyDAO 创建公司DAO实例
+func NewCompanyDAO(db *gorm.DB) *CompanyDAO {
+	return &CompanyDAO{db: db}
+}
+
+// NewCompanyMemberDAO 创建公司成员DAO实例
+func NewCompanyMemberDAO(db *gorm.DB) *CompanyMemberDAO {
+	return &CompanyMemberDAO{db: db}
+}
+
+// CreateWithOwner 在同一事务中创建公司并将创建人设为所有者
+// 创建人尚未设置所属公司时，同时将其所属公司设为该公司
+func (d *CompanyDAO) CreateWithOwner(company *model.Company) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(company).Error; err != nil {
+			return err
+		}
+		member := &model.CompanyMember{
+			CompanyID: company.ID,
+			UserID:    company.CreatedBy,
+			Role:      model.CompanyRoleOwner,
+		}
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).
+			Where("id = ? AND company_id = 0", company.CreatedBy).
+			Update("company_id", company.ID).Error
+	})
+}
+
+// GetByID 根据ID获取公司
+func (d *CompanyDAO) GetByID(id uint) (*model.Company, error) {
+	var company model.Company
+	if err := d.db.First(&company, id).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// GetByIDs 批量获取公司
+func (d *CompanyDAO) GetByIDs(ids []uint) ([]model.Company, error) {
+	var companies []model.Company
+	if len(ids) == 0 {
+		return companies, nil
+	}
+	err := d.db.Where("id IN ?", ids).Find(&companies).Error
+	return companies, err
+}
+
+// UpdateProfile 更新公司资料，认证与状态字段不在此更新
+func (d *CompanyDAO) UpdateProfile(company *model.Company) error {
+	return d.db.Model(&model.Company{}).Where("id = ?", company.ID).
+		Select("name", "short_name", "logo", "industry", "scale", "website", "address",
+			"description", "contact_email", "contact_phone", "credit_code").
+		Updates(company).Error
+}
+
+// UpdateVerifyStatus 更新公司认证状态
+func (d *CompanyDAO) UpdateVerifyStatus(id uint, updates map[string]interface{}) error {
+	return d.db.Model(&model.Company{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Create 添加公司成员
+func (d *CompanyMemberDAO) Create(member *model.CompanyMember) error {
+	return d.db.Create(member).Error
+}
+
+// Get 获取用户在公司中的成员记录
+func (d *CompanyMemberDAO) Get(companyID, userID uint) (*model.CompanyMember, error) {
+	var member model.CompanyMember
+	if err := d.db.Where("company_id = ? AND user_id = ?", companyID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListByCompany 获取公司的所有成员
+func (d *CompanyMemberDAO) ListByCompany(companyID uint) ([]model.CompanyMember, error) {
+	var members []model.CompanyMember
+	err := d.db.Where("company_id = ?", companyID).Order("id asc").Find(&members).Error
+	return members, err
+}
+
+// ListByUser 获取用户加入的所有公司成员记录
+func (d *CompanyMemberDAO) ListByUser(userID uint) ([]model.CompanyMember, error) {
+	var members []model.CompanyMember
+	err := d.db.Where("user_id = ?", userID).Order("id asc").Find(&members).Error
+	return members, err
+}
+
+// ListUserIDsByRoles 获取公司中指定角色的成员用户ID
+func (d *CompanyMemberDAO) ListUserIDsByRoles(companyID uint, roles []model.CompanyRole) ([]uint, error) {
+	var userIDs []uint
+	err := d.db.Model(&model.CompanyMember{}).
+		Where("company_id = ? AND role IN ?", companyID, roles).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// CountByRole 统计公司中指定角色的成员数量
+func (d *CompanyMemberDAO) CountByRole(companyID uint, role model.CompanyRole) (int64, error) {
+	var count int64
+	err := d.db.Model(&model.CompanyMember{}).
+		Where("company_id = ? AND role = ?", companyID, role).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateRole 更新成员角色
+func (d *CompanyMemberDAO) UpdateRole(companyID, userID uint, role model.CompanyRole) error {
+	return d.db.Model(&model.CompanyMember{}).
+		Where("company_id = ? AND user_id = ?", companyID, userID).
+		Update("role", role).Error
+}
+
+// Delete 移除公司成员
+func (d *CompanyMemberDAO) Delete(companyID, userID uint) error {
+	return d.db.Where("company_id = ? AND user_id = ?", companyID, userID).
+		Delete(&model.CompanyMember{}).Error
+}
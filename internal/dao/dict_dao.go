@@ -67,6 +67,15 @@ func (d *DictDAO) ListByCategory(category string) ([]model.Dict, error) {
 	return dicts, err
 }
 
+// ListEnabledByCategory 获取分类下启用且未删除的字典
+func (d *DictDAO) ListEnabledByCategory(category string) ([]model.Dict, error) {
+	var dicts []model.Dict
+	err := d.db.Where("category = ? AND status = 1 AND deleted_at IS NULL", category).
+		Order("sort, id").
+		Find(&dicts).Error
+	return dicts, err
+}
+
 // ListTree 获取字典树形结构
 func (d *DictDAO) ListTree(category string) ([]model.Dict, error) {
 	var dicts []model.Dict
@@ -0,0 +1,150 @@
+package dao
+
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// InterviewDAO 面试数据访问对象
+type InterviewDAO struct {
+	db *gorm.DB
+}
+
+// NewInterviewDAO 创建面试DAO实例
+func NewInterviewDAO(db *gorm.DB) *InterviewDAO {
+	return &InterviewDAO{db: db}
+}
+
+// interviewScheduleLock 面试日程咨询锁的命名空间，与用户ID组成事务级锁的两个键
+const interviewScheduleLock = 0x1e7e
+
+// Create 在同一事务中锁定候选人和面试官的日程、检测时间冲突并创建面试，面试官作为关联记录一并创建
+// 存在冲突时不创建面试，返回冲突的面试
+func (d *InterviewDAO) Create(interview *model.Interview) ([]model.Interview, error) {
+	var conflicts []model.Interview
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if conflicts, err = lockAndFindConflicts(tx, interview); err != nil || len(conflicts) > 0 {
+			return err
+		}
+		return tx.Create(interview).Error
+	})
+	return conflicts, err
+}
+
+// GetByID 获取面试及面试官
+func (d *InterviewDAO) GetByID(id uint) (*model.Interview, error) {
+	var interview model.Interview
+	if err := d.db.Preload("Interviewers").First(&interview, id).Error; err != nil {
+		return nil, err
+	}
+	return &interview, nil
+}
+
+// ListByApply 获取申请的所有面试，按轮次排序
+func (d *InterviewDAO) ListByApply(applyID uint) ([]model.Interview, error) {
+	var interviews []model.Interview
+	err := d.db.Preload("Interviewers").
+		Where("apply_id = ?", applyID).
+		Order("round ASC, start_time ASC").
+		Find(&interviews).Error
+	return interviews, err
+}
+
+// ListByInterviewer 获取面试官在时间范围内已安排的面试
+func (d *InterviewDAO) ListByInterviewer(userID uint, from, to time.Time) ([]model.Interview, error) {
+	var interviews []model.Interview
+	err := d.db.Preload("Interviewers").
+		Where("id IN (?)", d.db.Model(&model.InterviewInterviewer{}).Select("interview_id").Where("user_id = ?", userID)).
+		Where("status = ? AND start_time < ? AND end_time > ?", model.InterviewScheduled, to, from).
+		Order("start_time ASC").
+		Find(&interviews).Error
+	return interviews, err
+}
+
+// MaxRound 获取申请已安排的最大面试轮次，未安排时为0
+func (d *InterviewDAO) MaxRound(applyID uint) (int, error) {
+	var round int
+	err := d.db.Model(&model.Interview{}).
+		Where("apply_id = ?", applyID).
+		Select("COALESCE(MAX(round), 0)").
+		Scan(&round).Error
+	return round, err
+}
+
+// lockAndFindConflicts 按用户ID顺序获取候选人和面试官的事务级咨询锁，再查找与面试时间重叠的其他已安排面试
+// 同一参与人的排期在事务提交前串行执行，避免并发安排出重叠的面试
+func lockAndFindConflicts(tx *gorm.DB, interview *model.Interview) ([]model.Interview, error) {
+	interviewerIDs := make([]uint, len(interview.Interviewers))
+	for i, v := range interview.Interviewers {
+		interviewerIDs[i] = v.UserID
+	}
+	userIDs := append([]uint{interview.CandidateID}, interviewerIDs...)
+	slices.Sort(userIDs)
+	for _, id := range slices.Compact(userIDs) {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", interviewScheduleLock, id).Error; err != nil {
+			return nil, err
+		}
+	}
+	return findConflicts(tx, interviewerIDs, interview.CandidateID, interview.StartTime, interview.EndTime, interview.ID)
+}
+
+// findConflicts 查找与时间段重叠的已安排面试，面试官或候选人任一方时间冲突即返回
+// excludeID 为改期的面试本身，新建时为0
+func findConflicts(tx *gorm.DB, interviewerIDs []uint, candidateID uint, start, end time.Time, excludeID uint) ([]model.Interview, error) {
+	var interviews []model.Interview
+	participants := tx.Where("candidate_id = ?", candidateID)
+	if len(interviewerIDs) > 0 {
+		participants = participants.Or("id IN (?)",
+			tx.Model(&model.InterviewInterviewer{}).Select("interview_id").Where("user_id IN ?", interviewerIDs))
+	}
+	err := tx.Preload("Interviewers").
+		Where("status = ? AND start_time < ? AND end_time > ? AND id <> ?", model.InterviewScheduled, end, start, excludeID).
+		Where(participants).
+		Order("start_time ASC").
+		Find(&interviews).Error
+	return interviews, err
+}
+
+// Reschedule 在同一事务中锁定参与人的日程、检测时间冲突，再更新面试时间、地点并替换面试官，修订序号递增
+// 存在冲突时不修改面试，返回冲突的面试
+func (d *InterviewDAO) Reschedule(interview *model.Interview) ([]model.Interview, error) {
+	var conflicts []model.Interview
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if conflicts, err = lockAndFindConflicts(tx, interview); err != nil || len(conflicts) > 0 {
+			return err
+		}
+		if err := tx.Model(&model.Interview{}).Where("id = ?", interview.ID).
+			Select("title", "mode", "start_time", "end_time", "location", "meeting_url", "remark", "sequence").
+			Updates(interview).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("interview_id = ?", interview.ID).Delete(&model.InterviewInterviewer{}).Error; err != nil {
+			return err
+		}
+		if len(interview.Interviewers) == 0 {
+			return nil
+		}
+		for i := range interview.Interviewers {
+			interview.Interviewers[i].InterviewID = interview.ID
+		}
+		return tx.Create(&interview.Interviewers).Error
+	})
+	return conflicts, err
+}
+
+// UpdateStatus 更新已安排面试的状态，返回受影响行数，为0表示面试已不处于已安排状态
+func (d *InterviewDAO) UpdateStatus(id uint, status model.InterviewStatus, updates map[string]interface{}) (int64, error) {
+	if updates == nil {
+		updates = map[string]interface{}{}
+	}
+	updates["status"] = status
+	result := d.db.Model(&model.Interview{}).
+		Where("id = ? AND status = ?", id, model.InterviewScheduled).
+		Updates(updates)
+	return result.RowsAffected, result.Error
+}
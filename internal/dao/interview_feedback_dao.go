@@ -0,0 +1,59 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// InterviewFeedbackDAO 面试反馈数据访问对象
+type InterviewFeedbackDAO struct {
+	db *gorm.DB
+}
+
+// NewInterviewFeedbackDAO 创建面试反馈DAO实例
+func NewInterviewFeedbackDAO(db *gorm.DB) *InterviewFeedbackDAO {
+	return &InterviewFeedbackDAO{db: db}
+}
+
+// Save 保存面试官的反馈，同一面试官对同一场面试重复提交时覆盖
+func (d *InterviewFeedbackDAO) Save(feedback *model.InterviewFeedback) error {
+	return d.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "interview_id"}, {Name: "interviewer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ratings", "recommendation", "summary", "update_time"}),
+	}).Create(feedback).Error
+}
+
+// ListByInterview 获取一场面试的所有反馈
+func (d *InterviewFeedbackDAO) ListByInterview(interviewID uint) ([]model.InterviewFeedback, error) {
+	var feedbacks []model.InterviewFeedback
+	err := d.db.Where("interview_id = ?", interviewID).Order("create_time ASC").Find(&feedbacks).Error
+	return feedbacks, err
+}
+
+// ListByApply 获取申请的所有面试反馈
+func (d *InterviewFeedbackDAO) ListByApply(applyID uint) ([]model.InterviewFeedback, error) {
+	var feedbacks []model.InterviewFeedback
+	err := d.db.Where("apply_id = ?", applyID).Order("create_time ASC").Find(&feedbacks).Error
+	return feedbacks, err
+}
+
+// ApplyIDsWithFeedback 从给定申请中筛选出已有面试反馈的申请
+func (d *InterviewFeedbackDAO) ApplyIDsWithFeedback(applyIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool, len(applyIDs))
+	if len(applyIDs) == 0 {
+		return result, nil
+	}
+	var ids []uint
+	err := d.db.Model(&model.InterviewFeedback{}).
+		Where("apply_id IN ?", applyIDs).
+		Distinct().
+		Pluck("apply_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
@@ -1,10 +1,12 @@
 package dao
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 // JobApplyDAO 职位申请数据访问对象
@@ -12,15 +14,32 @@ type JobApplyDAO struct {
 	db *gorm.DB
 }
 
+// StatusTransition 一次申请状态流转，包含流转事件及流转后的进度名称
+type StatusTransition struct {
+	Event    *model.JobApplyEvent
+	Progress string
+}
+
 // NewJobApplyDAO 创建职位申请DAO实例
 func NewJobApplyDAO(db *gorm.DB) *JobApplyDAO {
 	return &JobApplyDAO{db: db}
 }
 
-// Create 创建职位申请记录
+// Create 创建职位申请记录，并在同一事务中写入提交申请事件
 func (d *JobApplyDAO) Create(apply *model.JobApply) error {
 	apply.ApplyTime = time.Now()
-	return d.db.Create(apply).Error
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(apply).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.JobApplyEvent{
+			ApplyID:   apply.ID,
+			JobID:     apply.JobID,
+			ActorType: model.PipelineActorCandidate,
+			ActorID:   apply.UserID,
+			ToStatus:  apply.Status,
+		}).Error
+	})
 }
 
 // Update 更新职位申请记录
@@ -44,17 +63,18 @@ func (d *JobApplyDAO) GetByID(id uint) (*model.JobApply, error) {
 }
 
 // List 获取申请列表
-func (d *JobApplyDAO) List(page, size int) ([]model.JobApply, int64, error) {
-	var applies []model.JobApply
-	var total int64
+func (d *JobApplyDAO) List(page pagination.Page) (*pagination.Result[model.JobApply], error) {
+	return paginate(d.db.Model(&model.JobApply{}), page, &applyListKey, "", applyCursor(&applyListKey))
+}
 
-	if err := d.db.Model(&model.JobApply{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+// GetByIDs 批量获取申请记录
+func (d *JobApplyDAO) GetByIDs(ids []uint) ([]model.JobApply, error) {
+	var applies []model.JobApply
+	if len(ids) == 0 {
+		return applies, nil
 	}
-
-	offset := (page - 1) * size
-	err := d.db.Offset(offset).Limit(size).Find(&applies).Error
-	return applies, total, err
+	err := d.db.Where("id IN ?", ids).Find(&applies).Error
+	return applies, err
 }
 
 // 只保留特定的业务方法
@@ -68,66 +88,258 @@ func (d *JobApplyDAO) GetByUserAndJob(userID, jobID uint) (*model.JobApply, erro
 }
 
 // ListByUser 获取用户的所有申请记录
-func (d *JobApplyDAO) ListByUser(userID uint, page, size int) ([]model.JobApply, int64, error) {
-	var applies []model.JobApply
-	var total int64
+func (d *JobApplyDAO) ListByUser(userID uint, page pagination.Page) (*pagination.Result[model.JobApply], error) {
+	query := d.db.Model(&model.JobApply{}).Where("user_id = ?", userID)
+	return paginate(query, page, &applyListKey, "", applyCursor(&applyListKey))
+}
+
+// ListRecentJobIDs 获取用户最近申请的职位ID，按申请时间倒序
+func (d *JobApplyDAO) ListRecentJobIDs(userID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := d.db.Model(&model.JobApply{}).
+		Where("user_id = ?", userID).
+		Order("apply_time DESC").
+		Limit(limit).
+		Pluck("job_id", &ids).Error
+	return ids, err
+}
+
+// ListByCompany 获取公司所有的职位申请记录，按筛选条件筛选和排序
+func (d *JobApplyDAO) ListByCompany(companyID uint, filter model.JobApplyFilter, page pagination.Page) (*pagination.Result[model.JobApply], error) {
+	return d.listFiltered(companyScope(companyID), filter, page)
+}
+
+// ListByJob 获取职位的所有申请记录，按筛选条件筛选和排序
+func (d *JobApplyDAO) ListByJob(jobID uint, filter model.JobApplyFilter, page pagination.Page) (*pagination.Result[model.JobApply], error) {
+	return d.listFiltered(jobScope(jobID), filter, page)
+}
+
+// StatusCount 申请状态及申请数
+type StatusCount struct {
+	Status int
+	Count  int64
+}
+
+// CountStatusByCompany 按筛选条件统计公司各状态的申请数，忽略状态条件
+func (d *JobApplyDAO) CountStatusByCompany(companyID uint, filter model.JobApplyFilter) ([]StatusCount, error) {
+	return d.countByStatus(companyScope(companyID), filter)
+}
+
+// CountStatusByJob 按筛选条件统计职位各状态的申请数，忽略状态条件
+func (d *JobApplyDAO) CountStatusByJob(jobID uint, filter model.JobApplyFilter) ([]StatusCount, error) {
+	return d.countByStatus(jobScope(jobID), filter)
+}
 
-	if err := d.db.Model(&model.JobApply{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
-		return nil, 0, err
+// companyScope 公司申请条件
+func companyScope(companyID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("company_id = ?", companyID)
 	}
+}
 
-	offset := (page - 1) * size
-	if err := d.db.Where("user_id = ?", userID).
-		Offset(offset).
-		Limit(size).
-		Order("apply_time DESC").
-		Find(&applies).Error; err != nil {
-		return nil, 0, err
+// jobScope 职位申请条件
+func jobScope(jobID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("job_id = ?", jobID)
 	}
+}
 
-	return applies, total, nil
+// listFiltered 在基础条件上应用筛选条件，分页查询申请记录
+func (d *JobApplyDAO) listFiltered(base func(*gorm.DB) *gorm.DB, filter model.JobApplyFilter, page pagination.Page) (*pagination.Result[model.JobApply], error) {
+	query := d.db.Model(&model.JobApply{}).Scopes(base, d.filterScope(filter))
+	key := applySortKey(filter.Sort)
+	return paginate(query, page, key, applySortClause(filter.Sort), applyCursor(key))
 }
 
-// ListByCompany 获取公司所有的职位申请记录
-func (d *JobApplyDAO) ListByCompany(companyID uint, page, size int) ([]model.JobApply, int64, error) {
-	var applies []model.JobApply
-	var total int64
+// countByStatus 在基础条件上应用除状态外的筛选条件，按状态分组统计申请数
+func (d *JobApplyDAO) countByStatus(base func(*gorm.DB) *gorm.DB, filter model.JobApplyFilter) ([]StatusCount, error) {
+	var counts []StatusCount
+	err := d.db.Model(&model.JobApply{}).
+		Scopes(base, d.filterScope(filter.WithoutStatuses())).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Order("status ASC").
+		Scan(&counts).Error
+	return counts, err
+}
 
-	if err := d.db.Model(&model.JobApply{}).Where("company_id = ? and status=0", companyID).Count(&total).Error; err != nil {
-		return nil, 0, err
+// filterScope 申请列表的筛选条件，多个标签须同时包含，简历条件通过子查询匹配
+func (d *JobApplyDAO) filterScope(filter model.JobApplyFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(filter.Statuses) > 0 {
+			db = db.Where("status IN ?", filter.Statuses)
+		}
+		if filter.JobID > 0 {
+			db = db.Where("job_id = ?", filter.JobID)
+		}
+		if !filter.AppliedFrom.IsZero() {
+			db = db.Where("apply_time >= ?", filter.AppliedFrom)
+		}
+		if !filter.AppliedTo.IsZero() {
+			db = db.Where("apply_time < ?", filter.AppliedTo)
+		}
+		if resumes := d.resumeSubquery(filter); resumes != nil {
+			db = db.Where("resume_id IN (?)", resumes)
+		}
+		if len(filter.Tags) > 0 {
+			lowered := make([]string, len(filter.Tags))
+			for i, tag := range filter.Tags {
+				lowered[i] = strings.ToLower(tag)
+			}
+			db = db.Where("id IN (?)", d.db.Model(&model.JobApplyTag{}).
+				Select("apply_id").
+				Where("LOWER(tag) IN ?", lowered).
+				Group("apply_id").
+				Having("COUNT(DISTINCT LOWER(tag)) = ?", len(lowered)))
+		}
+		if filter.MinRating > 0 {
+			db = db.Where("rating >= ?", filter.MinRating)
+		}
+		if filter.MaxRating > 0 {
+			db = db.Where("rating BETWEEN 1 AND ?", filter.MaxRating)
+		}
+		return db
 	}
+}
 
-	offset := (page - 1) * size
-	if err := d.db.Where("company_id = ? and status=0", companyID).
-		Offset(offset).
-		Limit(size).
-		Order("apply_time DESC").
-		Find(&applies).Error; err != nil {
-		return nil, 0, err
+// resumeSubquery 按简历工作年限、期望城市和学历筛选简历ID，没有简历条件时返回 nil
+func (d *JobApplyDAO) resumeSubquery(filter model.JobApplyFilter) *gorm.DB {
+	if filter.MinExperience == nil && filter.MaxExperience == nil && filter.ExpectedCity == "" && filter.Degree == "" {
+		return nil
+	}
+	query := d.db.Model(&model.Resume{}).Select("id")
+	if filter.MinExperience != nil {
+		query = query.Where("experience >= ?", *filter.MinExperience)
+	}
+	if filter.MaxExperience != nil {
+		query = query.Where("experience <= ?", *filter.MaxExperience)
+	}
+	if filter.ExpectedCity != "" {
+		query = query.Where("expected_city LIKE ?", "%"+filter.ExpectedCity+"%")
 	}
+	if filter.Degree != "" {
+		query = query.Where("id IN (?)", d.db.Model(&model.Education{}).
+			Select("resume_id").
+			Where("degree = ? AND deleted_at IS NULL", filter.Degree))
+	}
+	return query
+}
+
+// applyListKey 申请列表默认按申请时间倒序
+var applyListKey = sortKey{scope: "applies", timeColumn: "apply_time", desc: true}
 
-	return applies, total, nil
+// applySortKey 排序方式对应的键集分页排序键，排序列方向不一致的排序方式不支持游标分页，返回空
+func applySortKey(sort model.JobApplySort) *sortKey {
+	scope := "applies:" + string(sort)
+	switch sort {
+	case model.JobApplySortApplyTimeAsc:
+		return &sortKey{scope: scope, timeColumn: "apply_time"}
+	case model.JobApplySortRatingDesc:
+		return &sortKey{scope: scope, valueColumn: "rating", timeColumn: "apply_time", desc: true}
+	case model.JobApplySortUpdateDesc:
+		return &sortKey{scope: scope, timeColumn: "update_time", desc: true}
+	case model.JobApplySortUpdateAsc:
+		return &sortKey{scope: scope, timeColumn: "update_time"}
+	case model.JobApplySortRatingAsc, model.JobApplySortTag:
+		return nil
+	default:
+		return &applyListKey
+	}
 }
 
-// ListByJob 获取职位的所有申请记录
-func (d *JobApplyDAO) ListByJob(jobID uint, page, size int) ([]model.JobApply, int64, error) {
-	var applies []model.JobApply
-	var total int64
+// applyCursor 由申请记录生成分页位置，时间取排序键中的时间列
+func applyCursor(key *sortKey) func(*model.JobApply) pagination.Cursor {
+	return func(apply *model.JobApply) pagination.Cursor {
+		c := pagination.Cursor{Time: apply.ApplyTime, Value: int64(apply.Rating), ID: apply.ID}
+		if key != nil && key.timeColumn == "update_time" {
+			c.Time = apply.UpdateTime
+		}
+		return c
+	}
+}
 
-	if err := d.db.Model(&model.JobApply{}).Where("job_id = ? and status=0", jobID).Count(&total).Error; err != nil {
-		return nil, 0, err
+// applySortClause 不支持游标分页的排序方式对应的排序语句，未评分(0)和无标签的申请排在最后
+func applySortClause(sort model.JobApplySort) string {
+	switch sort {
+	case model.JobApplySortRatingAsc:
+		return "rating = 0, rating ASC, apply_time DESC, id DESC"
+	case model.JobApplySortTag:
+		return "(SELECT MIN(LOWER(tag)) FROM t_rc_job_apply_tag WHERE t_rc_job_apply_tag.apply_id = t_rc_job_apply.id) ASC NULLS LAST, apply_time DESC, id DESC"
+	default:
+		return applyListKey.order()
 	}
+}
 
-	offset := (page - 1) * size
-	if err := d.db.Where("job_id = ? and status=0", jobID).
-		Offset(offset).
-		Limit(size).
-		Order("apply_time DESC").
-		Find(&applies).Error; err != nil {
-		return nil, 0, err
+// UpdateRating 更新申请评分，rating 为0表示清除评分
+func (d *JobApplyDAO) UpdateRating(id uint, rating int, userID uint) error {
+	return d.db.Model(&model.JobApply{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"rating":      rating,
+			"rated_by":    userID,
+			"update_time": time.Now(),
+		}).Error
+}
+
+// ReplaceTags 替换申请的全部标签
+func (d *JobApplyDAO) ReplaceTags(apply *model.JobApply, tags []string, userID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("apply_id = ?", apply.ID).Delete(&model.JobApplyTag{}).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		records := make([]model.JobApplyTag, len(tags))
+		for i, tag := range tags {
+			records[i] = model.JobApplyTag{ApplyID: apply.ID, CompanyID: apply.CompanyID, Tag: tag, CreatedBy: userID}
+		}
+		return tx.Create(&records).Error
+	})
+}
+
+// ListTags 批量获取申请的标签，按添加顺序
+func (d *JobApplyDAO) ListTags(applyIDs []uint) (map[uint][]string, error) {
+	result := make(map[uint][]string, len(applyIDs))
+	if len(applyIDs) == 0 {
+		return result, nil
+	}
+	var tags []model.JobApplyTag
+	if err := d.db.Where("apply_id IN ?", applyIDs).Order("id ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		result[t.ApplyID] = append(result[t.ApplyID], t.Tag)
 	}
+	return result, nil
+}
+
+// TagCount 标签及使用次数
+type TagCount struct {
+	Tag   string
+	Count int64
+}
+
+// CountTagsByCompany 统计公司申请中使用的标签，按使用次数倒序
+func (d *JobApplyDAO) CountTagsByCompany(companyID uint) ([]TagCount, error) {
+	var counts []TagCount
+	err := d.db.Model(&model.JobApplyTag{}).
+		Select("tag, COUNT(*) AS count").
+		Where("company_id = ?", companyID).
+		Group("tag").
+		Order("count DESC, tag ASC").
+		Scan(&counts).Error
+	return counts, err
+}
 
-	return applies, total, nil
+// ListStatusesByJob 获取职位下申请当前所处的状态(去重)
+func (d *JobApplyDAO) ListStatusesByJob(jobID uint) ([]int, error) {
+	var statuses []int
+	err := d.db.Model(&model.JobApply{}).
+		Where("job_id = ?", jobID).
+		Distinct().
+		Pluck("status", &statuses).Error
+	return statuses, err
 }
 
 // UpdateProgress 更新申请进度
@@ -140,12 +352,107 @@ func (d *JobApplyDAO) UpdateProgress(id uint, progress string) error {
 		}).Error
 }
 
-// UpdateStatus 更新申请状态
-func (d *JobApplyDAO) UpdateStatus(id uint, status int) error {
-	return d.db.Model(&model.JobApply{}).
-		Where("id = ?", id).
-		Updates(map[string]interface{}{
-			"status":      status,
-			"update_time": time.Now(),
-		}).Error
+// TransitionStatus 将申请从 event.FromStatus 流转到 event.ToStatus，并在同一事务中写入流转事件
+// 以当前状态作为条件更新，返回受影响行数，为0表示申请状态已被并发修改，此时不写入事件
+func (d *JobApplyDAO) TransitionStatus(event *model.JobApplyEvent, progress string) (int64, error) {
+	var affected int64
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if affected, err = updateStatus(tx, event, progress); err != nil || affected == 0 {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// TransitionStatusBatch 在同一事务中批量流转申请状态并写入流转事件
+// 返回每条流转是否生效，状态已被并发修改的申请不生效，数据库错误时整体回滚
+func (d *JobApplyDAO) TransitionStatusBatch(transitions []StatusTransition) ([]bool, error) {
+	applied := make([]bool, len(transitions))
+	if len(transitions) == 0 {
+		return applied, nil
+	}
+
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		events := make([]*model.JobApplyEvent, 0, len(transitions))
+		for i, t := range transitions {
+			affected, err := updateStatus(tx, t.Event, t.Progress)
+			if err != nil {
+				return err
+			}
+			if affected > 0 {
+				applied[i] = true
+				events = append(events, t.Event)
+			}
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		return tx.Create(&events).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// updateStatus 以流转前状态为条件更新申请状态、进度与原因，返回受影响行数
+// 未填写原因时保留申请原有的原因，完整记录见流转事件
+func updateStatus(tx *gorm.DB, event *model.JobApplyEvent, progress string) (int64, error) {
+	updates := map[string]interface{}{
+		"status":         event.ToStatus,
+		"apply_progress": progress,
+		"update_time":    time.Now(),
+	}
+	if event.Reason != "" {
+		updates["reason"] = event.Reason
+	}
+	result := tx.Model(&model.JobApply{}).
+		Where("id = ? AND status = ?", event.ApplyID, event.FromStatus).
+		Updates(updates)
+	return result.RowsAffected, result.Error
+}
+
+// ListEvents 获取申请的状态流转事件，按发生时间正序
+func (d *JobApplyDAO) ListEvents(applyID uint) ([]model.JobApplyEvent, error) {
+	var events []model.JobApplyEvent
+	err := d.db.Where("apply_id = ?", applyID).Order("create_time ASC, id ASC").Find(&events).Error
+	return events, err
+}
+
+// BoardCard 看板卡片，申请关联简历姓名、最近一段工作经历的职位及进入当前阶段的时间
+type BoardCard struct {
+	ApplyID        uint
+	UserID         uint
+	ResumeID       uint
+	ApplyTime      time.Time
+	Rating         int
+	CandidateName  string
+	CurrentTitle   string
+	StageEnteredAt time.Time
+}
+
+// ListBoardCards 获取职位某一状态下的看板卡片，从 after 之后开始，按申请时间倒序
+// 进入阶段的时间取最近一次流转到该状态的事件，没有事件时取申请时间
+func (d *JobApplyDAO) ListBoardCards(jobID uint, status int, after *pagination.Cursor, limit int) ([]BoardCard, error) {
+	var cards []BoardCard
+	query := d.db.Table("t_rc_job_apply AS a").
+		Select(`a.id AS apply_id, a.user_id, a.resume_id, a.apply_time, a.rating,
+			COALESCE(r.name, '') AS candidate_name,
+			COALESCE((SELECT w.position FROM t_rc_resume_work_experience w
+				WHERE w.resume_id = a.resume_id AND w.deleted_at IS NULL
+				ORDER BY w.start_time DESC, w.id DESC LIMIT 1), '') AS current_title,
+			COALESCE((SELECT MAX(e.create_time) FROM t_rc_job_apply_event e
+				WHERE e.apply_id = a.id AND e.to_status = a.status), a.apply_time) AS stage_entered_at`).
+		Joins("LEFT JOIN t_rc_resume r ON r.id = a.resume_id").
+		Where("a.job_id = ? AND a.status = ?", jobID, status)
+	if after != nil {
+		query = query.Where("(a.apply_time, a.id) < (?, ?)", after.Time, after.ID)
+	}
+	err := query.Order("a.apply_time DESC, a.id DESC").Limit(limit).Scan(&cards).Error
+	return cards, err
 }
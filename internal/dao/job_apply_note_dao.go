@@ -0,0 +1,47 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// JobApplyNoteDAO 申请备注数据访问对象
+type JobApplyNoteDAO struct {
+	db *gorm.DB
+}
+
+// NewJobApplyNoteDAO 创建申请备注DAO实例
+func NewJobApplyNoteDAO(db *gorm.DB) *JobApplyNoteDAO {
+	return &JobApplyNoteDAO{db: db}
+}
+
+// Create 创建备注
+func (d *JobApplyNoteDAO) Create(note *model.JobApplyNote) error {
+	return d.db.Create(note).Error
+}
+
+// GetByID 获取备注
+func (d *JobApplyNoteDAO) GetByID(id uint) (*model.JobApplyNote, error) {
+	var note model.JobApplyNote
+	if err := d.db.First(&note, id).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// ListByApply 获取申请的所有备注，按创建时间正序
+func (d *JobApplyNoteDAO) ListByApply(applyID uint) ([]model.JobApplyNote, error) {
+	var notes []model.JobApplyNote
+	err := d.db.Where("apply_id = ?", applyID).Order("create_time ASC, id ASC").Find(&notes).Error
+	return notes, err
+}
+
+// Update 更新备注内容及提及的成员
+func (d *JobApplyNoteDAO) Update(note *model.JobApplyNote) error {
+	return d.db.Model(note).Select("content", "mentions").Updates(note).Error
+}
+
+// Delete 删除备注，顶层备注的回复一并删除
+func (d *JobApplyNoteDAO) Delete(id uint) error {
+	return d.db.Where("id = ? OR parent_id = ?", id, id).Delete(&model.JobApplyNote{}).Error
+}
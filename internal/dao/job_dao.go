@@ -1,10 +1,16 @@
 package dao
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
+	"org.thinkinai.com/recruit-center/pkg/utils"
 )
 
 // JobDAO 职位数据访问对象
@@ -17,14 +23,117 @@ func NewJobDAO(db *gorm.DB) *JobDAO {
 	return &JobDAO{db: db}
 }
 
-// Create 创建职位
-func (d *JobDAO) Create(job *model.Job) error {
-	return d.db.Create(job).Error
+// Create 创建职位并保存第一个修订版本，并在同一事务中建立全文检索列
+func (d *JobDAO) Create(job *model.Job, editorID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		return createJob(tx, job, editorID)
+	})
 }
 
-// Update 更新职位
-func (d *JobDAO) Update(job *model.Job) error {
-	return d.db.Save(job).Error
+// CreateBatch 在同一事务中批量创建职位，任一职位失败时全部回滚
+func (d *JobDAO) CreateBatch(jobs []*model.Job, editorID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		for _, job := range jobs {
+			if err := createJob(tx, job, editorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createJob 创建职位及其第一个修订版本，并建立全文检索列
+func createJob(tx *gorm.DB, job *model.Job, editorID uint) error {
+	job.Revision = 1
+	if err := tx.Create(job).Error; err != nil {
+		return err
+	}
+	if err := createRevision(tx, job, &model.JobRevision{Action: model.JobRevisionCreate, EditorID: editorID}); err != nil {
+		return err
+	}
+	return refreshSearchVector(tx, job)
+}
+
+// Update 更新职位并追加修订版本，并在同一事务中重建全文检索列
+// revision 指定操作类型和操作人；创建时间、统计数据等不由编辑修改的字段保留原值
+func (d *JobDAO) Update(job *model.Job, revision *model.JobRevision) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockRevision(tx, job.ID)
+		if err != nil {
+			return err
+		}
+		job.CreateTime = current.CreateTime
+		job.ViewCount = current.ViewCount
+		job.ApplyCount = current.ApplyCount
+		job.Priority = current.Priority
+		job.DeleteStatus = current.DeleteStatus
+		job.Revision = current.Revision + 1
+		if err := tx.Save(job).Error; err != nil {
+			return err
+		}
+		if err := createRevision(tx, job, revision); err != nil {
+			return err
+		}
+		return refreshSearchVector(tx, job)
+	})
+}
+
+// EnsureRevision 返回职位当前的修订版本号，升级前已存在的职位尚无修订版本时先将当前内容保存为第一个版本
+func (d *JobDAO) EnsureRevision(jobID uint) (int, error) {
+	var revision int
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockRevision(tx, jobID)
+		if err != nil {
+			return err
+		}
+		revision = current.Revision
+		return nil
+	})
+	return revision, err
+}
+
+// lockRevision 锁定职位行并返回当前内容，尚无修订版本时将当前内容保存为第一个版本
+func lockRevision(tx *gorm.DB, jobID uint) (*model.Job, error) {
+	var current model.Job
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, jobID).Error; err != nil {
+		return nil, err
+	}
+	if current.Revision > 0 {
+		return &current, nil
+	}
+	current.Revision = 1
+	if err := tx.Model(&current).UpdateColumn("revision", current.Revision).Error; err != nil {
+		return nil, err
+	}
+	return &current, createRevision(tx, &current, &model.JobRevision{Action: model.JobRevisionCreate})
+}
+
+// createRevision 以职位当前内容和版本号写入修订版本
+func createRevision(tx *gorm.DB, job *model.Job, revision *model.JobRevision) error {
+	revision.JobID = job.ID
+	revision.Revision = job.Revision
+	revision.Snapshot = model.NewJobSnapshot(job)
+	return tx.Create(revision).Error
+}
+
+// ListRevisions 获取职位的全部修订版本，按版本号倒序，不含内容快照
+func (d *JobDAO) ListRevisions(jobID uint) ([]model.JobRevision, error) {
+	var revisions []model.JobRevision
+	err := d.db.Omit("snapshot").
+		Where("job_id = ?", jobID).
+		Order("revision DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// GetRevision 获取职位的指定修订版本
+func (d *JobDAO) GetRevision(jobID uint, revision int) (*model.JobRevision, error) {
+	var r model.JobRevision
+	err := d.db.Where("job_id = ? AND revision = ?", jobID, revision).First(&r).Error
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
 }
 
 // Delete 删除职位
@@ -42,47 +151,55 @@ func (d *JobDAO) GetByID(id uint) (*model.Job, error) {
 	return &job, nil
 }
 
-// List 获取职位列表
-func (d *JobDAO) List(page, size int) ([]model.Job, int64, error) {
+// GetByIDs 批量获取职位
+func (d *JobDAO) GetByIDs(ids []uint) ([]model.Job, error) {
 	var jobs []model.Job
-	var total int64
-
-	if err := d.db.Model(&model.Job{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if len(ids) == 0 {
+		return jobs, nil
 	}
-
-	offset := (page - 1) * size
-	err := d.db.Offset(offset).Limit(size).Find(&jobs).Error
-	return jobs, total, err
+	err := d.db.Where("id IN ?", ids).Find(&jobs).Error
+	return jobs, err
 }
 
-// 只保留特定的业务方法，通用的CRUD方法继承自baseDAO
-func (d *JobDAO) SearchByKeyword(keyword string) ([]model.Job, error) {
-	var jobs []model.Job
-	err := d.db.Where("name LIKE ? OR job_skill LIKE ? OR job_describe LIKE ? AND job_expire_time > now() AND delete_status = 0",
-		"%"+keyword+"%", "%"+keyword+"%", "%"+keyword+"%").
-		Find(&jobs).Error
-	return jobs, err
+// hiddenJobStatuses 不对外展示的职位状态，包括待发布和未通过审核的职位
+var hiddenJobStatuses = append([]enums.JobStatus{enums.JobStatusScheduled}, enums.UnapprovedJobStatuses()...)
+
+// List 获取职位列表，不包含待发布和未通过审核的职位
+func (d *JobDAO) List(page pagination.Page) (*pagination.Result[model.Job], error) {
+	query := d.db.Model(&model.Job{}).Where("status NOT IN ?", hiddenJobStatuses)
+	return paginate(query, page, &jobListKey, "", func(job *model.Job) pagination.Cursor {
+		return pagination.Cursor{Time: job.CreateTime, ID: job.ID}
+	})
 }
 
-// GetActiveJobs 获取未过期的职位
-func (d *JobDAO) GetActiveJobs() ([]model.Job, error) {
+// jobListKey 职位列表按发布时间倒序
+var jobListKey = sortKey{scope: "jobs", timeColumn: "create_time", desc: true}
+
+// GetActiveJobs 获取已发布且未过期的职位，companyID 不为 0 时只获取该公司的职位，按发布时间倒序，limit 大于 0 时只获取最新的 limit 个
+func (d *JobDAO) GetActiveJobs(companyID uint, limit int) ([]model.Job, error) {
 	var jobs []model.Job
-	err := d.db.Where("job_expire_time > ? and delete_status=0", time.Now()).Find(&jobs).Error
+	query := d.db.Where("job_expire_time > ? and delete_status=0 and status = ?", time.Now(), enums.JobStatusNormal)
+	if companyID != 0 {
+		query = query.Where("company_id = ?", companyID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Order("COALESCE(publish_time, create_time) DESC, id DESC").Find(&jobs).Error
 	return jobs, err
 }
 
 // 获取指定类型且有效期内的职位
 func (d *JobDAO) GetJobsByType(jobType string) ([]model.Job, error) {
 	var jobs []model.Job
-	err := d.db.Where("job_type = ? AND job_expire_time > now() and delete_status=0", jobType).Find(&jobs).Error
+	err := d.db.Where("job_type = ? AND job_expire_time > now() and delete_status=0 and status NOT IN ?", jobType, hiddenJobStatuses).Find(&jobs).Error
 	return jobs, err
 }
 
 // 根据公司id统计该公司已经发布的岗位总数
 func (d *JobDAO) CountJobsByCompany(companyID uint) (int64, error) {
 	var count int64
-	err := d.db.Model(&model.Job{}).Where("company_id = ? AND job_expire_time > now() and delete_status=0", companyID).Count(&count).Error
+	err := d.db.Model(&model.Job{}).Where("company_id = ? AND job_expire_time > now() and delete_status=0 and status NOT IN ?", companyID, hiddenJobStatuses).Count(&count).Error
 	return count, err
 }
 
@@ -91,8 +208,8 @@ func (d *JobDAO) SearchByCompany(companyID uint, page, pageSize int) ([]model.Jo
 	var jobs []model.Job
 	var total int64
 
-	query := d.db.Model(&model.Job{}).Where("company_id = ? AND delete_status = 0 AND job_expire_time > ?",
-		companyID, time.Now())
+	query := d.db.Model(&model.Job{}).Where("company_id = ? AND delete_status = 0 AND job_expire_time > ? AND status NOT IN ?",
+		companyID, time.Now(), hiddenJobStatuses)
 
 	// 获取总数
 	if err := query.Count(&total).Error; err != nil {
@@ -109,46 +226,247 @@ func (d *JobDAO) SearchByCompany(companyID uint, page, pageSize int) ([]model.Jo
 	return jobs, total, err
 }
 
-// SearchByCondition 多条件搜索职位
-func (d *JobDAO) SearchByCondition(conditions map[string]interface{}, page, pageSize int) ([]model.Job, int64, error) {
+// ListByCompany 获取公司的全部职位，包括未发布和已过期的职位，按创建时间正序
+func (d *JobDAO) ListByCompany(companyID uint) ([]model.Job, error) {
 	var jobs []model.Job
-	var total int64
+	err := d.db.Where("company_id = ? AND delete_status = 0", companyID).Order("create_time, id").Find(&jobs).Error
+	return jobs, err
+}
+
+// 职位全文检索的权重：名称 A、技能要求 B、职位描述 C
+const jobSearchVectorExpr = "setweight(to_tsvector(?::regconfig, ?), 'A') || " +
+	"setweight(to_tsvector(?::regconfig, ?), 'B') || " +
+	"setweight(to_tsvector(?::regconfig, ?), 'C')"
 
-	// 添加基础条件
-	query := d.db.Model(&model.Job{}).Where("delete_status = 0 AND job_expire_time > ?", time.Now())
+// searchBackfillBatch 每批回填检索列的职位数
+const searchBackfillBatch = 200
 
-	// 添加搜索条件
-	for key, value := range conditions {
-		switch key {
-		case "keyword":
-			query = query.Where("name LIKE ? OR job_skill LIKE ? OR job_describe LIKE ?",
-				"%"+value.(string)+"%", "%"+value.(string)+"%", "%"+value.(string)+"%")
-		case "job_type":
-			query = query.Where("job_type = ?", value)
-		case "job_category":
-			query = query.Where("job_category = ?", value)
-		case "job_location":
-			query = query.Where("job_location LIKE ?", "%"+value.(string)+"%")
-		case "salary_min":
-			query = query.Where("CAST(SUBSTRING_INDEX(job_salary, '-', 1) AS SIGNED) >= ?", value)
-		case "salary_max":
-			query = query.Where("CAST(SUBSTRING_INDEX(job_salary, '-', -1) AS SIGNED) <= ?", value)
+// RefreshSearchVector 根据职位名称、技能要求和描述重建职位的全文检索列
+func (d *JobDAO) RefreshSearchVector(job *model.Job) error {
+	return refreshSearchVector(d.db, job)
+}
+
+// refreshSearchVector 在指定连接上重建职位的全文检索列
+func refreshSearchVector(db *gorm.DB, job *model.Job) error {
+	cfg := utils.TextSearchConfig()
+	return db.Model(&model.Job{}).
+		Where("id = ?", job.ID).
+		UpdateColumn("search_vector", gorm.Expr(jobSearchVectorExpr,
+			cfg, utils.SearchText(job.Name),
+			cfg, utils.SearchText(job.JobSkill),
+			cfg, utils.SearchText(job.JobDescribe))).Error
+}
+
+// BackfillSearchVectors 为尚未建立检索列的职位回填，返回回填的职位数
+func (d *JobDAO) BackfillSearchVectors() (int, error) {
+	total := 0
+	for {
+		var jobs []model.Job
+		if err := d.db.Select("id", "name", "job_skill", "job_describe").
+			Where("search_vector IS NULL").
+			Limit(searchBackfillBatch).
+			Find(&jobs).Error; err != nil {
+			return total, err
+		}
+		for i := range jobs {
+			if err := d.RefreshSearchVector(&jobs[i]); err != nil {
+				return total, err
+			}
+		}
+		total += len(jobs)
+		if len(jobs) < searchBackfillBatch {
+			return total, nil
 		}
 	}
+}
 
-	// 获取总数
-	if err := query.Count(&total).Error; err != nil {
+// Search 按条件搜索有效职位，指定关键词时按相关度排序，否则按发布时间倒序
+func (d *JobDAO) Search(cond model.JobSearchCondition, page, size int) ([]model.JobSearchHit, int64, error) {
+	var hits []model.JobSearchHit
+	var total int64
+
+	scope := d.searchScope(cond)
+	if err := d.db.Model(&model.Job{}).Scopes(scope).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// 获取分页数据
-	offset := (page - 1) * pageSize
-	err := query.Order("create_time DESC").
-		Offset(offset).
-		Limit(pageSize).
-		Find(&jobs).Error
+	query := d.db.Model(&model.Job{}).Scopes(scope)
+	keyword := strings.TrimSpace(cond.Keyword)
+	if keyword != "" {
+		query = query.Select("id AS job_id, ts_rank_cd(search_vector, plainto_tsquery(?::regconfig, ?)) AS rank",
+			utils.TextSearchConfig(), utils.SearchText(keyword))
+	} else {
+		query = query.Select("id AS job_id, 0 AS rank")
+	}
+	switch {
+	case cond.Sort == model.JobSearchSortDistance && cond.Near != nil:
+		query = query.Order(clause.OrderBy{Expression: gorm.Expr("? ASC NULLS LAST, create_time DESC, id DESC", jobDistance(*cond.Near))})
+	case cond.Sort == model.JobSearchSortNewest || keyword == "":
+		query = query.Order("create_time DESC, id DESC")
+	default:
+		query = query.Order("rank DESC, create_time DESC, id DESC")
+	}
+	offset := (page - 1) * size
+	err := query.Offset(offset).Limit(size).Scan(&hits).Error
+	return hits, total, err
+}
 
-	return jobs, total, err
+// searchScope 职位搜索条件，只包含未删除、未过期的已发布职位
+// 同一分面内的取值满足其一即可，不同分面之间须同时满足
+func (d *JobDAO) searchScope(cond model.JobSearchCondition) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("delete_status = 0 AND status = ? AND job_expire_time > ?", enums.JobStatusNormal, time.Now())
+		if keyword := strings.TrimSpace(cond.Keyword); keyword != "" {
+			db = db.Where("search_vector @@ plainto_tsquery(?::regconfig, ?)", utils.TextSearchConfig(), utils.SearchText(keyword))
+		}
+		if len(cond.JobTypes) > 0 {
+			db = db.Where("job_type IN ?", cond.JobTypes)
+		}
+		if len(cond.JobCategories) > 0 {
+			db = db.Where("job_category IN ?", cond.JobCategories)
+		}
+		if len(cond.RemoteTypes) > 0 {
+			db = db.Where("remote_type IN ?", cond.RemoteTypes)
+		}
+		if len(cond.Benefits) > 0 {
+			values := make([]string, len(cond.Benefits))
+			for i, benefit := range cond.Benefits {
+				values[i] = strconv.Itoa(int(benefit))
+			}
+			db = db.Where("EXISTS (SELECT 1 FROM "+jobBenefitsExpr+" AS bf(value) WHERE bf.value IN ?)", values)
+		}
+		if len(cond.Educations) > 0 {
+			db = db.Where("job_education IN ?", cond.Educations)
+		}
+		if len(cond.Experiences) > 0 {
+			db = db.Where("job_experience IN ?", cond.Experiences)
+		}
+		if len(cond.SalaryRanges) > 0 {
+			var ranges []clause.Expression
+			for _, key := range cond.SalaryRanges {
+				if r, ok := model.SalaryRangeByKey(key); ok {
+					ranges = append(ranges, salaryRangeCondition(r))
+				}
+			}
+			if len(ranges) > 0 {
+				db = db.Where(clause.Or(ranges...))
+			}
+		}
+		if len(cond.Locations) > 0 {
+			locations := make([]clause.Expression, len(cond.Locations))
+			for i, location := range cond.Locations {
+				locations[i] = gorm.Expr("job_location LIKE ?", "%"+location+"%")
+			}
+			db = db.Where(clause.Or(locations...))
+		}
+		if cond.SalaryMin > 0 {
+			db = db.Where("job_salary_max >= ?", cond.SalaryMin)
+		}
+		if cond.SalaryMax > 0 {
+			db = db.Where("job_salary <= ?", cond.SalaryMax)
+		}
+		if cond.CompanyID > 0 {
+			db = db.Where("company_id = ?", cond.CompanyID)
+		}
+		if cond.RegionCode != "" {
+			// 行政区划代码在各级之间唯一，匹配任一级即为该区划或其下级
+			db = db.Where("(province_code = ? OR city_code = ? OR district_code = ?)", cond.RegionCode, cond.RegionCode, cond.RegionCode)
+		}
+		if cond.Near != nil && cond.RadiusKm > 0 {
+			// 先按外接经纬度范围缩小范围，再计算精确距离
+			minLat, maxLat, minLng, maxLng := utils.BoundingBox(cond.Near.Latitude, cond.Near.Longitude, cond.RadiusKm)
+			db = db.Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng).
+				Where("? <= ?", jobDistance(*cond.Near), cond.RadiusKm)
+		}
+		return db
+	}
+}
+
+// jobDistanceExpr 职位坐标到中心点的球面距离(公里)，职位没有坐标时为 NULL
+const jobDistanceExpr = `2 * ? * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(t_rc_job.latitude - ?) / 2), 2) + ` +
+	`COS(RADIANS(?)) * COS(RADIANS(t_rc_job.latitude)) * POWER(SIN(RADIANS(t_rc_job.longitude - ?) / 2), 2))))`
+
+// jobDistance 职位到中心点的距离表达式
+func jobDistance(p model.GeoPoint) clause.Expr {
+	return gorm.Expr(jobDistanceExpr, utils.EarthRadiusKm, p.Latitude, p.Latitude, p.Longitude)
+}
+
+// jobBenefitsExpr 将福利列表展开为文本行，福利为空时不产生行
+const jobBenefitsExpr = `jsonb_array_elements_text(CASE WHEN jsonb_typeof(t_rc_job.benefits::jsonb) = 'array' ` +
+	`THEN t_rc_job.benefits::jsonb ELSE '[]'::jsonb END)`
+
+// salaryRangeCondition 职位薪资范围与区间有交集的条件
+func salaryRangeCondition(r model.SalaryRange) clause.Expr {
+	if r.Max == 0 {
+		return gorm.Expr("job_salary_max >= ?", r.Min)
+	}
+	return gorm.Expr("job_salary_max >= ? AND job_salary < ?", r.Min, r.Max)
+}
+
+// FacetCount 分面取值及职位数
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// maxFacetValues 每个分面最多返回的取值数，按职位数从多到少
+const maxFacetValues = 20
+
+// jobFacetColumns 按列取值统计的分面
+var jobFacetColumns = map[model.JobFacet]string{
+	model.JobFacetJobType:     "job_type",
+	model.JobFacetJobCategory: "job_category",
+	model.JobFacetRemoteType:  "remote_type",
+	model.JobFacetEducation:   "job_education",
+	model.JobFacetExperience:  "job_experience",
+	model.JobFacetLocation:    "job_location",
+}
+
+// Facets 统计各分面取值的职位数
+// 每个分面在去掉自身筛选、保留其他筛选的条件下统计，选中某个取值后同一分面的其他取值仍有计数
+func (d *JobDAO) Facets(cond model.JobSearchCondition) (map[model.JobFacet][]FacetCount, error) {
+	facets := make(map[model.JobFacet][]FacetCount, len(model.JobFacets))
+	for _, facet := range model.JobFacets {
+		counts, err := d.facetCounts(facet, cond.Without(facet))
+		if err != nil {
+			return nil, err
+		}
+		facets[facet] = counts
+	}
+	return facets, nil
+}
+
+// facetCounts 统计单个分面的取值及职位数
+func (d *JobDAO) facetCounts(facet model.JobFacet, cond model.JobSearchCondition) ([]FacetCount, error) {
+	var counts []FacetCount
+	query := d.db.Model(&model.Job{}).Scopes(d.searchScope(cond))
+	switch facet {
+	case model.JobFacetBenefit:
+		query = query.Joins("CROSS JOIN LATERAL " + jobBenefitsExpr + " AS b(value)").
+			Select("b.value AS value, COUNT(*) AS count").
+			Group("b.value")
+	case model.JobFacetSalary:
+		values := make([]string, len(model.SalaryRanges))
+		var args []interface{}
+		for i, r := range model.SalaryRanges {
+			values[i] = "(?::text, ?::int, ?::int)"
+			args = append(args, r.Key, r.Min, r.Max)
+		}
+		query = query.Joins("JOIN (VALUES "+strings.Join(values, ", ")+") AS r(range_key, range_min, range_max) "+
+			"ON t_rc_job.job_salary_max >= r.range_min AND (r.range_max = 0 OR t_rc_job.job_salary < r.range_max)", args...).
+			Select("r.range_key AS value, COUNT(*) AS count").
+			Group("r.range_key")
+	default:
+		column, ok := jobFacetColumns[facet]
+		if !ok {
+			return nil, nil
+		}
+		query = query.Where("COALESCE(" + column + "::text, '') NOT IN ('', '0')").
+			Select(column + "::text AS value, COUNT(*) AS count").
+			Group(column)
+	}
+	err := query.Order("count DESC, value").Limit(maxFacetValues).Scan(&counts).Error
+	return counts, err
 }
 
 // BatchUpdate 批量更新职位状态
@@ -168,3 +486,58 @@ func (d *JobDAO) GetExpiredJobs() ([]model.Job, error) {
 		time.Now()).Find(&jobs).Error
 	return jobs, err
 }
+
+// PublishDue 发布已到计划发布时间的待发布职位，返回发布的职位数
+func (d *JobDAO) PublishDue(now time.Time) (int64, error) {
+	result := d.db.Model(&model.Job{}).
+		Where("status = ? AND publish_time <= ? AND delete_status = 0", enums.JobStatusScheduled, now).
+		Updates(map[string]interface{}{"status": enums.JobStatusNormal, "update_time": now})
+	return result.RowsAffected, result.Error
+}
+
+// ExpireDue 将已过期仍为正常状态的职位置为已过期，返回处理的职位数
+func (d *JobDAO) ExpireDue(now time.Time) (int64, error) {
+	result := d.db.Model(&model.Job{}).
+		Where("status = ? AND job_expire_time <= ? AND delete_status = 0", enums.JobStatusNormal, now).
+		Updates(map[string]interface{}{"status": enums.JobStatusExpired, "update_time": now})
+	return result.RowsAffected, result.Error
+}
+
+// ListExpiring 获取在 before 之前过期、尚未针对当前过期时间发送提醒的正常职位，按过期时间排序
+func (d *JobDAO) ListExpiring(now, before time.Time, limit int) ([]model.Job, error) {
+	var jobs []model.Job
+	err := d.db.Where("status = ? AND delete_status = 0 AND job_expire_time > ? AND job_expire_time <= ?",
+		enums.JobStatusNormal, now, before).
+		Where("reminded_expire_time IS NULL OR reminded_expire_time <> job_expire_time").
+		Order("job_expire_time ASC, id ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// MarkExpireReminded 记录职位已针对 expireTime 发送到期提醒，职位已续期时不更新，返回是否已记录
+func (d *JobDAO) MarkExpireReminded(id uint, expireTime time.Time) (bool, error) {
+	result := d.db.Model(&model.Job{}).
+		Where("id = ? AND job_expire_time = ?", id, expireTime).
+		UpdateColumn("reminded_expire_time", expireTime)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListUnrecommended 获取 since 之后发布、尚未发送新职位推荐的有效职位，按ID排序
+func (d *JobDAO) ListUnrecommended(since, now time.Time, limit int) ([]model.Job, error) {
+	var jobs []model.Job
+	err := d.db.Where("status = ? AND delete_status = 0 AND job_expire_time > ? AND recommended_time IS NULL", enums.JobStatusNormal, now).
+		Where("COALESCE(publish_time, create_time) >= ?", since).
+		Order("id ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// MarkRecommended 记录职位已发送新职位推荐，已记录时不更新，返回是否由本次记录
+func (d *JobDAO) MarkRecommended(id uint, now time.Time) (bool, error) {
+	result := d.db.Model(&model.Job{}).
+		Where("id = ? AND recommended_time IS NULL", id).
+		UpdateColumn("recommended_time", now)
+	return result.RowsAffected > 0, result.Error
+}
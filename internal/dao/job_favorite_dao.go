@@ -4,6 +4,7 @@ import (
 	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/api/dto/response"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 type JobFavoriteDAO struct {
@@ -30,22 +31,16 @@ func (dao *JobFavoriteDAO) IsFavorited(userID, jobID uint) (bool, error) {
 	return count > 0, err
 }
 
-func (dao *JobFavoriteDAO) ListByUser(userID uint, page, size int) ([]model.JobFavorite, int64, error) {
-	var favorites []model.JobFavorite
-	var total int64
-
-	offset := (page - 1) * size
-	err := dao.db.Model(&model.JobFavorite{}).
-		Where("user_id = ?", userID).
-		Count(&total).
-		Offset(offset).
-		Limit(size).
-		Order("create_time DESC").
-		Find(&favorites).Error
-
-	return favorites, total, err
+func (dao *JobFavoriteDAO) ListByUser(userID uint, page pagination.Page) (*pagination.Result[model.JobFavorite], error) {
+	query := dao.db.Model(&model.JobFavorite{}).Where("user_id = ?", userID)
+	return paginate(query, page, &favoriteListKey, "", func(favorite *model.JobFavorite) pagination.Cursor {
+		return pagination.Cursor{Time: favorite.CreateTime, ID: favorite.ID}
+	})
 }
 
+// favoriteListKey 收藏列表按收藏时间倒序
+var favoriteListKey = sortKey{scope: "favorites", timeColumn: "create_time", desc: true}
+
 // GetUserFavoriteJobs 获取用户收藏的职位详情
 func (dao *JobFavoriteDAO) GetUserFavoriteJobs(userID uint) ([]response.FavoriteJobDetail, error) {
 	var jobs []response.FavoriteJobDetail
@@ -58,3 +53,14 @@ func (dao *JobFavoriteDAO) GetUserFavoriteJobs(userID uint) ([]response.Favorite
 
 	return jobs, err
 }
+
+// ListRecentJobIDs 获取用户最近收藏的职位ID，按收藏时间倒序
+func (dao *JobFavoriteDAO) ListRecentJobIDs(userID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := dao.db.Model(&model.JobFavorite{}).
+		Where("user_id = ?", userID).
+		Order("create_time DESC").
+		Limit(limit).
+		Pluck("job_id", &ids).Error
+	return ids, err
+}
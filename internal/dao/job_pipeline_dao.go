@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// JobPipelineDAO 职位招聘流程数据访问对象
+type JobPipelineDAO struct {
+	db *gorm.DB
+}
+
+// NewJobPipelineDAO 创建职位招聘流程DAO实例
+func NewJobPipelineDAO(db *gorm.DB) *JobPipelineDAO {
+	return &JobPipelineDAO{db: db}
+}
+
+// GetByJobID 获取职位自定义的招聘流程
+func (d *JobPipelineDAO) GetByJobID(jobID uint) (*model.JobPipeline, error) {
+	var pipeline model.JobPipeline
+	if err := d.db.Where("job_id = ?", jobID).First(&pipeline).Error; err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// Save 保存职位的招聘流程，已存在时覆盖
+func (d *JobPipelineDAO) Save(pipeline *model.JobPipeline) error {
+	return d.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"definition", "updated_by", "update_time"}),
+	}).Create(pipeline).Error
+}
+
+// DeleteByJobID 删除职位自定义的招聘流程，恢复使用默认流程
+func (d *JobPipelineDAO) DeleteByJobID(jobID uint) error {
+	return d.db.Where("job_id = ?", jobID).Delete(&model.JobPipeline{}).Error
+}
@@ -0,0 +1,109 @@
+package dao
+
+import (
+	stderrors "errors"
+	"time"
+
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
+)
+
+// JobReviewDAO 职位审核数据访问对象
+type JobReviewDAO struct {
+	db *gorm.DB
+}
+
+// NewJobReviewDAO 创建职位审核DAO实例
+func NewJobReviewDAO(db *gorm.DB) *JobReviewDAO {
+	return &JobReviewDAO{db: db}
+}
+
+// errJobStateChanged 职位状态已被并发修改，用于回滚事务
+var errJobStateChanged = stderrors.New("job state changed")
+
+// Submit 将处于 from 状态之一的职位置为待审核，并在同一事务中创建审核记录，返回是否已提交
+func (d *JobReviewDAO) Submit(review *model.JobReview, from []enums.JobStatus) (bool, error) {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Job{}).
+			Where("id = ? AND status IN ? AND delete_status = 0", review.JobID, from).
+			Update("status", enums.JobStatusPendingReview)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errJobStateChanged
+		}
+		return tx.Create(review).Error
+	})
+	if stderrors.Is(err, errJobStateChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Resolve 记录审核结果，并在同一事务中按 jobUpdates 更新待审核的职位，审核记录已处理或职位不再待审核时返回 false
+func (d *JobReviewDAO) Resolve(review *model.JobReview, jobUpdates map[string]interface{}) (bool, error) {
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.JobReview{}).
+			Where("id = ? AND status = ?", review.ID, model.JobReviewPending).
+			Updates(map[string]interface{}{
+				"status":      review.Status,
+				"reviewer_id": review.ReviewerID,
+				"reason":      review.Reason,
+				"review_time": review.ReviewTime,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errJobStateChanged
+		}
+		jobUpdates["update_time"] = time.Now()
+		result = tx.Model(&model.Job{}).
+			Where("id = ? AND status = ?", review.JobID, enums.JobStatusPendingReview).
+			Updates(jobUpdates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errJobStateChanged
+		}
+		return nil
+	})
+	if stderrors.Is(err, errJobStateChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetByID 获取审核记录
+func (d *JobReviewDAO) GetByID(id uint) (*model.JobReview, error) {
+	var review model.JobReview
+	if err := d.db.First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// ListByJob 获取职位的全部审核记录，按提交时间倒序
+func (d *JobReviewDAO) ListByJob(jobID uint) ([]model.JobReview, error) {
+	var reviews []model.JobReview
+	err := d.db.Where("job_id = ?", jobID).Order("create_time DESC, id DESC").Find(&reviews).Error
+	return reviews, err
+}
+
+// List 按审核结果分页获取审核记录，先提交的在前，status 为空时不限
+func (d *JobReviewDAO) List(status model.JobReviewStatus, page pagination.Page) (*pagination.Result[model.JobReview], error) {
+	query := d.db.Model(&model.JobReview{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return paginate(query, page, &jobReviewListKey, "", func(review *model.JobReview) pagination.Cursor {
+		return pagination.Cursor{Time: review.CreateTime, ID: review.ID}
+	})
+}
+
+// jobReviewListKey 审核队列按提交时间正序
+var jobReviewListKey = sortKey{scope: "job_reviews", timeColumn: "create_time"}
@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// JobScreeningDAO 职位筛选问题数据访问对象
+type JobScreeningDAO struct {
+	db *gorm.DB
+}
+
+// NewJobScreeningDAO 创建职位筛选问题DAO实例
+func NewJobScreeningDAO(db *gorm.DB) *JobScreeningDAO {
+	return &JobScreeningDAO{db: db}
+}
+
+// GetByJobID 获取职位的筛选问题
+func (d *JobScreeningDAO) GetByJobID(jobID uint) (*model.JobScreening, error) {
+	var screening model.JobScreening
+	if err := d.db.Where("job_id = ?", jobID).First(&screening).Error; err != nil {
+		return nil, err
+	}
+	return &screening, nil
+}
+
+// Save 保存职位的筛选问题，已存在时覆盖
+func (d *JobScreeningDAO) Save(screening *model.JobScreening) error {
+	return d.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"definition", "updated_by", "update_time"}),
+	}).Create(screening).Error
+}
+
+// DeleteByJobID 删除职位的筛选问题
+func (d *JobScreeningDAO) DeleteByJobID(jobID uint) error {
+	return d.db.Where("job_id = ?", jobID).Delete(&model.JobScreening{}).Error
+}
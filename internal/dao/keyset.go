@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
+)
+
+// sortKey 键集分页的排序键：先按数值列(可选)、再按时间列，最后按ID，三者同向排序
+// 游标记录上一页最后一条记录在这些列上的值，下一页从该位置之后开始，插入新记录不会造成跳过或重复
+type sortKey struct {
+	scope       string // 游标作用域，区分不同列表及排序方式
+	valueColumn string // 数值排序列，为空表示不按数值排序
+	timeColumn  string // 时间排序列
+	desc        bool   // 是否倒序
+}
+
+// order 排序语句
+func (k sortKey) order() string {
+	dir := "ASC"
+	if k.desc {
+		dir = "DESC"
+	}
+	if k.valueColumn != "" {
+		return fmt.Sprintf("%s %s, %s %s, id %s", k.valueColumn, dir, k.timeColumn, dir, dir)
+	}
+	return fmt.Sprintf("%s %s, id %s", k.timeColumn, dir, dir)
+}
+
+// after 游标之后的记录条件
+func (k sortKey) after(db *gorm.DB, c *pagination.Cursor) *gorm.DB {
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	if k.valueColumn != "" {
+		return db.Where(fmt.Sprintf("(%s, %s, id) %s (?, ?, ?)", k.valueColumn, k.timeColumn, op), c.Value, c.Time, c.ID)
+	}
+	return db.Where(fmt.Sprintf("(%s, id) %s (?, ?)", k.timeColumn, op), c.Time, c.ID)
+}
+
+// paginate 分页查询：需要时统计总数，按游标或页码取一页记录，多取一条判断是否还有下一页
+// key 为空表示该排序方式不支持游标分页，此时按 fallbackOrder 排序且不返回游标；cursorOf 由记录生成其分页位置
+// listScopes 只作用于记录查询，不影响统计总数，如预加载关联
+func paginate[T any](query *gorm.DB, page pagination.Page, key *sortKey, fallbackOrder string, cursorOf func(*T) pagination.Cursor, listScopes ...func(*gorm.DB) *gorm.DB) (*pagination.Result[T], error) {
+	query = query.Session(&gorm.Session{})
+	result := &pagination.Result[T]{}
+
+	list := query.Scopes(listScopes...).Limit(page.Size + 1)
+	switch {
+	case key == nil && page.Cursor != "":
+		return nil, pagination.ErrCursorUnsupported
+	case key == nil:
+		list = list.Order(fallbackOrder).Offset(page.Offset())
+	case page.Cursor != "":
+		after, err := pagination.Decode(page.Cursor, key.scope)
+		if err != nil {
+			return nil, err
+		}
+		list = key.after(list.Order(key.order()), after)
+	default:
+		list = list.Order(key.order()).Offset(page.Offset())
+	}
+
+	var records []T
+	if err := list.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) > page.Size {
+		records = records[:page.Size]
+		if key != nil {
+			next := cursorOf(&records[len(records)-1])
+			next.Scope = key.scope
+			result.NextCursor = pagination.Encode(next)
+		}
+	}
+	result.Records = records
+
+	if page.WithTotal {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+	return result, nil
+}
@@ -3,6 +3,7 @@ package dao
 import (
 	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 type NotificationDAO struct {
@@ -25,28 +26,36 @@ func (dao *NotificationDAO) Create(notification *model.Notification) error {
 	return dao.db.Create(notification).Error
 }
 
+// CreateBatch 批量创建通知
+func (dao *NotificationDAO) CreateBatch(notifications []model.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return dao.db.CreateInBatches(notifications, 100).Error
+}
+
 func (dao *NotificationDAO) UpdateReadStatus(id uint, isRead bool) error {
 	return dao.db.Model(&model.Notification{}).Where("id = ?", id).Update("is_read", isRead).Error
 }
 
-func (dao *NotificationDAO) ListByUser(userID uint, page, size int) ([]model.Notification, int64, error) {
-	var notifications []model.Notification
-	var total int64
+// UpdateUserReadStatus 更新指定用户通知的已读状态，返回受影响的行数
+func (dao *NotificationDAO) UpdateUserReadStatus(id, userID uint, isRead bool) (int64, error) {
+	result := dao.db.Model(&model.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("is_read", isRead)
+	return result.RowsAffected, result.Error
+}
 
-	offset := (page - 1) * size
+func (dao *NotificationDAO) ListByUser(userID uint, page pagination.Page) (*pagination.Result[model.Notification], error) {
 	db := dao.db.Model(&model.Notification{}).Where("user_id = ?", userID)
-
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	if err := db.Offset(offset).Limit(size).Order("create_time desc").Find(&notifications).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return notifications, total, nil
+	return paginate(db, page, &notificationListKey, "", func(n *model.Notification) pagination.Cursor {
+		return pagination.Cursor{Time: n.CreateTime, ID: n.ID}
+	})
 }
 
+// notificationListKey 通知列表按创建时间倒序
+var notificationListKey = sortKey{scope: "notifications", timeColumn: "create_time", desc: true}
+
 func (dao *NotificationDAO) CountUnread(userID uint) (int64, error) {
 	var count int64
 	err := dao.db.Model(&model.Notification{}).Where("user_id = ? AND is_read = ?", userID, false).Count(&count).Error
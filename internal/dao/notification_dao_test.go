@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/internal/testutil"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 func TestNotificationDAO_Create(t *testing.T) {
@@ -94,13 +95,15 @@ func TestNotificationDAO_ListByUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, total, err := dao.ListByUser(tt.userID, tt.page, tt.size)
+			got, err := dao.ListByUser(tt.userID, pagination.NewPage(tt.page, tt.size, "", nil))
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.wantCount, len(got))
-				assert.Equal(t, int64(tt.wantCount), total)
+				assert.Equal(t, tt.wantCount, len(got.Records))
+				if assert.NotNil(t, got.Total) {
+					assert.Equal(t, int64(tt.wantCount), *got.Total)
+				}
 			}
 		})
 	}
@@ -0,0 +1,188 @@
+package dao
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// errOfferChanged 审批期间Offer状态已变更，用于回滚审批事务
+var errOfferChanged = errors.New("offer status changed")
+
+// OfferDAO Offer数据访问对象
+type OfferDAO struct {
+	db *gorm.DB
+}
+
+// NewOfferDAO 创建Offer DAO实例
+func NewOfferDAO(db *gorm.DB) *OfferDAO {
+	return &OfferDAO{db: db}
+}
+
+// preloadApprovals 按步骤顺序预加载审批链
+func preloadApprovals(db *gorm.DB) *gorm.DB {
+	return db.Order("step ASC")
+}
+
+// Create 创建Offer及其审批链
+func (d *OfferDAO) Create(offer *model.Offer) error {
+	return d.db.Create(offer).Error
+}
+
+// GetByID 获取Offer及其审批链
+func (d *OfferDAO) GetByID(id uint) (*model.Offer, error) {
+	var offer model.Offer
+	if err := d.db.Preload("Approvals", preloadApprovals).First(&offer, id).Error; err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// ListByApply 获取申请的所有Offer，最新的在前
+func (d *OfferDAO) ListByApply(applyID uint) ([]model.Offer, error) {
+	var offers []model.Offer
+	err := d.db.Preload("Approvals", preloadApprovals).
+		Where("apply_id = ?", applyID).
+		Order("create_time DESC").
+		Find(&offers).Error
+	return offers, err
+}
+
+// ListAwaitingApprover 获取审批中且指定用户有待审批节点的Offer
+// 是否已轮到该用户审批由调用方根据审批链判断
+func (d *OfferDAO) ListAwaitingApprover(approverID uint) ([]model.Offer, error) {
+	var offers []model.Offer
+	err := d.db.Preload("Approvals", preloadApprovals).
+		Where("status = ?", model.OfferPendingApproval).
+		Where("id IN (?)", d.db.Model(&model.OfferApproval{}).
+			Select("offer_id").
+			Where("approver_id = ? AND status = ?", approverID, model.OfferApprovalPending)).
+		Order("create_time ASC").
+		Find(&offers).Error
+	return offers, err
+}
+
+// HasActive 申请是否已有进行中的Offer
+func (d *OfferDAO) HasActive(applyID uint) (bool, error) {
+	var count int64
+	err := d.db.Model(&model.Offer{}).
+		Where("apply_id = ? AND status IN ?", applyID, model.ActiveOfferStatuses).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateDraft 更新草稿状态的Offer并替换审批链，返回受影响行数，为0表示Offer已不是草稿
+func (d *OfferDAO) UpdateDraft(offer *model.Offer) (int64, error) {
+	var affected int64
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Offer{}).
+			Where("id = ? AND status = ?", offer.ID, model.OfferDraft).
+			Select("template_id", "position", "department", "currency", "base_salary", "salary_months",
+				"signing_bonus", "benefits", "location", "start_date", "expire_at", "remark").
+			Updates(offer)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return replaceApprovals(tx, offer.ID, offer.Approvals)
+	})
+	return affected, err
+}
+
+// Submit 提交草稿审批，审批链重置为待审批
+// 审批链为空时直接进入审批通过状态，返回受影响行数，为0表示Offer已不是草稿
+func (d *OfferDAO) Submit(id uint, status model.OfferStatus) (int64, error) {
+	var affected int64
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Offer{}).
+			Where("id = ? AND status = ?", id, model.OfferDraft).
+			Update("status", status)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return tx.Model(&model.OfferApproval{}).
+			Where("offer_id = ?", id).
+			Updates(map[string]interface{}{
+				"status":     model.OfferApprovalPending,
+				"comment":    "",
+				"decided_at": nil,
+			}).Error
+	})
+	return affected, err
+}
+
+// Decide 记录审批结果，并在需要时同步更新Offer状态
+// offerStatus 为0表示Offer保持审批中，返回受影响行数，为0表示该节点已被处理或Offer已不在审批中
+func (d *OfferDAO) Decide(approval *model.OfferApproval, offerStatus model.OfferStatus) (int64, error) {
+	var affected int64
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.OfferApproval{}).
+			Where("id = ? AND status = ?", approval.ID, model.OfferApprovalPending).
+			Updates(map[string]interface{}{
+				"status":     approval.Status,
+				"comment":    approval.Comment,
+				"decided_at": approval.DecidedAt,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if offerStatus != 0 {
+			result = tx.Model(&model.Offer{}).
+				Where("id = ? AND status = ?", approval.OfferID, model.OfferPendingApproval).
+				Update("status", offerStatus)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				// Offer已被撤回，回滚审批结果
+				return errOfferChanged
+			}
+		}
+		affected = 1
+		return nil
+	})
+	if err == errOfferChanged {
+		return 0, nil
+	}
+	return affected, err
+}
+
+// UpdateStatus 以当前状态为条件更新Offer状态，返回受影响行数，为0表示状态已被并发修改
+func (d *OfferDAO) UpdateStatus(id uint, from, to model.OfferStatus, updates map[string]interface{}) (int64, error) {
+	if updates == nil {
+		updates = map[string]interface{}{}
+	}
+	updates["status"] = to
+	result := d.db.Model(&model.Offer{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	return result.RowsAffected, result.Error
+}
+
+// ListOverdue 获取已超过答复截止时间但仍待候选人确认的Offer
+func (d *OfferDAO) ListOverdue(now time.Time, limit int) ([]model.Offer, error) {
+	var offers []model.Offer
+	err := d.db.Where("status = ? AND expire_at < ?", model.OfferSent, now).
+		Order("expire_at ASC").
+		Limit(limit).
+		Find(&offers).Error
+	return offers, err
+}
+
+// replaceApprovals 替换Offer的审批链
+func replaceApprovals(tx *gorm.DB, offerID uint, approvals []model.OfferApproval) error {
+	if err := tx.Where("offer_id = ?", offerID).Delete(&model.OfferApproval{}).Error; err != nil {
+		return err
+	}
+	if len(approvals) == 0 {
+		return nil
+	}
+	for i := range approvals {
+		approvals[i].ID = 0
+		approvals[i].OfferID = offerID
+	}
+	return tx.Create(&approvals).Error
+}
@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// OfferTemplateDAO Offer函模板数据访问对象
+type OfferTemplateDAO struct {
+	db *gorm.DB
+}
+
+// NewOfferTemplateDAO 创建Offer函模板DAO实例
+func NewOfferTemplateDAO(db *gorm.DB) *OfferTemplateDAO {
+	return &OfferTemplateDAO{db: db}
+}
+
+// Create 创建模板
+func (d *OfferTemplateDAO) Create(template *model.OfferTemplate) error {
+	return d.db.Create(template).Error
+}
+
+// GetByID 获取模板
+func (d *OfferTemplateDAO) GetByID(id uint) (*model.OfferTemplate, error) {
+	var template model.OfferTemplate
+	if err := d.db.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ListByCompany 获取公司的所有模板
+func (d *OfferTemplateDAO) ListByCompany(companyID uint) ([]model.OfferTemplate, error) {
+	var templates []model.OfferTemplate
+	err := d.db.Where("company_id = ?", companyID).Order("create_time ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Update 更新模板名称、标题和内容
+func (d *OfferTemplateDAO) Update(template *model.OfferTemplate) error {
+	return d.db.Model(&model.OfferTemplate{}).
+		Where("id = ? AND company_id = ?", template.ID, template.CompanyID).
+		Select("name", "title", "content").
+		Updates(template).Error
+}
+
+// Delete 删除模板
+func (d *OfferTemplateDAO) Delete(companyID, id uint) error {
+	return d.db.Where("id = ? AND company_id = ?", id, companyID).Delete(&model.OfferTemplate{}).Error
+}
@@ -5,6 +5,7 @@ import (
 
 	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 // ResumeDAO 简历数据访问对象
@@ -39,6 +40,9 @@ func (d *ResumeDAO) UpdateBasic(resume *model.Resume) error {
 	}
 	if resume.Location == "" {
 		resume.Location = oldResume.Location
+		if resume.GeoLocation == (model.GeoLocation{}) {
+			resume.GeoLocation = oldResume.GeoLocation
+		}
 	}
 
 	resume.UpdatedAt = time.Now()
@@ -77,6 +81,17 @@ func (d *ResumeDAO) GetByUser(userID uint) (*model.Resume, error) {
 	return &resume, nil
 }
 
+// ListSeeking 获取ID大于 afterID 的正在求职的简历，按ID排序，只包含推荐职位所需的求职意向字段
+func (d *ResumeDAO) ListSeeking(afterID uint, limit int) ([]model.Resume, error) {
+	var resumes []model.Resume
+	err := d.db.Select("id", "user_id", "experience", "expected_job", "expected_city", "skills").
+		Where("id > ? AND job_status = ? AND status = 1 AND deleted_at IS NULL", afterID, model.ResumeJobStatusSeeking).
+		Order("id ASC").
+		Limit(limit).
+		Find(&resumes).Error
+	return resumes, err
+}
+
 // 通过分享token获取简历
 func (d *ResumeDAO) GetByShareToken(token string) (*model.Resume, error) {
 	var resume model.Resume
@@ -175,23 +190,17 @@ func (d *ResumeDAO) UpdateAttachmentURL(attachmentID uint, fileURL string, fileN
 }
 
 // List 获取简历列表
-func (d *ResumeDAO) List(page, size int) ([]model.Resume, int64, error) {
-	var resumes []model.Resume
-	var total int64
-
-	if err := d.db.Model(&model.Resume{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * size
-	err := d.db.Preload("Educations").
-		Preload("WorkExperiences").
-		Preload("Projects").
-		Preload("Attachments").
-		Offset(offset).
-		Limit(size).
-		Find(&resumes).Error
+func (d *ResumeDAO) List(page pagination.Page) (*pagination.Result[model.Resume], error) {
 	// AfterFind 钩子会自动处理解密
-
-	return resumes, total, err
+	return paginate(d.db.Model(&model.Resume{}), page, &resumeListKey, "", func(resume *model.Resume) pagination.Cursor {
+		return pagination.Cursor{Time: resume.CreatedAt, ID: resume.ID}
+	}, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Educations").
+			Preload("WorkExperiences").
+			Preload("Projects").
+			Preload("Attachments")
+	})
 }
+
+// resumeListKey 简历列表按创建时间倒序
+var resumeListKey = sortKey{scope: "resumes", timeColumn: "created_at", desc: true}
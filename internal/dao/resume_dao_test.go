@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/internal/testutil"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 func TestResumeDAO_CreateAndGetByID(t *testing.T) {
@@ -192,8 +193,18 @@ func TestResumeDAO_List(t *testing.T) {
 		_ = dao.Create(resume)
 	}
 
-	resumes, total, err := dao.List(1, 2)
+	first, err := dao.List(pagination.NewPage(1, 2, "", nil))
 	assert.NoError(t, err)
-	assert.Equal(t, int64(5), total)
-	assert.Len(t, resumes, 2)
+	if assert.NotNil(t, first.Total) {
+		assert.Equal(t, int64(5), *first.Total)
+	}
+	assert.Len(t, first.Records, 2)
+	assert.NotEmpty(t, first.NextCursor)
+
+	// 按游标继续翻页，不重复统计总数
+	second, err := dao.List(pagination.NewPage(1, 2, first.NextCursor, nil))
+	assert.NoError(t, err)
+	assert.Nil(t, second.Total)
+	assert.Len(t, second.Records, 2)
+	assert.NotEqual(t, first.Records[1].ID, second.Records[0].ID)
 }
@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// ScorecardDAO 面试评分卡模板数据访问对象
+type ScorecardDAO struct {
+	db *gorm.DB
+}
+
+// NewScorecardDAO 创建面试评分卡模板DAO实例
+func NewScorecardDAO(db *gorm.DB) *ScorecardDAO {
+	return &ScorecardDAO{db: db}
+}
+
+// GetByJobID 获取职位的评分卡模板
+func (d *ScorecardDAO) GetByJobID(jobID uint) (*model.ScorecardTemplate, error) {
+	var template model.ScorecardTemplate
+	if err := d.db.Where("job_id = ?", jobID).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Save 保存职位的评分卡模板，已存在时覆盖
+func (d *ScorecardDAO) Save(template *model.ScorecardTemplate) error {
+	return d.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"definition", "require_feedback", "updated_by", "update_time"}),
+	}).Create(template).Error
+}
+
+// DeleteByJobID 删除职位的评分卡模板
+func (d *ScorecardDAO) DeleteByJobID(jobID uint) error {
+	return d.db.Where("job_id = ?", jobID).Delete(&model.ScorecardTemplate{}).Error
+}
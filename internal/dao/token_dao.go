@@ -0,0 +1,155 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// TokenSessionDAO 登录会话数据访问对象
+type TokenSessionDAO struct {
+	db *gorm.DB
+}
+
+// RevokedTokenDAO 令牌黑名单数据访问对象
+type RevokedTokenDAO struct {
+	db *gorm.DB
+}
+
+// NewTokenSessionDAO 创建登录会话DAO实例
+func NewTokenSessionDAO(db *gorm.DB) *TokenSessionDAO {
+	return &TokenSessionDAO{db: db}
+}
+
+// NewRevokedTokenDAO 创建令牌黑名单DAO实例
+func NewRevokedTokenDAO(db *gorm.DB) *RevokedTokenDAO {
+	return &RevokedTokenDAO{db: db}
+}
+
+// Create 创建登录会话
+func (d *TokenSessionDAO) Create(session *model.TokenSession) error {
+	return d.db.Create(session).Error
+}
+
+// GetByRefreshJTI 根据刷新令牌ID获取会话
+func (d *TokenSessionDAO) GetByRefreshJTI(jti string) (*model.TokenSession, error) {
+	var session model.TokenSession
+	if err := d.db.Where("refresh_jti = ?", jti).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByAccessJTI 根据访问令牌ID获取会话
+func (d *TokenSessionDAO) GetByAccessJTI(jti string) (*model.TokenSession, error) {
+	var session model.TokenSession
+	if err := d.db.Where("access_jti = ?", jti).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate 刷新轮换：在同一事务中创建新会话，吊销旧会话并将旧令牌加入黑名单
+func (d *TokenSessionDAO) Rotate(old, next *model.TokenSession) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		// 仅吊销仍然有效的旧会话，防止同一刷新令牌被并发重复使用
+		result := tx.Model(&model.TokenSession{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Updates(map[string]interface{}{
+				"revoked_at":  time.Now(),
+				"replaced_by": next.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return denySession(tx, old, model.RevokeReasonRotated)
+	})
+}
+
+// Revoke 吊销单个会话
+func (d *TokenSessionDAO) Revoke(session *model.TokenSession, reason string) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.TokenSession{}).
+			Where("id = ? AND revoked_at IS NULL", session.ID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return denySession(tx, session, reason)
+	})
+}
+
+// RevokeAllByUser 吊销用户所有未过期的会话，返回吊销的会话数量
+func (d *TokenSessionDAO) RevokeAllByUser(userID uint, reason string) (int64, error) {
+	var revoked int64
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		var sessions []model.TokenSession
+		if err := tx.Where("user_id = ? AND revoked_at IS NULL AND refresh_expires_at > ?", userID, time.Now()).
+			Find(&sessions).Error; err != nil {
+			return err
+		}
+		for i := range sessions {
+			if err := denySession(tx, &sessions[i], reason); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&model.TokenSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now())
+		revoked = result.RowsAffected
+		return result.Error
+	})
+	return revoked, err
+}
+
+// denySession 将会话中尚未过期的访问令牌与刷新令牌加入黑名单
+func denySession(tx *gorm.DB, session *model.TokenSession, reason string) error {
+	now := time.Now()
+	tokens := make([]model.RevokedToken, 0, 2)
+	if session.AccessExpiresAt.After(now) {
+		tokens = append(tokens, model.RevokedToken{
+			JTI:       session.AccessJTI,
+			UserID:    session.UserID,
+			ExpiresAt: session.AccessExpiresAt,
+			Reason:    reason,
+		})
+	}
+	if session.RefreshExpiresAt.After(now) {
+		tokens = append(tokens, model.RevokedToken{
+			JTI:       session.RefreshJTI,
+			UserID:    session.UserID,
+			ExpiresAt: session.RefreshExpiresAt,
+			Reason:    reason,
+		})
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+}
+
+// Create 将令牌加入黑名单
+func (d *RevokedTokenDAO) Create(token *model.RevokedToken) error {
+	return d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// IsRevoked 检查令牌是否已被吊销，实现 utils.TokenDenylist
+func (d *RevokedTokenDAO) IsRevoked(jti string) (bool, error) {
+	var count int64
+	err := d.db.Model(&model.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// PurgeExpired 清理原令牌已过期的黑名单记录
+func (d *RevokedTokenDAO) PurgeExpired(before time.Time) (int64, error) {
+	result := d.db.Where("expires_at <= ?", before).Delete(&model.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,149 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// UserDAO 用户数据访问对象
+type UserDAO struct {
+	db *gorm.DB
+}
+
+// UserTokenDAO 用户一次性令牌数据访问对象
+type UserTokenDAO struct {
+	db *gorm.DB
+}
+
+// NewUserDAO 创建用户DAO实例
+func NewUserDAO(db *gorm.DB) *UserDAO {
+	return &UserDAO{db: db}
+}
+
+// NewUserTokenDAO 创建用户一次性令牌DAO实例
+func NewUserTokenDAO(db *gorm.DB) *UserTokenDAO {
+	return &UserTokenDAO{db: db}
+}
+
+// Create 创建用户
+func (d *UserDAO) Create(user *model.User) error {
+	return d.db.Create(user).Error
+}
+
+// GetByID 根据ID获取用户
+func (d *UserDAO) GetByID(id uint) (*model.User, error) {
+	var user model.User
+	if err := d.db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByIDs 批量获取用户
+func (d *UserDAO) GetByIDs(ids []uint) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := d.db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+// GetByUsername 根据用户名获取用户
+func (d *UserDAO) GetByUsername(username string) (*model.User, error) {
+	var user model.User
+	if err := d.db.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByEmail 根据邮箱获取用户
+func (d *UserDAO) GetByEmail(email string) (*model.User, error) {
+	var user model.User
+	if err := d.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ExistsByUsernameOrEmail 检查用户名或邮箱是否已被占用
+func (d *UserDAO) ExistsByUsernameOrEmail(username, email string) (bool, error) {
+	var count int64
+	err := d.db.Model(&model.User{}).
+		Where("username = ? OR email = ?", username, email).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateLastLogin 更新最后登录时间
+func (d *UserDAO) UpdateLastLogin(id uint, at time.Time) error {
+	return d.db.Model(&model.User{}).Where("id = ?", id).Update("last_login_at", at).Error
+}
+
+// MarkEmailVerified 在同一事务中标记邮箱已验证并使令牌失效
+func (d *UserDAO) MarkEmailVerified(token *model.UserToken) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := useToken(tx, token, now); err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", token.UserID).
+			Updates(map[string]interface{}{
+				"email_verified":    true,
+				"email_verified_at": now,
+			}).Error
+	})
+}
+
+// ResetPassword 在同一事务中更新密码哈希并使令牌失效
+func (d *UserDAO) ResetPassword(token *model.UserToken, passwordHash string) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := useToken(tx, token, now); err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", token.UserID).
+			Updates(map[string]interface{}{
+				"password_hash":       passwordHash,
+				"password_changed_at": now,
+			}).Error
+	})
+}
+
+// useToken 标记令牌已使用，令牌已被使用时返回 gorm.ErrRecordNotFound
+func useToken(tx *gorm.DB, token *model.UserToken, now time.Time) error {
+	result := tx.Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Replace 使用户同一用途的旧令牌失效并创建新令牌
+func (d *UserTokenDAO) Replace(token *model.UserToken) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// GetByHash 根据令牌摘要和用途获取令牌
+func (d *UserTokenDAO) GetByHash(purpose model.UserTokenPurpose, tokenHash string) (*model.UserToken, error) {
+	var token model.UserToken
+	if err := d.db.Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
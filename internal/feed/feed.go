@@ -0,0 +1,81 @@
+// Package feed 将有效职位渲染为职位聚合网站和搜索引擎使用的订阅格式：
+// Indeed 风格的 XML 职位源、RSS 2.0、Atom 以及 schema.org JobPosting 结构化数据
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// Channel 订阅源信息
+type Channel struct {
+	Title       string    // 标题，同时作为发布方名称
+	Description string    // 描述
+	Link        string    // 求职站点地址
+	Self        string    // 订阅源自身的地址
+	Updated     time.Time // 最后更新时间，为职位的最晚更新时间
+}
+
+// Item 订阅源中的一个职位
+type Item struct {
+	Job      *model.Job
+	Company  *model.Company // 职位所属公司，可能为空
+	URL      string         // 职位详情页地址
+	Province string         // 省级行政区划名称，未解析时为空
+	City     string         // 市级行政区划名称，未解析时为空
+	District string         // 区县级行政区划名称，未解析时为空
+}
+
+// companyName 公司名称，公司不存在时为空
+func (it Item) companyName() string {
+	if it.Company == nil {
+		return ""
+	}
+	return it.Company.Name
+}
+
+// posted 职位的发布时间，升级前发布的职位没有发布时间时使用创建时间
+func (it Item) posted() time.Time {
+	if it.Job.PublishTime != nil {
+		return *it.Job.PublishTime
+	}
+	return it.Job.CreateTime
+}
+
+// locality 职位所在城市，未解析行政区划时使用工作地点原文
+func (it Item) locality() string {
+	if it.City != "" {
+		return it.City
+	}
+	return it.Job.JobLocation
+}
+
+// salaryText 薪资范围的文字描述，单位为元/月
+func salaryText(job *model.Job) string {
+	switch {
+	case job.JobSalary <= 0:
+		return ""
+	case job.JobSalaryMax > job.JobSalary:
+		return fmt.Sprintf("%d-%d元/月", job.JobSalary, job.JobSalaryMax)
+	default:
+		return fmt.Sprintf("%d元/月", job.JobSalary)
+	}
+}
+
+// summary 职位摘要：公司、地点、薪资和职位描述
+func summary(it Item) string {
+	var parts []string
+	for _, s := range []string{it.companyName(), it.Job.JobLocation, salaryText(it.Job)} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	head := strings.Join(parts, " | ")
+	if head == "" {
+		return it.Job.JobDescribe
+	}
+	return head + "\n" + it.Job.JobDescribe
+}
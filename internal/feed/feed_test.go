@@ -0,0 +1,128 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+func testItems() (Channel, []Item) {
+	posted := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	lat, lng := 31.23, 121.47
+	job := &model.Job{
+		ID: 42, Name: "高级Go工程师", JobDescribe: "负责订单系统\n要求 <3年> & 熟悉 Go",
+		JobSalary: 15000, JobSalaryMax: 25000, JobLocation: "上海市浦东新区", JobType: int(model.FullTime),
+		JobCategory: "技术", Tags: []string{"急招"}, RemoteType: model.FullRemote,
+		JobExpireTime: posted.AddDate(0, 1, 0), PublishTime: &posted, CreateTime: posted.AddDate(0, 0, -1), UpdateTime: posted,
+		GeoLocation: model.GeoLocation{Latitude: &lat, Longitude: &lng},
+	}
+	ch := Channel{Title: "招聘", Description: "最新职位", Link: "https://jobs.example.com", Self: "https://api.example.com/feeds/jobs.atom", Updated: posted}
+	return ch, []Item{{
+		Job: job, Company: &model.Company{Name: "示例科技", Website: "https://example.com"},
+		URL: "https://jobs.example.com/jobs/42", Province: "上海市", City: "上海市",
+	}}
+}
+
+func TestRenderIndeed(t *testing.T) {
+	ch, items := testItems()
+	body, err := RenderIndeed(ch, items)
+	require.NoError(t, err)
+
+	var doc struct {
+		Publisher string `xml:"publisher"`
+		Jobs      []struct {
+			Title       string  `xml:"title"`
+			Reference   string  `xml:"referencenumber"`
+			Description string  `xml:"description"`
+			Salary      string  `xml:"salary"`
+			JobType     string  `xml:"jobtype"`
+			RemoteType  string  `xml:"remotetype"`
+			Education   *string `xml:"education"`
+		} `xml:"job"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+	assert.Equal(t, "招聘", doc.Publisher)
+	require.Len(t, doc.Jobs, 1)
+	job := doc.Jobs[0]
+	assert.Equal(t, "高级Go工程师", job.Title)
+	assert.Equal(t, "42", job.Reference)
+	assert.Equal(t, items[0].Job.JobDescribe, job.Description)
+	assert.Equal(t, "15000-25000 CNY per month", job.Salary)
+	assert.Equal(t, "fulltime", job.JobType)
+	assert.Equal(t, "Fully remote", job.RemoteType)
+	assert.Nil(t, job.Education)
+	assert.Contains(t, string(body), "<![CDATA[高级Go工程师]]>")
+}
+
+func TestRenderRSSAndAtom(t *testing.T) {
+	ch, items := testItems()
+	body, err := RenderRSS(ch, items)
+	require.NoError(t, err)
+	rss := string(body)
+	assert.True(t, strings.HasPrefix(rss, xml.Header))
+	assert.Contains(t, rss, `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, rss, `<atom:link href="https://api.example.com/feeds/jobs.atom" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, rss, `<guid isPermaLink="true">https://jobs.example.com/jobs/42</guid>`)
+	assert.Contains(t, rss, "<pubDate>Thu, 01 Oct 2026 09:00:00 +0000</pubDate>")
+	assert.Contains(t, rss, "<category>技术</category>")
+
+	body, err = RenderAtom(ch, items)
+	require.NoError(t, err)
+	var feed struct {
+		ID      string `xml:"id"`
+		Updated string `xml:"updated"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Published string `xml:"published"`
+			Author    string `xml:"author>name"`
+			Summary   string `xml:"summary"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &feed))
+	assert.Equal(t, ch.Self, feed.ID)
+	assert.Equal(t, "2026-10-01T09:00:00Z", feed.Updated)
+	require.Len(t, feed.Entries, 1)
+	assert.Equal(t, "https://jobs.example.com/jobs/42", feed.Entries[0].ID)
+	assert.Equal(t, "示例科技", feed.Entries[0].Author)
+	assert.True(t, strings.HasPrefix(feed.Entries[0].Summary, "示例科技 | 上海市浦东新区 | 15000-25000元/月\n"))
+}
+
+func TestNewJobPosting(t *testing.T) {
+	_, items := testItems()
+	posting := NewJobPosting(items[0])
+	body, err := json.Marshal(posting)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &doc))
+	assert.Equal(t, "https://schema.org", doc["@context"])
+	assert.Equal(t, "JobPosting", doc["@type"])
+	assert.Equal(t, "FULL_TIME", doc["employmentType"])
+	assert.Equal(t, "TELECOMMUTE", doc["jobLocationType"])
+	assert.Equal(t, "2026-10-01T09:00:00Z", doc["datePosted"])
+	assert.Equal(t, "2026-11-01T09:00:00Z", doc["validThrough"])
+	assert.Equal(t, "负责订单系统<br>要求 &lt;3年&gt; &amp; 熟悉 Go", doc["description"])
+
+	salary := doc["baseSalary"].(map[string]interface{})
+	assert.Equal(t, "CNY", salary["currency"])
+	assert.Equal(t, map[string]interface{}{"@type": "QuantitativeValue", "minValue": 15000.0, "maxValue": 25000.0, "unitText": "MONTH"}, salary["value"])
+
+	address := doc["jobLocation"].(map[string]interface{})["address"].(map[string]interface{})
+	assert.Equal(t, "上海市", address["addressLocality"])
+	assert.Equal(t, "CN", address["addressCountry"])
+	assert.Equal(t, "示例科技", doc["hiringOrganization"].(map[string]interface{})["name"])
+
+	// 办公室办公的职位没有远程标记，单一薪资输出 value
+	items[0].Job.RemoteType = model.OnSite
+	items[0].Job.JobSalaryMax = items[0].Job.JobSalary
+	posting = NewJobPosting(items[0])
+	assert.Empty(t, posting.JobLocationType)
+	assert.Nil(t, posting.ApplicantLocationRequirements)
+	assert.Equal(t, 15000, posting.BaseSalary.Value.Value)
+}
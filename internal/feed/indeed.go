@@ -0,0 +1,124 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// indeedSource Indeed 风格 XML 职位源的根元素
+type indeedSource struct {
+	XMLName       xml.Name    `xml:"source"`
+	Publisher     string      `xml:"publisher"`
+	PublisherURL  string      `xml:"publisherurl"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Jobs          []indeedJob `xml:"job"`
+}
+
+// indeedJob Indeed 职位源中的职位，文本字段使用 CDATA
+type indeedJob struct {
+	Title           cdata  `xml:"title"`
+	Date            cdata  `xml:"date"`
+	ReferenceNumber cdata  `xml:"referencenumber"`
+	URL             cdata  `xml:"url"`
+	Company         cdata  `xml:"company"`
+	City            cdata  `xml:"city"`
+	State           cdata  `xml:"state"`
+	Country         cdata  `xml:"country"`
+	Description     cdata  `xml:"description"`
+	Salary          *cdata `xml:"salary,omitempty"`
+	JobType         *cdata `xml:"jobtype,omitempty"`
+	Category        *cdata `xml:"category,omitempty"`
+	Experience      *cdata `xml:"experience,omitempty"`
+	Education       *cdata `xml:"education,omitempty"`
+	RemoteType      *cdata `xml:"remotetype,omitempty"`
+	ExpirationDate  cdata  `xml:"expirationdate"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+// optional 空字符串不输出元素
+func optional(s string) *cdata {
+	if s == "" {
+		return nil
+	}
+	return &cdata{Value: s}
+}
+
+// indeedJobTypes 职位类型对应的 Indeed 职位类型
+var indeedJobTypes = map[model.JobType]string{
+	model.FullTime:   "fulltime",
+	model.PartTime:   "parttime",
+	model.Internship: "internship",
+}
+
+// indeedRemoteType 远程办公类型对应的 Indeed 远程类型，办公室办公不输出
+func indeedRemoteType(t model.RemoteType) string {
+	switch t {
+	case model.FullRemote:
+		return "Fully remote"
+	case model.Hybrid, model.Flexible:
+		return "Hybrid remote"
+	}
+	return ""
+}
+
+// indeedSalary 薪资范围，单位为人民币每月
+func indeedSalary(job *model.Job) string {
+	switch {
+	case job.JobSalary <= 0:
+		return ""
+	case job.JobSalaryMax > job.JobSalary:
+		return fmt.Sprintf("%d-%d CNY per month", job.JobSalary, job.JobSalaryMax)
+	default:
+		return fmt.Sprintf("%d CNY per month", job.JobSalary)
+	}
+}
+
+// RenderIndeed 渲染 Indeed 风格的 XML 职位源
+func RenderIndeed(ch Channel, items []Item) ([]byte, error) {
+	source := indeedSource{
+		Publisher:    ch.Title,
+		PublisherURL: ch.Link,
+		Jobs:         make([]indeedJob, len(items)),
+	}
+	if !ch.Updated.IsZero() {
+		source.LastBuildDate = ch.Updated.UTC().Format(time.RFC1123)
+	}
+	for i, it := range items {
+		job := it.Job
+		source.Jobs[i] = indeedJob{
+			Title:           cdata{job.Name},
+			Date:            cdata{it.posted().UTC().Format(time.RFC1123)},
+			ReferenceNumber: cdata{strconv.FormatUint(uint64(job.ID), 10)},
+			URL:             cdata{it.URL},
+			Company:         cdata{it.companyName()},
+			City:            cdata{it.locality()},
+			State:           cdata{it.Province},
+			Country:         cdata{"CN"},
+			Description:     cdata{job.JobDescribe},
+			Salary:          optional(indeedSalary(job)),
+			JobType:         optional(indeedJobTypes[model.JobType(job.JobType)]),
+			Category:        optional(job.JobCategory),
+			Experience:      optional(job.JobExperience),
+			Education:       optional(job.JobEducation),
+			RemoteType:      optional(indeedRemoteType(job.RemoteType)),
+			ExpirationDate:  cdata{job.JobExpireTime.UTC().Format(time.RFC1123)},
+		}
+	}
+	return marshalXML(source)
+}
+
+// marshalXML 输出带 XML 声明的缩进文档
+func marshalXML(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
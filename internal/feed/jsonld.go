@@ -0,0 +1,159 @@
+package feed
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// JobPosting schema.org JobPosting 结构化数据，嵌入职位详情页供搜索引擎识别
+type JobPosting struct {
+	Context                       string          `json:"@context"`
+	Type                          string          `json:"@type"`
+	Title                         string          `json:"title"`
+	Description                   string          `json:"description"`
+	Identifier                    *PropertyValue  `json:"identifier,omitempty"`
+	URL                           string          `json:"url,omitempty"`
+	DatePosted                    string          `json:"datePosted"`
+	ValidThrough                  string          `json:"validThrough"`
+	EmploymentType                string          `json:"employmentType,omitempty"`
+	HiringOrganization            *Organization   `json:"hiringOrganization,omitempty"`
+	JobLocation                   *Place          `json:"jobLocation,omitempty"`
+	JobLocationType               string          `json:"jobLocationType,omitempty"`
+	ApplicantLocationRequirements *Country        `json:"applicantLocationRequirements,omitempty"`
+	BaseSalary                    *MonetaryAmount `json:"baseSalary,omitempty"`
+	OccupationalCategory          string          `json:"occupationalCategory,omitempty"`
+	Skills                        string          `json:"skills,omitempty"`
+	ExperienceRequirements        string          `json:"experienceRequirements,omitempty"`
+	EducationRequirements         string          `json:"educationRequirements,omitempty"`
+	JobBenefits                   string          `json:"jobBenefits,omitempty"`
+	DirectApply                   bool            `json:"directApply"`
+}
+
+// PropertyValue schema.org PropertyValue
+type PropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Organization schema.org Organization
+type Organization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs,omitempty"`
+	Logo   string `json:"logo,omitempty"`
+}
+
+// Place schema.org Place
+type Place struct {
+	Type    string          `json:"@type"`
+	Address PostalAddress   `json:"address"`
+	Geo     *GeoCoordinates `json:"geo,omitempty"`
+}
+
+// PostalAddress schema.org PostalAddress
+type PostalAddress struct {
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+// GeoCoordinates schema.org GeoCoordinates
+type GeoCoordinates struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Country schema.org Country
+type Country struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// MonetaryAmount schema.org MonetaryAmount
+type MonetaryAmount struct {
+	Type     string            `json:"@type"`
+	Currency string            `json:"currency"`
+	Value    QuantitativeValue `json:"value"`
+}
+
+// QuantitativeValue schema.org QuantitativeValue
+type QuantitativeValue struct {
+	Type     string `json:"@type"`
+	Value    int    `json:"value,omitempty"`
+	MinValue int    `json:"minValue,omitempty"`
+	MaxValue int    `json:"maxValue,omitempty"`
+	UnitText string `json:"unitText"`
+}
+
+// employmentTypes 职位类型对应的 schema.org 雇佣类型
+var employmentTypes = map[model.JobType]string{
+	model.FullTime:   "FULL_TIME",
+	model.PartTime:   "PART_TIME",
+	model.Internship: "INTERN",
+}
+
+// NewJobPosting 将职位转换为 schema.org JobPosting
+// 薪资按人民币月薪输出；全远程职位标记为 TELECOMMUTE 并要求申请人位于中国，混合办公仍按工作地点输出
+func NewJobPosting(it Item) JobPosting {
+	job := it.Job
+	posting := JobPosting{
+		Context:                "https://schema.org",
+		Type:                   "JobPosting",
+		Title:                  job.Name,
+		Description:            htmlText(job.JobDescribe),
+		URL:                    it.URL,
+		DatePosted:             it.posted().Format(time.RFC3339),
+		ValidThrough:           job.JobExpireTime.Format(time.RFC3339),
+		EmploymentType:         employmentTypes[model.JobType(job.JobType)],
+		OccupationalCategory:   job.JobCategory,
+		Skills:                 job.JobSkill,
+		ExperienceRequirements: job.JobExperience,
+		EducationRequirements:  job.JobEducation,
+		JobBenefits:            strings.TrimSpace(strings.Join([]string{job.JobBenefit, job.BenefitDesc}, " ")),
+		DirectApply:            true,
+	}
+	if it.Company != nil {
+		posting.HiringOrganization = &Organization{Type: "Organization", Name: it.Company.Name, SameAs: it.Company.Website, Logo: it.Company.Logo}
+		posting.Identifier = &PropertyValue{Type: "PropertyValue", Name: it.Company.Name, Value: strconv.FormatUint(uint64(job.ID), 10)}
+	}
+	if job.JobLocation != "" || it.City != "" {
+		place := &Place{Type: "Place", Address: PostalAddress{
+			Type:            "PostalAddress",
+			StreetAddress:   job.JobLocation,
+			AddressLocality: it.locality(),
+			AddressRegion:   it.Province,
+			AddressCountry:  "CN",
+		}}
+		if job.HasCoordinates() {
+			place.Geo = &GeoCoordinates{Type: "GeoCoordinates", Latitude: *job.Latitude, Longitude: *job.Longitude}
+		}
+		posting.JobLocation = place
+	}
+	if job.RemoteType == model.FullRemote {
+		posting.JobLocationType = "TELECOMMUTE"
+		posting.ApplicantLocationRequirements = &Country{Type: "Country", Name: "CN"}
+	}
+	if job.JobSalary > 0 {
+		value := QuantitativeValue{Type: "QuantitativeValue", UnitText: "MONTH"}
+		if job.JobSalaryMax > job.JobSalary {
+			value.MinValue, value.MaxValue = job.JobSalary, job.JobSalaryMax
+		} else {
+			value.Value = job.JobSalary
+		}
+		posting.BaseSalary = &MonetaryAmount{Type: "MonetaryAmount", Currency: "CNY", Value: value}
+	}
+	return posting
+}
+
+// htmlText 将纯文本转换为 HTML，搜索引擎要求职位描述为 HTML
+func htmlText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
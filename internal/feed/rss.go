@@ -0,0 +1,165 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// rssDocument RSS 2.0 文档
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Author      string   `xml:"author,omitempty"`
+	Categories  []string `xml:"category"`
+	Description cdata    `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RenderRSS 渲染 RSS 2.0 订阅源
+func RenderRSS(ch Channel, items []Item) ([]byte, error) {
+	channel := rssChannel{
+		Title:       ch.Title,
+		Link:        ch.Link,
+		Description: ch.Description,
+		Language:    "zh-cn",
+		AtomLink:    atomLink{Href: ch.Self, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]rssItem, len(items)),
+	}
+	if !ch.Updated.IsZero() {
+		channel.LastBuildDate = ch.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for i, it := range items {
+		channel.Items[i] = rssItem{
+			Title:       it.Job.Name,
+			Link:        it.URL,
+			GUID:        rssGUID{IsPermaLink: true, Value: it.URL},
+			PubDate:     it.posted().UTC().Format(time.RFC1123Z),
+			Categories:  categories(it),
+			Description: cdata{summary(it)},
+		}
+	}
+	return marshalXML(rssDocument{Version: "2.0", AtomNS: atomNS, Channel: channel})
+}
+
+// categories 职位分类和标签
+func categories(it Item) []string {
+	var list []string
+	if it.Job.JobCategory != "" {
+		list = append(list, it.Job.JobCategory)
+	}
+	return append(list, it.Job.Tags...)
+}
+
+// atomNS Atom 命名空间
+const atomNS = "http://www.w3.org/2005/Atom"
+
+// atomFeed Atom 文档
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	NS       string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Summary    atomText       `xml:"summary"`
+}
+
+// RenderAtom 渲染 Atom 订阅源，订阅源以自身地址作为 ID，职位以详情页地址作为 ID
+func RenderAtom(ch Channel, items []Item) ([]byte, error) {
+	feed := atomFeed{
+		NS:       atomNS,
+		Lang:     "zh-CN",
+		ID:       ch.Self,
+		Title:    ch.Title,
+		Subtitle: ch.Description,
+		Updated:  atomTime(ch.Updated),
+		Links: []atomLink{
+			{Href: ch.Self, Rel: "self", Type: "application/atom+xml"},
+			{Href: ch.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Author:  atomPerson{Name: ch.Title},
+		Entries: make([]atomEntry, len(items)),
+	}
+	for i, it := range items {
+		entry := atomEntry{
+			ID:        it.URL,
+			Title:     it.Job.Name,
+			Link:      atomLink{Href: it.URL, Rel: "alternate", Type: "text/html"},
+			Published: atomTime(it.posted()),
+			Updated:   atomTime(it.Job.UpdateTime),
+			Summary:   atomText{Type: "text", Value: summary(it)},
+		}
+		if name := it.companyName(); name != "" {
+			entry.Author = &atomPerson{Name: name}
+		}
+		for _, term := range categories(it) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: term})
+		}
+		feed.Entries[i] = entry
+	}
+	return marshalXML(feed)
+}
+
+// atomTime Atom 要求的 RFC 3339 时间，零值输出 Unix 纪元以保证文档有效
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
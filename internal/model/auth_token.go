@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// TokenSession 登录会话，记录一次签发的访问令牌与刷新令牌
+type TokenSession struct {
+	ID               uint       `gorm:"primarykey" json:"id"`
+	UserID           uint       `gorm:"not null;index:idx_user_revoked,priority:1" json:"userId"`
+	AccessJTI        string     `gorm:"size:64;not null;uniqueIndex" json:"-"`              // 访问令牌ID
+	RefreshJTI       string     `gorm:"size:64;not null;uniqueIndex" json:"-"`              // 刷新令牌ID
+	AccessExpiresAt  time.Time  `gorm:"not null" json:"accessExpiresAt"`                    // 访问令牌过期时间
+	RefreshExpiresAt time.Time  `gorm:"not null" json:"refreshExpiresAt"`                   // 刷新令牌过期时间
+	RevokedAt        *time.Time `gorm:"index:idx_user_revoked,priority:2" json:"revokedAt"` // 吊销时间，为空表示有效
+	ReplacedBy       uint       `gorm:"default:0" json:"replacedBy"`                        // 刷新轮换后的新会话ID
+	ClientIP         string     `gorm:"size:64" json:"clientIp"`                            // 签发时的客户端IP
+	UserAgent        string     `gorm:"size:255" json:"userAgent"`                          // 签发时的客户端标识
+	CreateTime       time.Time  `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime       time.Time  `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (TokenSession) TableName() string {
+	return "t_rc_token_session"
+}
+
+// IsRevoked 会话是否已吊销
+func (s *TokenSession) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// RevokedToken 已吊销令牌黑名单
+type RevokedToken struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	JTI        string    `gorm:"size:64;not null;uniqueIndex" json:"jti"` // 令牌ID
+	UserID     uint      `gorm:"not null;index" json:"userId"`
+	ExpiresAt  time.Time `gorm:"not null;index" json:"expiresAt"` // 令牌原过期时间，之后记录可清理
+	Reason     string    `gorm:"size:50" json:"reason"`           // 吊销原因
+	CreateTime time.Time `gorm:"autoCreateTime" json:"createTime"`
+}
+
+// TableName 指定表名
+func (RevokedToken) TableName() string {
+	return "t_rc_revoked_token"
+}
+
+// 令牌吊销原因
+const (
+	RevokeReasonLogout    = "logout"     // 退出登录
+	RevokeReasonLogoutAll = "logout_all" // 退出所有设备
+	RevokeReasonRotated   = "rotated"    // 刷新轮换
+)
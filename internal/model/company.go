@@ -0,0 +1,113 @@
+package model
+
+import "time"
+
+// CompanyStatus 公司状态
+type CompanyStatus int
+
+const (
+	CompanyStatusActive   CompanyStatus = 1 // 正常
+	CompanyStatusInactive CompanyStatus = 2 // 停用
+)
+
+// CompanyVerifyStatus 公司认证状态
+type CompanyVerifyStatus int
+
+const (
+	CompanyVerifyPending  CompanyVerifyStatus = 0 // 待认证
+	CompanyVerified       CompanyVerifyStatus = 1 // 已认证
+	CompanyVerifyRejected CompanyVerifyStatus = 2 // 认证驳回
+)
+
+// IsValid 认证状态是否有效
+func (s CompanyVerifyStatus) IsValid() bool {
+	return s == CompanyVerifyPending || s == CompanyVerified || s == CompanyVerifyRejected
+}
+
+// Company 公司信息
+type Company struct {
+	ID           uint                `gorm:"primarykey" json:"id"`
+	Name         string              `gorm:"size:100;not null;index" json:"name"` // 公司名称
+	ShortName    string              `gorm:"size:50" json:"shortName"`            // 公司简称
+	Logo         string              `gorm:"size:255" json:"logo"`                // 公司Logo
+	Industry     string              `gorm:"size:50" json:"industry"`             // 所属行业
+	Scale        string              `gorm:"size:50" json:"scale"`                // 公司规模
+	Website      string              `gorm:"size:255" json:"website"`             // 公司官网
+	Address      string              `gorm:"size:255" json:"address"`             // 公司地址
+	Description  string              `gorm:"type:text" json:"description"`        // 公司介绍
+	ContactEmail string              `gorm:"size:100" json:"contactEmail"`        // 联系邮箱
+	ContactPhone string              `gorm:"size:20" json:"contactPhone"`         // 联系电话
+	CreditCode   string              `gorm:"size:18;index" json:"creditCode"`     // 统一社会信用代码
+	VerifyStatus CompanyVerifyStatus `gorm:"default:0;index" json:"verifyStatus"` // 认证状态 0: 待认证 1: 已认证 2: 认证驳回
+	VerifyRemark string              `gorm:"size:255" json:"verifyRemark"`        // 认证备注
+	VerifiedAt   *time.Time          `json:"verifiedAt"`                          // 认证时间
+	Status       CompanyStatus       `gorm:"default:1" json:"status"`             // 状态 1: 正常 2: 停用
+	CreatedBy    uint                `gorm:"not null" json:"createdBy"`           // 创建人
+	CreateTime   time.Time           `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime   time.Time           `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (Company) TableName() string {
+	return "t_rc_company"
+}
+
+// IsActive 公司是否处于正常状态
+func (c *Company) IsActive() bool {
+	return c.Status == CompanyStatusActive
+}
+
+// IsVerified 公司是否已通过认证
+func (c *Company) IsVerified() bool {
+	return c.VerifyStatus == CompanyVerified
+}
+
+// CompanyRole 公司成员角色
+type CompanyRole string
+
+const (
+	CompanyRoleOwner     CompanyRole = "owner"     // 所有者，可管理成员与公司信息
+	CompanyRoleRecruiter CompanyRole = "recruiter" // 招聘者，可管理职位与申请
+	CompanyRoleViewer    CompanyRole = "viewer"    // 观察者，只读
+)
+
+// IsValid 角色是否有效
+func (r CompanyRole) IsValid() bool {
+	return r == CompanyRoleOwner || r == CompanyRoleRecruiter || r == CompanyRoleViewer
+}
+
+// 公司成员权限分组
+var (
+	// CompanyManagers 可管理公司信息与成员的角色
+	CompanyManagers = []CompanyRole{CompanyRoleOwner}
+	// CompanyHirers 可管理职位与申请的角色
+	CompanyHirers = []CompanyRole{CompanyRoleOwner, CompanyRoleRecruiter}
+	// CompanyReaders 可查看公司招聘数据的角色
+	CompanyReaders = []CompanyRole{CompanyRoleOwner, CompanyRoleRecruiter, CompanyRoleViewer}
+)
+
+// CompanyMember 公司成员，关联用户与公司
+type CompanyMember struct {
+	ID         uint        `gorm:"primarykey" json:"id"`
+	CompanyID  uint        `gorm:"not null;uniqueIndex:idx_company_user,priority:1" json:"companyId"`
+	UserID     uint        `gorm:"not null;uniqueIndex:idx_company_user,priority:2;index" json:"userId"`
+	Role       CompanyRole `gorm:"size:20;not null" json:"role"` // 成员角色 owner/recruiter/viewer
+	InvitedBy  uint        `gorm:"default:0" json:"invitedBy"`   // 邀请人
+	CreateTime time.Time   `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime time.Time   `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (CompanyMember) TableName() string {
+	return "t_rc_company_member"
+}
+
+// HasRole 成员角色是否在允许列表中
+func (m *CompanyMember) HasRole(roles ...CompanyRole) bool {
+	for _, role := range roles {
+		if m.Role == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package model
+
+import "time"
+
+// InterviewStatus 面试状态
+type InterviewStatus int
+
+const (
+	InterviewScheduled InterviewStatus = 1 // 已安排
+	InterviewCompleted InterviewStatus = 2 // 已完成
+	InterviewCancelled InterviewStatus = 3 // 已取消
+)
+
+// String 面试状态名称
+func (s InterviewStatus) String() string {
+	switch s {
+	case InterviewScheduled:
+		return "已安排"
+	case InterviewCompleted:
+		return "已完成"
+	case InterviewCancelled:
+		return "已取消"
+	default:
+		return "未知状态"
+	}
+}
+
+// InterviewMode 面试方式
+type InterviewMode string
+
+const (
+	InterviewOnsite InterviewMode = "onsite" // 现场面试
+	InterviewVideo  InterviewMode = "video"  // 视频面试
+	InterviewPhone  InterviewMode = "phone"  // 电话面试
+)
+
+// IsValid 面试方式是否有效
+func (m InterviewMode) IsValid() bool {
+	return m == InterviewOnsite || m == InterviewVideo || m == InterviewPhone
+}
+
+// Interview 面试安排，关联一条职位申请，同一申请可以安排多轮面试
+type Interview struct {
+	ID           uint                   `gorm:"primarykey" json:"id"`
+	ApplyID      uint                   `gorm:"not null;index" json:"applyId"`
+	JobID        uint                   `gorm:"not null;index" json:"jobId"`
+	CompanyID    uint                   `gorm:"not null;index" json:"companyId"`
+	CandidateID  uint                   `gorm:"not null;index" json:"candidateId"` // 候选人用户ID
+	Round        int                    `gorm:"not null;default:1" json:"round"`   // 面试轮次
+	Title        string                 `gorm:"size:100" json:"title"`             // 面试标题，如"技术面"
+	Mode         InterviewMode          `gorm:"size:20;not null" json:"mode"`      // 面试方式 onsite/video/phone
+	StartTime    time.Time              `gorm:"not null;index:idx_interview_time,priority:1" json:"startTime"`
+	EndTime      time.Time              `gorm:"not null;index:idx_interview_time,priority:2" json:"endTime"`
+	Location     string                 `gorm:"size:255" json:"location"`                   // 面试地点
+	MeetingURL   string                 `gorm:"size:500" json:"meetingUrl"`                 // 视频会议链接
+	Status       InterviewStatus        `gorm:"default:1;index" json:"status"`              // 状态 1: 已安排 2: 已完成 3: 已取消
+	UID          string                 `gorm:"size:100;not null;uniqueIndex" json:"uid"`   // 日历事件UID，改期和取消时保持不变
+	Sequence     int                    `gorm:"not null;default:0" json:"sequence"`         // 日历修订序号，每次改期或取消递增
+	Remark       string                 `gorm:"size:500" json:"remark"`                     // 面试说明
+	CancelReason string                 `gorm:"size:255" json:"cancelReason"`               // 取消原因
+	CancelledAt  *time.Time             `json:"cancelledAt"`                                // 取消时间
+	CreatedBy    uint                   `gorm:"not null" json:"createdBy"`                  // 安排人
+	Interviewers []InterviewInterviewer `gorm:"foreignKey:InterviewID" json:"interviewers"` // 面试官
+	CreateTime   time.Time              `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime   time.Time              `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (Interview) TableName() string {
+	return "t_rc_interview"
+}
+
+// IsScheduled 面试是否处于已安排状态，只有已安排的面试可以改期、取消或完成
+func (i *Interview) IsScheduled() bool {
+	return i.Status == InterviewScheduled
+}
+
+// InterviewerIDs 面试官用户ID列表
+func (i *Interview) InterviewerIDs() []uint {
+	ids := make([]uint, len(i.Interviewers))
+	for k, v := range i.Interviewers {
+		ids[k] = v.UserID
+	}
+	return ids
+}
+
+// HasInterviewer 用户是否为该面试的面试官
+func (i *Interview) HasInterviewer(userID uint) bool {
+	for _, v := range i.Interviewers {
+		if v.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// InterviewInterviewer 面试官，用于面试官日程冲突检测
+type InterviewInterviewer struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	InterviewID uint      `gorm:"not null;uniqueIndex:idx_interview_user,priority:1" json:"interviewId"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_interview_user,priority:2;index" json:"userId"`
+	CreateTime  time.Time `gorm:"autoCreateTime" json:"createTime"`
+}
+
+// TableName 指定表名
+func (InterviewInterviewer) TableName() string {
+	return "t_rc_interview_interviewer"
+}
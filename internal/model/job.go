@@ -52,7 +52,7 @@ type Job struct {
 	JobDescribe   string    `gorm:"type:text" json:"jobDescribe"`
 	JobLocation   string    `gorm:"size:200" json:"jobLocation"`
 	JobExpireTime time.Time `gorm:"index:idx_expire_status_del,priority:1" json:"jobExpireTime"`
-	Status        int       `gorm:"default:1;index:idx_name_status_del,priority:2;index:idx_company_status_del,priority:2;index:idx_expire_status_del,priority:2" json:"status"` // 职位状态 1: 待发布 2: 已发布 3: 暂停发布
+	Status        int       `gorm:"default:1;index:idx_name_status_del,priority:2;index:idx_company_status_del,priority:2;index:idx_expire_status_del,priority:2" json:"status"` // 职位状态 0: 已过期 1: 正常 2: 待发布 3: 草稿 4: 待审核 5: 审核驳回
 	JobType       int       `gorm:"size:50" json:"jobType"`
 	JobCategory   string    `gorm:"size:50;index:idx_category_status_del,priority:1" json:"jobCategory"`
 	JobExperience string    `gorm:"size:50" json:"jobExperience"`
@@ -65,10 +65,10 @@ type Job struct {
 	UpdateTime    time.Time `gorm:"autoUpdateTime" json:"updateTime"`
 
 	// 添加新的字段和方法
-	ViewCount  int      `gorm:"default:0" json:"viewCount"`  // 浏览次数
-	ApplyCount int      `gorm:"default:0" json:"applyCount"` // 申请次数
-	Priority   int      `gorm:"default:0" json:"priority"`   // 优先级
-	Tags       []string `gorm:"type:json" json:"tags"`       // 职位标签
+	ViewCount  int      `gorm:"default:0" json:"viewCount"`            // 浏览次数
+	ApplyCount int      `gorm:"default:0" json:"applyCount"`           // 申请次数
+	Priority   int      `gorm:"default:0" json:"priority"`             // 优先级
+	Tags       []string `gorm:"type:json;serializer:json" json:"tags"` // 职位标签
 
 	// 远程办公相关字段
 	RemoteType  RemoteType `gorm:"default:1" json:"remoteType"`  // 远程办公类型
@@ -76,8 +76,19 @@ type Job struct {
 	RemoteRatio int        `gorm:"default:0" json:"remoteRatio"` // 远程办公比例(0-100)
 
 	// 福利相关字段
-	Benefits    []JobBenefitType `gorm:"type:json" json:"benefits"`   // 福利列表
-	BenefitDesc string           `gorm:"size:500" json:"benefitDesc"` // 福利补充说明
+	Benefits    []JobBenefitType `gorm:"type:json;serializer:json" json:"benefits"` // 福利列表
+	BenefitDesc string           `gorm:"size:500" json:"benefitDesc"`               // 福利补充说明
+
+	// 结构化工作地点，由行政区划字典解析
+	GeoLocation `gorm:"embedded" json:"geoLocation"`
+
+	// 发布周期，由定时任务按时发布、过期并发送到期提醒
+	PublishTime        *time.Time `gorm:"index" json:"publishTime"` // 发布时间，待发布的职位为计划发布时间
+	RemindedExpireTime *time.Time `json:"-"`                        // 已发送到期提醒时的过期时间，续期后可再次提醒
+	RecommendedTime    *time.Time `json:"-"`                        // 向匹配的求职者发送新职位推荐的时间，每个职位只推荐一次
+
+	// 当前修订版本号，每次修改职位内容时递增，升级前已存在且未修改过的职位为0
+	Revision int `gorm:"not null;default:0" json:"revision"`
 
 	Applications []JobApply `gorm:"foreignKey:JobID" json:"-"`
 }
@@ -99,6 +110,11 @@ func (j *Job) IsExpired() bool {
 	return j.JobExpireTime.Before(time.Now())
 }
 
+// IsScheduled 是否为待发布的定时职位
+func (j *Job) IsScheduled() bool {
+	return j.Status == int(enums.JobStatusScheduled)
+}
+
 // IsActive 检查职位是否有效
 func (j *Job) IsActive() bool {
 	return j.Status == int(enums.JobStatusNormal) && !j.IsExpired()
@@ -116,7 +132,17 @@ func (j *Job) IsRemoteEnabled() bool {
 
 // GetRemoteTypeText 获取远程办公类型文本
 func (j *Job) GetRemoteTypeText() string {
-	switch j.RemoteType {
+	return j.RemoteType.Text()
+}
+
+// GetBenefitText 获取福利类型文本
+func (j *Job) GetBenefitText(benefit JobBenefitType) string {
+	return benefit.Text()
+}
+
+// Text 远程办公类型文本
+func (t RemoteType) Text() string {
+	switch t {
 	case OnSite:
 		return "办公室办公"
 	case Hybrid:
@@ -130,19 +156,21 @@ func (j *Job) GetRemoteTypeText() string {
 	}
 }
 
-// GetBenefitText 获取福利类型文本
-func (j *Job) GetBenefitText(benefit JobBenefitType) string {
-	benefitTexts := map[JobBenefitType]string{
-		Insurance:    "五险一金",
-		Bonus:        "年终奖",
-		Leave:        "带薪休假",
-		Training:     "培训发展",
-		Meals:        "餐补",
-		Transport:    "交通补助",
-		Stock:        "股票期权",
-		FlexibleTime: "弹性工作",
-		Healthcare:   "医疗保险",
-		Gym:          "健身设施",
-	}
-	return benefitTexts[benefit]
+// benefitTexts 福利类型文本
+var benefitTexts = map[JobBenefitType]string{
+	Insurance:    "五险一金",
+	Bonus:        "年终奖",
+	Leave:        "带薪休假",
+	Training:     "培训发展",
+	Meals:        "餐补",
+	Transport:    "交通补助",
+	Stock:        "股票期权",
+	FlexibleTime: "弹性工作",
+	Healthcare:   "医疗保险",
+	Gym:          "健身设施",
+}
+
+// Text 福利类型文本
+func (b JobBenefitType) Text() string {
+	return benefitTexts[b]
 }
@@ -4,17 +4,21 @@ import "time"
 
 // JobApply 职位申请记录
 type JobApply struct {
-	ID            uint      `gorm:"primarykey" json:"id"`
-	JobID         uint      `gorm:"not null;index:idx_job_company_status,priority:1" json:"jobId"`
-	CompanyID     uint      `gorm:"not null;index:idx_job_company_status,priority:2" json:"companyId"` // 企业ID
-	UserID        uint      `gorm:"not null;index:idx_user_resume,priority:1" json:"userId"`
-	ResumeID      uint      `gorm:"not null;index:idx_user_resume,priority:2" json:"resumeId"`
-	ApplyTime     time.Time `gorm:"not null;index" json:"applyTime"`
-	ApplyProgress string    `gorm:"size:50" json:"applyProgress"`
-	Reason        string    `gorm:"size:255" json:"reason"`                                          //拒绝原因
-	Status        int       `gorm:"default:1;index:idx_job_company_status,priority:3" json:"status"` // 状态 1: 正常 0: 删除
-	CreateTime    time.Time `gorm:"autoCreateTime" json:"createTime"`
-	UpdateTime    time.Time `gorm:"autoUpdateTime" json:"updateTime"`
+	ID               uint              `gorm:"primarykey" json:"id"`
+	JobID            uint              `gorm:"not null;index:idx_job_company_status,priority:1" json:"jobId"`
+	CompanyID        uint              `gorm:"not null;index:idx_job_company_status,priority:2" json:"companyId"` // 企业ID
+	UserID           uint              `gorm:"not null;index:idx_user_resume,priority:1" json:"userId"`
+	ResumeID         uint              `gorm:"not null;index:idx_user_resume,priority:2" json:"resumeId"`
+	ApplyTime        time.Time         `gorm:"not null;index" json:"applyTime"`
+	ApplyProgress    string            `gorm:"size:50" json:"applyProgress"`
+	Reason           string            `gorm:"size:255" json:"reason"`                                          //拒绝原因
+	Status           int               `gorm:"default:1;index:idx_job_company_status,priority:3" json:"status"` // 状态 1: 正常 0: 删除
+	Rating           int               `gorm:"not null;default:0;index" json:"rating"`                          // 招聘方评分 1-5，0 表示未评分
+	RatedBy          uint              `json:"ratedBy"`                                                         // 最后评分人
+	ScreeningAnswers []ScreeningAnswer `gorm:"type:json;serializer:json" json:"screeningAnswers"`               // 职位筛选问题的回答
+	JobRevision      int               `gorm:"not null;default:0" json:"jobRevision"`                           // 申请时职位的修订版本号
+	CreateTime       time.Time         `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime       time.Time         `gorm:"autoUpdateTime" json:"updateTime"`
 }
 
 // TableName 指定表名
@@ -30,3 +34,49 @@ func NewJobApply(jobID, userID uint) *JobApply {
 		ApplyTime: time.Now(),
 	}
 }
+
+// JobApplySort 申请列表排序方式
+type JobApplySort string
+
+const (
+	JobApplySortApplyTimeDesc JobApplySort = "apply_time_desc"  // 按申请时间倒序，默认
+	JobApplySortApplyTimeAsc  JobApplySort = "apply_time_asc"   // 按申请时间正序
+	JobApplySortRatingDesc    JobApplySort = "rating_desc"      // 按评分从高到低，未评分的排在最后
+	JobApplySortRatingAsc     JobApplySort = "rating_asc"       // 按评分从低到高，未评分的排在最后
+	JobApplySortTag           JobApplySort = "tag"              // 按标签字母顺序，无标签的排在最后
+	JobApplySortUpdateDesc    JobApplySort = "update_time_desc" // 按最后更新时间倒序
+	JobApplySortUpdateAsc     JobApplySort = "update_time_asc"  // 按最后更新时间正序
+)
+
+// IsValid 排序方式是否有效，为空时使用默认排序
+func (s JobApplySort) IsValid() bool {
+	switch s {
+	case "", JobApplySortApplyTimeDesc, JobApplySortApplyTimeAsc, JobApplySortRatingDesc, JobApplySortRatingAsc, JobApplySortTag,
+		JobApplySortUpdateDesc, JobApplySortUpdateAsc:
+		return true
+	default:
+		return false
+	}
+}
+
+// JobApplyFilter 公司侧申请列表的筛选与排序条件，零值表示不限
+type JobApplyFilter struct {
+	Statuses      []int        // 申请状态，满足其一即可
+	JobID         uint         // 职位ID，仅公司申请列表使用
+	AppliedFrom   time.Time    // 申请时间起(含)
+	AppliedTo     time.Time    // 申请时间止(不含)
+	MinExperience *int         // 简历最低工作年限
+	MaxExperience *int         // 简历最高工作年限
+	ExpectedCity  string       // 简历期望城市
+	Degree        string       // 简历中任一教育经历的学历
+	Tags          []string     // 须同时包含的标签，不区分大小写
+	MinRating     int          // 最低评分
+	MaxRating     int          // 最高评分
+	Sort          JobApplySort // 排序方式
+}
+
+// WithoutStatuses 去掉状态条件，用于统计各状态的申请数
+func (f JobApplyFilter) WithoutStatuses() JobApplyFilter {
+	f.Statuses = nil
+	return f
+}
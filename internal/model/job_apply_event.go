@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// JobApplyEvent 申请状态流转事件，只追加不修改，构成申请的完整时间线
+type JobApplyEvent struct {
+	ID         uint          `gorm:"primarykey" json:"id"`
+	ApplyID    uint          `gorm:"not null;index:idx_apply_time,priority:1" json:"applyId"`
+	JobID      uint          `gorm:"not null;index" json:"jobId"`
+	ActorType  PipelineActor `gorm:"size:20;not null" json:"actorType"` // 触发方 candidate/company/system
+	ActorID    uint          `gorm:"not null" json:"actorId"`           // 触发人用户ID，系统流转时为0
+	FromStatus int           `gorm:"not null" json:"fromStatus"`        // 流转前状态，提交申请时为0
+	ToStatus   int           `gorm:"not null" json:"toStatus"`          // 流转后状态
+	Reason     string        `gorm:"size:255" json:"reason"`            // 流转原因
+	Note       string        `gorm:"type:text" json:"note"`             // 备注
+	CreateTime time.Time     `gorm:"autoCreateTime;index:idx_apply_time,priority:2" json:"createTime"`
+}
+
+// TableName 指定表名
+func (JobApplyEvent) TableName() string {
+	return "t_rc_job_apply_event"
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 申请评分与标签的限制
+const (
+	MaxApplyRating    = 5  // 最高评分
+	MaxApplyTags      = 20 // 每条申请最多标签数
+	MaxApplyTagLength = 30 // 标签最大长度(字符)
+)
+
+// JobApplyTag 招聘方为申请添加的标签，仅公司成员可见
+type JobApplyTag struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ApplyID    uint      `gorm:"not null;uniqueIndex:idx_apply_tag,priority:1" json:"applyId"`
+	Tag        string    `gorm:"size:30;not null;uniqueIndex:idx_apply_tag,priority:2;index:idx_company_tag,priority:2" json:"tag"`
+	CompanyID  uint      `gorm:"not null;index:idx_company_tag,priority:1" json:"companyId"`
+	CreatedBy  uint      `gorm:"not null" json:"createdBy"`
+	CreateTime time.Time `gorm:"autoCreateTime" json:"createTime"`
+}
+
+// TableName 指定表名
+func (JobApplyTag) TableName() string {
+	return "t_rc_job_apply_tag"
+}
+
+// NormalizeApplyTags 整理标签：去除首尾空白，忽略空标签，按不区分大小写去重并保持原有顺序
+func NormalizeApplyTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxApplyTagLength {
+			return nil, fmt.Errorf("标签「%s」超过%d个字符", tag, MaxApplyTagLength)
+		}
+		key := strings.ToLower(tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+	}
+	if len(result) > MaxApplyTags {
+		return nil, fmt.Errorf("每条申请最多%d个标签", MaxApplyTags)
+	}
+	return result, nil
+}
+
+// JobApplyNote 招聘方在申请上的私有备注，仅公司成员可见
+// 回复只能挂在顶层备注下，可提及公司成员，被提及的成员会收到通知
+type JobApplyNote struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ApplyID    uint      `gorm:"not null;index" json:"applyId"`
+	CompanyID  uint      `gorm:"not null;index" json:"companyId"`
+	ParentID   *uint     `gorm:"index" json:"parentId"`                     // 回复的顶层备注，为空表示顶层备注
+	AuthorID   uint      `gorm:"not null" json:"authorId"`                  // 作者
+	Content    string    `gorm:"type:text;not null" json:"content"`         // 备注内容
+	Mentions   []uint    `gorm:"type:json;serializer:json" json:"mentions"` // 提及的公司成员
+	CreateTime time.Time `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime time.Time `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (JobApplyNote) TableName() string {
+	return "t_rc_job_apply_note"
+}
+
+// IsReply 是否为回复
+func (n *JobApplyNote) IsReply() bool {
+	return n.ParentID != nil
+}
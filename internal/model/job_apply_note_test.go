@@ -0,0 +1,24 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeApplyTags(t *testing.T) {
+	tags, err := NormalizeApplyTags([]string{" Go ", "go", "", "  ", "后端", "GO"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Go", "后端"}, tags)
+
+	_, err = NormalizeApplyTags([]string{strings.Repeat("长", MaxApplyTagLength+1)})
+	assert.Error(t, err)
+
+	many := make([]string, MaxApplyTags+1)
+	for i := range many {
+		many[i] = strings.Repeat("a", i+1)
+	}
+	_, err = NormalizeApplyTags(many)
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"org.thinkinai.com/recruit-center/pkg/enums"
+)
+
+// PipelineActor 触发申请状态流转的一方
+type PipelineActor string
+
+const (
+	PipelineActorCandidate PipelineActor = "candidate" // 候选人(求职者)
+	PipelineActorCompany   PipelineActor = "company"   // 公司(所有者、招聘者)
+	PipelineActorSystem    PipelineActor = "system"    // 系统自动流转(如Offer过期)，不受流程定义约束，不能配置到流程中
+)
+
+// IsValid 流转触发方是否可配置到流程中
+func (a PipelineActor) IsValid() bool {
+	return a == PipelineActorCandidate || a == PipelineActorCompany
+}
+
+// PipelineStage 招聘流程中的一个阶段，对应一个申请状态
+type PipelineStage struct {
+	Status enums.JobApplyEnum `json:"status"` // 申请状态
+	Name   string             `json:"name"`   // 阶段名称，为空时使用状态默认名称
+}
+
+// DisplayName 阶段展示名称
+func (s PipelineStage) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Status.String()
+}
+
+// PipelineTransition 允许的状态流转及可触发的一方
+type PipelineTransition struct {
+	From   enums.JobApplyEnum `json:"from"`   // 起始状态
+	To     enums.JobApplyEnum `json:"to"`     // 目标状态
+	Actors []PipelineActor    `json:"actors"` // 可触发流转的一方
+}
+
+// AllowedBy 流转是否允许由指定一方触发
+func (t PipelineTransition) AllowedBy(actor PipelineActor) bool {
+	for _, a := range t.Actors {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
+
+// PipelineDefinition 招聘流程定义，描述申请可处于的阶段及阶段之间的流转规则
+type PipelineDefinition struct {
+	Stages      []PipelineStage      `json:"stages"`
+	Transitions []PipelineTransition `json:"transitions"`
+}
+
+// DefaultPipeline 默认招聘流程
+// 初筛 → 面试 → Offer，候选人可在Offer确认前撤回申请，Offer由候选人接受或拒绝
+func DefaultPipeline() *PipelineDefinition {
+	company := []PipelineActor{PipelineActorCompany}
+	candidate := []PipelineActor{PipelineActorCandidate}
+
+	return &PipelineDefinition{
+		Stages: []PipelineStage{
+			{Status: enums.JobApplyPending},
+			{Status: enums.JobApplyInProgress},
+			{Status: enums.JobApplyWaitInterview},
+			{Status: enums.JobApplyInterviewPass},
+			{Status: enums.JobApplyInterviewFail},
+			{Status: enums.JobApplyOfferSent},
+			{Status: enums.JobApplyOfferAccept},
+			{Status: enums.JobApplyOfferReject},
+			{Status: enums.JobApplyAccepted},
+			{Status: enums.JobApplyRejected},
+			{Status: enums.JobApplyWithdrawn},
+		},
+		Transitions: []PipelineTransition{
+			{From: enums.JobApplyPending, To: enums.JobApplyInProgress, Actors: company},
+			{From: enums.JobApplyPending, To: enums.JobApplyWaitInterview, Actors: company},
+			{From: enums.JobApplyPending, To: enums.JobApplyRejected, Actors: company},
+			{From: enums.JobApplyPending, To: enums.JobApplyWithdrawn, Actors: candidate},
+			{From: enums.JobApplyInProgress, To: enums.JobApplyWaitInterview, Actors: company},
+			{From: enums.JobApplyInProgress, To: enums.JobApplyAccepted, Actors: company},
+			{From: enums.JobApplyInProgress, To: enums.JobApplyRejected, Actors: company},
+			{From: enums.JobApplyInProgress, To: enums.JobApplyWithdrawn, Actors: candidate},
+			{From: enums.JobApplyWaitInterview, To: enums.JobApplyInterviewPass, Actors: company},
+			{From: enums.JobApplyWaitInterview, To: enums.JobApplyInterviewFail, Actors: company},
+			{From: enums.JobApplyWaitInterview, To: enums.JobApplyWithdrawn, Actors: candidate},
+			{From: enums.JobApplyInterviewPass, To: enums.JobApplyOfferSent, Actors: company},
+			{From: enums.JobApplyInterviewPass, To: enums.JobApplyRejected, Actors: company},
+			{From: enums.JobApplyInterviewPass, To: enums.JobApplyWithdrawn, Actors: candidate},
+			{From: enums.JobApplyOfferSent, To: enums.JobApplyOfferAccept, Actors: candidate},
+			{From: enums.JobApplyOfferSent, To: enums.JobApplyOfferReject, Actors: candidate},
+			{From: enums.JobApplyOfferAccept, To: enums.JobApplyAccepted, Actors: company},
+		},
+	}
+}
+
+// Stage 获取状态对应的阶段
+func (p *PipelineDefinition) Stage(status enums.JobApplyEnum) (PipelineStage, bool) {
+	for _, s := range p.Stages {
+		if s.Status == status {
+			return s, true
+		}
+	}
+	return PipelineStage{}, false
+}
+
+// NextTransitions 获取从指定状态出发、允许由指定一方触发的流转
+// actor 为空时返回所有流转，用于管理员查看
+func (p *PipelineDefinition) NextTransitions(from enums.JobApplyEnum, actor PipelineActor) []PipelineTransition {
+	var result []PipelineTransition
+	for _, t := range p.Transitions {
+		if t.From != from {
+			continue
+		}
+		if actor != "" && !t.AllowedBy(actor) {
+			continue
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// CanTransition 指定一方能否将申请从 from 流转到 to
+func (p *PipelineDefinition) CanTransition(from, to enums.JobApplyEnum, actor PipelineActor) bool {
+	for _, t := range p.NextTransitions(from, actor) {
+		if t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal 状态是否为终态，即没有任何后续流转
+func (p *PipelineDefinition) IsTerminal(status enums.JobApplyEnum) bool {
+	return len(p.NextTransitions(status, "")) == 0
+}
+
+// Validate 校验流程定义
+// 阶段状态必须有效且不重复，必须包含初始状态"待处理"，流转两端必须是已定义的阶段且至少有一个触发方
+func (p *PipelineDefinition) Validate() error {
+	if len(p.Stages) == 0 {
+		return fmt.Errorf("流程至少需要一个阶段")
+	}
+
+	stages := make(map[enums.JobApplyEnum]bool, len(p.Stages))
+	for _, s := range p.Stages {
+		if !s.Status.IsValid() {
+			return fmt.Errorf("无效的阶段状态: %d", s.Status)
+		}
+		if stages[s.Status] {
+			return fmt.Errorf("阶段状态重复: %s", s.Status.String())
+		}
+		stages[s.Status] = true
+	}
+	if !stages[enums.JobApplyPending] {
+		return fmt.Errorf("流程必须包含初始阶段: %s", enums.JobApplyPending.String())
+	}
+
+	type edge struct{ from, to enums.JobApplyEnum }
+	edges := make(map[edge]bool, len(p.Transitions))
+	for _, t := range p.Transitions {
+		if !stages[t.From] || !stages[t.To] {
+			return fmt.Errorf("流转引用了未定义的阶段: %d -> %d", t.From, t.To)
+		}
+		if t.From == t.To {
+			return fmt.Errorf("流转的起止阶段不能相同: %s", t.From.String())
+		}
+		if edges[edge{t.From, t.To}] {
+			return fmt.Errorf("流转重复: %s -> %s", t.From.String(), t.To.String())
+		}
+		edges[edge{t.From, t.To}] = true
+		if len(t.Actors) == 0 {
+			return fmt.Errorf("流转缺少触发方: %s -> %s", t.From.String(), t.To.String())
+		}
+		for _, a := range t.Actors {
+			if !a.IsValid() {
+				return fmt.Errorf("无效的流转触发方: %s", a)
+			}
+		}
+	}
+	return nil
+}
+
+// JobPipeline 职位自定义的招聘流程，未配置的职位使用默认流程
+type JobPipeline struct {
+	ID         uint               `gorm:"primarykey" json:"id"`
+	JobID      uint               `gorm:"not null;uniqueIndex" json:"jobId"`
+	CompanyID  uint               `gorm:"not null;index" json:"companyId"`
+	Definition PipelineDefinition `gorm:"type:json;serializer:json" json:"definition"` // 流程定义
+	UpdatedBy  uint               `gorm:"not null" json:"updatedBy"`                   // 最后修改人
+	CreateTime time.Time          `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime time.Time          `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (JobPipeline) TableName() string {
+	return "t_rc_job_pipeline"
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+)
+
+func TestDefaultPipeline(t *testing.T) {
+	p := DefaultPipeline()
+	assert.NoError(t, p.Validate())
+
+	// 公司推进流程，候选人撤回申请、处理Offer
+	assert.True(t, p.CanTransition(enums.JobApplyPending, enums.JobApplyWaitInterview, PipelineActorCompany))
+	assert.False(t, p.CanTransition(enums.JobApplyPending, enums.JobApplyWaitInterview, PipelineActorCandidate))
+	assert.True(t, p.CanTransition(enums.JobApplyPending, enums.JobApplyWithdrawn, PipelineActorCandidate))
+	assert.False(t, p.CanTransition(enums.JobApplyPending, enums.JobApplyWithdrawn, PipelineActorCompany))
+	assert.True(t, p.CanTransition(enums.JobApplyOfferSent, enums.JobApplyOfferAccept, PipelineActorCandidate))
+	assert.False(t, p.CanTransition(enums.JobApplyOfferSent, enums.JobApplyOfferAccept, PipelineActorCompany))
+
+	// 未定义的流转不允许
+	assert.False(t, p.CanTransition(enums.JobApplyPending, enums.JobApplyOfferSent, PipelineActorCompany))
+
+	for _, status := range []enums.JobApplyEnum{enums.JobApplyRejected, enums.JobApplyWithdrawn, enums.JobApplyAccepted, enums.JobApplyOfferReject} {
+		assert.True(t, p.IsTerminal(status), status.String())
+	}
+	assert.False(t, p.IsTerminal(enums.JobApplyPending))
+}
+
+func TestPipelineNextTransitions(t *testing.T) {
+	p := DefaultPipeline()
+
+	candidate := p.NextTransitions(enums.JobApplyPending, PipelineActorCandidate)
+	if assert.Len(t, candidate, 1) {
+		assert.Equal(t, enums.JobApplyWithdrawn, candidate[0].To)
+	}
+
+	company := p.NextTransitions(enums.JobApplyPending, PipelineActorCompany)
+	assert.Len(t, company, 3)
+
+	all := p.NextTransitions(enums.JobApplyPending, "")
+	assert.Len(t, all, len(candidate)+len(company))
+}
+
+func TestPipelineCustomStageName(t *testing.T) {
+	p := &PipelineDefinition{
+		Stages: []PipelineStage{
+			{Status: enums.JobApplyPending},
+			{Status: enums.JobApplyWaitInterview, Name: "技术面试"},
+			{Status: enums.JobApplyRejected},
+		},
+		Transitions: []PipelineTransition{
+			{From: enums.JobApplyPending, To: enums.JobApplyWaitInterview, Actors: []PipelineActor{PipelineActorCompany}},
+			{From: enums.JobApplyWaitInterview, To: enums.JobApplyRejected, Actors: []PipelineActor{PipelineActorCompany}},
+		},
+	}
+	assert.NoError(t, p.Validate())
+
+	stage, ok := p.Stage(enums.JobApplyWaitInterview)
+	assert.True(t, ok)
+	assert.Equal(t, "技术面试", stage.DisplayName())
+
+	stage, _ = p.Stage(enums.JobApplyPending)
+	assert.Equal(t, enums.JobApplyPending.String(), stage.DisplayName())
+
+	// 自定义流程跳过了初筛，待处理不能直接拒绝
+	assert.False(t, p.CanTransition(enums.JobApplyPending, enums.JobApplyRejected, PipelineActorCompany))
+}
+
+func TestPipelineValidate(t *testing.T) {
+	company := []PipelineActor{PipelineActorCompany}
+
+	tests := []struct {
+		name string
+		p    PipelineDefinition
+	}{
+		{"无阶段", PipelineDefinition{}},
+		{"缺少初始阶段", PipelineDefinition{
+			Stages: []PipelineStage{{Status: enums.JobApplyRejected}},
+		}},
+		{"无效状态", PipelineDefinition{
+			Stages: []PipelineStage{{Status: enums.JobApplyPending}, {Status: 99}},
+		}},
+		{"阶段重复", PipelineDefinition{
+			Stages: []PipelineStage{{Status: enums.JobApplyPending}, {Status: enums.JobApplyPending}},
+		}},
+		{"流转引用未定义阶段", PipelineDefinition{
+			Stages:      []PipelineStage{{Status: enums.JobApplyPending}},
+			Transitions: []PipelineTransition{{From: enums.JobApplyPending, To: enums.JobApplyRejected, Actors: company}},
+		}},
+		{"流转起止相同", PipelineDefinition{
+			Stages:      []PipelineStage{{Status: enums.JobApplyPending}},
+			Transitions: []PipelineTransition{{From: enums.JobApplyPending, To: enums.JobApplyPending, Actors: company}},
+		}},
+		{"流转重复", PipelineDefinition{
+			Stages: []PipelineStage{{Status: enums.JobApplyPending}, {Status: enums.JobApplyRejected}},
+			Transitions: []PipelineTransition{
+				{From: enums.JobApplyPending, To: enums.JobApplyRejected, Actors: company},
+				{From: enums.JobApplyPending, To: enums.JobApplyRejected, Actors: company},
+			},
+		}},
+		{"缺少触发方", PipelineDefinition{
+			Stages:      []PipelineStage{{Status: enums.JobApplyPending}, {Status: enums.JobApplyRejected}},
+			Transitions: []PipelineTransition{{From: enums.JobApplyPending, To: enums.JobApplyRejected}},
+		}},
+		{"无效触发方", PipelineDefinition{
+			Stages:      []PipelineStage{{Status: enums.JobApplyPending}, {Status: enums.JobApplyRejected}},
+			Transitions: []PipelineTransition{{From: enums.JobApplyPending, To: enums.JobApplyRejected, Actors: []PipelineActor{"admin"}}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.p.Validate())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// JobReviewStatus 职位审核结果
+type JobReviewStatus string
+
+const (
+	JobReviewPending  JobReviewStatus = "pending"  // 待审核
+	JobReviewApproved JobReviewStatus = "approved" // 审核通过
+	JobReviewRejected JobReviewStatus = "rejected" // 审核驳回
+)
+
+// IsValid 审核结果是否有效
+func (s JobReviewStatus) IsValid() bool {
+	return s == JobReviewPending || s == JobReviewApproved || s == JobReviewRejected
+}
+
+// 内容检查规则
+const (
+	ContentRuleSensitiveWord = "sensitive_word" // 包含敏感词
+	ContentRuleContactInfo   = "contact_info"   // 职位内容中包含联系方式
+	ContentRuleSalary        = "salary"         // 薪资不合理
+)
+
+// ContentIssue 内容检查发现的问题
+type ContentIssue struct {
+	Rule    string `json:"rule"`    // 检查规则
+	Field   string `json:"field"`   // 问题所在字段，与职位响应中的字段名一致
+	Message string `json:"message"` // 问题说明
+}
+
+// JoinContentIssues 将问题说明合并为一段文本，用于错误提示和驳回原因
+func JoinContentIssues(issues []ContentIssue) string {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+	return strings.Join(messages, "；")
+}
+
+// JobReview 职位审核记录，每次提交审核生成一条，审核后记录审核结果
+type JobReview struct {
+	ID          uint            `gorm:"primarykey" json:"id"`
+	JobID       uint            `gorm:"not null;index" json:"jobId"`
+	CompanyID   uint            `gorm:"not null;index" json:"companyId"`
+	Revision    int             `gorm:"not null" json:"revision"`                                               // 提交审核时职位的修订版本号
+	SubmitterID uint            `gorm:"not null" json:"submitterId"`                                            // 提交人
+	Status      JobReviewStatus `gorm:"size:20;not null;index:idx_review_status_time,priority:1" json:"status"` // 审核结果
+	Issues      []ContentIssue  `gorm:"type:json;serializer:json" json:"issues"`                                // 提交时内容检查发现的问题
+	ReviewerID  uint            `gorm:"not null;default:0" json:"reviewerId"`                                   // 审核人，自动审核为0
+	Reason      string          `gorm:"size:500" json:"reason"`                                                 // 审核意见，驳回时必填
+	ReviewTime  *time.Time      `json:"reviewTime"`                                                             // 审核时间
+	CreateTime  time.Time       `gorm:"autoCreateTime;index:idx_review_status_time,priority:2" json:"createTime"`
+}
+
+// TableName 指定表名
+func (JobReview) TableName() string {
+	return "t_rc_job_review"
+}
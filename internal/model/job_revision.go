@@ -0,0 +1,133 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JobRevisionAction 产生修订版本的操作
+type JobRevisionAction string
+
+const (
+	JobRevisionCreate  JobRevisionAction = "create"  // 创建职位，升级前已存在的职位首次修改时补存的版本也记为创建
+	JobRevisionUpdate  JobRevisionAction = "update"  // 修改职位
+	JobRevisionRestore JobRevisionAction = "restore" // 恢复到历史版本
+)
+
+// JobRevision 职位修订版本，每次创建、修改或恢复职位时保存职位内容的完整快照，只追加不修改
+type JobRevision struct {
+	ID           uint              `gorm:"primarykey" json:"id"`
+	JobID        uint              `gorm:"not null;uniqueIndex:idx_job_revision,priority:1" json:"jobId"`
+	Revision     int               `gorm:"not null;uniqueIndex:idx_job_revision,priority:2" json:"revision"` // 版本号，从1开始递增
+	Action       JobRevisionAction `gorm:"size:20;not null" json:"action"`                                   // 产生该版本的操作
+	RestoredFrom int               `gorm:"not null;default:0" json:"restoredFrom"`                           // 恢复自的版本号，非恢复操作为0
+	EditorID     uint              `gorm:"not null" json:"editorId"`                                         // 操作人用户ID，补存的版本为0
+	Snapshot     JobSnapshot       `gorm:"type:json;serializer:json" json:"snapshot"`                        // 职位内容快照
+	CreateTime   time.Time         `gorm:"autoCreateTime" json:"createTime"`
+}
+
+// TableName 指定表名
+func (JobRevision) TableName() string {
+	return "t_rc_job_revision"
+}
+
+// JobSnapshot 职位内容快照，只包含招聘方编辑的职位内容，不含状态、发布周期和统计数据
+type JobSnapshot struct {
+	Name          string           `json:"name"`
+	JobSkill      string           `json:"jobSkill"`
+	JobSalary     int              `json:"jobSalary"`
+	JobSalaryMax  int              `json:"jobSalaryMax"`
+	JobDescribe   string           `json:"jobDescribe"`
+	JobLocation   string           `json:"jobLocation"`
+	JobType       int              `json:"jobType"`
+	JobCategory   string           `json:"jobCategory"`
+	JobExperience string           `json:"jobExperience"`
+	JobEducation  string           `json:"jobEducation"`
+	JobBenefit    string           `json:"jobBenefit"`
+	JobContact    string           `json:"jobContact"`
+	JobSource     string           `json:"jobSource"`
+	Tags          []string         `json:"tags"`
+	RemoteType    RemoteType       `json:"remoteType"`
+	RemoteDesc    string           `json:"remoteDesc"`
+	RemoteRatio   int              `json:"remoteRatio"`
+	Benefits      []JobBenefitType `json:"benefits"`
+	BenefitDesc   string           `json:"benefitDesc"`
+	GeoLocation   GeoLocation      `json:"geoLocation"`
+}
+
+// NewJobSnapshot 生成职位内容快照
+func NewJobSnapshot(job *Job) JobSnapshot {
+	return JobSnapshot{
+		Name:          job.Name,
+		JobSkill:      job.JobSkill,
+		JobSalary:     job.JobSalary,
+		JobSalaryMax:  job.JobSalaryMax,
+		JobDescribe:   job.JobDescribe,
+		JobLocation:   job.JobLocation,
+		JobType:       job.JobType,
+		JobCategory:   job.JobCategory,
+		JobExperience: job.JobExperience,
+		JobEducation:  job.JobEducation,
+		JobBenefit:    job.JobBenefit,
+		JobContact:    job.JobContact,
+		JobSource:     job.JobSource,
+		Tags:          job.Tags,
+		RemoteType:    job.RemoteType,
+		RemoteDesc:    job.RemoteDesc,
+		RemoteRatio:   job.RemoteRatio,
+		Benefits:      job.Benefits,
+		BenefitDesc:   job.BenefitDesc,
+		GeoLocation:   job.GeoLocation,
+	}
+}
+
+// ApplyTo 将快照中的职位内容写回职位
+func (s JobSnapshot) ApplyTo(job *Job) {
+	job.Name = s.Name
+	job.JobSkill = s.JobSkill
+	job.JobSalary = s.JobSalary
+	job.JobSalaryMax = s.JobSalaryMax
+	job.JobDescribe = s.JobDescribe
+	job.JobLocation = s.JobLocation
+	job.JobType = s.JobType
+	job.JobCategory = s.JobCategory
+	job.JobExperience = s.JobExperience
+	job.JobEducation = s.JobEducation
+	job.JobBenefit = s.JobBenefit
+	job.JobContact = s.JobContact
+	job.JobSource = s.JobSource
+	job.Tags = s.Tags
+	job.RemoteType = s.RemoteType
+	job.RemoteDesc = s.RemoteDesc
+	job.RemoteRatio = s.RemoteRatio
+	job.Benefits = s.Benefits
+	job.BenefitDesc = s.BenefitDesc
+	job.GeoLocation = s.GeoLocation
+}
+
+// JobFieldChange 两个版本之间一个字段的变化
+type JobFieldChange struct {
+	Field string      `json:"field"` // 字段名，与职位响应中的字段名一致
+	From  interface{} `json:"from"`  // 旧版本的值
+	To    interface{} `json:"to"`    // 新版本的值
+}
+
+// Diff 按字段比较两个快照，返回从 s 到 other 发生变化的字段，按快照字段顺序排列
+// 空列表与未设置视为相同
+func (s JobSnapshot) Diff(other JobSnapshot) []JobFieldChange {
+	from, to := reflect.ValueOf(s), reflect.ValueOf(other)
+	changes := make([]JobFieldChange, 0)
+	for i := 0; i < from.NumField(); i++ {
+		a, b := from.Field(i), to.Field(i)
+		if a.Kind() == reflect.Slice && a.Len() == 0 && b.Len() == 0 {
+			continue
+		}
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			continue
+		}
+		field, _, _ := strings.Cut(from.Type().Field(i).Tag.Get("json"), ",")
+		changes = append(changes, JobFieldChange{Field: field, From: a.Interface(), To: b.Interface()})
+	}
+	return changes
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSnapshotDiff(t *testing.T) {
+	lat, lng := 39.9, 116.4
+	before := NewJobSnapshot(&Job{
+		Name:        "Go工程师",
+		JobSalary:   15000,
+		Tags:        []string{"急招"},
+		GeoLocation: GeoLocation{CityCode: "110100", Latitude: &lat, Longitude: &lng},
+	})
+
+	// 内容相同，空列表与未设置视为相同
+	same := before
+	same.Benefits = []JobBenefitType{}
+	assert.Empty(t, before.Diff(same))
+
+	after := before
+	after.Name = "高级Go工程师"
+	after.JobSalary = 20000
+	after.Tags = []string{"急招", "双休"}
+	otherLat := 31.2
+	after.GeoLocation.Latitude = &otherLat
+
+	changes := before.Diff(after)
+	if assert.Len(t, changes, 4) {
+		assert.Equal(t, JobFieldChange{Field: "name", From: "Go工程师", To: "高级Go工程师"}, changes[0])
+		assert.Equal(t, JobFieldChange{Field: "jobSalary", From: 15000, To: 20000}, changes[1])
+		assert.Equal(t, "tags", changes[2].Field)
+		assert.Equal(t, "geoLocation", changes[3].Field)
+	}
+
+	// 写回职位后与快照一致
+	job := &Job{Status: 1}
+	after.ApplyTo(job)
+	assert.Empty(t, NewJobSnapshot(job).Diff(after))
+	assert.Equal(t, 1, job.Status)
+}
@@ -0,0 +1,155 @@
+package model
+
+// JobSearchCondition 职位搜索条件，零值表示不限，只搜索有效职位
+// 同一分面内的多个取值满足其一即可，不同分面之间须同时满足
+type JobSearchCondition struct {
+	Keyword       string           // 关键词，匹配职位名称、技能要求和职位描述
+	JobTypes      []int            // 职位类型
+	JobCategories []string         // 职位分类
+	RemoteTypes   []RemoteType     // 远程办公类型
+	Benefits      []JobBenefitType // 福利，包含其一即可
+	Educations    []string         // 学历要求
+	Experiences   []string         // 经验要求
+	SalaryRanges  []string         // 薪资区间标识，见 SalaryRanges
+	Locations     []string         // 工作地点，模糊匹配
+	SalaryMin     int              // 期望最低薪资，职位薪资上限不低于该值
+	SalaryMax     int              // 期望最高薪资，职位薪资下限不高于该值
+	CompanyID     uint             // 公司ID
+	RegionCode    string           // 行政区划代码，匹配该区划及其下级区划的职位
+	Near          *GeoPoint        // 距离搜索的中心点
+	RadiusKm      float64          // 距中心点的最大距离(公里)，0表示不限，需指定中心点
+	Sort          JobSearchSort    // 排序方式
+}
+
+// GeoPoint 经纬度坐标
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// JobSearchSort 职位搜索排序方式
+type JobSearchSort string
+
+const (
+	JobSearchSortRelevance JobSearchSort = ""         // 默认，有关键词时按相关度，否则按发布时间
+	JobSearchSortNewest    JobSearchSort = "newest"   // 按发布时间从新到旧
+	JobSearchSortDistance  JobSearchSort = "distance" // 按距中心点从近到远，没有坐标的职位排在最后
+)
+
+// IsValid 是否为支持的排序方式
+func (s JobSearchSort) IsValid() bool {
+	switch s {
+	case JobSearchSortRelevance, JobSearchSortNewest, JobSearchSortDistance:
+		return true
+	default:
+		return false
+	}
+}
+
+// Without 去掉某个分面自身的筛选，用于计算该分面各取值的数量，使分面内可以继续多选
+func (c JobSearchCondition) Without(facet JobFacet) JobSearchCondition {
+	switch facet {
+	case JobFacetJobType:
+		c.JobTypes = nil
+	case JobFacetJobCategory:
+		c.JobCategories = nil
+	case JobFacetRemoteType:
+		c.RemoteTypes = nil
+	case JobFacetBenefit:
+		c.Benefits = nil
+	case JobFacetEducation:
+		c.Educations = nil
+	case JobFacetExperience:
+		c.Experiences = nil
+	case JobFacetSalary:
+		c.SalaryRanges = nil
+	case JobFacetLocation:
+		c.Locations = nil
+	}
+	return c
+}
+
+// JobSearchHit 职位搜索命中，Rank 为关键词相关度，未指定关键词时为0
+type JobSearchHit struct {
+	JobID uint
+	Rank  float64
+}
+
+// JobFacet 职位搜索分面
+type JobFacet string
+
+const (
+	JobFacetJobType     JobFacet = "jobType"     // 职位类型
+	JobFacetJobCategory JobFacet = "jobCategory" // 职位分类
+	JobFacetRemoteType  JobFacet = "remoteType"  // 远程办公类型
+	JobFacetBenefit     JobFacet = "benefit"     // 福利
+	JobFacetEducation   JobFacet = "education"   // 学历要求
+	JobFacetExperience  JobFacet = "experience"  // 经验要求
+	JobFacetSalary      JobFacet = "salaryRange" // 薪资区间
+	JobFacetLocation    JobFacet = "location"    // 工作地点
+)
+
+// JobFacets 搜索结果返回的分面，按展示顺序排列
+var JobFacets = []JobFacet{
+	JobFacetJobType,
+	JobFacetJobCategory,
+	JobFacetRemoteType,
+	JobFacetBenefit,
+	JobFacetEducation,
+	JobFacetExperience,
+	JobFacetSalary,
+	JobFacetLocation,
+}
+
+// DisplayName 分面名称
+func (f JobFacet) DisplayName() string {
+	switch f {
+	case JobFacetJobType:
+		return "职位类型"
+	case JobFacetJobCategory:
+		return "职位分类"
+	case JobFacetRemoteType:
+		return "办公方式"
+	case JobFacetBenefit:
+		return "福利待遇"
+	case JobFacetEducation:
+		return "学历要求"
+	case JobFacetExperience:
+		return "经验要求"
+	case JobFacetSalary:
+		return "薪资范围"
+	case JobFacetLocation:
+		return "工作地点"
+	default:
+		return string(f)
+	}
+}
+
+// SalaryRange 薪资区间 [Min, Max)，Max 为0表示不设上限
+// 职位薪资范围与区间有交集即视为落在该区间，一个职位可以落在多个区间
+type SalaryRange struct {
+	Key  string
+	Name string
+	Min  int
+	Max  int
+}
+
+// SalaryRanges 薪资分面的区间，单位为元/月
+var SalaryRanges = []SalaryRange{
+	{Key: "0-5k", Name: "5千以下", Min: 0, Max: 5000},
+	{Key: "5k-10k", Name: "5千-1万", Min: 5000, Max: 10000},
+	{Key: "10k-20k", Name: "1万-2万", Min: 10000, Max: 20000},
+	{Key: "20k-30k", Name: "2万-3万", Min: 20000, Max: 30000},
+	{Key: "30k-50k", Name: "3万-5万", Min: 30000, Max: 50000},
+	{Key: "50k+", Name: "5万以上", Min: 50000},
+}
+
+// SalaryRangeByKey 根据标识获取薪资区间
+func SalaryRangeByKey(key string) (SalaryRange, bool) {
+	for _, r := range SalaryRanges {
+		if r.Key == key {
+			return r, true
+		}
+	}
+	return SalaryRange{}, false
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSearchConditionWithout(t *testing.T) {
+	cond := JobSearchCondition{
+		Keyword:      "Go",
+		JobTypes:     []int{1, 2},
+		RemoteTypes:  []RemoteType{Hybrid},
+		Benefits:     []JobBenefitType{Insurance},
+		SalaryRanges: []string{"10k-20k"},
+		Locations:    []string{"北京"},
+	}
+
+	// 只去掉分面自身的筛选，其他条件保留
+	withoutType := cond.Without(JobFacetJobType)
+	assert.Empty(t, withoutType.JobTypes)
+	assert.Equal(t, "Go", withoutType.Keyword)
+	assert.Equal(t, []RemoteType{Hybrid}, withoutType.RemoteTypes)
+	assert.Equal(t, []string{"10k-20k"}, withoutType.SalaryRanges)
+
+	withoutSalary := cond.Without(JobFacetSalary)
+	assert.Empty(t, withoutSalary.SalaryRanges)
+	assert.Equal(t, []int{1, 2}, withoutSalary.JobTypes)
+
+	// 原条件不受影响
+	assert.Equal(t, []int{1, 2}, cond.JobTypes)
+	assert.Equal(t, []string{"10k-20k"}, cond.SalaryRanges)
+
+	for _, facet := range JobFacets {
+		assert.NotEqual(t, string(facet), facet.DisplayName())
+	}
+}
+
+func TestSalaryRangeByKey(t *testing.T) {
+	r, ok := SalaryRangeByKey("10k-20k")
+	assert.True(t, ok)
+	assert.Equal(t, 10000, r.Min)
+	assert.Equal(t, 20000, r.Max)
+
+	r, ok = SalaryRangeByKey("50k+")
+	assert.True(t, ok)
+	assert.Zero(t, r.Max)
+
+	_, ok = SalaryRangeByKey("unknown")
+	assert.False(t, ok)
+}
@@ -12,6 +12,37 @@ const (
 	UserTypeCompany                       // 企业用户
 )
 
+// Code 获取用户类型编码，作为令牌中的角色声明
+func (t UserType) Code() string {
+	switch t {
+	case UserTypeJobSeeker:
+		return "job_seeker"
+	case UserTypeRecruiter:
+		return "recruiter"
+	case UserTypeAdmin:
+		return "admin"
+	case UserTypeCompany:
+		return "company"
+	default:
+		return ""
+	}
+}
+
+// IsCompanySide 是否为企业侧用户(招聘者或企业用户)
+func (t UserType) IsCompanySide() bool {
+	return t == UserTypeRecruiter || t == UserTypeCompany
+}
+
+// ParseUserType 根据角色编码解析用户类型
+func ParseUserType(code string) (UserType, bool) {
+	for _, t := range []UserType{UserTypeJobSeeker, UserTypeRecruiter, UserTypeAdmin, UserTypeCompany} {
+		if t.Code() == code {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 // NotificationType 通知类型
 type NotificationType int
 
@@ -20,6 +51,8 @@ const (
 	NotificationTypeStatusUpdate                             // 申请状态更新
 	NotificationTypeInterview                                // 面试通知
 	NotificationTypeSystem                                   // 系统通知
+	NotificationTypeOffer                                    // Offer通知
+	NotificationTypeMention                                  // 备注中被提及
 )
 
 // NotificationChannel 通知渠道
@@ -55,7 +88,7 @@ type NotificationTemplate struct {
 	Title      string              `gorm:"size:100" json:"title"`                              // 模板标题
 	Content    string              `gorm:"size:1000" json:"content"`                           // 模板内容
 	Type       NotificationType    `gorm:"not null" json:"type"`                               // 通知类型
-	UserTypes  []UserType          `gorm:"type:json" json:"userTypes"`                         // 适用的用户类型
+	UserTypes  []UserType          `gorm:"type:json;serializer:json" json:"userTypes"`         // 适用的用户类型
 	Channels   NotificationChannel `gorm:"not null" json:"channels"`                           // 支持的通知渠道
 	IsActive   bool                `gorm:"default:true;index:idx_code_active" json:"isActive"` // 是否启用
 	CreateTime time.Time           `gorm:"autoCreateTime" json:"createTime"`
@@ -0,0 +1,167 @@
+package model
+
+import "time"
+
+// OfferStatus Offer状态
+type OfferStatus int
+
+const (
+	OfferDraft           OfferStatus = 1 // 草稿
+	OfferPendingApproval OfferStatus = 2 // 审批中
+	OfferApproved        OfferStatus = 3 // 审批通过，待发送
+	OfferSent            OfferStatus = 4 // 已发送，待候选人确认
+	OfferAccepted        OfferStatus = 5 // 候选人已接受
+	OfferDeclined        OfferStatus = 6 // 候选人已拒绝
+	OfferExpired         OfferStatus = 7 // 已过期
+	OfferWithdrawn       OfferStatus = 8 // 已撤回
+)
+
+// String Offer状态名称
+func (s OfferStatus) String() string {
+	switch s {
+	case OfferDraft:
+		return "草稿"
+	case OfferPendingApproval:
+		return "审批中"
+	case OfferApproved:
+		return "待发送"
+	case OfferSent:
+		return "待候选人确认"
+	case OfferAccepted:
+		return "已接受"
+	case OfferDeclined:
+		return "已拒绝"
+	case OfferExpired:
+		return "已过期"
+	case OfferWithdrawn:
+		return "已撤回"
+	default:
+		return "未知状态"
+	}
+}
+
+// IsActive Offer是否仍在进行中，同一申请同时只能有一个进行中的Offer
+func (s OfferStatus) IsActive() bool {
+	return s == OfferDraft || s == OfferPendingApproval || s == OfferApproved || s == OfferSent
+}
+
+// ActiveOfferStatuses 进行中的Offer状态
+var ActiveOfferStatuses = []OfferStatus{OfferDraft, OfferPendingApproval, OfferApproved, OfferSent}
+
+// OfferApprovalStatus Offer审批节点状态
+type OfferApprovalStatus string
+
+const (
+	OfferApprovalPending  OfferApprovalStatus = "pending"  // 待审批
+	OfferApprovalApproved OfferApprovalStatus = "approved" // 已通过
+	OfferApprovalRejected OfferApprovalStatus = "rejected" // 已驳回
+)
+
+// Offer 录用通知，关联一条职位申请
+// 创建后为草稿，提交后按审批链依次审批，全部通过后发送给候选人，候选人在截止时间前接受或拒绝
+type Offer struct {
+	ID            uint            `gorm:"primarykey" json:"id"`
+	ApplyID       uint            `gorm:"not null;index" json:"applyId"`
+	JobID         uint            `gorm:"not null;index" json:"jobId"`
+	CompanyID     uint            `gorm:"not null;index" json:"companyId"`
+	CandidateID   uint            `gorm:"not null;index" json:"candidateId"`            // 候选人用户ID
+	TemplateID    *uint           `json:"templateId"`                                   // Offer函模板，为空时使用默认模板
+	Position      string          `gorm:"size:100;not null" json:"position"`            // 录用职位名称
+	Department    string          `gorm:"size:100" json:"department"`                   // 所属部门
+	Currency      string          `gorm:"size:10;not null;default:CNY" json:"currency"` // 币种
+	BaseSalary    int64           `gorm:"not null" json:"baseSalary"`                   // 月基本工资
+	SalaryMonths  int             `gorm:"not null;default:12" json:"salaryMonths"`      // 年薪月数
+	SigningBonus  int64           `gorm:"default:0" json:"signingBonus"`                // 签字费
+	Benefits      string          `gorm:"size:500" json:"benefits"`                     // 福利待遇
+	Location      string          `gorm:"size:255" json:"location"`                     // 工作地点
+	StartDate     time.Time       `gorm:"type:date;not null" json:"startDate"`          // 入职日期
+	ExpireAt      time.Time       `gorm:"not null;index" json:"expireAt"`               // 候选人答复截止时间
+	Remark        string          `gorm:"size:500" json:"remark"`                       // 备注，写入Offer函
+	Status        OfferStatus     `gorm:"not null;default:1;index" json:"status"`       // 状态
+	SentAt        *time.Time      `json:"sentAt"`                                       // 发送时间
+	RespondedAt   *time.Time      `json:"respondedAt"`                                  // 候选人答复时间
+	DeclineReason string          `gorm:"size:255" json:"declineReason"`                // 拒绝原因
+	CreatedBy     uint            `gorm:"not null" json:"createdBy"`                    // 创建人
+	Approvals     []OfferApproval `gorm:"foreignKey:OfferID" json:"approvals"`          // 审批链，按步骤排序
+	CreateTime    time.Time       `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime    time.Time       `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (Offer) TableName() string {
+	return "t_rc_offer"
+}
+
+// AnnualSalary 年薪，月基本工资乘以年薪月数
+func (o *Offer) AnnualSalary() int64 {
+	return o.BaseSalary * int64(o.SalaryMonths)
+}
+
+// IsExpired Offer是否已超过答复截止时间
+func (o *Offer) IsExpired(now time.Time) bool {
+	return !o.ExpireAt.IsZero() && now.After(o.ExpireAt)
+}
+
+// CurrentApproval 当前待审批的节点，审批链为空或已全部通过时返回 nil
+func (o *Offer) CurrentApproval() *OfferApproval {
+	for i := range o.Approvals {
+		if o.Approvals[i].Status != OfferApprovalApproved {
+			return &o.Approvals[i]
+		}
+	}
+	return nil
+}
+
+// OfferApproval Offer审批节点，审批人按步骤依次审批
+type OfferApproval struct {
+	ID         uint                `gorm:"primarykey" json:"id"`
+	OfferID    uint                `gorm:"not null;uniqueIndex:idx_offer_step,priority:1" json:"offerId"`
+	Step       int                 `gorm:"not null;uniqueIndex:idx_offer_step,priority:2" json:"step"` // 审批顺序，从1开始
+	ApproverID uint                `gorm:"not null;index" json:"approverId"`                           // 审批人用户ID
+	Status     OfferApprovalStatus `gorm:"size:20;not null;default:pending" json:"status"`             // 审批状态
+	Comment    string              `gorm:"size:500" json:"comment"`                                    // 审批意见
+	DecidedAt  *time.Time          `json:"decidedAt"`                                                  // 审批时间
+	CreateTime time.Time           `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime time.Time           `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (OfferApproval) TableName() string {
+	return "t_rc_offer_approval"
+}
+
+// OfferTemplate 公司的Offer函模板，内容中的 {{变量名}} 在生成时替换，每行生成一个段落
+type OfferTemplate struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	CompanyID  uint      `gorm:"not null;index" json:"companyId"`
+	Name       string    `gorm:"size:100;not null" json:"name"`  // 模板名称
+	Title      string    `gorm:"size:100;not null" json:"title"` // Offer函标题
+	Content    string    `gorm:"type:text;not null" json:"content"`
+	CreatedBy  uint      `gorm:"not null" json:"createdBy"`
+	CreateTime time.Time `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime time.Time `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (OfferTemplate) TableName() string {
+	return "t_rc_offer_template"
+}
+
+// DefaultOfferTemplate 未指定模板时使用的默认Offer函
+func DefaultOfferTemplate() *OfferTemplate {
+	return &OfferTemplate{
+		Name:  "默认模板",
+		Title: "录用通知书",
+		Content: `尊敬的 {{candidateName}}：
+感谢您对{{companyName}}的关注。经过面试评估，我们很高兴地通知您，您已被录用为{{department}}{{position}}。
+薪资待遇：月基本工资 {{baseSalary}} {{currency}}，每年 {{salaryMonths}} 薪，年薪合计 {{annualSalary}} {{currency}}。
+签字费：{{signingBonus}}
+福利待遇：{{benefits}}
+工作地点：{{location}}
+入职日期：{{startDate}}
+{{remark}}
+请于 {{expireAt}} 前登录招聘中心确认是否接受本录用通知，逾期未确认视为自动放弃。
+{{companyName}}
+{{today}}`,
+	}
+}
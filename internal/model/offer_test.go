@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfferCurrentApproval(t *testing.T) {
+	offer := &Offer{Approvals: []OfferApproval{
+		{Step: 1, ApproverID: 1, Status: OfferApprovalApproved},
+		{Step: 2, ApproverID: 2, Status: OfferApprovalPending},
+		{Step: 3, ApproverID: 3, Status: OfferApprovalPending},
+	}}
+	if current := offer.CurrentApproval(); assert.NotNil(t, current) {
+		assert.Equal(t, uint(2), current.ApproverID)
+	}
+
+	offer.Approvals[1].Status = OfferApprovalApproved
+	offer.Approvals[2].Status = OfferApprovalApproved
+	assert.Nil(t, offer.CurrentApproval())
+	assert.Nil(t, (&Offer{}).CurrentApproval())
+}
+
+func TestOfferIsExpired(t *testing.T) {
+	now := time.Now()
+	assert.True(t, (&Offer{ExpireAt: now.Add(-time.Minute)}).IsExpired(now))
+	assert.False(t, (&Offer{ExpireAt: now.Add(time.Minute)}).IsExpired(now))
+	assert.False(t, (&Offer{}).IsExpired(now))
+}
+
+func TestOfferAnnualSalary(t *testing.T) {
+	offer := &Offer{BaseSalary: 20000, SalaryMonths: 14}
+	assert.Equal(t, int64(280000), offer.AnnualSalary())
+	assert.True(t, OfferSent.IsActive())
+	assert.False(t, OfferExpired.IsActive())
+}
@@ -0,0 +1,203 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RegionDictCategory 行政区划字典分类
+// 省、市、区县按 ParentID 组成树，Code 为行政区划代码，Value 为中心点坐标"经度,纬度"
+const RegionDictCategory = "region"
+
+// RegionLevel 行政区划级别
+type RegionLevel int
+
+const (
+	RegionProvince RegionLevel = 1 // 省级
+	RegionCity     RegionLevel = 2 // 市级
+	RegionDistrict RegionLevel = 3 // 区县级
+)
+
+// GeoLocation 结构化地点，经纬度取自所在行政区划的中心点，未知时为空
+type GeoLocation struct {
+	ProvinceCode string   `gorm:"size:20;index" json:"provinceCode"` // 省级行政区划代码
+	CityCode     string   `gorm:"size:20;index" json:"cityCode"`     // 市级行政区划代码
+	DistrictCode string   `gorm:"size:20;index" json:"districtCode"` // 区县级行政区划代码
+	Latitude     *float64 `gorm:"index" json:"latitude"`             // 纬度
+	Longitude    *float64 `json:"longitude"`                         // 经度
+}
+
+// HasCoordinates 是否有经纬度
+func (l GeoLocation) HasCoordinates() bool {
+	return l.Latitude != nil && l.Longitude != nil
+}
+
+// Region 行政区划
+type Region struct {
+	Code      string
+	Name      string
+	Level     RegionLevel
+	Parent    *Region
+	Children  []*Region
+	Latitude  *float64
+	Longitude *float64
+}
+
+// Location 转换为结构化地点，本级没有坐标时使用上级的坐标
+func (r *Region) Location() GeoLocation {
+	var loc GeoLocation
+	for node := r; node != nil; node = node.Parent {
+		switch node.Level {
+		case RegionProvince:
+			loc.ProvinceCode = node.Code
+		case RegionCity:
+			loc.CityCode = node.Code
+		case RegionDistrict:
+			loc.DistrictCode = node.Code
+		}
+		if !loc.HasCoordinates() && node.Latitude != nil && node.Longitude != nil {
+			loc.Latitude, loc.Longitude = node.Latitude, node.Longitude
+		}
+	}
+	return loc
+}
+
+// isAncestorOf 是否为另一区划的上级
+func (r *Region) isAncestorOf(other *Region) bool {
+	for node := other.Parent; node != nil; node = node.Parent {
+		if node == r {
+			return true
+		}
+	}
+	return false
+}
+
+// RegionIndex 行政区划索引，用于按代码查找和从地址文本中解析区划
+type RegionIndex struct {
+	byCode map[string]*Region
+	all    []*Region
+}
+
+// NewRegionIndex 由行政区划字典构建索引，父级不存在的字典项及三级以下的字典项被忽略
+func NewRegionIndex(dicts []Dict) *RegionIndex {
+	children := make(map[uint][]*Dict, len(dicts))
+	for i := range dicts {
+		children[dicts[i].ParentID] = append(children[dicts[i].ParentID], &dicts[i])
+	}
+
+	idx := &RegionIndex{byCode: make(map[string]*Region, len(dicts))}
+	var add func(dict *Dict, parent *Region, level RegionLevel)
+	add = func(dict *Dict, parent *Region, level RegionLevel) {
+		if level > RegionDistrict {
+			return
+		}
+		region := &Region{Code: dict.Code, Name: dict.Name, Level: level, Parent: parent}
+		region.Longitude, region.Latitude = parseCoordinates(dict.Value)
+		if parent != nil {
+			parent.Children = append(parent.Children, region)
+		}
+		idx.byCode[region.Code] = region
+		idx.all = append(idx.all, region)
+		for _, child := range children[dict.ID] {
+			add(child, region, level+1)
+		}
+	}
+	for _, dict := range children[0] {
+		add(dict, nil, RegionProvince)
+	}
+	return idx
+}
+
+// parseCoordinates 解析"经度,纬度"，格式不正确时返回空
+func parseCoordinates(value string) (*float64, *float64) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, nil
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return nil, nil
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, nil
+	}
+	return &lng, &lat
+}
+
+// Get 根据行政区划代码查找区划
+func (idx *RegionIndex) Get(code string) (*Region, bool) {
+	region, ok := idx.byCode[code]
+	return region, ok
+}
+
+// regionSuffixes 区划名称的常见后缀，按长度从长到短排列，去掉后缀的简称也可以匹配地址
+var regionSuffixes = []string{"特别行政区", "自治区", "自治州", "自治县", "地区", "省", "市", "盟", "区", "县"}
+
+// shortName 去掉后缀的简称，简称少于两个字时不使用
+func (r *Region) shortName() string {
+	for _, suffix := range regionSuffixes {
+		if short := strings.TrimSuffix(r.Name, suffix); short != r.Name {
+			if utf8.RuneCountInString(short) >= 2 {
+				return short
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// matchedName 地址文本中出现的区划名称，全称优先，未出现时返回空
+func (r *Region) matchedName(text string) string {
+	if strings.Contains(text, r.Name) {
+		return r.Name
+	}
+	if short := r.shortName(); short != "" && strings.Contains(text, short) {
+		return short
+	}
+	return ""
+}
+
+// Match 从地址文本中解析最具体的行政区划
+// 选择与文本中其他区划名称在层级上最一致的区划，如"北京朝阳区"解析为北京市朝阳区；无法区分同名区划时返回空
+func (idx *RegionIndex) Match(text string) (*Region, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, false
+	}
+
+	type hit struct {
+		region *Region
+		length int
+	}
+	var hits []hit
+	for _, region := range idx.all {
+		if name := region.matchedName(text); name != "" {
+			hits = append(hits, hit{region: region, length: utf8.RuneCountInString(name)})
+		}
+	}
+
+	var best *Region
+	bestScore, bestLength, ambiguous := 0, 0, false
+	for _, h := range hits {
+		score := 0
+		for _, other := range hits {
+			if other.region == h.region || other.region.isAncestorOf(h.region) {
+				score++
+			}
+		}
+		switch {
+		case best == nil || score > bestScore ||
+			(score == bestScore && h.region.Level > best.Level) ||
+			(score == bestScore && h.region.Level == best.Level && h.length > bestLength):
+			best, bestScore, bestLength, ambiguous = h.region, score, h.length, false
+		case score == bestScore && h.region.Level == best.Level && h.length == bestLength && !h.region.isAncestorOf(best):
+			ambiguous = true
+		}
+	}
+	if best == nil || ambiguous {
+		return nil, false
+	}
+	return best, true
+}
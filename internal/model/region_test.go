@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRegionDicts 北京市朝阳区、吉林省长春市朝阳区及浙江省杭州市西湖区
+func testRegionDicts() []Dict {
+	return []Dict{
+		{ID: 1, Category: RegionDictCategory, Code: "110000", Name: "北京市", Value: "116.4074,39.9042"},
+		{ID: 2, ParentID: 1, Category: RegionDictCategory, Code: "110100", Name: "北京市"},
+		{ID: 3, ParentID: 2, Category: RegionDictCategory, Code: "110105", Name: "朝阳区", Value: "116.4436,39.9219"},
+		{ID: 4, Category: RegionDictCategory, Code: "220000", Name: "吉林省", Value: "125.3245,43.8868"},
+		{ID: 5, ParentID: 4, Category: RegionDictCategory, Code: "220100", Name: "长春市", Value: "125.3235,43.8171"},
+		{ID: 6, ParentID: 5, Category: RegionDictCategory, Code: "220104", Name: "朝阳区", Value: "125.2883,43.8334"},
+		{ID: 7, Category: RegionDictCategory, Code: "330000", Name: "浙江省", Value: "120.1536,30.2875"},
+		{ID: 8, ParentID: 7, Category: RegionDictCategory, Code: "330100", Name: "杭州市", Value: "120.1551,30.2741"},
+		{ID: 9, ParentID: 8, Category: RegionDictCategory, Code: "330106", Name: "西湖区", Value: "invalid"},
+	}
+}
+
+func TestRegionLocation(t *testing.T) {
+	idx := NewRegionIndex(testRegionDicts())
+
+	region, ok := idx.Get("110105")
+	if assert.True(t, ok) {
+		loc := region.Location()
+		assert.Equal(t, "110000", loc.ProvinceCode)
+		assert.Equal(t, "110100", loc.CityCode)
+		assert.Equal(t, "110105", loc.DistrictCode)
+		if assert.True(t, loc.HasCoordinates()) {
+			assert.Equal(t, 39.9219, *loc.Latitude)
+			assert.Equal(t, 116.4436, *loc.Longitude)
+		}
+	}
+
+	// 本级坐标无效时使用上级坐标
+	region, ok = idx.Get("330106")
+	if assert.True(t, ok) {
+		loc := region.Location()
+		assert.Equal(t, "330100", loc.CityCode)
+		if assert.True(t, loc.HasCoordinates()) {
+			assert.Equal(t, 30.2741, *loc.Latitude)
+		}
+	}
+
+	_, ok = idx.Get("999999")
+	assert.False(t, ok)
+}
+
+func TestRegionMatch(t *testing.T) {
+	idx := NewRegionIndex(testRegionDicts())
+
+	tests := []struct {
+		text string
+		code string
+	}{
+		{"北京市朝阳区建国路88号", "110105"},
+		{"北京朝阳", "110105"},
+		{"长春朝阳区", "220104"},
+		{"杭州西湖区文三路", "330106"},
+		{"浙江杭州", "330100"},
+		{"吉林省", "220000"},
+		// 同名区划无法区分
+		{"朝阳区", ""},
+		{"上海市浦东新区", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		region, ok := idx.Match(tt.text)
+		if tt.code == "" {
+			assert.False(t, ok, tt.text)
+			continue
+		}
+		if assert.True(t, ok, tt.text) {
+			assert.Equal(t, tt.code, region.Code, tt.text)
+		}
+	}
+}
@@ -7,6 +7,13 @@ import (
 	"org.thinkinai.com/recruit-center/pkg/utils"
 )
 
+// 简历求职状态
+const (
+	ResumeJobStatusIdle    = 0 // 未找工作
+	ResumeJobStatusSeeking = 1 // 在找工作
+	ResumeJobStatusFound   = 2 // 已找到工作
+)
+
 // Resume 简历基本信息
 type Resume struct {
 	ID             uint      `gorm:"primarykey" json:"id"`
@@ -33,6 +40,9 @@ type Resume struct {
 	UpdatedAt      time.Time
 	DeletedAt      *time.Time `gorm:"index:idx_user_status_del,priority:3;index:idx_status_access_del,priority:4"`
 
+	// 结构化所在地，由行政区划字典解析
+	GeoLocation `gorm:"embedded" json:"geoLocation"`
+
 	// 关联
 	Educations      []Education        `json:"educations"`
 	WorkExperiences []WorkExperience   `json:"workExperiences"`
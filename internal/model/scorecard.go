@@ -0,0 +1,279 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// 评分卡考察项的评分范围
+const (
+	ScorecardMinScale = 2  // 最少两档评分
+	ScorecardMaxScale = 10 // 最多十档评分
+)
+
+// ScorecardCompetency 评分卡考察项，评分范围为 1 到 Scale
+type ScorecardCompetency struct {
+	Key         string `json:"key"`         // 考察项标识，职位内唯一
+	Name        string `json:"name"`        // 考察项名称
+	Description string `json:"description"` // 评分说明
+	Scale       int    `json:"scale"`       // 评分档数
+	Weight      int    `json:"weight"`      // 汇总权重，为0时按1计算
+}
+
+// EffectiveWeight 汇总时使用的权重
+func (c ScorecardCompetency) EffectiveWeight() int {
+	if c.Weight <= 0 {
+		return 1
+	}
+	return c.Weight
+}
+
+// ScorecardDefinition 评分卡定义
+type ScorecardDefinition struct {
+	Competencies []ScorecardCompetency `json:"competencies"`
+}
+
+// Competency 获取指定标识的考察项
+func (d *ScorecardDefinition) Competency(key string) (ScorecardCompetency, bool) {
+	for _, c := range d.Competencies {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return ScorecardCompetency{}, false
+}
+
+// Validate 校验评分卡定义
+// 考察项标识不能为空且不能重复，评分档数须在允许范围内
+func (d *ScorecardDefinition) Validate() error {
+	if len(d.Competencies) == 0 {
+		return fmt.Errorf("评分卡至少需要一个考察项")
+	}
+	keys := make(map[string]bool, len(d.Competencies))
+	for _, c := range d.Competencies {
+		if c.Key == "" || c.Name == "" {
+			return fmt.Errorf("考察项标识和名称不能为空")
+		}
+		if keys[c.Key] {
+			return fmt.Errorf("考察项标识重复: %s", c.Key)
+		}
+		keys[c.Key] = true
+		if c.Scale < ScorecardMinScale || c.Scale > ScorecardMaxScale {
+			return fmt.Errorf("考察项「%s」的评分档数须在%d到%d之间", c.Name, ScorecardMinScale, ScorecardMaxScale)
+		}
+		if c.Weight < 0 {
+			return fmt.Errorf("考察项「%s」的权重不能为负数", c.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateRatings 校验面试反馈的评分，每个考察项必须且只能评分一次
+func (d *ScorecardDefinition) ValidateRatings(ratings []CompetencyRating) error {
+	rated := make(map[string]bool, len(ratings))
+	for _, r := range ratings {
+		c, ok := d.Competency(r.Key)
+		if !ok {
+			return fmt.Errorf("评分卡中不存在考察项: %s", r.Key)
+		}
+		if rated[r.Key] {
+			return fmt.Errorf("考察项「%s」重复评分", c.Name)
+		}
+		rated[r.Key] = true
+		if r.Score < 1 || r.Score > c.Scale {
+			return fmt.Errorf("考察项「%s」的评分须在1到%d之间", c.Name, c.Scale)
+		}
+	}
+	for _, c := range d.Competencies {
+		if !rated[c.Key] {
+			return fmt.Errorf("考察项「%s」未评分", c.Name)
+		}
+	}
+	return nil
+}
+
+// ScorecardTemplate 职位的面试评分卡模板，未配置的职位只记录面试结论
+type ScorecardTemplate struct {
+	ID              uint                `gorm:"primarykey" json:"id"`
+	JobID           uint                `gorm:"not null;uniqueIndex" json:"jobId"`
+	CompanyID       uint                `gorm:"not null;index" json:"companyId"`
+	Definition      ScorecardDefinition `gorm:"type:json;serializer:json" json:"definition"`   // 评分卡定义
+	RequireFeedback bool                `gorm:"not null;default:false" json:"requireFeedback"` // 流转到面试通过或未通过前是否必须提交面试反馈
+	UpdatedBy       uint                `gorm:"not null" json:"updatedBy"`                     // 最后修改人
+	CreateTime      time.Time           `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime      time.Time           `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (ScorecardTemplate) TableName() string {
+	return "t_rc_scorecard_template"
+}
+
+// FeedbackRecommendation 面试官的录用建议
+type FeedbackRecommendation string
+
+const (
+	RecommendStrongNo  FeedbackRecommendation = "strong_no"  // 强烈不建议录用
+	RecommendNo        FeedbackRecommendation = "no"         // 不建议录用
+	RecommendYes       FeedbackRecommendation = "yes"        // 建议录用
+	RecommendStrongYes FeedbackRecommendation = "strong_yes" // 强烈建议录用
+	RecommendUndecided FeedbackRecommendation = "undecided"  // 意见不一致，仅用于汇总结果
+)
+
+// IsValid 录用建议是否有效，面试官只能给出明确的建议
+func (r FeedbackRecommendation) IsValid() bool {
+	switch r {
+	case RecommendStrongNo, RecommendNo, RecommendYes, RecommendStrongYes:
+		return true
+	default:
+		return false
+	}
+}
+
+// Score 录用建议对应的分值，用于汇总
+func (r FeedbackRecommendation) Score() int {
+	switch r {
+	case RecommendStrongNo:
+		return -2
+	case RecommendNo:
+		return -1
+	case RecommendYes:
+		return 1
+	case RecommendStrongYes:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// String 录用建议名称
+func (r FeedbackRecommendation) String() string {
+	switch r {
+	case RecommendStrongNo:
+		return "强烈不建议录用"
+	case RecommendNo:
+		return "不建议录用"
+	case RecommendYes:
+		return "建议录用"
+	case RecommendStrongYes:
+		return "强烈建议录用"
+	case RecommendUndecided:
+		return "意见不一致"
+	default:
+		return "暂无反馈"
+	}
+}
+
+// CompetencyRating 考察项评分
+type CompetencyRating struct {
+	Key     string `json:"key"`     // 考察项标识
+	Score   int    `json:"score"`   // 评分
+	Comment string `json:"comment"` // 评语
+}
+
+// InterviewFeedback 面试官对一场面试提交的反馈，每位面试官每场面试一条，可重复提交覆盖
+type InterviewFeedback struct {
+	ID             uint                   `gorm:"primarykey" json:"id"`
+	InterviewID    uint                   `gorm:"not null;uniqueIndex:idx_feedback_interviewer,priority:1" json:"interviewId"`
+	InterviewerID  uint                   `gorm:"not null;uniqueIndex:idx_feedback_interviewer,priority:2" json:"interviewerId"`
+	ApplyID        uint                   `gorm:"not null;index" json:"applyId"`
+	JobID          uint                   `gorm:"not null;index" json:"jobId"`
+	Ratings        []CompetencyRating     `gorm:"type:json;serializer:json" json:"ratings"` // 考察项评分
+	Recommendation FeedbackRecommendation `gorm:"size:20;not null" json:"recommendation"`   // 录用建议
+	Summary        string                 `gorm:"type:text" json:"summary"`                 // 综合评价
+	CreateTime     time.Time              `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime     time.Time              `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (InterviewFeedback) TableName() string {
+	return "t_rc_interview_feedback"
+}
+
+// CompetencyScore 考察项的汇总评分
+type CompetencyScore struct {
+	Key     string  `json:"key"`
+	Name    string  `json:"name"`
+	Scale   int     `json:"scale"`
+	Average float64 `json:"average"` // 平均分
+	Count   int     `json:"count"`   // 评分人数
+}
+
+// FeedbackSummary 申请的面试反馈汇总
+type FeedbackSummary struct {
+	Count                int                            `json:"count"`                // 反馈数量
+	Recommendation       FeedbackRecommendation         `json:"recommendation"`       // 汇总建议，无反馈时为空
+	RecommendationScore  float64                        `json:"recommendationScore"`  // 录用建议平均分值，-2 到 2
+	RecommendationCounts map[FeedbackRecommendation]int `json:"recommendationCounts"` // 各录用建议的数量
+	Competencies         []CompetencyScore              `json:"competencies"`         // 考察项汇总评分
+	OverallScore         float64                        `json:"overallScore"`         // 按权重汇总的综合得分，0 到 100
+}
+
+// SummarizeFeedback 汇总面试反馈
+// 汇总建议取录用建议分值的平均值：不低于1.5为强烈建议，大于0为建议，为0表示意见不一致，依此类推
+// 考察项评分按当前评分卡汇总，已从评分卡移除的考察项不计入
+func SummarizeFeedback(definition *ScorecardDefinition, feedbacks []InterviewFeedback) FeedbackSummary {
+	summary := FeedbackSummary{
+		Count:                len(feedbacks),
+		RecommendationCounts: make(map[FeedbackRecommendation]int),
+	}
+	if len(feedbacks) == 0 {
+		return summary
+	}
+
+	total := 0
+	for _, f := range feedbacks {
+		summary.RecommendationCounts[f.Recommendation]++
+		total += f.Recommendation.Score()
+	}
+	avg := float64(total) / float64(len(feedbacks))
+	summary.RecommendationScore = round2(avg)
+	switch {
+	case avg >= 1.5:
+		summary.Recommendation = RecommendStrongYes
+	case avg > 0:
+		summary.Recommendation = RecommendYes
+	case avg <= -1.5:
+		summary.Recommendation = RecommendStrongNo
+	case avg < 0:
+		summary.Recommendation = RecommendNo
+	default:
+		summary.Recommendation = RecommendUndecided
+	}
+
+	if definition == nil {
+		return summary
+	}
+
+	var weighted, weights float64
+	for _, c := range definition.Competencies {
+		score := CompetencyScore{Key: c.Key, Name: c.Name, Scale: c.Scale}
+		sum := 0
+		for _, f := range feedbacks {
+			for _, r := range f.Ratings {
+				if r.Key == c.Key && r.Score >= 1 && r.Score <= c.Scale {
+					sum += r.Score
+					score.Count++
+				}
+			}
+		}
+		if score.Count > 0 {
+			average := float64(sum) / float64(score.Count)
+			score.Average = round2(average)
+			// 归一化到 0-100，消除不同评分档数的差异
+			weighted += (average - 1) / float64(c.Scale-1) * 100 * float64(c.EffectiveWeight())
+			weights += float64(c.EffectiveWeight())
+		}
+		summary.Competencies = append(summary.Competencies, score)
+	}
+	if weights > 0 {
+		summary.OverallScore = round2(weighted / weights)
+	}
+	return summary
+}
+
+// round2 保留两位小数
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testScorecard() *ScorecardDefinition {
+	return &ScorecardDefinition{
+		Competencies: []ScorecardCompetency{
+			{Key: "coding", Name: "编码能力", Scale: 5, Weight: 3},
+			{Key: "communication", Name: "沟通表达", Scale: 3},
+		},
+	}
+}
+
+func TestScorecardValidate(t *testing.T) {
+	assert.NoError(t, testScorecard().Validate())
+	assert.Error(t, (&ScorecardDefinition{}).Validate())
+
+	duplicated := testScorecard()
+	duplicated.Competencies[1].Key = "coding"
+	assert.Error(t, duplicated.Validate())
+
+	badScale := testScorecard()
+	badScale.Competencies[0].Scale = 1
+	assert.Error(t, badScale.Validate())
+}
+
+func TestScorecardValidateRatings(t *testing.T) {
+	d := testScorecard()
+
+	assert.NoError(t, d.ValidateRatings([]CompetencyRating{
+		{Key: "coding", Score: 5},
+		{Key: "communication", Score: 1},
+	}))
+	// 缺少考察项
+	assert.Error(t, d.ValidateRatings([]CompetencyRating{{Key: "coding", Score: 3}}))
+	// 超出评分范围
+	assert.Error(t, d.ValidateRatings([]CompetencyRating{
+		{Key: "coding", Score: 3},
+		{Key: "communication", Score: 4},
+	}))
+	// 未定义的考察项
+	assert.Error(t, d.ValidateRatings([]CompetencyRating{
+		{Key: "coding", Score: 3},
+		{Key: "communication", Score: 2},
+		{Key: "design", Score: 2},
+	}))
+}
+
+func TestSummarizeFeedback(t *testing.T) {
+	d := testScorecard()
+
+	empty := SummarizeFeedback(d, nil)
+	assert.Equal(t, 0, empty.Count)
+	assert.Empty(t, empty.Recommendation)
+
+	summary := SummarizeFeedback(d, []InterviewFeedback{
+		{
+			Recommendation: RecommendStrongYes,
+			Ratings:        []CompetencyRating{{Key: "coding", Score: 5}, {Key: "communication", Score: 3}},
+		},
+		{
+			Recommendation: RecommendYes,
+			Ratings:        []CompetencyRating{{Key: "coding", Score: 3}, {Key: "communication", Score: 1}, {Key: "removed", Score: 4}},
+		},
+	})
+	assert.Equal(t, 2, summary.Count)
+	assert.Equal(t, RecommendStrongYes, summary.Recommendation)
+	assert.Equal(t, 1.5, summary.RecommendationScore)
+	assert.Equal(t, 1, summary.RecommendationCounts[RecommendYes])
+	if assert.Len(t, summary.Competencies, 2) {
+		assert.Equal(t, 4.0, summary.Competencies[0].Average)
+		assert.Equal(t, 2.0, summary.Competencies[1].Average)
+	}
+	// 编码 (4-1)/4=75 权重3，沟通 (2-1)/2=50 权重1
+	assert.Equal(t, 68.75, summary.OverallScore)
+
+	mixed := SummarizeFeedback(nil, []InterviewFeedback{
+		{Recommendation: RecommendYes},
+		{Recommendation: RecommendNo},
+	})
+	assert.Equal(t, RecommendUndecided, mixed.Recommendation)
+	assert.Empty(t, mixed.Competencies)
+}
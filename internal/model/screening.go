@@ -0,0 +1,342 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 筛选问题的数量及长度限制
+const (
+	MaxScreeningQuestions  = 20   // 每个职位最多筛选问题数
+	MaxScreeningOptions    = 20   // 选择题最多选项数
+	MaxScreeningTextLength = 2000 // 文本回答最大长度(字符)
+)
+
+// ScreeningQuestionType 筛选问题类型
+type ScreeningQuestionType string
+
+const (
+	ScreeningYesNo        ScreeningQuestionType = "yes_no"        // 是/否
+	ScreeningSingleChoice ScreeningQuestionType = "single_choice" // 单选
+	ScreeningMultiChoice  ScreeningQuestionType = "multi_choice"  // 多选
+	ScreeningNumeric      ScreeningQuestionType = "numeric"       // 数值
+	ScreeningText         ScreeningQuestionType = "text"          // 文本
+)
+
+// IsValid 问题类型是否有效
+func (t ScreeningQuestionType) IsValid() bool {
+	switch t {
+	case ScreeningYesNo, ScreeningSingleChoice, ScreeningMultiChoice, ScreeningNumeric, ScreeningText:
+		return true
+	default:
+		return false
+	}
+}
+
+// KnockoutOperator 淘汰规则的比较方式，描述回答须满足的条件
+type KnockoutOperator string
+
+const (
+	KnockoutEquals      KnockoutOperator = "eq"           // 是/否题的回答须等于 Bool，数值题须等于 Number
+	KnockoutGreaterOrEq KnockoutOperator = "gte"          // 数值须不小于 Number
+	KnockoutLessOrEq    KnockoutOperator = "lte"          // 数值须不大于 Number
+	KnockoutIn          KnockoutOperator = "in"           // 单选须为 Options 之一
+	KnockoutIncludesAny KnockoutOperator = "includes_any" // 多选须包含 Options 中至少一项
+	KnockoutIncludesAll KnockoutOperator = "includes_all" // 多选须包含 Options 中全部选项
+)
+
+// KnockoutRule 淘汰规则，回答不满足条件时申请被自动拒绝
+type KnockoutRule struct {
+	Operator KnockoutOperator `json:"operator"`          // 比较方式
+	Bool     *bool            `json:"bool,omitempty"`    // 是/否题的期望回答
+	Number   *float64         `json:"number,omitempty"`  // 数值题的比较值
+	Options  []string         `json:"options,omitempty"` // 选择题的期望选项
+	Reason   string           `json:"reason"`            // 自动拒绝的原因，为空时使用默认原因
+}
+
+// ScreeningQuestion 职位筛选问题
+type ScreeningQuestion struct {
+	Key      string                `json:"key"`                // 问题标识，职位内唯一
+	Text     string                `json:"text"`               // 问题内容
+	Type     ScreeningQuestionType `json:"type"`               // 问题类型
+	Options  []string              `json:"options,omitempty"`  // 选择题的选项
+	Required bool                  `json:"required"`           // 是否必答，设置了淘汰规则的问题必答
+	Knockout *KnockoutRule         `json:"knockout,omitempty"` // 淘汰规则
+}
+
+// hasOption 选项是否属于该问题
+func (q *ScreeningQuestion) hasOption(option string) bool {
+	for _, o := range q.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// validateKnockout 校验淘汰规则与问题类型匹配
+func (q *ScreeningQuestion) validateKnockout() error {
+	k := q.Knockout
+	switch q.Type {
+	case ScreeningYesNo:
+		if k.Operator != KnockoutEquals || k.Bool == nil {
+			return fmt.Errorf("是/否题「%s」的淘汰规则须为 eq 并指定期望回答", q.Text)
+		}
+	case ScreeningNumeric:
+		if k.Number == nil || (k.Operator != KnockoutEquals && k.Operator != KnockoutGreaterOrEq && k.Operator != KnockoutLessOrEq) {
+			return fmt.Errorf("数值题「%s」的淘汰规则须为 eq/gte/lte 并指定比较值", q.Text)
+		}
+	case ScreeningSingleChoice, ScreeningMultiChoice:
+		allowed := k.Operator == KnockoutIn
+		if q.Type == ScreeningMultiChoice {
+			allowed = k.Operator == KnockoutIncludesAny || k.Operator == KnockoutIncludesAll
+		}
+		if !allowed || len(k.Options) == 0 {
+			return fmt.Errorf("选择题「%s」的淘汰规则比较方式无效或未指定期望选项", q.Text)
+		}
+		for _, o := range k.Options {
+			if !q.hasOption(o) {
+				return fmt.Errorf("选择题「%s」的淘汰规则包含不存在的选项: %s", q.Text, o)
+			}
+		}
+	default:
+		return fmt.Errorf("文本题「%s」不支持淘汰规则", q.Text)
+	}
+	return nil
+}
+
+// ScreeningDefinition 职位的筛选问题定义
+type ScreeningDefinition struct {
+	Questions []ScreeningQuestion `json:"questions"`
+}
+
+// Question 获取指定标识的问题
+func (d *ScreeningDefinition) Question(key string) (*ScreeningQuestion, bool) {
+	for i := range d.Questions {
+		if d.Questions[i].Key == key {
+			return &d.Questions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate 校验筛选问题定义
+// 问题标识不能为空且不能重复，选择题须有不重复的选项，淘汰规则须与问题类型匹配
+func (d *ScreeningDefinition) Validate() error {
+	if len(d.Questions) > MaxScreeningQuestions {
+		return fmt.Errorf("每个职位最多%d个筛选问题", MaxScreeningQuestions)
+	}
+	keys := make(map[string]bool, len(d.Questions))
+	for i := range d.Questions {
+		q := &d.Questions[i]
+		if q.Key == "" || strings.TrimSpace(q.Text) == "" {
+			return fmt.Errorf("问题标识和内容不能为空")
+		}
+		if keys[q.Key] {
+			return fmt.Errorf("问题标识重复: %s", q.Key)
+		}
+		keys[q.Key] = true
+		if !q.Type.IsValid() {
+			return fmt.Errorf("问题「%s」的类型无效", q.Text)
+		}
+
+		isChoice := q.Type == ScreeningSingleChoice || q.Type == ScreeningMultiChoice
+		if isChoice && (len(q.Options) < 2 || len(q.Options) > MaxScreeningOptions) {
+			return fmt.Errorf("选择题「%s」须有2到%d个选项", q.Text, MaxScreeningOptions)
+		}
+		if !isChoice && len(q.Options) > 0 {
+			return fmt.Errorf("问题「%s」不是选择题，不能设置选项", q.Text)
+		}
+		options := make(map[string]bool, len(q.Options))
+		for _, o := range q.Options {
+			if strings.TrimSpace(o) == "" || options[o] {
+				return fmt.Errorf("选择题「%s」的选项不能为空或重复", q.Text)
+			}
+			options[o] = true
+		}
+
+		if q.Knockout != nil {
+			if err := q.validateKnockout(); err != nil {
+				return err
+			}
+			q.Required = true
+		}
+	}
+	return nil
+}
+
+// ScreeningAnswer 申请人对筛选问题的回答，按问题类型填写对应字段
+type ScreeningAnswer struct {
+	Key        string   `json:"key"`                  // 问题标识
+	Question   string   `json:"question"`             // 回答时的问题内容
+	Bool       *bool    `json:"bool,omitempty"`       // 是/否题的回答
+	Number     *float64 `json:"number,omitempty"`     // 数值题的回答
+	Text       string   `json:"text,omitempty"`       // 文本题的回答
+	Choices    []string `json:"choices,omitempty"`    // 选择题的回答
+	KnockedOut bool     `json:"knockedOut,omitempty"` // 是否未满足淘汰规则
+}
+
+// answered 是否已作答
+func (a *ScreeningAnswer) answered() bool {
+	return a.Bool != nil || a.Number != nil || strings.TrimSpace(a.Text) != "" || len(a.Choices) > 0
+}
+
+// validateAnswer 校验回答与问题类型匹配
+func (q *ScreeningQuestion) validateAnswer(a *ScreeningAnswer) error {
+	switch q.Type {
+	case ScreeningYesNo:
+		if a.Bool == nil || a.Number != nil || a.Text != "" || len(a.Choices) > 0 {
+			return fmt.Errorf("问题「%s」须回答是或否", q.Text)
+		}
+	case ScreeningNumeric:
+		if a.Number == nil || a.Bool != nil || a.Text != "" || len(a.Choices) > 0 {
+			return fmt.Errorf("问题「%s」须回答数值", q.Text)
+		}
+	case ScreeningText:
+		if a.Bool != nil || a.Number != nil || len(a.Choices) > 0 {
+			return fmt.Errorf("问题「%s」须回答文本", q.Text)
+		}
+		if utf8.RuneCountInString(a.Text) > MaxScreeningTextLength {
+			return fmt.Errorf("问题「%s」的回答不能超过%d个字符", q.Text, MaxScreeningTextLength)
+		}
+	case ScreeningSingleChoice, ScreeningMultiChoice:
+		if a.Bool != nil || a.Number != nil || a.Text != "" {
+			return fmt.Errorf("问题「%s」须选择选项", q.Text)
+		}
+		if q.Type == ScreeningSingleChoice && len(a.Choices) != 1 {
+			return fmt.Errorf("问题「%s」只能选择一项", q.Text)
+		}
+		seen := make(map[string]bool, len(a.Choices))
+		for _, c := range a.Choices {
+			if !q.hasOption(c) || seen[c] {
+				return fmt.Errorf("问题「%s」的选项无效: %s", q.Text, c)
+			}
+			seen[c] = true
+		}
+	}
+	return nil
+}
+
+// passes 回答是否满足淘汰规则，未设置规则时总是满足
+func (q *ScreeningQuestion) passes(a *ScreeningAnswer) bool {
+	k := q.Knockout
+	if k == nil {
+		return true
+	}
+	switch k.Operator {
+	case KnockoutEquals:
+		if q.Type == ScreeningYesNo {
+			return *a.Bool == *k.Bool
+		}
+		return *a.Number == *k.Number
+	case KnockoutGreaterOrEq:
+		return *a.Number >= *k.Number
+	case KnockoutLessOrEq:
+		return *a.Number <= *k.Number
+	case KnockoutIn, KnockoutIncludesAny:
+		for _, c := range a.Choices {
+			for _, o := range k.Options {
+				if c == o {
+					return true
+				}
+			}
+		}
+		return false
+	case KnockoutIncludesAll:
+		chosen := make(map[string]bool, len(a.Choices))
+		for _, c := range a.Choices {
+			chosen[c] = true
+		}
+		for _, o := range k.Options {
+			if !chosen[o] {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// knockoutReason 自动拒绝的原因
+func (q *ScreeningQuestion) knockoutReason() string {
+	if q.Knockout.Reason != "" {
+		return q.Knockout.Reason
+	}
+	return fmt.Sprintf("未满足职位要求：%s", q.Text)
+}
+
+// ScreeningResult 筛选问题的评估结果
+type ScreeningResult struct {
+	Answers        []ScreeningAnswer // 按问题顺序整理后的回答，附带问题内容
+	KnockedOut     bool              // 是否未满足任一淘汰规则
+	KnockoutReason string            // 第一个未满足的淘汰规则对应的拒绝原因
+}
+
+// Evaluate 校验回答并评估淘汰规则
+// 必答问题须作答，回答须与问题类型匹配，不能回答不存在的问题；回答格式错误时返回 error
+func (d *ScreeningDefinition) Evaluate(answers []ScreeningAnswer) (*ScreeningResult, error) {
+	byKey := make(map[string]*ScreeningAnswer, len(answers))
+	for i := range answers {
+		a := &answers[i]
+		if _, ok := d.Question(a.Key); !ok {
+			return nil, fmt.Errorf("职位不存在筛选问题: %s", a.Key)
+		}
+		if byKey[a.Key] != nil {
+			return nil, fmt.Errorf("筛选问题重复回答: %s", a.Key)
+		}
+		byKey[a.Key] = a
+	}
+
+	result := &ScreeningResult{Answers: make([]ScreeningAnswer, 0, len(answers))}
+	for i := range d.Questions {
+		q := &d.Questions[i]
+		a, ok := byKey[q.Key]
+		if !ok || !a.answered() {
+			if q.Required {
+				return nil, fmt.Errorf("问题「%s」为必答", q.Text)
+			}
+			continue
+		}
+		if err := q.validateAnswer(a); err != nil {
+			return nil, err
+		}
+		answer := *a
+		answer.Question = q.Text
+		answer.KnockedOut = !q.passes(a)
+		if answer.KnockedOut && !result.KnockedOut {
+			result.KnockedOut = true
+			result.KnockoutReason = q.knockoutReason()
+		}
+		result.Answers = append(result.Answers, answer)
+	}
+	return result, nil
+}
+
+// PublicView 返回隐藏淘汰规则的问题定义，供申请人查看
+func (d *ScreeningDefinition) PublicView() ScreeningDefinition {
+	view := ScreeningDefinition{Questions: make([]ScreeningQuestion, len(d.Questions))}
+	for i, q := range d.Questions {
+		q.Knockout = nil
+		view.Questions[i] = q
+	}
+	return view
+}
+
+// JobScreening 职位的筛选问题配置，未配置的职位申请时无需回答问题
+type JobScreening struct {
+	ID         uint                `gorm:"primarykey" json:"id"`
+	JobID      uint                `gorm:"not null;uniqueIndex" json:"jobId"`
+	CompanyID  uint                `gorm:"not null;index" json:"companyId"`
+	Definition ScreeningDefinition `gorm:"type:json;serializer:json" json:"definition"` // 筛选问题定义
+	UpdatedBy  uint                `gorm:"not null" json:"updatedBy"`                   // 最后修改人
+	CreateTime time.Time           `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime time.Time           `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (JobScreening) TableName() string {
+	return "t_rc_job_screening"
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func boolPtr(v bool) *bool        { return &v }
+func floatPtr(v float64) *float64 { return &v }
+
+func testScreening() *ScreeningDefinition {
+	return &ScreeningDefinition{Questions: []ScreeningQuestion{
+		{Key: "auth", Text: "是否拥有工作许可", Type: ScreeningYesNo,
+			Knockout: &KnockoutRule{Operator: KnockoutEquals, Bool: boolPtr(true), Reason: "需要工作许可"}},
+		{Key: "years", Text: "工作年限", Type: ScreeningNumeric,
+			Knockout: &KnockoutRule{Operator: KnockoutGreaterOrEq, Number: floatPtr(3)}},
+		{Key: "langs", Text: "熟悉的语言", Type: ScreeningMultiChoice, Options: []string{"Go", "Java", "Rust"},
+			Knockout: &KnockoutRule{Operator: KnockoutIncludesAny, Options: []string{"Go", "Rust"}}},
+		{Key: "intro", Text: "自我介绍", Type: ScreeningText},
+	}}
+}
+
+func TestScreeningDefinition_Validate(t *testing.T) {
+	d := testScreening()
+	require.NoError(t, d.Validate())
+	assert.True(t, d.Questions[0].Required, "设置淘汰规则的问题必答")
+	assert.False(t, d.Questions[3].Required)
+
+	invalid := []ScreeningDefinition{
+		{Questions: []ScreeningQuestion{{Key: "a", Text: "A", Type: ScreeningYesNo}, {Key: "a", Text: "B", Type: ScreeningText}}},
+		{Questions: []ScreeningQuestion{{Key: "a", Text: "A", Type: "unknown"}}},
+		{Questions: []ScreeningQuestion{{Key: "a", Text: "A", Type: ScreeningSingleChoice, Options: []string{"x"}}}},
+		{Questions: []ScreeningQuestion{{Key: "a", Text: "A", Type: ScreeningSingleChoice, Options: []string{"x", "y"},
+			Knockout: &KnockoutRule{Operator: KnockoutIn, Options: []string{"z"}}}}},
+		{Questions: []ScreeningQuestion{{Key: "a", Text: "A", Type: ScreeningNumeric,
+			Knockout: &KnockoutRule{Operator: KnockoutIncludesAll, Number: floatPtr(1)}}}},
+		{Questions: []ScreeningQuestion{{Key: "a", Text: "A", Type: ScreeningText,
+			Knockout: &KnockoutRule{Operator: KnockoutEquals}}}},
+	}
+	for i := range invalid {
+		assert.Error(t, invalid[i].Validate(), "case %d", i)
+	}
+}
+
+func TestScreeningDefinition_Evaluate(t *testing.T) {
+	d := testScreening()
+	require.NoError(t, d.Validate())
+
+	passed, err := d.Evaluate([]ScreeningAnswer{
+		{Key: "years", Number: floatPtr(5)},
+		{Key: "auth", Bool: boolPtr(true)},
+		{Key: "langs", Choices: []string{"Java", "Go"}},
+	})
+	require.NoError(t, err)
+	assert.False(t, passed.KnockedOut)
+	require.Len(t, passed.Answers, 3)
+	assert.Equal(t, "auth", passed.Answers[0].Key, "回答按问题顺序整理")
+	assert.Equal(t, "是否拥有工作许可", passed.Answers[0].Question)
+
+	failed, err := d.Evaluate([]ScreeningAnswer{
+		{Key: "auth", Bool: boolPtr(true)},
+		{Key: "years", Number: floatPtr(2)},
+		{Key: "langs", Choices: []string{"Java"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, failed.KnockedOut)
+	assert.Equal(t, "未满足职位要求：工作年限", failed.KnockoutReason, "返回第一个未满足的规则")
+	assert.True(t, failed.Answers[1].KnockedOut)
+	assert.True(t, failed.Answers[2].KnockedOut)
+
+	configured, err := d.Evaluate([]ScreeningAnswer{
+		{Key: "auth", Bool: boolPtr(false)},
+		{Key: "years", Number: floatPtr(3)},
+		{Key: "langs", Choices: []string{"Rust"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "需要工作许可", configured.KnockoutReason)
+
+	_, err = d.Evaluate([]ScreeningAnswer{{Key: "auth", Bool: boolPtr(true)}})
+	assert.Error(t, err, "必答问题未回答")
+	_, err = d.Evaluate([]ScreeningAnswer{{Key: "auth", Number: floatPtr(1)}})
+	assert.Error(t, err, "回答类型不匹配")
+	_, err = d.Evaluate([]ScreeningAnswer{{Key: "unknown", Text: "x"}})
+	assert.Error(t, err, "不存在的问题")
+	_, err = d.Evaluate([]ScreeningAnswer{
+		{Key: "auth", Bool: boolPtr(true)},
+		{Key: "years", Number: floatPtr(3)},
+		{Key: "langs", Choices: []string{"C++"}},
+	})
+	assert.Error(t, err, "选项不存在")
+
+	empty := &ScreeningDefinition{}
+	result, err := empty.Evaluate(nil)
+	require.NoError(t, err)
+	assert.False(t, result.KnockedOut)
+}
+
+func TestScreeningDefinition_PublicView(t *testing.T) {
+	d := testScreening()
+	view := d.PublicView()
+	for _, q := range view.Questions {
+		assert.Nil(t, q.Knockout)
+	}
+	assert.NotNil(t, d.Questions[0].Knockout, "不修改原定义")
+}
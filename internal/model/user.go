@@ -0,0 +1,78 @@
+package model
+
+import "time"
+
+// UserStatus 用户状态
+type UserStatus int
+
+const (
+	UserStatusActive   UserStatus = 1 // 正常
+	UserStatusDisabled UserStatus = 2 // 禁用
+)
+
+// User 用户账号
+type User struct {
+	ID                uint       `gorm:"primarykey" json:"id"`
+	Username          string     `gorm:"size:50;not null;uniqueIndex" json:"username"` // 用户名
+	Email             string     `gorm:"size:100;not null;uniqueIndex" json:"email"`   // 邮箱
+	Phone             string     `gorm:"size:20;index" json:"phone"`                   // 手机号
+	PasswordHash      string     `gorm:"size:100;not null" json:"-"`                   // bcrypt密码哈希
+	RealName          string     `gorm:"size:50" json:"realName"`                      // 真实姓名
+	Avatar            string     `gorm:"size:255" json:"avatar"`                       // 头像
+	UserType          UserType   `gorm:"not null;default:1;index" json:"userType"`     // 用户类型
+	CompanyID         uint       `gorm:"default:0;index" json:"companyId"`             // 所属公司ID，企业侧用户使用
+	Status            UserStatus `gorm:"default:1" json:"status"`                      // 状态 1: 正常 2: 禁用
+	EmailVerified     bool       `gorm:"default:false" json:"emailVerified"`           // 邮箱是否已验证
+	EmailVerifiedAt   *time.Time `json:"emailVerifiedAt"`                              // 邮箱验证时间
+	LastLoginAt       *time.Time `json:"lastLoginAt"`                                  // 最后登录时间
+	PasswordChangedAt *time.Time `json:"-"`                                            // 最后修改密码时间
+	CreateTime        time.Time  `gorm:"autoCreateTime" json:"createTime"`
+	UpdateTime        time.Time  `gorm:"autoUpdateTime" json:"updateTime"`
+}
+
+// TableName 指定表名
+func (User) TableName() string {
+	return "t_rc_user"
+}
+
+// IsActive 账号是否可用
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
+
+// DisplayName 展示名称，优先使用真实姓名
+func (u *User) DisplayName() string {
+	if u.RealName != "" {
+		return u.RealName
+	}
+	return u.Username
+}
+
+// UserTokenPurpose 一次性令牌用途
+type UserTokenPurpose string
+
+const (
+	UserTokenEmailVerify   UserTokenPurpose = "email_verify"   // 邮箱验证
+	UserTokenPasswordReset UserTokenPurpose = "password_reset" // 重置密码
+)
+
+// UserToken 邮箱验证、重置密码等一次性令牌，只保存令牌摘要
+type UserToken struct {
+	ID         uint             `gorm:"primarykey" json:"id"`
+	UserID     uint             `gorm:"not null;index:idx_user_purpose,priority:1" json:"userId"`
+	Purpose    UserTokenPurpose `gorm:"size:30;not null;index:idx_user_purpose,priority:2" json:"purpose"` // 令牌用途
+	TokenHash  string           `gorm:"size:64;not null;uniqueIndex" json:"-"`                             // 令牌SHA-256摘要
+	ExpiresAt  time.Time        `gorm:"not null" json:"expiresAt"`                                         // 过期时间
+	UsedAt     *time.Time       `json:"usedAt"`                                                            // 使用时间，为空表示未使用
+	CreateTime time.Time        `gorm:"autoCreateTime" json:"createTime"`
+}
+
+// TableName 指定表名
+func (UserToken) TableName() string {
+	return "t_rc_user_token"
+}
+
+// IsUsable 令牌是否未使用且未过期
+func (t *UserToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
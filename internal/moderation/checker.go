@@ -0,0 +1,55 @@
+// Package moderation 职位内容检查，提交审核和修改已发布职位时发现敏感词、联系方式和不合理薪资等问题
+package moderation
+
+import (
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/config"
+)
+
+// Checker 职位内容检查器，返回发现的问题，没有问题时返回空
+type Checker interface {
+	Check(job *model.Job) []model.ContentIssue
+}
+
+// Checkers 依次执行多个检查器并汇总发现的问题
+type Checkers []Checker
+
+// Check 执行全部检查器
+func (c Checkers) Check(job *model.Job) []model.ContentIssue {
+	var issues []model.ContentIssue
+	for _, checker := range c {
+		issues = append(issues, checker.Check(job)...)
+	}
+	return issues
+}
+
+// New 按配置创建默认的检查器组合：敏感词、联系方式和薪资检查
+func New(cfg config.ModerationConfig) Checkers {
+	return Checkers{
+		NewSensitiveWordChecker(cfg.SensitiveWords),
+		ContactInfoChecker{},
+		SalaryChecker{Min: cfg.MinSalary, Max: cfg.MaxSalary, MaxRatio: cfg.MaxSalaryRatio},
+	}
+}
+
+// textField 参与文本检查的职位字段
+type textField struct {
+	name  string
+	value string
+}
+
+// textFields 职位中由招聘方填写的文本内容，联系方式字段除外
+func textFields(job *model.Job) []textField {
+	fields := []textField{
+		{"name", job.Name},
+		{"jobSkill", job.JobSkill},
+		{"jobDescribe", job.JobDescribe},
+		{"jobBenefit", job.JobBenefit},
+		{"benefitDesc", job.BenefitDesc},
+		{"remoteDesc", job.RemoteDesc},
+	}
+	for _, tag := range job.Tags {
+		fields = append(fields, textField{"tags", tag})
+	}
+	return fields
+}
@@ -0,0 +1,73 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/config"
+)
+
+func issueFields(issues []model.ContentIssue, rule string) []string {
+	var fields []string
+	for _, issue := range issues {
+		if issue.Rule == rule {
+			fields = append(fields, issue.Field)
+		}
+	}
+	return fields
+}
+
+func TestCheckers(t *testing.T) {
+	checker := New(config.ModerationConfig{
+		SensitiveWords: []string{" 赌博 ", "Casino", "casino", ""},
+		MinSalary:      1000,
+		MaxSalary:      500000,
+		MaxSalaryRatio: 3,
+	})
+
+	clean := &model.Job{
+		Name:        "高级Go工程师",
+		JobDescribe: "负责订单系统开发，要求3-5年经验，2024年起薪资15000-25000。",
+		JobContact:  "hr@example.com 13800138000",
+		JobSalary:   15000, JobSalaryMax: 25000,
+	}
+	assert.Empty(t, checker.Check(clean))
+
+	job := *clean
+	job.Name = "CASINO 运营"
+	job.JobDescribe = "简历请发送至 hr@example.com，或加微信：abc_hr2024"
+	job.JobBenefit = "电话13800138000"
+	job.BenefitDesc = "QQ：123456789"
+	job.Tags = []string{"赌博"}
+	issues := checker.Check(&job)
+	assert.Equal(t, []string{"name", "tags"}, issueFields(issues, model.ContentRuleSensitiveWord))
+	assert.Equal(t, []string{"jobDescribe", "jobBenefit", "benefitDesc"}, issueFields(issues, model.ContentRuleContactInfo))
+	assert.Empty(t, issueFields(issues, model.ContentRuleSalary))
+}
+
+func TestSalaryChecker(t *testing.T) {
+	checker := SalaryChecker{Min: 1000, Max: 100000, MaxRatio: 3}
+	cases := []struct {
+		min, max int
+		field    string
+	}{
+		{0, 1000, "jobSalary"},
+		{8000, 6000, "jobSalaryMax"},
+		{500, 1200, "jobSalary"},
+		{60000, 150000, "jobSalaryMax"},
+		{5000, 20000, "jobSalaryMax"},
+		{5000, 15000, ""},
+	}
+	for _, tc := range cases {
+		issues := checker.Check(&model.Job{JobSalary: tc.min, JobSalaryMax: tc.max})
+		if tc.field == "" {
+			assert.Empty(t, issues, "%d-%d", tc.min, tc.max)
+			continue
+		}
+		assert.Equal(t, []string{tc.field}, issueFields(issues, model.ContentRuleSalary), "%d-%d", tc.min, tc.max)
+	}
+
+	// 未配置限制时只检查薪资范围本身
+	assert.Empty(t, SalaryChecker{}.Check(&model.Job{JobSalary: 1, JobSalaryMax: 1000000}))
+}
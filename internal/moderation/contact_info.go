@@ -0,0 +1,46 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// contactPatterns 职位内容中的联系方式，联系方式只能填写在联系方式字段中，由平台控制展示
+var contactPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"手机号", regexp.MustCompile(`(?:^|\D)1[3-9]\d{9}(?:\D|$)`)},
+	{"固定电话", regexp.MustCompile(`(?:^|\D)0\d{2,3}-\d{7,8}(?:\D|$)`)},
+	{"邮箱", regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{"微信号", regexp.MustCompile(`(?i)(?:微信|v信|weixin|wechat|vx|wx)号?\s*[:：]?\s*[A-Za-z][A-Za-z0-9_-]{5,19}`)},
+	{"QQ号", regexp.MustCompile(`(?i)(?:qq|扣扣)号?\s*[:：]?\s*[1-9]\d{4,10}`)},
+}
+
+// ContactInfoChecker 检查职位文本中是否夹带手机号、邮箱、微信、QQ等联系方式
+type ContactInfoChecker struct{}
+
+// Check 检查职位文本中的联系方式，每个字段最多报告一次
+func (ContactInfoChecker) Check(job *model.Job) []model.ContentIssue {
+	var issues []model.ContentIssue
+	reported := make(map[string]bool)
+	for _, field := range textFields(job) {
+		if reported[field.name] {
+			continue
+		}
+		for _, p := range contactPatterns {
+			if p.pattern.MatchString(field.value) {
+				reported[field.name] = true
+				issues = append(issues, model.ContentIssue{
+					Rule:    model.ContentRuleContactInfo,
+					Field:   field.name,
+					Message: fmt.Sprintf("%s中包含%s，联系方式请填写在联系方式字段中", fieldLabels[field.name], p.kind),
+				})
+				break
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,34 @@
+package moderation
+
+import (
+	"fmt"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// SalaryChecker 薪资合理性检查，零值的限制不检查
+type SalaryChecker struct {
+	Min      int     // 最低薪资下限
+	Max      int     // 最高薪资上限
+	MaxRatio float64 // 最高薪资与最低薪资的最大倍数
+}
+
+// Check 检查薪资范围是否完整、有序且在合理区间内
+func (c SalaryChecker) Check(job *model.Job) []model.ContentIssue {
+	issue := func(field, format string, args ...interface{}) []model.ContentIssue {
+		return []model.ContentIssue{{Rule: model.ContentRuleSalary, Field: field, Message: fmt.Sprintf(format, args...)}}
+	}
+	switch {
+	case job.JobSalary <= 0:
+		return issue("jobSalary", "最低薪资须大于0")
+	case job.JobSalaryMax < job.JobSalary:
+		return issue("jobSalaryMax", "最高薪资不能低于最低薪资")
+	case c.Min > 0 && job.JobSalary < c.Min:
+		return issue("jobSalary", "最低薪资不能低于%d", c.Min)
+	case c.Max > 0 && job.JobSalaryMax > c.Max:
+		return issue("jobSalaryMax", "最高薪资不能高于%d", c.Max)
+	case c.MaxRatio > 0 && float64(job.JobSalaryMax) > float64(job.JobSalary)*c.MaxRatio:
+		return issue("jobSalaryMax", "最高薪资不能超过最低薪资的%g倍", c.MaxRatio)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package moderation
+
+import (
+	"fmt"
+	"strings"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// SensitiveWordChecker 敏感词检查，不区分大小写，每个字段最多报告一次
+type SensitiveWordChecker struct {
+	words []string
+}
+
+// NewSensitiveWordChecker 创建敏感词检查器，忽略空白和重复的词
+func NewSensitiveWordChecker(words []string) *SensitiveWordChecker {
+	seen := make(map[string]bool, len(words))
+	c := &SensitiveWordChecker{}
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		c.words = append(c.words, word)
+	}
+	return c
+}
+
+// Check 检查职位文本和联系方式中的敏感词
+func (c *SensitiveWordChecker) Check(job *model.Job) []model.ContentIssue {
+	if len(c.words) == 0 {
+		return nil
+	}
+	var issues []model.ContentIssue
+	reported := make(map[string]bool)
+	for _, field := range append(textFields(job), textField{"jobContact", job.JobContact}) {
+		if reported[field.name] {
+			continue
+		}
+		if found := c.find(field.value); len(found) > 0 {
+			reported[field.name] = true
+			issues = append(issues, model.ContentIssue{
+				Rule:    model.ContentRuleSensitiveWord,
+				Field:   field.name,
+				Message: fmt.Sprintf("%s包含敏感词：%s", fieldLabels[field.name], strings.Join(found, "、")),
+			})
+		}
+	}
+	return issues
+}
+
+// find 返回文本中出现的敏感词
+func (c *SensitiveWordChecker) find(text string) []string {
+	text = strings.ToLower(text)
+	var found []string
+	for _, word := range c.words {
+		if strings.Contains(text, word) {
+			found = append(found, word)
+		}
+	}
+	return found
+}
+
+// fieldLabels 字段在问题说明中的名称
+var fieldLabels = map[string]string{
+	"name":         "职位名称",
+	"jobSkill":     "技能要求",
+	"jobDescribe":  "职位描述",
+	"jobBenefit":   "职位福利",
+	"benefitDesc":  "福利说明",
+	"remoteDesc":   "远程办公说明",
+	"tags":         "职位标签",
+	"jobContact":   "联系方式",
+	"jobSalary":    "最低薪资",
+	"jobSalaryMax": "最高薪资",
+}
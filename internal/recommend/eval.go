@@ -0,0 +1,99 @@
+package recommend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// Fixture 离线评估数据：候选职位和标注了实际感兴趣职位的求职者
+type Fixture struct {
+	Jobs  []*model.Job  `json:"jobs"`
+	Users []FixtureUser `json:"users"`
+}
+
+// FixtureUser 离线评估的求职者
+type FixtureUser struct {
+	Name     string        `json:"name"`
+	Resume   *model.Resume `json:"resume"`   // 简历，可以为空
+	History  []uint        `json:"history"`  // 收藏和投递过的职位
+	Relevant []uint        `json:"relevant"` // 实际感兴趣的职位，不应包括 History 中的职位
+}
+
+// LoadFixture 读取 JSON 格式的离线评估数据
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("解析评估数据失败: %w", err)
+	}
+	return &f, nil
+}
+
+// UserMetrics 单个求职者的评估结果
+type UserMetrics struct {
+	Name      string
+	Hits      int
+	Precision float64
+	Recall    float64
+}
+
+// Metrics 离线评估结果，准确率和召回率为各求职者的平均值
+type Metrics struct {
+	K         int
+	Precision float64 // 前 K 个推荐中感兴趣职位的比例
+	Recall    float64 // 感兴趣职位出现在前 K 个推荐中的比例
+	Users     []UserMetrics
+}
+
+// Evaluate 用打分器为评估数据中的每个求职者推荐 k 个职位，计算 Precision@K 和 Recall@K
+// 没有标注感兴趣职位的求职者不参与评估
+func Evaluate(s Scorer, f *Fixture, k int) (Metrics, error) {
+	m := Metrics{K: k}
+	if k <= 0 {
+		return m, fmt.Errorf("k 必须大于 0")
+	}
+	jobs := make(map[uint]*model.Job, len(f.Jobs))
+	for _, job := range f.Jobs {
+		jobs[job.ID] = job
+	}
+	for _, u := range f.Users {
+		if len(u.Relevant) == 0 {
+			continue
+		}
+		history := make([]*model.Job, 0, len(u.History))
+		for _, id := range u.History {
+			job, ok := jobs[id]
+			if !ok {
+				return m, fmt.Errorf("求职者 %s 的历史职位 %d 不存在", u.Name, id)
+			}
+			history = append(history, job)
+		}
+		relevant := make(map[uint]bool, len(u.Relevant))
+		for _, id := range u.Relevant {
+			relevant[id] = true
+		}
+
+		um := UserMetrics{Name: u.Name}
+		for _, match := range s.Rank(NewProfile(u.Resume, history), f.Jobs, k) {
+			if relevant[match.Job.ID] {
+				um.Hits++
+			}
+		}
+		um.Precision = float64(um.Hits) / float64(k)
+		um.Recall = float64(um.Hits) / float64(len(relevant))
+		m.Users = append(m.Users, um)
+		m.Precision += um.Precision
+		m.Recall += um.Recall
+	}
+	if n := len(m.Users); n > 0 {
+		m.Precision /= float64(n)
+		m.Recall /= float64(n)
+	}
+	return m, nil
+}
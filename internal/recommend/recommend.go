@@ -0,0 +1,218 @@
+// Package recommend 职位推荐：按求职者的简历、收藏和投递记录为职位打分，并计算职位之间的相似度
+package recommend
+
+import (
+	"sort"
+	"strings"
+
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+// 推荐理由，对应匹配项的得分不低于 reasonThreshold 时给出
+const (
+	ReasonTitle      = "与期望职位相符"
+	ReasonSkill      = "技能匹配"
+	ReasonCity       = "位于期望城市"
+	ReasonExperience = "经验符合要求"
+	ReasonHistory    = "与收藏或投递过的职位相似"
+)
+
+// reasonThreshold 匹配项给出推荐理由的最低得分
+const reasonThreshold = 0.5
+
+// Weights 各匹配项在总分中的权重
+type Weights struct {
+	Title      float64 // 期望职位与职位名称
+	Skill      float64 // 简历技能对职位技能要求的覆盖
+	City       float64 // 期望城市与工作地点
+	Experience float64 // 工作年限与经验要求
+	History    float64 // 与收藏和投递过的职位的相似度
+}
+
+// DefaultWeights 默认权重
+var DefaultWeights = Weights{Title: 0.3, Skill: 0.3, City: 0.15, Experience: 0.1, History: 0.15}
+
+// total 权重之和
+func (w Weights) total() float64 {
+	return w.Title + w.Skill + w.City + w.Experience + w.History
+}
+
+// Profile 求职者画像
+type Profile struct {
+	ExpectedJob  string            // 期望职位
+	ExpectedCity string            // 期望城市
+	Location     model.GeoLocation // 期望城市解析出的结构化地点，未解析时按名称匹配工作地点
+	Skills       map[string]bool   // 技能，小写
+	Experience   int               // 工作年限，没有简历时为 -1
+	History      []*model.Job      // 收藏和投递过的职位
+	Exclude      map[uint]bool     // 不再推荐的职位
+}
+
+// NewProfile 由简历和收藏、投递过的职位构建求职者画像，收藏和投递过的职位不再推荐，resume 可以为空
+func NewProfile(resume *model.Resume, history []*model.Job) *Profile {
+	p := &Profile{Experience: -1, Skills: tokenSet(""), History: history, Exclude: make(map[uint]bool, len(history))}
+	if resume != nil {
+		p.ExpectedJob = strings.TrimSpace(resume.ExpectedJob)
+		p.ExpectedCity = strings.TrimSpace(resume.ExpectedCity)
+		p.Skills = tokenSet(resume.Skills)
+		p.Experience = resume.Experience
+	}
+	for _, job := range history {
+		p.Exclude[job.ID] = true
+	}
+	return p
+}
+
+// cityScore 工作地点与期望城市的匹配度，全远程职位视为匹配
+func (p *Profile) cityScore(job *model.Job) float64 {
+	if p.ExpectedCity == "" && p.Location.ProvinceCode == "" {
+		return 0
+	}
+	if job.RemoteType == model.FullRemote {
+		return 1
+	}
+	if loc := p.Location; loc.ProvinceCode != "" && job.ProvinceCode != "" {
+		switch {
+		case loc.CityCode != "" && loc.CityCode == job.CityCode:
+			return 1
+		case loc.ProvinceCode != job.ProvinceCode:
+			return 0
+		case loc.CityCode == "":
+			// 直辖市等只解析到省级的期望城市
+			return 1
+		default:
+			return 0.5
+		}
+	}
+	name := strings.TrimSuffix(p.ExpectedCity, "市")
+	if name != "" && strings.Contains(job.JobLocation, name) {
+		return 1
+	}
+	return 0
+}
+
+// historyScore 与收藏和投递过的职位的最高相似度
+func (p *Profile) historyScore(job *model.Job) float64 {
+	best := 0.0
+	for _, h := range p.History {
+		if s := Similarity(job, h); s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+// Match 职位及其得分
+type Match struct {
+	Job     *model.Job
+	Score   float64  // 匹配度，0-1
+	Reasons []string // 推荐理由
+}
+
+// Scorer 按权重为职位打分
+type Scorer struct {
+	Weights Weights
+}
+
+// Default 使用默认权重的打分器
+var Default = Scorer{Weights: DefaultWeights}
+
+// Score 计算职位与求职者画像的匹配度，各匹配项得分按权重加权平均
+func (s Scorer) Score(p *Profile, job *model.Job) Match {
+	m := Match{Job: job}
+	total := s.Weights.total()
+	if total <= 0 {
+		return m
+	}
+	add := func(weight, value float64, reason string) {
+		if weight <= 0 {
+			return
+		}
+		m.Score += weight * value
+		if reason != "" && value >= reasonThreshold {
+			m.Reasons = append(m.Reasons, reason)
+		}
+	}
+	add(s.Weights.Title, titleScore(p.ExpectedJob, job.Name), ReasonTitle)
+	add(s.Weights.Skill, coverage(p.Skills, tokenSet(job.JobSkill)), ReasonSkill)
+	add(s.Weights.City, p.cityScore(job), ReasonCity)
+	// 职位未写明经验要求时不给出经验理由
+	exp, known := experienceScore(p.Experience, job.JobExperience)
+	reason := ""
+	if known {
+		reason = ReasonExperience
+	}
+	add(s.Weights.Experience, exp, reason)
+	add(s.Weights.History, p.historyScore(job), ReasonHistory)
+	m.Score /= total
+	return m
+}
+
+// Rank 为候选职位打分并按得分倒序返回前 limit 个，跳过画像中排除的职位
+// 得分相同时保持候选职位原有的顺序，候选职位按发布时间倒序时没有简历和记录的求职者得到最新的职位
+func (s Scorer) Rank(p *Profile, candidates []*model.Job, limit int) []Match {
+	matches := make([]Match, 0, len(candidates))
+	for _, job := range candidates {
+		if p.Exclude[job.ID] {
+			continue
+		}
+		matches = append(matches, s.Score(p, job))
+	}
+	return top(matches, limit)
+}
+
+// Similar 按与 target 的相似度倒序返回前 limit 个候选职位，不包括 target 本身和完全不相似的职位
+func Similar(target *model.Job, candidates []*model.Job, limit int) []Match {
+	matches := make([]Match, 0, len(candidates))
+	for _, job := range candidates {
+		if job.ID == target.ID {
+			continue
+		}
+		if score := Similarity(target, job); score > 0 {
+			matches = append(matches, Match{Job: job, Score: score})
+		}
+	}
+	return top(matches, limit)
+}
+
+// Similarity 两个职位的相似度，0-1：技能和标签的重合度为主，职位名称、分类和工作城市为辅
+func Similarity(a, b *model.Job) float64 {
+	score := 0.5*jaccard(jobTerms(a), jobTerms(b)) + 0.3*dice(a.Name, b.Name)
+	if a.JobCategory != "" && a.JobCategory == b.JobCategory {
+		score += 0.1
+	}
+	if sameCity(a, b) {
+		score += 0.1
+	}
+	return score
+}
+
+// jobTerms 职位的技能要求和标签
+func jobTerms(job *model.Job) map[string]bool {
+	terms := tokenSet(job.JobSkill)
+	for _, tag := range job.Tags {
+		if tag = normalize(tag); tag != "" {
+			terms[tag] = true
+		}
+	}
+	return terms
+}
+
+// sameCity 两个职位是否在同一城市，没有结构化地点时比较工作地点文本
+func sameCity(a, b *model.Job) bool {
+	if a.CityCode != "" || b.CityCode != "" {
+		return a.CityCode == b.CityCode
+	}
+	return a.JobLocation != "" && strings.TrimSpace(a.JobLocation) == strings.TrimSpace(b.JobLocation)
+}
+
+// top 按得分倒序稳定排序并截取前 limit 个，limit 不大于 0 时不截取
+func top(matches []Match, limit int) []Match {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
@@ -0,0 +1,117 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"org.thinkinai.com/recruit-center/internal/model"
+)
+
+func TestExperienceScore(t *testing.T) {
+	tests := []struct {
+		years       int
+		requirement string
+		want        float64
+		known       bool
+	}{
+		{4, "3-5年", 1, true},
+		{1, "3-5年", 0.5, true},
+		{8, "3-5年", 0.7, true},
+		{20, "3-5年", 0.5, true},
+		{2, "5年以上", 0.25, true},
+		{10, "5年以上", 1, true},
+		{3, "1年以下", 0.8, true},
+		{3, "不限", 1, false},
+		{-1, "3-5年", 0, true},
+	}
+	for _, tt := range tests {
+		got, known := experienceScore(tt.years, tt.requirement)
+		assert.InDelta(t, tt.want, got, 1e-9, "%d %s", tt.years, tt.requirement)
+		assert.Equal(t, tt.known, known, "%d %s", tt.years, tt.requirement)
+	}
+}
+
+func TestScore(t *testing.T) {
+	resume := &model.Resume{ExpectedJob: "Go开发工程师", ExpectedCity: "北京市", Skills: "go, Docker，kubernetes", Experience: 4}
+	p := NewProfile(resume, nil)
+	job := &model.Job{ID: 1, Name: "高级Go开发工程师", JobSkill: "Go,Docker,Kubernetes,MySQL", JobExperience: "3-5年", JobLocation: "北京朝阳区"}
+
+	m := Default.Score(p, job)
+	// 名称 1，技能 3/4，城市 1，经验 1，没有收藏和投递记录
+	assert.InDelta(t, 0.3+0.3*0.75+0.15+0.1, m.Score, 1e-9)
+	assert.Equal(t, []string{ReasonTitle, ReasonSkill, ReasonCity, ReasonExperience}, m.Reasons)
+
+	// 结构化地点优先于名称，同省不同市得一半，全远程职位不限城市
+	p.Location = model.GeoLocation{ProvinceCode: "440000", CityCode: "440300"}
+	job.GeoLocation = model.GeoLocation{ProvinceCode: "440000", CityCode: "440100"}
+	assert.Equal(t, 0.5, p.cityScore(job))
+	job.GeoLocation = model.GeoLocation{ProvinceCode: "110000", CityCode: "110100"}
+	assert.Equal(t, 0.0, p.cityScore(job))
+	job.RemoteType = model.FullRemote
+	assert.Equal(t, 1.0, p.cityScore(job))
+}
+
+func TestRankExcludesHistoryAndKeepsOrderOnTies(t *testing.T) {
+	seen := &model.Job{ID: 1, Name: "前端开发工程师", JobSkill: "Vue.js,React,TypeScript"}
+	jobs := []*model.Job{
+		seen,
+		{ID: 2, Name: "销售经理", JobSkill: "客户开发"},
+		{ID: 3, Name: "React前端工程师", JobSkill: "React,TypeScript,Node.js"},
+		{ID: 4, Name: "新媒体运营", JobSkill: "文案"},
+	}
+	matches := Default.Rank(NewProfile(nil, []*model.Job{seen}), jobs, 2)
+	require.Len(t, matches, 2)
+	assert.Equal(t, uint(3), matches[0].Job.ID)
+	assert.Greater(t, matches[0].Score, 0.0)
+	assert.Equal(t, uint(2), matches[1].Job.ID)
+
+	// 没有简历和记录时按候选职位的顺序返回
+	matches = Default.Rank(NewProfile(nil, nil), jobs, 0)
+	require.Len(t, matches, 4)
+	for i, m := range matches {
+		assert.Equal(t, jobs[i].ID, m.Job.ID)
+		assert.Zero(t, m.Score)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	target := &model.Job{ID: 1, Name: "Go后端开发工程师", JobSkill: "Go,MySQL,Redis", JobCategory: "技术", JobLocation: "北京"}
+	jobs := []*model.Job{
+		target,
+		{ID: 2, Name: "新媒体运营", JobSkill: "文案", JobCategory: "运营", JobLocation: "上海"},
+		{ID: 3, Name: "Java开发工程师", JobSkill: "Java,MySQL,Redis", JobCategory: "技术", JobLocation: "上海"},
+		{ID: 4, Name: "高级Go开发工程师", JobSkill: "Go,MySQL,Docker", Tags: []string{"急招"}, JobCategory: "技术", JobLocation: "北京"},
+	}
+	matches := Similar(target, jobs, 10)
+	require.Len(t, matches, 2)
+	assert.Equal(t, uint(4), matches[0].Job.ID)
+	assert.Equal(t, uint(3), matches[1].Job.ID)
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+	assert.InDelta(t, 1.0, Similarity(target, target), 1e-9)
+}
+
+// TestEvaluate 离线评估：在 testdata/eval.json 上计算默认权重的 Precision@3 和 Recall@3，并与只按发布顺序推荐的基线比较
+// 调整权重或打分规则后可以运行 make eval-recommend 查看各求职者的结果
+func TestEvaluate(t *testing.T) {
+	fixture, err := LoadFixture("testdata/eval.json")
+	require.NoError(t, err)
+
+	const k = 3
+	m, err := Evaluate(Default, fixture, k)
+	require.NoError(t, err)
+	for _, u := range m.Users {
+		t.Logf("%s: hits=%d precision=%.2f recall=%.2f", u.Name, u.Hits, u.Precision, u.Recall)
+	}
+	t.Logf("Precision@%d=%.3f Recall@%d=%.3f", k, m.Precision, k, m.Recall)
+
+	baseline, err := Evaluate(Scorer{}, fixture, k)
+	require.NoError(t, err)
+	t.Logf("baseline Precision@%d=%.3f Recall@%d=%.3f", k, baseline.Precision, k, baseline.Recall)
+
+	assert.Len(t, m.Users, len(fixture.Users))
+	assert.GreaterOrEqual(t, m.Precision, 0.7)
+	assert.GreaterOrEqual(t, m.Recall, 0.8)
+	assert.Greater(t, m.Precision, baseline.Precision)
+	assert.Greater(t, m.Recall, baseline.Recall)
+}
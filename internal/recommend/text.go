@@ -0,0 +1,143 @@
+package recommend
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// isTermSeparator 技能列表的分隔符
+func isTermSeparator(r rune) bool {
+	switch r {
+	case ',', '，', '、', '/', ';', '；', '|':
+		return true
+	}
+	return unicode.IsSpace(r)
+}
+
+// normalize 统一大小写并去除首尾空白
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// tokenSet 将技能描述拆分为技能集合
+func tokenSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, term := range strings.FieldsFunc(normalize(s), isTermSeparator) {
+		set[term] = true
+	}
+	return set
+}
+
+// coverage 职位要求的技能中求职者具备的比例，职位没有技能要求时为 0
+func coverage(have, want map[string]bool) float64 {
+	if len(want) == 0 {
+		return 0
+	}
+	hit := 0
+	for term := range want {
+		if have[term] {
+			hit++
+		}
+	}
+	return float64(hit) / float64(len(want))
+}
+
+// jaccard 两个集合的 Jaccard 相似度
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for term := range a {
+		if b[term] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// bigrams 字符二元组集合，中文职位名称没有分词，按相邻字符比较
+func bigrams(s string) map[string]bool {
+	runes := []rune(strings.Join(strings.Fields(normalize(s)), ""))
+	set := make(map[string]bool, len(runes))
+	if len(runes) == 1 {
+		set[string(runes)] = true
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		set[string(runes[i:i+2])] = true
+	}
+	return set
+}
+
+// dice 两段文本的字符二元组 Dice 系数
+func dice(a, b string) float64 {
+	x, y := bigrams(a), bigrams(b)
+	if len(x) == 0 || len(y) == 0 {
+		return 0
+	}
+	inter := 0
+	for g := range x {
+		if y[g] {
+			inter++
+		}
+	}
+	return 2 * float64(inter) / float64(len(x)+len(y))
+}
+
+// titleScore 期望职位与职位名称的匹配度，一方包含另一方时完全匹配
+func titleScore(expected, name string) float64 {
+	e, n := normalize(expected), normalize(name)
+	if e == "" || n == "" {
+		return 0
+	}
+	if strings.Contains(n, e) || strings.Contains(e, n) {
+		return 1
+	}
+	return dice(e, n)
+}
+
+var yearsPattern = regexp.MustCompile(`\d+`)
+
+// experienceRange 解析经验要求中的年限范围，如 "3-5年"、"5年以上"、"1年以下"，"不限" 等无法解析时返回 false
+func experienceRange(text string) (min, max int, ok bool) {
+	nums := yearsPattern.FindAllString(text, 2)
+	switch len(nums) {
+	case 0:
+		return 0, 0, false
+	case 2:
+		a, _ := strconv.Atoi(nums[0])
+		b, _ := strconv.Atoi(nums[1])
+		if a > b {
+			a, b = b, a
+		}
+		return a, b, true
+	}
+	n, _ := strconv.Atoi(nums[0])
+	if strings.Contains(text, "以下") || strings.Contains(text, "以内") {
+		return 0, n, true
+	}
+	return n, math.MaxInt32, true
+}
+
+// experienceScore 工作年限与经验要求的匹配度：不足要求时每差一年扣 0.25，超出要求时每多一年扣 0.1 且不低于 0.5
+// 职位未写明经验要求时得满分，第二个返回值为 false；没有简历时得 0
+func experienceScore(years int, requirement string) (float64, bool) {
+	min, max, ok := experienceRange(requirement)
+	if years < 0 {
+		return 0, ok
+	}
+	if !ok {
+		return 1, false
+	}
+	switch {
+	case years < min:
+		return math.Max(0, 1-0.25*float64(min-years)), true
+	case years > max:
+		return math.Max(0.5, 1-0.1*float64(years-max)), true
+	default:
+		return 1, true
+	}
+}
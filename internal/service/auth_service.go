@@ -0,0 +1,170 @@
+package service
+
+import (
+	stderrors "errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// AuthService 令牌签发、刷新与吊销服务
+type AuthService struct {
+	sessionDAO      *dao.TokenSessionDAO
+	revokedTokenDAO *dao.RevokedTokenDAO
+	userDAO         *dao.UserDAO
+}
+
+// NewAuthService 创建认证服务实例
+func NewAuthService(sessionDAO *dao.TokenSessionDAO, revokedTokenDAO *dao.RevokedTokenDAO, userDAO *dao.UserDAO) *AuthService {
+	return &AuthService{
+		sessionDAO:      sessionDAO,
+		revokedTokenDAO: revokedTokenDAO,
+		userDAO:         userDAO,
+	}
+}
+
+// TokenClient 签发令牌时的客户端信息
+type TokenClient struct {
+	IP        string
+	UserAgent string
+}
+
+// IssueTokens 签发访问令牌与刷新令牌，并记录登录会话
+func (s *AuthService) IssueTokens(userID, companyID uint, username, role string, client TokenClient) (*utils.TokenPair, error) {
+	pair, err := utils.GenerateTokenPair(userID, companyID, username, role)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	session := newTokenSession(userID, pair, client)
+	if err := s.sessionDAO.Create(session); err != nil {
+		logger.L.Error("创建登录会话失败", zap.Error(err), zap.Uint("user_id", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return pair, nil
+}
+
+// Refresh 使用刷新令牌换取新的令牌对
+// 刷新令牌只能使用一次，旧的访问令牌与刷新令牌会一并吊销
+// 新令牌按用户当前的角色和所属企业签发，已禁用的用户不能刷新
+func (s *AuthService) Refresh(refreshToken string, client TokenClient) (*utils.TokenPair, error) {
+	claims, err := utils.ParseToken(refreshToken)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	if !claims.IsRefresh() {
+		return nil, errors.New(errors.InvalidToken)
+	}
+
+	session, err := s.sessionDAO.GetByRefreshJTI(claims.Id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.InvalidToken)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if session.IsRevoked() || session.UserID != claims.UserID {
+		return nil, errors.New(errors.TokenRevoked)
+	}
+
+	user, err := s.userDAO.GetByID(claims.UserID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.InvalidToken)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if !user.IsActive() {
+		return nil, errors.New(errors.UserDisabled)
+	}
+
+	pair, err := utils.GenerateTokenPair(user.ID, user.CompanyID, user.Username, user.UserType.Code())
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	next := newTokenSession(user.ID, pair, client)
+	if err := s.sessionDAO.Rotate(session, next); err != nil {
+		// 旧会话已被并发刷新吊销
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.TokenRevoked)
+		}
+		logger.L.Error("刷新令牌失败", zap.Error(err), zap.Uint("session_id", session.ID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return pair, nil
+}
+
+// Logout 退出当前登录，吊销当前访问令牌所属会话
+func (s *AuthService) Logout(userID uint, accessJTI string, expiresAt time.Time) error {
+	session, err := s.sessionDAO.GetByAccessJTI(accessJTI)
+	if err == nil {
+		if session.UserID != userID {
+			return errors.New(errors.Forbidden)
+		}
+		if err := s.sessionDAO.Revoke(session, model.RevokeReasonLogout); err != nil {
+			return errors.Wrap(err, errors.InternalServerError)
+		}
+		return nil
+	}
+	if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+
+	// 没有会话记录的令牌(如直接签发的访问令牌)仅加入黑名单
+	token := &model.RevokedToken{
+		JTI:       accessJTI,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		Reason:    model.RevokeReasonLogout,
+	}
+	if err := s.revokedTokenDAO.Create(token); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// LogoutAll 退出所有设备，吊销用户全部会话，返回吊销的会话数量
+func (s *AuthService) LogoutAll(userID uint) (int64, error) {
+	count, err := s.sessionDAO.RevokeAllByUser(userID, model.RevokeReasonLogoutAll)
+	if err != nil {
+		logger.L.Error("吊销用户会话失败", zap.Error(err), zap.Uint("user_id", userID))
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	return count, nil
+}
+
+// newTokenSession 根据令牌对构建会话记录
+func newTokenSession(userID uint, pair *utils.TokenPair, client TokenClient) *model.TokenSession {
+	return &model.TokenSession{
+		UserID:           userID,
+		AccessJTI:        pair.AccessJTI,
+		RefreshJTI:       pair.RefreshJTI,
+		AccessExpiresAt:  pair.AccessExpiresAt,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+		ClientIP:         client.IP,
+		UserAgent:        client.UserAgent,
+	}
+}
+
+// tokenError 将令牌解析错误转换为业务错误
+func tokenError(err error) *errors.Error {
+	switch {
+	case stderrors.Is(err, utils.ErrTokenExpired):
+		return errors.New(errors.TokenExpired)
+	case stderrors.Is(err, utils.ErrTokenMalformed):
+		return errors.New(errors.TokenMalformed)
+	case stderrors.Is(err, utils.ErrTokenRevoked):
+		return errors.New(errors.TokenRevoked)
+	case stderrors.Is(err, utils.ErrTokenInvalid):
+		return errors.New(errors.InvalidToken)
+	default:
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+}
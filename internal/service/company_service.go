@@ -0,0 +1,297 @@
+package service
+
+import (
+	stderrors "errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// CompanyService 公司与成员服务
+type CompanyService struct {
+	companyDAO *dao.CompanyDAO
+	memberDAO  *dao.CompanyMemberDAO
+	userDAO    *dao.UserDAO
+}
+
+// NewCompanyService 创建公司服务实例
+func NewCompanyService(companyDAO *dao.CompanyDAO, memberDAO *dao.CompanyMemberDAO, userDAO *dao.UserDAO) *CompanyService {
+	return &CompanyService{
+		companyDAO: companyDAO,
+		memberDAO:  memberDAO,
+		userDAO:    userDAO,
+	}
+}
+
+// Create 创建公司，创建人成为公司所有者
+func (s *CompanyService) Create(company *model.Company, userID uint) error {
+	company.CreatedBy = userID
+	company.Status = model.CompanyStatusActive
+	company.VerifyStatus = model.CompanyVerifyPending
+
+	if err := s.companyDAO.CreateWithOwner(company); err != nil {
+		logger.L.Error("创建公司失败",
+			zap.Error(err),
+			zap.String("name", company.Name),
+			zap.Uint("user_id", userID))
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// GetByID 获取公司信息
+func (s *CompanyService) GetByID(id uint) (*model.Company, error) {
+	company, err := s.companyDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.CompanyNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return company, nil
+}
+
+// EnsureActive 验证公司存在且处于正常状态
+func (s *CompanyService) EnsureActive(id uint) (*model.Company, error) {
+	company, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !company.IsActive() {
+		return nil, errors.New(errors.CompanyInactive)
+	}
+	return company, nil
+}
+
+// UpdateProfile 更新公司资料，仅公司所有者可以操作
+func (s *CompanyService) UpdateProfile(company *model.Company, userID uint) error {
+	if _, err := s.CheckMember(company.ID, userID, model.CompanyManagers...); err != nil {
+		return err
+	}
+	if err := s.companyDAO.UpdateProfile(company); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// Verify 审核公司认证信息，由管理员操作
+func (s *CompanyService) Verify(id uint, status model.CompanyVerifyStatus, remark string) error {
+	if !status.IsValid() {
+		return errors.New(errors.InvalidParams)
+	}
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"verify_status": status,
+		"verify_remark": remark,
+		"verified_at":   nil,
+	}
+	if status == model.CompanyVerified {
+		updates["verified_at"] = time.Now()
+	}
+	if err := s.companyDAO.UpdateVerifyStatus(id, updates); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// CheckMember 验证用户是公司成员且角色在允许列表中，返回成员记录
+func (s *CompanyService) CheckMember(companyID, userID uint, roles ...model.CompanyRole) (*model.CompanyMember, error) {
+	if companyID == 0 || userID == 0 {
+		return nil, errors.New(errors.CompanyAccessDenied)
+	}
+	member, err := s.memberDAO.Get(companyID, userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			logger.L.Warn("非公司成员尝试访问公司资源",
+				zap.Uint("companyId", companyID),
+				zap.Uint("userId", userID))
+			return nil, errors.New(errors.CompanyAccessDenied)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if !member.HasRole(roles...) {
+		logger.L.Warn("公司成员角色无权执行该操作",
+			zap.Uint("companyId", companyID),
+			zap.Uint("userId", userID),
+			zap.String("role", string(member.Role)))
+		return nil, errors.New(errors.CompanyAccessDenied)
+	}
+	return member, nil
+}
+
+// IsMember 判断用户是否为公司成员，供路由层的公司访问校验使用
+func (s *CompanyService) IsMember(companyID, userID uint) (bool, error) {
+	_, err := s.memberDAO.Get(companyID, userID)
+	if err == nil {
+		return true, nil
+	}
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// ListMembers 获取公司成员列表
+func (s *CompanyService) ListMembers(companyID uint) ([]model.CompanyMember, error) {
+	members, err := s.memberDAO.ListByCompany(companyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return members, nil
+}
+
+// ListMemberships 获取用户加入的公司及对应的成员记录
+func (s *CompanyService) ListMemberships(userID uint) ([]model.CompanyMember, map[uint]*model.Company, error) {
+	members, err := s.memberDAO.ListByUser(userID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	ids := make([]uint, len(members))
+	for i, m := range members {
+		ids[i] = m.CompanyID
+	}
+	companyMap, err := s.GetCompanyMap(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return members, companyMap, nil
+}
+
+// GetCompanyMap 批量获取公司，以公司ID为键
+func (s *CompanyService) GetCompanyMap(ids []uint) (map[uint]*model.Company, error) {
+	companies, err := s.companyDAO.GetByIDs(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	companyMap := make(map[uint]*model.Company, len(companies))
+	for i := range companies {
+		companyMap[companies[i].ID] = &companies[i]
+	}
+	return companyMap, nil
+}
+
+// ListMemberUserIDs 获取公司中指定角色的成员用户ID，用于向公司侧发送通知
+func (s *CompanyService) ListMemberUserIDs(companyID uint, roles ...model.CompanyRole) ([]uint, error) {
+	return s.memberDAO.ListUserIDsByRoles(companyID, roles)
+}
+
+// AddMember 添加公司成员，仅公司所有者可以操作
+func (s *CompanyService) AddMember(companyID, operatorID, userID uint, role model.CompanyRole) (*model.CompanyMember, error) {
+	if !role.IsValid() {
+		return nil, errors.New(errors.InvalidParams)
+	}
+	if _, err := s.CheckMember(companyID, operatorID, model.CompanyManagers...); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userDAO.GetByID(userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.UserNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	// 只有企业侧用户可以加入公司
+	if !user.UserType.IsCompanySide() {
+		return nil, errors.New(errors.InvalidParams).WithMessage("只有招聘者或企业用户可以加入公司")
+	}
+
+	if exists, err := s.IsMember(companyID, userID); err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	} else if exists {
+		return nil, errors.New(errors.CompanyMemberExists)
+	}
+
+	member := &model.CompanyMember{
+		CompanyID: companyID,
+		UserID:    userID,
+		Role:      role,
+		InvitedBy: operatorID,
+	}
+	if err := s.memberDAO.Create(member); err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return member, nil
+}
+
+// UpdateMemberRole 修改成员角色，仅公司所有者可以操作，不能移除最后一名所有者
+func (s *CompanyService) UpdateMemberRole(companyID, operatorID, userID uint, role model.CompanyRole) error {
+	if !role.IsValid() {
+		return errors.New(errors.InvalidParams)
+	}
+	if _, err := s.CheckMember(companyID, operatorID, model.CompanyManagers...); err != nil {
+		return err
+	}
+
+	member, err := s.getMember(companyID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == model.CompanyRoleOwner && role != model.CompanyRoleOwner {
+		if err := s.ensureAnotherOwner(companyID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.memberDAO.UpdateRole(companyID, userID, role); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// RemoveMember 移除公司成员，公司所有者可以移除任何成员，成员可以自行退出
+func (s *CompanyService) RemoveMember(companyID, operatorID, userID uint) error {
+	if operatorID != userID {
+		if _, err := s.CheckMember(companyID, operatorID, model.CompanyManagers...); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.getMember(companyID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == model.CompanyRoleOwner {
+		if err := s.ensureAnotherOwner(companyID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.memberDAO.Delete(companyID, userID); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// getMember 获取公司成员记录
+func (s *CompanyService) getMember(companyID, userID uint) (*model.CompanyMember, error) {
+	member, err := s.memberDAO.Get(companyID, userID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.CompanyMemberNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return member, nil
+}
+
+// ensureAnotherOwner 确认公司除当前所有者外还有其他所有者
+func (s *CompanyService) ensureAnotherOwner(companyID uint) error {
+	owners, err := s.memberDAO.CountByRole(companyID, model.CompanyRoleOwner)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if owners <= 1 {
+		return errors.New(errors.CompanyLastOwner)
+	}
+	return nil
+}
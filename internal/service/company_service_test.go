@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/testutil"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// TestCompanyService_CreateAndMembers 测试创建公司与成员角色校验
+func TestCompanyService_CreateAndMembers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+
+	company := &model.Company{Name: "测试公司"}
+	assert.NoError(t, service.Create(company, 1001))
+	assert.NotZero(t, company.ID)
+
+	// 创建人成为所有者
+	member, err := service.CheckMember(company.ID, 1001, model.CompanyManagers...)
+	assert.NoError(t, err)
+	assert.Equal(t, model.CompanyRoleOwner, member.Role)
+
+	// 非成员无权访问
+	_, err = service.CheckMember(company.ID, 1002, model.CompanyReaders...)
+	assert.Equal(t, errors.CompanyAccessDenied, err.(*errors.Error).Code)
+
+	// 不能移除最后一名所有者
+	err = service.RemoveMember(company.ID, 1001, 1001)
+	assert.Equal(t, errors.CompanyLastOwner, err.(*errors.Error).Code)
+}
@@ -0,0 +1,542 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// 面试通知模板
+const TemplateInterviewInvite = "interview_invite"
+
+// interviewTimeLayout 通知中展示的面试时间格式
+const interviewTimeLayout = "2006-01-02 15:04"
+
+// InterviewService 面试安排服务
+type InterviewService struct {
+	interviewDAO        *dao.InterviewDAO
+	jobApplyDAO         *dao.JobApplyDAO
+	jobService          *JobService
+	companyService      *CompanyService
+	pipelineService     *JobPipelineService
+	userService         *UserService
+	notificationService *NotificationService
+}
+
+// NewInterviewService 创建面试安排服务实例
+func NewInterviewService(interviewDAO *dao.InterviewDAO, jobApplyDAO *dao.JobApplyDAO, jobService *JobService, companyService *CompanyService,
+	pipelineService *JobPipelineService, userService *UserService, notificationService *NotificationService) *InterviewService {
+	return &InterviewService{
+		interviewDAO:        interviewDAO,
+		jobApplyDAO:         jobApplyDAO,
+		jobService:          jobService,
+		companyService:      companyService,
+		pipelineService:     pipelineService,
+		userService:         userService,
+		notificationService: notificationService,
+	}
+}
+
+// InterviewSchedule 面试安排内容，用于新建和改期
+type InterviewSchedule struct {
+	Round          int                 // 面试轮次，为0时自动取下一轮
+	Title          string              // 面试标题
+	Mode           model.InterviewMode // 面试方式
+	StartTime      time.Time           // 开始时间
+	EndTime        time.Time           // 结束时间
+	Location       string              // 面试地点
+	MeetingURL     string              // 视频会议链接
+	Remark         string              // 面试说明
+	InterviewerIDs []uint              // 面试官用户ID
+}
+
+// Schedule 为申请安排面试，仅申请所属公司的所有者或招聘者可以操作
+// 面试官须为公司成员，面试官或候选人在该时段已有面试时拒绝安排
+func (s *InterviewService) Schedule(applyID, userID uint, schedule InterviewSchedule) (*response.InterviewResponse, error) {
+	apply, err := s.jobApplyDAO.GetByID(applyID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobApplicationNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+
+	// 已结束的申请不能再安排面试
+	pipeline, _, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline.IsTerminal(enums.JobApplyEnum(apply.Status)) {
+		return nil, errors.New(errors.InterviewNotAllowed).
+			WithMessage(fmt.Sprintf("申请已处于「%s」，不能安排面试", enums.GetStatusText(apply.Status)))
+	}
+
+	if err := s.validateSchedule(apply.CompanyID, &schedule); err != nil {
+		return nil, err
+	}
+
+	if schedule.Round == 0 {
+		maxRound, err := s.interviewDAO.MaxRound(applyID)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.InternalServerError)
+		}
+		schedule.Round = maxRound + 1
+	}
+	uid, err := utils.GenerateNanoID()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	interview := &model.Interview{
+		ApplyID:     apply.ID,
+		JobID:       apply.JobID,
+		CompanyID:   apply.CompanyID,
+		CandidateID: apply.UserID,
+		Status:      model.InterviewScheduled,
+		UID:         fmt.Sprintf("interview-%s@recruit-center", uid),
+		CreatedBy:   userID,
+	}
+	applySchedule(interview, schedule)
+
+	conflicts, err := s.interviewDAO.Create(interview)
+	if err != nil {
+		logger.L.Error("创建面试失败",
+			zap.Error(err),
+			zap.Uint("applyId", applyID),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if len(conflicts) > 0 {
+		return nil, conflictError(conflicts, schedule.InterviewerIDs, apply.UserID)
+	}
+
+	s.notify(interview, "面试邀请", "")
+	return s.toResponse(interview)
+}
+
+// Reschedule 修改面试时间、方式、地点或面试官，日历修订序号递增并重新发送邀请
+func (s *InterviewService) Reschedule(id, userID uint, schedule InterviewSchedule) (*response.InterviewResponse, error) {
+	interview, err := s.getForWrite(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSchedule(interview.CompanyID, &schedule); err != nil {
+		return nil, err
+	}
+
+	previous := interview.StartTime
+	applySchedule(interview, schedule)
+	interview.Sequence++
+	conflicts, err := s.interviewDAO.Reschedule(interview)
+	if err != nil {
+		logger.L.Error("面试改期失败",
+			zap.Error(err),
+			zap.Uint("interviewId", id),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if len(conflicts) > 0 {
+		return nil, conflictError(conflicts, schedule.InterviewerIDs, interview.CandidateID)
+	}
+
+	s.notify(interview, "面试时间变更", fmt.Sprintf("原定于 %s 的面试已调整。", previous.Local().Format(interviewTimeLayout)))
+	return s.toResponse(interview)
+}
+
+// Cancel 取消面试，日历修订序号递增并发送取消通知
+func (s *InterviewService) Cancel(id, userID uint, reason string) error {
+	interview, err := s.getForWrite(id, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	interview.Sequence++
+	affected, err := s.interviewDAO.UpdateStatus(id, model.InterviewCancelled, map[string]interface{}{
+		"sequence":      interview.Sequence,
+		"cancel_reason": reason,
+		"cancelled_at":  now,
+	})
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return errors.New(errors.InterviewNotAllowed).WithMessage("面试已完成或已取消")
+	}
+	interview.Status = model.InterviewCancelled
+	interview.CancelReason = reason
+	interview.CancelledAt = &now
+
+	note := ""
+	if reason != "" {
+		note = "取消原因：" + reason
+	}
+	s.notify(interview, "面试取消", note)
+	return nil
+}
+
+// Complete 将面试标记为已完成
+func (s *InterviewService) Complete(id, userID uint) error {
+	interview, err := s.getForWrite(id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := s.interviewDAO.UpdateStatus(interview.ID, model.InterviewCompleted, nil)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return errors.New(errors.InterviewNotAllowed).WithMessage("面试已完成或已取消")
+	}
+	return nil
+}
+
+// Get 获取面试详情
+func (s *InterviewService) Get(id, userID uint, userType model.UserType) (*response.InterviewResponse, error) {
+	interview, err := s.getForRead(id, userID, userType)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(interview)
+}
+
+// ListByApply 获取申请的所有面试，候选人只能查看本人的申请，企业侧须为申请所属公司的成员
+func (s *InterviewService) ListByApply(applyID, userID uint, userType model.UserType) ([]*response.InterviewResponse, error) {
+	apply, err := s.jobApplyDAO.GetByID(applyID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobApplicationNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if err := s.authorizeRead(apply.CompanyID, apply.UserID, userID, userType); err != nil {
+		return nil, err
+	}
+
+	interviews, err := s.interviewDAO.ListByApply(applyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return s.toResponses(interviews)
+}
+
+// ListByInterviewer 获取面试官在时间范围内已安排的面试，即面试官日程
+func (s *InterviewService) ListByInterviewer(userID uint, from, to time.Time) ([]*response.InterviewResponse, error) {
+	if !to.After(from) {
+		return nil, errors.New(errors.InvalidParams).WithMessage("结束时间必须晚于开始时间")
+	}
+	interviews, err := s.interviewDAO.ListByInterviewer(userID, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return s.toResponses(interviews)
+}
+
+// ICS 生成面试的 iCalendar 文件，已取消的面试生成取消事件
+func (s *InterviewService) ICS(id, userID uint, userType model.UserType) ([]byte, error) {
+	interview, err := s.getForRead(id, userID, userType)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.calendarEvent(interview)
+	if err != nil {
+		return nil, err
+	}
+	return utils.BuildICS(event), nil
+}
+
+// validateSchedule 校验面试安排内容，面试官须为公司成员
+func (s *InterviewService) validateSchedule(companyID uint, schedule *InterviewSchedule) error {
+	if !schedule.Mode.IsValid() {
+		return errors.New(errors.InvalidParams).WithMessage("无效的面试方式")
+	}
+	if !schedule.EndTime.After(schedule.StartTime) {
+		return errors.New(errors.InvalidParams).WithMessage("面试结束时间必须晚于开始时间")
+	}
+	if !schedule.StartTime.After(time.Now()) {
+		return errors.New(errors.InvalidParams).WithMessage("面试开始时间必须晚于当前时间")
+	}
+	if schedule.Mode == model.InterviewVideo && schedule.MeetingURL == "" {
+		return errors.New(errors.InvalidParams).WithMessage("视频面试需要提供会议链接")
+	}
+	if schedule.Mode == model.InterviewOnsite && schedule.Location == "" {
+		return errors.New(errors.InvalidParams).WithMessage("现场面试需要提供面试地点")
+	}
+
+	schedule.InterviewerIDs = uniqueIDs(schedule.InterviewerIDs)
+	for _, interviewerID := range schedule.InterviewerIDs {
+		if _, err := s.companyService.CheckMember(companyID, interviewerID, model.CompanyReaders...); err != nil {
+			return errors.New(errors.InvalidParams).WithMessage(fmt.Sprintf("面试官(用户ID %d)不是公司成员", interviewerID))
+		}
+	}
+	return nil
+}
+
+// conflictError 面试官和候选人的日程冲突错误，冲突的面试放在错误详情中返回
+func conflictError(conflicts []model.Interview, interviewerIDs []uint, candidateID uint) error {
+	requested := make(map[uint]bool, len(interviewerIDs))
+	for _, id := range interviewerIDs {
+		requested[id] = true
+	}
+
+	details := make([]response.InterviewConflictResponse, 0, len(conflicts))
+	var descriptions []string
+	for _, c := range conflicts {
+		detail := response.InterviewConflictResponse{
+			InterviewID: c.ID,
+			StartTime:   c.StartTime,
+			EndTime:     c.EndTime,
+			Candidate:   c.CandidateID == candidateID,
+		}
+		for _, v := range c.Interviewers {
+			if requested[v.UserID] {
+				detail.InterviewerIDs = append(detail.InterviewerIDs, v.UserID)
+			}
+		}
+		details = append(details, detail)
+		descriptions = append(descriptions, fmt.Sprintf("%s-%s",
+			c.StartTime.Local().Format(interviewTimeLayout), c.EndTime.Local().Format("15:04")))
+	}
+
+	return errors.New(errors.InterviewConflict).
+		WithMessage("面试官或候选人在以下时段已有面试安排：" + strings.Join(descriptions, "、")).
+		WithDetails(details)
+}
+
+// getInterview 获取面试
+func (s *InterviewService) getInterview(id uint) (*model.Interview, error) {
+	interview, err := s.interviewDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.InterviewNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return interview, nil
+}
+
+// getForRead 获取面试并校验查看权限
+func (s *InterviewService) getForRead(id, userID uint, userType model.UserType) (*model.Interview, error) {
+	interview, err := s.getInterview(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeRead(interview.CompanyID, interview.CandidateID, userID, userType); err != nil {
+		return nil, err
+	}
+	return interview, nil
+}
+
+// getForWrite 获取已安排的面试并校验操作人是公司的所有者或招聘者
+func (s *InterviewService) getForWrite(id, userID uint) (*model.Interview, error) {
+	interview, err := s.getInterview(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.companyService.CheckMember(interview.CompanyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+	if !interview.IsScheduled() {
+		return nil, errors.New(errors.InterviewNotAllowed).
+			WithMessage(fmt.Sprintf("面试%s，不能修改", interview.Status.String()))
+	}
+	return interview, nil
+}
+
+// authorizeRead 管理员可查看所有面试，企业侧须为公司成员，求职者只能查看本人的面试
+func (s *InterviewService) authorizeRead(companyID, candidateID, userID uint, userType model.UserType) error {
+	switch {
+	case userType == model.UserTypeAdmin:
+		return nil
+	case userType.IsCompanySide():
+		_, err := s.companyService.CheckMember(companyID, userID, model.CompanyReaders...)
+		return err
+	case candidateID == userID:
+		return nil
+	default:
+		return errors.New(errors.Forbidden)
+	}
+}
+
+// applySchedule 将面试安排内容写入面试
+func applySchedule(interview *model.Interview, schedule InterviewSchedule) {
+	if schedule.Round > 0 {
+		interview.Round = schedule.Round
+	}
+	interview.Title = schedule.Title
+	interview.Mode = schedule.Mode
+	interview.StartTime = schedule.StartTime
+	interview.EndTime = schedule.EndTime
+	interview.Location = schedule.Location
+	interview.MeetingURL = schedule.MeetingURL
+	interview.Remark = schedule.Remark
+	interview.Interviewers = make([]model.InterviewInterviewer, len(schedule.InterviewerIDs))
+	for i, id := range schedule.InterviewerIDs {
+		interview.Interviewers[i] = model.InterviewInterviewer{InterviewID: interview.ID, UserID: id}
+	}
+}
+
+// calendarEvent 构建面试的日历事件，组织者为公司，参与人为候选人和面试官
+func (s *InterviewService) calendarEvent(interview *model.Interview) (utils.CalendarEvent, error) {
+	company, err := s.companyService.GetByID(interview.CompanyID)
+	if err != nil {
+		return utils.CalendarEvent{}, err
+	}
+	jobs, err := s.jobService.GetJobMap([]uint{interview.JobID})
+	if err != nil {
+		return utils.CalendarEvent{}, err
+	}
+	users, err := s.userService.GetUserMap(append([]uint{interview.CandidateID}, interview.InterviewerIDs()...))
+	if err != nil {
+		return utils.CalendarEvent{}, err
+	}
+
+	jobName := ""
+	if job, ok := jobs[interview.JobID]; ok {
+		jobName = job.Name
+	}
+	summary := fmt.Sprintf("%s 第%d轮面试", company.Name, interview.Round)
+	if jobName != "" {
+		summary = fmt.Sprintf("%s - %s 第%d轮面试", company.Name, jobName, interview.Round)
+	}
+	if interview.Title != "" {
+		summary += "(" + interview.Title + ")"
+	}
+
+	location := interview.Location
+	if location == "" && interview.Mode == model.InterviewPhone {
+		location = "电话面试"
+	}
+
+	event := utils.CalendarEvent{
+		UID:         interview.UID,
+		Sequence:    interview.Sequence,
+		Method:      utils.CalendarMethodRequest,
+		Start:       interview.StartTime,
+		End:         interview.EndTime,
+		Summary:     summary,
+		Description: interview.Remark,
+		Location:    location,
+		URL:         interview.MeetingURL,
+		Organizer:   utils.CalendarAttendee{Name: company.Name, Email: company.ContactEmail},
+	}
+	if interview.Status == model.InterviewCancelled {
+		event.Method = utils.CalendarMethodCancel
+	}
+	for _, id := range append([]uint{interview.CandidateID}, interview.InterviewerIDs()...) {
+		if u, ok := users[id]; ok {
+			event.Attendees = append(event.Attendees, utils.CalendarAttendee{Name: u.DisplayName(), Email: u.Email})
+		}
+	}
+	return event, nil
+}
+
+// notify 向候选人和面试官发送面试邀请、改期或取消通知，发送失败只记录日志
+// 候选人的首次邀请使用 interview_invite 模板，通知变量中附带日历文件下载地址和修订序号
+func (s *InterviewService) notify(interview *model.Interview, title, note string) {
+	companyName, jobName := "", "该职位"
+	if company, err := s.companyService.GetByID(interview.CompanyID); err == nil {
+		companyName = company.Name
+	}
+	if jobs, err := s.jobService.GetJobMap([]uint{interview.JobID}); err == nil {
+		if job, ok := jobs[interview.JobID]; ok {
+			jobName = job.Name
+		}
+	}
+	interviewTime := interview.StartTime.Local().Format(interviewTimeLayout)
+	icsURL := fmt.Sprintf("/api/v1/interviews/%d/ics", interview.ID)
+
+	if interview.Sequence == 0 && interview.IsScheduled() {
+		err := s.notificationService.SendNotification(interview.CandidateID, model.UserTypeJobSeeker, TemplateInterviewInvite, map[string]interface{}{
+			"companyName":   companyName,
+			"jobName":       jobName,
+			"interviewTime": interviewTime,
+			"icsUrl":        icsURL,
+			"sequence":      interview.Sequence,
+		})
+		if err == nil {
+			s.notifyInterviewers(interview, title, jobName, interviewTime, note)
+			return
+		}
+		logger.L.Warn("面试邀请模板发送失败，改用站内通知", zap.Error(err), zap.Uint("interviewId", interview.ID))
+	}
+
+	content := fmt.Sprintf("%s %s 第%d轮面试，时间：%s。", companyName, jobName, interview.Round, interviewTime)
+	if note != "" {
+		content += note
+	}
+	if interview.IsScheduled() {
+		content += "日历文件：" + icsURL
+	}
+	if err := s.notificationService.Create(&model.Notification{
+		UserID:   interview.CandidateID,
+		UserType: model.UserTypeJobSeeker,
+		Type:     model.NotificationTypeInterview,
+		Title:    title,
+		Content:  content,
+		Channels: model.ChannelInApp,
+	}); err != nil {
+		logger.L.Error("发送候选人面试通知失败", zap.Error(err), zap.Uint("interviewId", interview.ID))
+	}
+	s.notifyInterviewers(interview, title, jobName, interviewTime, note)
+}
+
+// notifyInterviewers 批量通知面试官
+func (s *InterviewService) notifyInterviewers(interview *model.Interview, title, jobName, interviewTime, note string) {
+	content := fmt.Sprintf("职位 %s 第%d轮面试，时间：%s。%s", jobName, interview.Round, interviewTime, note)
+	notifications := make([]model.Notification, 0, len(interview.Interviewers))
+	for _, v := range interview.Interviewers {
+		notifications = append(notifications, model.Notification{
+			UserID:   v.UserID,
+			UserType: model.UserTypeRecruiter,
+			Type:     model.NotificationTypeInterview,
+			Title:    title,
+			Content:  content,
+			Channels: model.ChannelInApp,
+		})
+	}
+	if err := s.notificationService.CreateBatch(notifications); err != nil {
+		logger.L.Error("发送面试官通知失败", zap.Error(err), zap.Uint("interviewId", interview.ID))
+	}
+}
+
+// toResponse 转换为面试响应，附带面试官姓名
+func (s *InterviewService) toResponse(interview *model.Interview) (*response.InterviewResponse, error) {
+	responses, err := s.toResponses([]model.Interview{*interview})
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
+}
+
+// toResponses 批量转换为面试响应，面试官姓名一次性查询
+func (s *InterviewService) toResponses(interviews []model.Interview) ([]*response.InterviewResponse, error) {
+	var userIDs []uint
+	for i := range interviews {
+		userIDs = append(userIDs, interviews[i].InterviewerIDs()...)
+	}
+	users, err := s.userService.GetUserMap(uniqueIDs(userIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*response.InterviewResponse, len(interviews))
+	for i := range interviews {
+		result[i] = response.NewInterviewResponse(&interviews[i], users)
+	}
+	return result, nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// mentionPreviewLength 提及通知中备注内容的最大长度
+const mentionPreviewLength = 100
+
+// JobApplyNoteService 申请私有备注服务，备注仅公司成员可见
+type JobApplyNoteService struct {
+	noteDAO             *dao.JobApplyNoteDAO
+	jobApplyDAO         *dao.JobApplyDAO
+	companyService      *CompanyService
+	userService         *UserService
+	notificationService *NotificationService
+}
+
+// NewJobApplyNoteService 创建申请备注服务实例
+func NewJobApplyNoteService(noteDAO *dao.JobApplyNoteDAO, jobApplyDAO *dao.JobApplyDAO, companyService *CompanyService,
+	userService *UserService, notificationService *NotificationService) *JobApplyNoteService {
+	return &JobApplyNoteService{
+		noteDAO:             noteDAO,
+		jobApplyDAO:         jobApplyDAO,
+		companyService:      companyService,
+		userService:         userService,
+		notificationService: notificationService,
+	}
+}
+
+// List 获取申请的备注，顶层备注按时间正序，回复挂在所属顶层备注下
+func (s *JobApplyNoteService) List(applyID, userID uint) ([]response.JobApplyNoteResponse, error) {
+	if _, err := s.getApply(applyID, userID); err != nil {
+		return nil, err
+	}
+	notes, err := s.noteDAO.ListByApply(applyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	users, err := s.noteUsers(notes...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]response.JobApplyNoteResponse, 0, len(notes))
+	index := make(map[uint]int, len(notes))
+	for i := range notes {
+		if notes[i].IsReply() {
+			continue
+		}
+		index[notes[i].ID] = len(resp)
+		resp = append(resp, response.NewJobApplyNoteResponse(&notes[i], users))
+	}
+	for i := range notes {
+		if !notes[i].IsReply() {
+			continue
+		}
+		if pos, ok := index[*notes[i].ParentID]; ok {
+			resp[pos].Replies = append(resp[pos].Replies, response.NewJobApplyNoteResponse(&notes[i], users))
+		}
+	}
+	return resp, nil
+}
+
+// Create 添加备注或回复，公司成员均可添加，被提及的成员会收到通知
+// 回复只能针对同一申请的顶层备注，提及的用户须为公司成员
+func (s *JobApplyNoteService) Create(applyID, userID uint, note *model.JobApplyNote) (*response.JobApplyNoteResponse, error) {
+	apply, err := s.getApply(applyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if note.ParentID != nil {
+		parent, err := s.getNote(applyID, *note.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.IsReply() {
+			return nil, errors.New(errors.InvalidParams).WithMessage("只能回复顶层备注")
+		}
+	}
+	if note.Mentions, err = s.validateMentions(apply.CompanyID, userID, note.Mentions); err != nil {
+		return nil, err
+	}
+
+	note.ApplyID = apply.ID
+	note.CompanyID = apply.CompanyID
+	note.AuthorID = userID
+	if err := s.noteDAO.Create(note); err != nil {
+		logger.L.Error("添加申请备注失败",
+			zap.Error(err),
+			zap.Uint("applyId", applyID),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	s.notifyMentions(note, userID, note.Mentions)
+	return s.toResponse(note)
+}
+
+// Update 修改备注内容及提及的成员，只有作者可以修改，新提及的成员会收到通知
+func (s *JobApplyNoteService) Update(applyID, noteID, userID uint, content string, mentions []uint) (*response.JobApplyNoteResponse, error) {
+	apply, err := s.getApply(applyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	note, err := s.getNote(applyID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if note.AuthorID != userID {
+		return nil, errors.New(errors.Forbidden).WithMessage("只能修改自己的备注")
+	}
+	if mentions, err = s.validateMentions(apply.CompanyID, userID, mentions); err != nil {
+		return nil, err
+	}
+
+	previous := make(map[uint]bool, len(note.Mentions))
+	for _, id := range note.Mentions {
+		previous[id] = true
+	}
+	var added []uint
+	for _, id := range mentions {
+		if !previous[id] {
+			added = append(added, id)
+		}
+	}
+
+	note.Content = content
+	note.Mentions = mentions
+	if err := s.noteDAO.Update(note); err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	s.notifyMentions(note, userID, added)
+	return s.toResponse(note)
+}
+
+// Delete 删除备注，作者或公司所有者可以删除，删除顶层备注时回复一并删除
+func (s *JobApplyNoteService) Delete(applyID, noteID, userID uint) error {
+	apply, err := s.getApply(applyID, userID)
+	if err != nil {
+		return err
+	}
+	note, err := s.getNote(applyID, noteID)
+	if err != nil {
+		return err
+	}
+	if note.AuthorID != userID {
+		if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyManagers...); err != nil {
+			return errors.New(errors.Forbidden).WithMessage("只有作者或公司所有者可以删除备注")
+		}
+	}
+	if err := s.noteDAO.Delete(note.ID); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// getApply 获取申请并校验操作人是申请所属公司的成员
+func (s *JobApplyNoteService) getApply(applyID, userID uint) (*model.JobApply, error) {
+	apply, err := s.jobApplyDAO.GetByID(applyID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobApplicationNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyReaders...); err != nil {
+		return nil, err
+	}
+	return apply, nil
+}
+
+// getNote 获取申请下的备注
+func (s *JobApplyNoteService) getNote(applyID, noteID uint) (*model.JobApplyNote, error) {
+	note, err := s.noteDAO.GetByID(noteID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.ApplyNoteNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if note.ApplyID != applyID {
+		return nil, errors.New(errors.ApplyNoteNotFound)
+	}
+	return note, nil
+}
+
+// validateMentions 校验提及的用户均为公司成员，去重并忽略作者本人
+func (s *JobApplyNoteService) validateMentions(companyID, authorID uint, mentions []uint) ([]uint, error) {
+	result := make([]uint, 0, len(mentions))
+	for _, id := range uniqueIDs(mentions) {
+		if id == authorID {
+			continue
+		}
+		if _, err := s.companyService.CheckMember(companyID, id, model.CompanyReaders...); err != nil {
+			return nil, errors.New(errors.InvalidParams).WithMessage(fmt.Sprintf("用户 %d 不是公司成员，不能提及", id))
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// notifyMentions 通知被提及的成员，发送失败只记录日志
+func (s *JobApplyNoteService) notifyMentions(note *model.JobApplyNote, authorID uint, userIDs []uint) {
+	if len(userIDs) == 0 {
+		return
+	}
+	authorName := "同事"
+	if author, err := s.userService.GetByID(authorID); err == nil {
+		authorName = author.DisplayName()
+	}
+	preview := note.Content
+	if utf8.RuneCountInString(preview) > mentionPreviewLength {
+		preview = string([]rune(preview)[:mentionPreviewLength]) + "…"
+	}
+
+	notifications := make([]model.Notification, 0, len(userIDs))
+	for _, id := range userIDs {
+		notifications = append(notifications, model.Notification{
+			UserID:   id,
+			Type:     model.NotificationTypeMention,
+			Title:    "有人在申请备注中提到了您",
+			Content:  fmt.Sprintf("%s 在申请 #%d 的备注中提到了您：%s", authorName, note.ApplyID, preview),
+			Channels: model.ChannelInApp,
+		})
+	}
+	if err := s.notificationService.CreateBatch(notifications); err != nil {
+		logger.L.Error("发送提及通知失败", zap.Error(err), zap.Uint("noteId", note.ID))
+	}
+}
+
+// noteUsers 一次性查询备注作者和被提及成员
+func (s *JobApplyNoteService) noteUsers(notes ...model.JobApplyNote) (map[uint]*model.User, error) {
+	var ids []uint
+	for i := range notes {
+		ids = append(ids, notes[i].AuthorID)
+		ids = append(ids, notes[i].Mentions...)
+	}
+	if len(ids) == 0 {
+		return map[uint]*model.User{}, nil
+	}
+	return s.userService.GetUserMap(uniqueIDs(ids))
+}
+
+// toResponse 转换为备注响应
+func (s *JobApplyNoteService) toResponse(note *model.JobApplyNote) (*response.JobApplyNoteResponse, error) {
+	users, err := s.noteUsers(*note)
+	if err != nil {
+		return nil, err
+	}
+	resp := response.NewJobApplyNoteResponse(note, users)
+	return &resp, nil
+}
@@ -1,35 +1,49 @@
 package service
 
 import (
+	stderrors "errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/api/dto/response"
 	"org.thinkinai.com/recruit-center/internal/dao"
 	"org.thinkinai.com/recruit-center/internal/model"
 	"org.thinkinai.com/recruit-center/pkg/enums"
 	"org.thinkinai.com/recruit-center/pkg/errors"
 	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 // JobApplyService 职位申请服务
 type JobApplyService struct {
 	jobApplyDAO         *dao.JobApplyDAO
 	jobService          *JobService
+	companyService      *CompanyService
+	pipelineService     *JobPipelineService
+	scorecardService    *ScorecardService
+	screeningService    *JobScreeningService
 	notificationService *NotificationService
 }
 
 // NewJobApplyService 创建职位申请服务实例
-func NewJobApplyService(jobApplyDao *dao.JobApplyDAO, jobService *JobService, notificationService *NotificationService) *JobApplyService {
+func NewJobApplyService(jobApplyDao *dao.JobApplyDAO, jobService *JobService, companyService *CompanyService, pipelineService *JobPipelineService,
+	scorecardService *ScorecardService, screeningService *JobScreeningService, notificationService *NotificationService) *JobApplyService {
 	return &JobApplyService{
 		jobApplyDAO:         jobApplyDao,
 		jobService:          jobService,
+		companyService:      companyService,
+		pipelineService:     pipelineService,
+		scorecardService:    scorecardService,
+		screeningService:    screeningService,
 		notificationService: notificationService,
 	}
 }
 
-// Create 创建职位申请
-func (s *JobApplyService) Create(apply *model.JobApply) error {
+// Create 创建职位申请，answers 为职位筛选问题的回答
+// 回答未满足职位淘汰规则时，申请创建后自动拒绝并按状态变更通知候选人和招聘方
+func (s *JobApplyService) Create(apply *model.JobApply, answers []model.ScreeningAnswer) error {
 	// 1. 验证职位是否存在且有效
 	job, err := s.jobService.GetByID(apply.JobID, 0)
 	if err != nil {
@@ -45,11 +59,29 @@ func (s *JobApplyService) Create(apply *model.JobApply) error {
 		return errors.New(errors.JobAlreadyApplied)
 	}
 
-	// 3. 设置初始状态
+	// 3. 校验筛选问题的回答
+	screening, err := s.screeningService.Evaluate(apply.JobID, answers)
+	if err != nil {
+		return err
+	}
+	if len(screening.Answers) > 0 {
+		apply.ScreeningAnswers = screening.Answers
+	}
+
+	// 4. 设置初始状态，进度名称取职位招聘流程中的阶段名称
+	pipeline, _, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return err
+	}
+	stage, _ := pipeline.Stage(enums.JobApplyPending)
+	apply.CompanyID = job.CompanyID
 	apply.Status = int(enums.JobApplyPending)
-	apply.ApplyProgress = enums.JobApplyPending.String()
+	if apply.JobRevision, err = s.jobService.CurrentRevision(job); err != nil {
+		return err
+	}
+	apply.ApplyProgress = stage.DisplayName()
 
-	// 4. 创建申请记录
+	// 5. 创建申请记录
 	if err := s.jobApplyDAO.Create(apply); err != nil {
 		logger.L.Error("创建职位申请失败",
 			zap.Error(err),
@@ -58,6 +90,23 @@ func (s *JobApplyService) Create(apply *model.JobApply) error {
 		return err
 	}
 
+	// 6. 未满足淘汰规则时自动拒绝，申请已创建，拒绝失败只记录日志
+	if screening.KnockedOut {
+		rejected, err := s.SystemTransition(apply.ID, enums.JobApplyPending, enums.JobApplyRejected, screening.KnockoutReason)
+		if err != nil {
+			logger.L.Error("筛选问题自动拒绝申请失败", zap.Error(err), zap.Uint("id", apply.ID))
+			return nil
+		}
+		if rejected {
+			apply.Status = int(enums.JobApplyRejected)
+			apply.Reason = screening.KnockoutReason
+			apply.ApplyProgress = enums.JobApplyRejected.String()
+			if stage, ok := pipeline.Stage(enums.JobApplyRejected); ok {
+				apply.ApplyProgress = stage.DisplayName()
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -68,13 +117,16 @@ func (s *JobApplyService) ConvertToJobApplyResponse(apply *model.JobApply) *resp
 	}
 
 	resp := &response.JobApplyResponse{
-		ID:            apply.ID,
-		JobID:         apply.JobID,
-		UserID:        apply.UserID,
-		ResumeID:      apply.ResumeID,
-		Status:        apply.Status,
-		ApplyProgress: apply.ApplyProgress,
-		ApplyTime:     apply.ApplyTime,
+		ID:               apply.ID,
+		JobID:            apply.JobID,
+		UserID:           apply.UserID,
+		ResumeID:         apply.ResumeID,
+		Status:           apply.Status,
+		ApplyProgress:    apply.ApplyProgress,
+		ApplyTime:        apply.ApplyTime,
+		Reason:           apply.Reason,
+		ScreeningAnswers: apply.ScreeningAnswers,
+		JobRevision:      apply.JobRevision,
 	}
 
 	return resp
@@ -89,42 +141,42 @@ func (s *JobApplyService) GetByID(id uint) (*response.JobApplyResponse, error) {
 	return s.ConvertToJobApplyResponse(apply), nil
 }
 
-// ListByUser 获取用户的申请列表
-func (s *JobApplyService) ListByUser(userID uint, page, size int) (*response.JobApplyListResponse, error) {
-	applies, total, err := s.jobApplyDAO.ListByUser(userID, page, size)
+// ListByUser 获取用户的申请列表，按申请时间倒序，支持页码和游标分页
+func (s *JobApplyService) ListByUser(userID uint, page pagination.Page) (*response.JobApplyListResponse, error) {
+	result, err := s.jobApplyDAO.ListByUser(userID, page)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.JobApplyListResponse{
-		Total:   total,
-		Records: make([]response.JobApplyResponse, len(applies)),
+		Total:      result.Total,
+		Records:    make([]response.JobApplyResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
 	}
 
-	for i, apply := range applies {
+	for i, apply := range result.Records {
 		resp.Records[i] = *s.ConvertToJobApplyResponse(&apply)
 	}
 
 	return resp, nil
 }
 
-// ListByJob 获取职位的申请列表
-func (s *JobApplyService) ListByJob(jobID uint, page, size int) (*response.JobApplyListResponse, error) {
-	applies, total, err := s.jobApplyDAO.ListByJob(jobID, page, size)
+// ListByJob 获取职位的申请列表，按筛选条件筛选和排序，结果附带标签、评分及各阶段的申请数
+func (s *JobApplyService) ListByJob(jobID uint, filter model.JobApplyFilter, page pagination.Page) (*response.JobApplyListResponse, error) {
+	filter.JobID = 0
+	result, err := s.jobApplyDAO.ListByJob(jobID, filter, page)
 	if err != nil {
 		return nil, err
 	}
-
-	resp := &response.JobApplyListResponse{
-		Total:   total,
-		Records: make([]response.JobApplyResponse, len(applies)),
+	counts, err := s.jobApplyDAO.CountStatusByJob(jobID, filter)
+	if err != nil {
+		return nil, err
 	}
-
-	for i, apply := range applies {
-		resp.Records[i] = *s.ConvertToJobApplyResponse(&apply)
+	pipeline, _, err := s.pipelineService.GetForJob(jobID)
+	if err != nil {
+		return nil, err
 	}
-
-	return resp, nil
+	return s.toCompanyListResponse(result, pipeline, counts)
 }
 
 // VerifyApplyOwner 验证申请是否属于指定用户
@@ -143,6 +195,22 @@ func (s *JobApplyService) VerifyApplyOwner(applyID, userID uint) error {
 	return nil
 }
 
+// VerifyApplyCompany 验证操作人是申请所属公司的成员，且角色在允许列表中
+func (s *JobApplyService) VerifyApplyCompany(applyID, userID uint, roles ...model.CompanyRole) error {
+	apply, err := s.jobApplyDAO.GetByID(applyID)
+	if err != nil {
+		return errors.Wrap(err, errors.JobApplicationNotFound)
+	}
+	if _, err := s.companyService.CheckMember(apply.CompanyID, userID, roles...); err != nil {
+		logger.L.Warn("非法操作：尝试操作其他公司的申请记录",
+			zap.Uint("applyID", applyID),
+			zap.Uint("userID", userID),
+			zap.Uint("ownerCompanyID", apply.CompanyID))
+		return err
+	}
+	return nil
+}
+
 // Delete 删除职位申请
 func (s *JobApplyService) Delete(id, userID uint) error {
 	// 验证操作权限
@@ -160,13 +228,8 @@ func (s *JobApplyService) Delete(id, userID uint) error {
 }
 
 // getStatusNotification 获取状态变更的通知内容
-func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status enums.JobApplyEnum) (userNotify, companyNotify *model.Notification) {
-	job, _ := s.jobService.GetByID(apply.JobID, 0)
-	jobName := "该职位"
-	if job != nil {
-		jobName = job.Name
-	}
-
+// 公司侧通知不指定接收人，由 notifyStatusChange 发送给公司的所有者和招聘者
+func (s *JobApplyService) getStatusNotification(apply *model.JobApply, jobName string, status enums.JobApplyEnum) (userNotify, companyNotify *model.Notification) {
 	// 根据不同状态生成不同的通知内容
 	switch status {
 	case enums.JobApplyWaitInterview:
@@ -177,7 +240,6 @@ func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status en
 			Type:    model.NotificationTypeInterview,
 		}
 		companyNotify = &model.Notification{
-			UserID:  job.CompanyID,
 			Title:   "候选人状态更新",
 			Content: fmt.Sprintf("职位 %s 的候选人已进入面试环节，请及时安排面试", jobName),
 			Type:    model.NotificationTypeStatusUpdate,
@@ -191,7 +253,6 @@ func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status en
 			Type:    model.NotificationTypeStatusUpdate,
 		}
 		companyNotify = &model.Notification{
-			UserID:  job.CompanyID,
 			Title:   "面试结果提醒",
 			Content: fmt.Sprintf("职位 %s 的候选人面试已通过，请及时处理后续流程", jobName),
 			Type:    model.NotificationTypeStatusUpdate,
@@ -213,7 +274,6 @@ func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status en
 			Type:    model.NotificationTypeStatusUpdate,
 		}
 		companyNotify = &model.Notification{
-			UserID:  job.CompanyID,
 			Title:   "Offer已发送",
 			Content: fmt.Sprintf("您已向 %s 发送了录用意向，请等待候选人确认", jobName),
 			Type:    model.NotificationTypeStatusUpdate,
@@ -226,7 +286,6 @@ func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status en
 			Type:    model.NotificationTypeStatusUpdate,
 		}
 		companyNotify = &model.Notification{
-			UserID:  job.CompanyID,
 			Title:   "Offer已被接受",
 			Content: fmt.Sprintf("候选人已接受 %s 的录用意向，请准备入职相关事宜", jobName),
 			Type:    model.NotificationTypeStatusUpdate,
@@ -239,11 +298,16 @@ func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status en
 			Type:    model.NotificationTypeStatusUpdate,
 		}
 		companyNotify = &model.Notification{
-			UserID:  job.CompanyID,
 			Title:   "Offer已被拒绝",
 			Content: fmt.Sprintf("候选人已拒绝 %s 的录用意向，请继续寻找合适人选", jobName),
 			Type:    model.NotificationTypeStatusUpdate,
 		}
+	case enums.JobApplyWithdrawn:
+		companyNotify = &model.Notification{
+			Title:   "候选人撤回申请",
+			Content: fmt.Sprintf("职位 %s 的候选人已撤回申请", jobName),
+			Type:    model.NotificationTypeStatusUpdate,
+		}
 	default:
 		userNotify = &model.Notification{
 			UserID:  apply.UserID,
@@ -256,97 +320,457 @@ func (s *JobApplyService) getStatusNotification(apply *model.JobApply, status en
 	return userNotify, companyNotify
 }
 
-// UpdateStatus 更新申请状态
+// StatusChange 申请状态变更内容
+type StatusChange struct {
+	Status enums.JobApplyEnum // 目标状态
+	Reason string             // 流转原因，如拒绝原因
+	Note   string             // 备注
+}
+
+// UpdateStatus 公司侧更新申请状态，仅申请所属公司的所有者或招聘者可以操作
 func (s *JobApplyService) UpdateStatus(id uint, userID uint, status enums.JobApplyEnum) error {
-	// 验证操作权限
-	if err := s.VerifyApplyOwner(id, userID); err != nil {
-		return err
-	}
+	return s.Transition(id, userID, model.PipelineActorCompany, StatusChange{Status: status})
+}
+
+// Transition 按职位的招聘流程流转申请状态，并记录流转事件
+// 公司侧操作人须为申请所属公司的所有者或招聘者，候选人只能操作本人的申请
+func (s *JobApplyService) Transition(id, userID uint, actor model.PipelineActor, change StatusChange) error {
+	status := change.Status
 
 	// 1. 验证状态是否有效
 	if !status.IsValid() {
-		return fmt.Errorf("无效的状态值")
+		return errors.New(errors.InvalidParams).WithMessage("无效的状态值")
 	}
 
-	// 2. 检查申请是否存在
-	apply, err := s.jobApplyDAO.GetByID(id)
+	// 2. 获取申请并验证操作权限
+	apply, err := s.getApplyForActor(id, userID, actor)
 	if err != nil {
-		return errors.Wrap(err, errors.NotFound)
+		return err
 	}
 
-	// 3. 验证状态流转是否合法
-	if !s.isValidStatusTransition(apply.Status, int(status)) {
-		return fmt.Errorf("无效的状态流转")
+	// 3. 按职位的招聘流程验证状态流转是否合法
+	pipeline, _, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return err
+	}
+	from := enums.JobApplyEnum(apply.Status)
+	if !pipeline.CanTransition(from, status, actor) {
+		logger.L.Warn("不允许的申请状态流转",
+			zap.Uint("id", id),
+			zap.Uint("userId", userID),
+			zap.String("actor", string(actor)),
+			zap.Int("from", apply.Status),
+			zap.Int("to", int(status)))
+		return errors.New(errors.InvalidStatusTransition).
+			WithMessage(fmt.Sprintf("申请当前处于「%s」，不能流转到「%s」", from.String(), status.String()))
+	}
+	// 职位要求时，面试通过或未通过前须已提交面试反馈
+	if err := s.scorecardService.CheckFeedback(apply, status); err != nil {
+		return err
 	}
 
-	// 4. 更新状态
-	if err := s.jobApplyDAO.UpdateStatus(id, int(status)); err != nil {
+	// 4. 以当前状态为条件更新并写入流转事件，避免并发操作覆盖
+	stage, _ := pipeline.Stage(status)
+	event := &model.JobApplyEvent{
+		ApplyID:    apply.ID,
+		JobID:      apply.JobID,
+		ActorType:  actor,
+		ActorID:    userID,
+		FromStatus: apply.Status,
+		ToStatus:   int(status),
+		Reason:     change.Reason,
+		Note:       change.Note,
+	}
+	affected, err := s.jobApplyDAO.TransitionStatus(event, stage.DisplayName())
+	if err != nil {
 		logger.L.Error("更新申请状态失败",
 			zap.Error(err),
 			zap.Uint("id", id),
 			zap.Int("status", int(status)))
-		return err
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return errors.New(errors.Conflict).WithMessage("申请状态已变更，请刷新后重试")
 	}
 
 	// 5. 发送通知
-	userNotify, companyNotify := s.getStatusNotification(apply, status)
+	s.notifyStatusChange(apply, status)
+	return nil
+}
+
+// SystemTransition 系统自动流转申请状态，不受职位招聘流程约束，如Offer过期
+// 仅当申请仍处于 from 状态时生效，返回是否已流转
+func (s *JobApplyService) SystemTransition(id uint, from, to enums.JobApplyEnum, reason string) (bool, error) {
+	apply, err := s.getApply(id)
+	if err != nil {
+		return false, err
+	}
+	if apply.Status != int(from) {
+		return false, nil
+	}
+
+	pipeline, _, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return false, err
+	}
+	progress := to.String()
+	if stage, ok := pipeline.Stage(to); ok {
+		progress = stage.DisplayName()
+	}
+	event := &model.JobApplyEvent{
+		ApplyID:    apply.ID,
+		JobID:      apply.JobID,
+		ActorType:  model.PipelineActorSystem,
+		FromStatus: int(from),
+		ToStatus:   int(to),
+		Reason:     reason,
+	}
+	affected, err := s.jobApplyDAO.TransitionStatus(event, progress)
+	if err != nil {
+		logger.L.Error("系统流转申请状态失败",
+			zap.Error(err),
+			zap.Uint("id", id),
+			zap.Int("to", int(to)))
+		return false, errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	s.notifyStatusChange(apply, to)
+	return true, nil
+}
+
+// BulkTransition 公司侧批量流转申请状态
+// 逐条校验操作权限与职位的招聘流程，校验通过的申请在同一事务中更新，返回每条申请的处理结果
+func (s *JobApplyService) BulkTransition(ids []uint, userID uint, change StatusChange) (*response.JobApplyBulkStatusResponse, error) {
+	status := change.Status
+	if !status.IsValid() {
+		return nil, errors.New(errors.InvalidParams).WithMessage("无效的状态值")
+	}
+
+	ids = uniqueIDs(ids)
+	applies, err := s.jobApplyDAO.GetByIDs(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	applyMap := make(map[uint]*model.JobApply, len(applies))
+	for i := range applies {
+		applyMap[applies[i].ID] = &applies[i]
+	}
+
+	results := make([]response.JobApplyBulkStatusItem, len(ids))
+	// 同一公司的权限、同一职位的流程与反馈要求只查询一次
+	access := make(map[uint]error)
+	pipelines := make(map[uint]*model.PipelineDefinition)
+	feedbackRequired := make(map[uint]bool)
+	var submitted map[uint]bool
+	var transitions []dao.StatusTransition
+	var pending []int
+
+	for i, id := range ids {
+		results[i] = response.JobApplyBulkStatusItem{ID: id}
+
+		apply, ok := applyMap[id]
+		if !ok {
+			results[i].Fail(errors.New(errors.JobApplicationNotFound))
+			continue
+		}
+
+		accessErr, checked := access[apply.CompanyID]
+		if !checked {
+			_, accessErr = s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyHirers...)
+			access[apply.CompanyID] = accessErr
+		}
+		if accessErr != nil {
+			results[i].Fail(accessErr)
+			continue
+		}
+
+		pipeline, ok := pipelines[apply.JobID]
+		if !ok {
+			if pipeline, _, err = s.pipelineService.GetForJob(apply.JobID); err != nil {
+				return nil, err
+			}
+			pipelines[apply.JobID] = pipeline
+		}
+		from := enums.JobApplyEnum(apply.Status)
+		if !pipeline.CanTransition(from, status, model.PipelineActorCompany) {
+			results[i].Fail(errors.New(errors.InvalidStatusTransition).
+				WithMessage(fmt.Sprintf("申请当前处于「%s」，不能流转到「%s」", from.String(), status.String())))
+			continue
+		}
+
+		required, ok := feedbackRequired[apply.JobID]
+		if !ok {
+			if required, err = s.scorecardService.RequiresFeedback(apply.JobID, status); err != nil {
+				return nil, err
+			}
+			feedbackRequired[apply.JobID] = required
+		}
+		if required {
+			if submitted == nil {
+				if submitted, err = s.scorecardService.FeedbackSubmitted(ids); err != nil {
+					return nil, err
+				}
+			}
+			if !submitted[apply.ID] {
+				results[i].Fail(feedbackRequiredError(status))
+				continue
+			}
+		}
+
+		stage, _ := pipeline.Stage(status)
+		transitions = append(transitions, dao.StatusTransition{
+			Event: &model.JobApplyEvent{
+				ApplyID:    apply.ID,
+				JobID:      apply.JobID,
+				ActorType:  model.PipelineActorCompany,
+				ActorID:    userID,
+				FromStatus: apply.Status,
+				ToStatus:   int(status),
+				Reason:     change.Reason,
+				Note:       change.Note,
+			},
+			Progress: stage.DisplayName(),
+		})
+		pending = append(pending, i)
+	}
+
+	applied, err := s.jobApplyDAO.TransitionStatusBatch(transitions)
+	if err != nil {
+		logger.L.Error("批量更新申请状态失败",
+			zap.Error(err),
+			zap.Uint("userId", userID),
+			zap.Int("count", len(transitions)),
+			zap.Int("status", int(status)))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	changed := make([]*model.JobApply, 0, len(pending))
+	for k, i := range pending {
+		if !applied[k] {
+			results[i].Fail(errors.New(errors.Conflict).WithMessage("申请状态已变更，请刷新后重试"))
+			continue
+		}
+		results[i].Success = true
+		changed = append(changed, applyMap[results[i].ID])
+	}
+
+	s.notifyBulkStatusChange(changed, status)
+	return response.NewJobApplyBulkStatusResponse(results), nil
+}
+
+// NextStates 获取申请在当前阶段可流转到的状态
+// actor 为空时返回所有可能的流转，用于管理员查看；调用方负责校验访问权限
+func (s *JobApplyService) NextStates(id uint, actor model.PipelineActor) (*response.JobApplyNextStatesResponse, error) {
+	apply, err := s.getApply(id)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, custom, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := enums.JobApplyEnum(apply.Status)
+	stage, ok := pipeline.Stage(current)
+	if !ok {
+		stage = model.PipelineStage{Status: current}
+	}
+	resp := &response.JobApplyNextStatesResponse{
+		ApplyID:        apply.ID,
+		Status:         apply.Status,
+		StageName:      stage.DisplayName(),
+		Terminal:       pipeline.IsTerminal(current),
+		CustomPipeline: custom,
+		Next:           []response.PipelineTransitionResponse{},
+	}
+	for _, t := range pipeline.NextTransitions(current, actor) {
+		resp.Next = append(resp.Next, response.NewPipelineTransitionResponse(pipeline, t))
+	}
+	return resp, nil
+}
+
+// Timeline 获取申请的状态流转时间线，调用方负责校验访问权限
+func (s *JobApplyService) Timeline(id uint) ([]response.JobApplyEventResponse, error) {
+	apply, err := s.getApply(id)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, _, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.jobApplyDAO.ListEvents(id)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	resp := make([]response.JobApplyEventResponse, len(events))
+	for i := range events {
+		resp[i] = response.NewJobApplyEventResponse(pipeline, &events[i])
+	}
+	return resp, nil
+}
+
+// getApply 获取申请记录
+func (s *JobApplyService) getApply(id uint) (*model.JobApply, error) {
+	apply, err := s.jobApplyDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobApplicationNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return apply, nil
+}
+
+// getApplyForActor 获取申请记录并验证操作人能以指定身份操作该申请
+func (s *JobApplyService) getApplyForActor(id, userID uint, actor model.PipelineActor) (*model.JobApply, error) {
+	apply, err := s.getApply(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch actor {
+	case model.PipelineActorCompany:
+		if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyHirers...); err != nil {
+			logger.L.Warn("非法操作：尝试操作其他公司的申请记录",
+				zap.Uint("applyID", id),
+				zap.Uint("userID", userID),
+				zap.Uint("ownerCompanyID", apply.CompanyID))
+			return nil, err
+		}
+	case model.PipelineActorCandidate:
+		if apply.UserID != userID {
+			logger.L.Warn("非法操作：用户尝试操作非本人的申请记录",
+				zap.Uint("applyID", id),
+				zap.Uint("userID", userID),
+				zap.Uint("ownerID", apply.UserID))
+			return nil, errors.New(errors.Forbidden)
+		}
+	default:
+		return nil, errors.New(errors.Forbidden)
+	}
+	return apply, nil
+}
+
+// notifyStatusChange 向求职者及公司的所有者、招聘者发送状态变更通知，发送失败只记录日志
+func (s *JobApplyService) notifyStatusChange(apply *model.JobApply, status enums.JobApplyEnum) {
+	jobName := "该职位"
+	if job, _ := s.jobService.GetByID(apply.JobID, 0); job != nil {
+		jobName = job.Name
+	}
+	userNotify, companyNotify := s.getStatusNotification(apply, jobName, status)
 
 	// 发送给求职者的通知
 	if userNotify != nil {
+		userNotify.UserType = model.UserTypeJobSeeker
 		if err := s.notificationService.Create(userNotify); err != nil {
 			logger.L.Error("发送求职者通知失败", zap.Error(err))
 		}
 	}
 
 	// 发送给公司的通知
-	if companyNotify != nil {
-		if err := s.notificationService.Create(companyNotify); err != nil {
-			logger.L.Error("发送公司通知失败", zap.Error(err))
+	if companyNotify == nil {
+		return
+	}
+	userIDs, err := s.companyService.ListMemberUserIDs(apply.CompanyID, model.CompanyHirers...)
+	if err != nil {
+		logger.L.Error("获取公司成员失败", zap.Error(err), zap.Uint("companyId", apply.CompanyID))
+		return
+	}
+	for _, userID := range userIDs {
+		notify := *companyNotify
+		notify.UserID = userID
+		notify.UserType = model.UserTypeRecruiter
+		if err := s.notificationService.Create(&notify); err != nil {
+			logger.L.Error("发送公司通知失败", zap.Error(err), zap.Uint("userId", userID))
 		}
 	}
+}
 
-	return nil
+// notifyBulkStatusChange 批量发送状态变更通知
+// 每位求职者收到一条通知，公司的所有者和招聘者每人只收到一条汇总通知，所有通知一次性写入
+func (s *JobApplyService) notifyBulkStatusChange(applies []*model.JobApply, status enums.JobApplyEnum) {
+	if len(applies) == 0 {
+		return
+	}
+
+	jobIDs := make([]uint, 0, len(applies))
+	companyCounts := make(map[uint]int)
+	for _, apply := range applies {
+		jobIDs = append(jobIDs, apply.JobID)
+		companyCounts[apply.CompanyID]++
+	}
+	jobs, err := s.jobService.GetJobMap(uniqueIDs(jobIDs))
+	if err != nil {
+		logger.L.Error("获取职位信息失败", zap.Error(err))
+		jobs = map[uint]*model.Job{}
+	}
+
+	notifications := make([]model.Notification, 0, len(applies))
+	for _, apply := range applies {
+		jobName := "该职位"
+		if job, ok := jobs[apply.JobID]; ok {
+			jobName = job.Name
+		}
+		if userNotify, _ := s.getStatusNotification(apply, jobName, status); userNotify != nil {
+			userNotify.UserType = model.UserTypeJobSeeker
+			notifications = append(notifications, *userNotify)
+		}
+	}
+
+	for companyID, count := range companyCounts {
+		userIDs, err := s.companyService.ListMemberUserIDs(companyID, model.CompanyHirers...)
+		if err != nil {
+			logger.L.Error("获取公司成员失败", zap.Error(err), zap.Uint("companyId", companyID))
+			continue
+		}
+		for _, userID := range userIDs {
+			notifications = append(notifications, model.Notification{
+				UserID:   userID,
+				UserType: model.UserTypeRecruiter,
+				Title:    "候选人状态批量更新",
+				Content:  fmt.Sprintf("%d 位候选人的申请状态已更新为：%s", count, status.String()),
+				Type:     model.NotificationTypeStatusUpdate,
+			})
+		}
+	}
+
+	if err := s.notificationService.CreateBatch(notifications); err != nil {
+		logger.L.Error("批量发送状态变更通知失败", zap.Error(err), zap.Int("count", len(notifications)))
+	}
 }
 
-// isValidStatusTransition 验证状态流转是否合法
-func (s *JobApplyService) isValidStatusTransition(from, to int) bool {
-	// 定义状态流转规则
-	transitions := map[int][]int{
-		int(enums.JobApplyPending): {
-			int(enums.JobApplyInProgress),
-			int(enums.JobApplyRejected),
-			int(enums.JobApplyWithdrawn),
-		},
-		int(enums.JobApplyInProgress): {
-			int(enums.JobApplyAccepted),
-			int(enums.JobApplyRejected),
-		},
-	}
-
-	// 检查状态流转是否允许
-	if allowedStatus, exists := transitions[from]; exists {
-		for _, status := range allowedStatus {
-			if status == to {
-				return true
-			}
+// uniqueIDs 去除重复ID，保持原有顺序
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
 		}
 	}
-	return false
+	return result
 }
 
 // List 获取职位申请列表
-func (s *JobApplyService) List(page, size int) (*response.JobApplyListResponse, error) {
-	applies, total, err := s.jobApplyDAO.List(page, size)
+func (s *JobApplyService) List(page pagination.Page) (*response.JobApplyListResponse, error) {
+	result, err := s.jobApplyDAO.List(page)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.JobApplyListResponse{
-		Total:   total,
-		Records: make([]response.JobApplyResponse, len(applies)),
+		Total:      result.Total,
+		Records:    make([]response.JobApplyResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
 	}
 
-	for i, apply := range applies {
+	for i, apply := range result.Records {
 		resp.Records[i] = *s.ConvertToJobApplyResponse(&apply)
 	}
 
@@ -354,39 +778,256 @@ func (s *JobApplyService) List(page, size int) (*response.JobApplyListResponse,
 }
 
 // ListByUserID 根据用户id，查询其全部的申请信息
-func (s *JobApplyService) ListByUserID(userID uint, page, size int) (*response.JobApplyListResponse, error) {
-	applies, total, err := s.jobApplyDAO.ListByUser(userID, page, size)
+func (s *JobApplyService) ListByUserID(userID uint, page pagination.Page) (*response.JobApplyListResponse, error) {
+	result, err := s.jobApplyDAO.ListByUser(userID, page)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.JobApplyListResponse{
-		Total:   total,
-		Records: make([]response.JobApplyResponse, len(applies)),
+		Total:      result.Total,
+		Records:    make([]response.JobApplyResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
 	}
 
-	for i, apply := range applies {
+	for i, apply := range result.Records {
 		resp.Records[i] = *s.ConvertToJobApplyResponse(&apply)
 	}
 
 	return resp, nil
 }
 
-// ListByCompanyID 根据公司信息，查询所有的职位申请记录
-func (s *JobApplyService) ListByCompanyID(companyID uint, page, size int) (*response.JobApplyListResponse, error) {
-	applies, total, err := s.jobApplyDAO.ListByCompany(companyID, page, size)
+// ListByCompanyID 根据公司信息，查询所有的职位申请记录，按筛选条件筛选和排序，结果附带标签、评分及各状态的申请数
+// 指定职位时状态名称取该职位招聘流程中的阶段名称，否则使用默认流程
+func (s *JobApplyService) ListByCompanyID(companyID uint, filter model.JobApplyFilter, page pagination.Page) (*response.JobApplyListResponse, error) {
+	result, err := s.jobApplyDAO.ListByCompany(companyID, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.jobApplyDAO.CountStatusByCompany(companyID, filter)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := model.DefaultPipeline()
+	if filter.JobID > 0 {
+		if pipeline, _, err = s.pipelineService.GetForJob(filter.JobID); err != nil {
+			return nil, err
+		}
+	}
+	return s.toCompanyListResponse(result, pipeline, counts)
+}
+
+// statusCounts 按招聘流程的阶段顺序整理各状态的申请数，流程外仍有申请的状态排在最后
+func statusCounts(pipeline *model.PipelineDefinition, counts []dao.StatusCount) []response.ApplyStatusCountResponse {
+	byStatus := make(map[int]int64, len(counts))
+	for _, c := range counts {
+		byStatus[c.Status] = c.Count
+	}
+	result := make([]response.ApplyStatusCountResponse, 0, len(pipeline.Stages)+len(counts))
+	for _, stage := range pipeline.Stages {
+		status := int(stage.Status)
+		result = append(result, response.ApplyStatusCountResponse{Status: status, Name: stage.DisplayName(), Count: byStatus[status]})
+		delete(byStatus, status)
+	}
+	for _, c := range counts {
+		if _, ok := byStatus[c.Status]; ok {
+			result = append(result, response.ApplyStatusCountResponse{Status: c.Status, Name: enums.GetStatusText(c.Status), Count: c.Count})
+		}
+	}
+	return result
+}
+
+// toCompanyListResponse 转换为公司侧申请列表响应，附带仅公司成员可见的标签、评分及各状态的申请数
+func (s *JobApplyService) toCompanyListResponse(result *pagination.Result[model.JobApply], pipeline *model.PipelineDefinition, counts []dao.StatusCount) (*response.JobApplyListResponse, error) {
+	applies := result.Records
+	ids := make([]uint, len(applies))
+	for i := range applies {
+		ids[i] = applies[i].ID
+	}
+	tags, err := s.jobApplyDAO.ListTags(ids)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.JobApplyListResponse{
-		Total:   total,
-		Records: make([]response.JobApplyResponse, len(applies)),
+		Total:        result.Total,
+		Records:      make([]response.JobApplyResponse, len(applies)),
+		NextCursor:   result.NextCursor,
+		StatusCounts: statusCounts(pipeline, counts),
 	}
+	for i := range applies {
+		record := s.ConvertToJobApplyResponse(&applies[i])
+		record.Rating = applies[i].Rating
+		record.Tags = tags[applies[i].ID]
+		resp.Records[i] = *record
+	}
+	return resp, nil
+}
 
-	for i, apply := range applies {
-		resp.Records[i] = *s.ConvertToJobApplyResponse(&apply)
+// SetRating 设置申请评分，rating 为0表示清除评分，仅申请所属公司的所有者或招聘者可以操作
+func (s *JobApplyService) SetRating(id, userID uint, rating int) error {
+	if rating < 0 || rating > model.MaxApplyRating {
+		return errors.New(errors.InvalidParams).WithMessage(fmt.Sprintf("评分须在1到%d之间", model.MaxApplyRating))
+	}
+	apply, err := s.getApplyForActor(id, userID, model.PipelineActorCompany)
+	if err != nil {
+		return err
+	}
+	if err := s.jobApplyDAO.UpdateRating(apply.ID, rating, userID); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// SetTags 替换申请的标签，仅申请所属公司的所有者或招聘者可以操作
+func (s *JobApplyService) SetTags(id, userID uint, tags []string) ([]string, error) {
+	tags, err := model.NormalizeApplyTags(tags)
+	if err != nil {
+		return nil, errors.New(errors.InvalidParams).WithMessage(err.Error())
+	}
+	apply, err := s.getApplyForActor(id, userID, model.PipelineActorCompany)
+	if err != nil {
+		return nil, err
 	}
+	if err := s.jobApplyDAO.ReplaceTags(apply, tags, userID); err != nil {
+		logger.L.Error("更新申请标签失败", zap.Error(err), zap.Uint("id", id), zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return tags, nil
+}
 
+// ListCompanyTags 获取公司申请中使用过的标签及使用次数，用于筛选
+func (s *JobApplyService) ListCompanyTags(companyID, userID uint) ([]response.ApplyTagCountResponse, error) {
+	if _, err := s.companyService.CheckMember(companyID, userID, model.CompanyReaders...); err != nil {
+		return nil, err
+	}
+	counts, err := s.jobApplyDAO.CountTagsByCompany(companyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	resp := make([]response.ApplyTagCountResponse, len(counts))
+	for i, c := range counts {
+		resp[i] = response.ApplyTagCountResponse{Tag: c.Tag, Count: c.Count}
+	}
 	return resp, nil
 }
+
+// 看板每个阶段的卡片数量
+const (
+	DefaultBoardCards = 10
+	MaxBoardCards     = 50
+)
+
+// boardCursorScope 看板阶段内游标的作用域，按申请时间倒序、ID倒序
+const boardCursorScope = "board"
+
+// Board 获取职位看板，返回招聘流程的每个阶段及其申请数和第一页候选人卡片
+// 流程外仍有申请的状态排在最后，调用方须已校验操作人可以查看职位的申请
+func (s *JobApplyService) Board(jobID uint, limit int) (*response.JobBoardResponse, error) {
+	pipeline, _, err := s.pipelineService.GetForJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.jobApplyDAO.CountStatusByJob(jobID, model.JobApplyFilter{})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	board := &response.JobBoardResponse{JobID: jobID}
+	for _, c := range statusCounts(pipeline, counts) {
+		stage, err := s.boardStage(jobID, c, nil, limit)
+		if err != nil {
+			return nil, err
+		}
+		board.Stages = append(board.Stages, *stage)
+	}
+	return board, nil
+}
+
+// BoardStage 按游标加载看板某一阶段的更多候选人卡片
+func (s *JobApplyService) BoardStage(jobID uint, status enums.JobApplyEnum, cursor string, limit int) (*response.BoardStageResponse, error) {
+	if !status.IsValid() {
+		return nil, errors.New(errors.InvalidParams).WithMessage("无效的申请状态")
+	}
+	var after *pagination.Cursor
+	if cursor != "" {
+		decoded, err := pagination.Decode(cursor, boardCursorScope)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	pipeline, _, err := s.pipelineService.GetForJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.jobApplyDAO.CountStatusByJob(jobID, model.JobApplyFilter{Statuses: []int{int(status)}})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	header := response.ApplyStatusCountResponse{Status: int(status), Name: status.String()}
+	if stage, ok := pipeline.Stage(status); ok {
+		header.Name = stage.DisplayName()
+	}
+	for _, c := range counts {
+		if c.Status == int(status) {
+			header.Count = c.Count
+		}
+	}
+	return s.boardStage(jobID, header, after, limit)
+}
+
+// boardStage 查询阶段的一页卡片，多取一条判断是否还有更多
+func (s *JobApplyService) boardStage(jobID uint, header response.ApplyStatusCountResponse, after *pagination.Cursor, limit int) (*response.BoardStageResponse, error) {
+	stage := &response.BoardStageResponse{
+		Status: header.Status,
+		Name:   header.Name,
+		Count:  header.Count,
+		Cards:  []response.BoardCardResponse{},
+	}
+	if header.Count == 0 {
+		return stage, nil
+	}
+
+	cards, err := s.jobApplyDAO.ListBoardCards(jobID, header.Status, after, limit+1)
+	if err != nil {
+		logger.L.Error("查询看板卡片失败", zap.Error(err), zap.Uint("jobId", jobID), zap.Int("status", header.Status))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if len(cards) > limit {
+		cards = cards[:limit]
+		last := cards[len(cards)-1]
+		stage.NextCursor = pagination.Encode(pagination.Cursor{Scope: boardCursorScope, Time: last.ApplyTime, ID: last.ApplyID})
+	}
+
+	ids := make([]uint, len(cards))
+	for i := range cards {
+		ids[i] = cards[i].ApplyID
+	}
+	tags, err := s.jobApplyDAO.ListTags(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	now := time.Now()
+	for _, card := range cards {
+		cardTags := tags[card.ApplyID]
+		if cardTags == nil {
+			cardTags = []string{}
+		}
+		stage.Cards = append(stage.Cards, response.BoardCardResponse{
+			ApplyID:        card.ApplyID,
+			UserID:         card.UserID,
+			ResumeID:       card.ResumeID,
+			CandidateName:  card.CandidateName,
+			CurrentTitle:   card.CurrentTitle,
+			StageEnteredAt: card.StageEnteredAt,
+			DaysInStage:    int(now.Sub(card.StageEnteredAt).Hours() / 24),
+			ApplyTime:      card.ApplyTime,
+			Rating:         card.Rating,
+			Tags:           cardTags,
+		})
+	}
+	return stage, nil
+}
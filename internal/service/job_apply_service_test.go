@@ -16,11 +16,13 @@ func TestJobApplyService_Create(t *testing.T) {
 	jobDao := dao.NewJobDAO(db)
 	favorDao := dao.NewJobFavoriteDAO(db)
 	jobApplyDao := dao.NewJobApplyDAO(db)
-	mockJobService := NewJobService(jobDao, favorDao, jobApplyDao)
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	mockJobService := NewJobService(jobDao, favorDao, jobApplyDao, companyService, NewRegionService(dao.NewDictDAO(db)))
 	notifyDao := dao.NewNotificationDAO(db)
 	notificationDAO := dao.NewNotificationTemplateDAO(db)
 	mockNotificationService := NewNotificationService(notifyDao, notificationDAO)
-	service := NewJobApplyService(mockDAO, mockJobService, mockNotificationService)
+	scorecardService := NewScorecardService(dao.NewScorecardDAO(db), dao.NewInterviewFeedbackDAO(db), dao.NewInterviewDAO(db), jobApplyDao, mockJobService, companyService, nil)
+	service := NewJobApplyService(mockDAO, mockJobService, companyService, NewJobPipelineService(dao.NewJobPipelineDAO(db), jobApplyDao, mockJobService), scorecardService, NewJobScreeningService(dao.NewJobScreeningDAO(db), mockJobService), mockNotificationService)
 	apply := &model.JobApply{
 		JobID:         1,
 		UserID:        1,
@@ -28,7 +30,7 @@ func TestJobApplyService_Create(t *testing.T) {
 		Status:        int(enums.JobApplyPending),
 		ApplyProgress: enums.JobApplyPending.String(),
 	}
-	err := service.Create(apply)
+	err := service.Create(apply, nil)
 	if err != nil {
 		t.Errorf("Create() error = %v", err)
 		return
@@ -42,12 +44,14 @@ func TestJobApplyService_UpdateStatus(t *testing.T) {
 	jobDao := dao.NewJobDAO(db)
 	favorDao := dao.NewJobFavoriteDAO(db)
 	jobApplyDao := dao.NewJobApplyDAO(db)
-	mockJobService := NewJobService(jobDao, favorDao, jobApplyDao)
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	mockJobService := NewJobService(jobDao, favorDao, jobApplyDao, companyService, NewRegionService(dao.NewDictDAO(db)))
 	notifyDao := dao.NewNotificationDAO(db)
 	notificationDAO := dao.NewNotificationTemplateDAO(db)
 	mockNotificationService := NewNotificationService(notifyDao, notificationDAO)
 
-	service := NewJobApplyService(mockDAO, mockJobService, mockNotificationService)
+	scorecardService := NewScorecardService(dao.NewScorecardDAO(db), dao.NewInterviewFeedbackDAO(db), dao.NewInterviewDAO(db), jobApplyDao, mockJobService, companyService, nil)
+	service := NewJobApplyService(mockDAO, mockJobService, companyService, NewJobPipelineService(dao.NewJobPipelineDAO(db), jobApplyDao, mockJobService), scorecardService, NewJobScreeningService(dao.NewJobScreeningDAO(db), mockJobService), mockNotificationService)
 
 	err := service.UpdateStatus(1, 1, enums.JobApplyAccepted)
 	if err != nil {
@@ -55,3 +59,48 @@ func TestJobApplyService_UpdateStatus(t *testing.T) {
 		return
 	}
 }
+
+// TestJobApplyService_BulkTransition 测试批量流转申请状态，不存在的申请逐条返回失败
+func TestJobApplyService_BulkTransition(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	jobApplyDao := dao.NewJobApplyDAO(db)
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	jobService := NewJobService(dao.NewJobDAO(db), dao.NewJobFavoriteDAO(db), jobApplyDao, companyService, NewRegionService(dao.NewDictDAO(db)))
+	pipelineService := NewJobPipelineService(dao.NewJobPipelineDAO(db), jobApplyDao, jobService)
+	notificationService := NewNotificationService(dao.NewNotificationDAO(db), dao.NewNotificationTemplateDAO(db))
+	scorecardService := NewScorecardService(dao.NewScorecardDAO(db), dao.NewInterviewFeedbackDAO(db), dao.NewInterviewDAO(db), jobApplyDao, jobService, companyService, nil)
+	service := NewJobApplyService(jobApplyDao, jobService, companyService, pipelineService, scorecardService, NewJobScreeningService(dao.NewJobScreeningDAO(db), jobService), notificationService)
+
+	resp, err := service.BulkTransition([]uint{999998, 999999, 999998}, 1, StatusChange{Status: enums.JobApplyRejected})
+	if err != nil {
+		t.Fatalf("BulkTransition() error = %v", err)
+	}
+	if resp.Total != 2 || resp.Failed != 2 {
+		t.Errorf("BulkTransition() total = %d, failed = %d, want 2, 2", resp.Total, resp.Failed)
+	}
+}
+
+// TestStatusCounts 测试按流程阶段整理各状态的申请数，流程外的状态排在最后
+func TestStatusCounts(t *testing.T) {
+	pipeline := &model.PipelineDefinition{Stages: []model.PipelineStage{
+		{Status: enums.JobApplyPending, Name: "简历筛选"},
+		{Status: enums.JobApplyRejected},
+	}}
+	counts := statusCounts(pipeline, []dao.StatusCount{
+		{Status: int(enums.JobApplyRejected), Count: 2},
+		{Status: int(enums.JobApplyWithdrawn), Count: 1},
+	})
+
+	if len(counts) != 3 {
+		t.Fatalf("statusCounts() len = %d, want 3", len(counts))
+	}
+	if counts[0].Name != "简历筛选" || counts[0].Count != 0 {
+		t.Errorf("statusCounts()[0] = %+v, want 简历筛选 with 0", counts[0])
+	}
+	if counts[1].Status != int(enums.JobApplyRejected) || counts[1].Count != 2 {
+		t.Errorf("statusCounts()[1] = %+v, want rejected with 2", counts[1])
+	}
+	if counts[2].Status != int(enums.JobApplyWithdrawn) || counts[2].Name != enums.JobApplyWithdrawn.String() {
+		t.Errorf("statusCounts()[2] = %+v, want withdrawn appended", counts[2])
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"org.thinkinai.com/recruit-center/api/dto/response"
 	"org.thinkinai.com/recruit-center/internal/dao"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 type JobFavoriteService struct {
@@ -39,19 +40,20 @@ func (s *JobFavoriteService) IsFavorited(userID, jobID uint) (bool, error) {
 	return s.favoriteDAO.IsFavorited(userID, jobID)
 }
 
-// ListFavorites 获取用户收藏的职位列表
-func (s *JobFavoriteService) ListFavorites(userID uint, page, size int) (*response.JobListResponse, error) {
-	favorites, total, err := s.favoriteDAO.ListByUser(userID, page, size)
+// ListFavorites 获取用户收藏的职位列表，按收藏时间倒序，支持页码和游标分页
+func (s *JobFavoriteService) ListFavorites(userID uint, page pagination.Page) (*response.JobListResponse, error) {
+	result, err := s.favoriteDAO.ListByUser(userID, page)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.JobListResponse{
-		Total:   total,
-		Records: make([]response.JobResponse, 0),
+		Total:      result.Total,
+		Records:    make([]response.JobResponse, 0),
+		NextCursor: result.NextCursor,
 	}
 
-	for _, fav := range favorites {
+	for _, fav := range result.Records {
 		if job, err := s.jobService.GetByID(fav.JobID, userID); err == nil {
 			job.IsFavorited = true
 			job.FavoriteTime = fav.CreateTime // 设置收藏时间
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"org.thinkinai.com/recruit-center/internal/dao"
 	"org.thinkinai.com/recruit-center/internal/testutil"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 // TestJobFavoriteService_AddFavorite 测试添加收藏
@@ -13,7 +14,8 @@ func TestJobFavoriteService_AddFavorite(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDAO := dao.NewJobFavoriteDAO(db)
 	jobDao := dao.NewJobDAO(db)
-	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db))
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db), companyService, NewRegionService(dao.NewDictDAO(db)))
 	service := NewJobFavoriteService(mockDAO, mockJobService)
 
 	err := service.AddFavorite(1, 3)
@@ -28,7 +30,8 @@ func TestJobFavoriteService_RemoveFavorite(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDAO := dao.NewJobFavoriteDAO(db)
 	jobDao := dao.NewJobDAO(db)
-	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db))
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db), companyService, NewRegionService(dao.NewDictDAO(db)))
 	service := NewJobFavoriteService(mockDAO, mockJobService)
 
 	err := service.RemoveFavorite(1, 3)
@@ -43,10 +46,11 @@ func TestJobFavoriteService_ListFavorites(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDAO := dao.NewJobFavoriteDAO(db)
 	jobDao := dao.NewJobDAO(db)
-	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db))
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db), companyService, NewRegionService(dao.NewDictDAO(db)))
 	service := NewJobFavoriteService(mockDAO, mockJobService)
 
-	favorites, err := service.ListFavorites(1, 1, 10)
+	favorites, err := service.ListFavorites(1, pagination.NewPage(1, 10, "", nil))
 	if err != nil {
 		t.Errorf("ListFavorites() error = %v", err)
 		return
@@ -59,7 +63,8 @@ func TestJobFavoriteService_GetUserStatistics(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDAO := dao.NewJobFavoriteDAO(db)
 	jobDao := dao.NewJobDAO(db)
-	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db))
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	mockJobService := NewJobService(jobDao, mockDAO, dao.NewJobApplyDAO(db), companyService, NewRegionService(dao.NewDictDAO(db)))
 	service := NewJobFavoriteService(mockDAO, mockJobService)
 	stats, err := service.GetUserStatistics(1)
 	if err != nil {
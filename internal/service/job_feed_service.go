@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/feed"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/config"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+// JobFeedService 职位订阅源服务，向职位聚合网站和搜索引擎提供已发布且未过期的职位
+type JobFeedService struct {
+	jobDao         *dao.JobDAO
+	companyService *CompanyService
+	regionService  *RegionService
+	cfg            config.FeedConfig
+}
+
+// NewJobFeedService 创建职位订阅源服务实例
+func NewJobFeedService(jobDao *dao.JobDAO, companyService *CompanyService, regionService *RegionService, cfg config.FeedConfig) *JobFeedService {
+	return &JobFeedService{
+		jobDao:         jobDao,
+		companyService: companyService,
+		regionService:  regionService,
+		cfg:            cfg,
+	}
+}
+
+// Channel 订阅源信息，self 为订阅源自身的地址，更新时间取职位的最晚更新时间
+func (s *JobFeedService) Channel(self string, items []feed.Item) feed.Channel {
+	ch := feed.Channel{
+		Title:       s.cfg.Title,
+		Description: s.cfg.Description,
+		Link:        strings.TrimRight(s.cfg.SiteURL, "/"),
+		Self:        self,
+	}
+	for _, it := range items {
+		if it.Job.UpdateTime.After(ch.Updated) {
+			ch.Updated = it.Job.UpdateTime
+		}
+	}
+	return ch
+}
+
+// Items 获取订阅源中的职位，按发布时间倒序，companyID 不为 0 时只包含该公司的职位，停用公司的职位不输出
+func (s *JobFeedService) Items(companyID uint) ([]feed.Item, error) {
+	if companyID != 0 {
+		if _, err := s.companyService.EnsureActive(companyID); err != nil {
+			return nil, err
+		}
+	}
+	jobs, err := s.jobDao.GetActiveJobs(companyID, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	ids := make([]uint, 0, len(jobs))
+	for i := range jobs {
+		ids = append(ids, jobs[i].CompanyID)
+	}
+	companies, err := s.companyService.GetCompanyMap(ids)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]feed.Item, 0, len(jobs))
+	for i := range jobs {
+		company, ok := companies[jobs[i].CompanyID]
+		if !ok || !company.IsActive() {
+			continue
+		}
+		item, err := s.newItem(&jobs[i], company)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Item 获取单个职位的订阅信息，未发布、已过期或所属公司已停用的职位视为不存在
+func (s *JobFeedService) Item(jobID uint) (*feed.Item, error) {
+	job, err := s.jobDao.GetByID(jobID)
+	if err != nil || job.DeleteStatus != 0 || !job.IsActive() {
+		return nil, errors.New(errors.JobNotFound)
+	}
+	company, err := s.companyService.EnsureActive(job.CompanyID)
+	if err != nil {
+		return nil, errors.New(errors.JobNotFound)
+	}
+	item, err := s.newItem(job, company)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// newItem 组装订阅源中的职位，补充详情页地址和行政区划名称
+func (s *JobFeedService) newItem(job *model.Job, company *model.Company) (feed.Item, error) {
+	item := feed.Item{
+		Job:     job,
+		Company: company,
+		URL:     fmt.Sprintf("%s/jobs/%d", strings.TrimRight(s.cfg.SiteURL, "/"), job.ID),
+	}
+	var err error
+	item.Province, item.City, item.District, err = s.regionService.Names(job.GeoLocation)
+	if err != nil {
+		return feed.Item{}, err
+	}
+	return item, nil
+}
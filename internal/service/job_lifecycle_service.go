@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// 职位到期提醒通知模板
+const TemplateJobExpireRemind = "job_expire_remind"
+
+// jobRemindBatch 每轮最多发送到期提醒的职位数，剩余的在下一轮发送
+const jobRemindBatch = 100
+
+// JobLifecycleService 职位发布周期服务，由定时任务按时发布、过期职位并发送到期提醒
+type JobLifecycleService struct {
+	jobDao              *dao.JobDAO
+	companyService      *CompanyService
+	notificationService *NotificationService
+}
+
+// NewJobLifecycleService 创建职位发布周期服务实例
+func NewJobLifecycleService(jobDao *dao.JobDAO, companyService *CompanyService, notificationService *NotificationService) *JobLifecycleService {
+	return &JobLifecycleService{
+		jobDao:              jobDao,
+		companyService:      companyService,
+		notificationService: notificationService,
+	}
+}
+
+// PublishScheduled 发布已到计划发布时间的待发布职位，返回发布的职位数
+func (s *JobLifecycleService) PublishScheduled(now time.Time) (int64, error) {
+	n, err := s.jobDao.PublishDue(now)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	if n > 0 {
+		logger.L.Info("已发布定时职位", zap.Int64("count", n))
+	}
+	return n, nil
+}
+
+// ExpireOverdue 将超过过期时间的职位置为已过期，返回处理的职位数
+func (s *JobLifecycleService) ExpireOverdue(now time.Time) (int64, error) {
+	n, err := s.jobDao.ExpireDue(now)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	if n > 0 {
+		logger.L.Info("已将过期职位下线", zap.Int64("count", n))
+	}
+	return n, nil
+}
+
+// RemindExpiring 向公司招聘成员发送将在 days 天内过期的职位提醒，每个职位的每个过期时间只提醒一次，返回提醒的职位数
+func (s *JobLifecycleService) RemindExpiring(now time.Time, days int) (int, error) {
+	jobs, err := s.jobDao.ListExpiring(now, now.AddDate(0, 0, days), jobRemindBatch)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	reminded := 0
+	for i := range jobs {
+		// 先记录再发送，多实例切换或重试时不会重复提醒
+		marked, err := s.jobDao.MarkExpireReminded(jobs[i].ID, jobs[i].JobExpireTime)
+		if err != nil {
+			logger.L.Error("记录职位到期提醒失败", zap.Error(err), zap.Uint("jobId", jobs[i].ID))
+			continue
+		}
+		if marked {
+			s.remind(&jobs[i], now)
+			reminded++
+		}
+	}
+	return reminded, nil
+}
+
+// remind 向职位所属公司的招聘成员发送到期提醒，模板不可用时改用站内通知，失败只记录日志
+func (s *JobLifecycleService) remind(job *model.Job, now time.Time) {
+	userIDs, err := s.companyService.ListMemberUserIDs(job.CompanyID, model.CompanyHirers...)
+	if err != nil {
+		logger.L.Error("获取公司成员失败", zap.Error(err), zap.Uint("companyId", job.CompanyID))
+		return
+	}
+	daysLeft := daysUntil(job.JobExpireTime, now)
+	expireTime := job.JobExpireTime.Local().Format(interviewTimeLayout)
+	for _, userID := range userIDs {
+		err := s.notificationService.SendNotification(userID, model.UserTypeRecruiter, TemplateJobExpireRemind, map[string]interface{}{
+			"jobName":    job.Name,
+			"days":       daysLeft,
+			"expireTime": expireTime,
+		})
+		if err == nil {
+			continue
+		}
+		logger.L.Warn("职位到期提醒模板发送失败，改用站内通知", zap.Error(err), zap.Uint("jobId", job.ID))
+		if err := s.notificationService.Create(&model.Notification{
+			UserID:   userID,
+			UserType: model.UserTypeRecruiter,
+			Type:     model.NotificationTypeSystem,
+			Title:    "职位即将到期",
+			Content:  fmt.Sprintf("您发布的%s职位将于%s到期，请及时续期。", job.Name, expireTime),
+			Channels: model.ChannelInApp,
+		}); err != nil {
+			logger.L.Error("发送职位到期提醒失败", zap.Error(err), zap.Uint("jobId", job.ID), zap.Uint("userId", userID))
+		}
+	}
+}
+
+// daysUntil 距离 expire 的剩余天数，不足一天按一天计算
+func daysUntil(expire, now time.Time) int {
+	return int(math.Ceil(expire.Sub(now).Hours() / 24))
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaysUntil(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+
+	// 整天数不多算一天
+	assert.Equal(t, 1, daysUntil(now.AddDate(0, 0, 1), now))
+	assert.Equal(t, 3, daysUntil(now.AddDate(0, 0, 3), now))
+
+	// 不足一天按一天计算
+	assert.Equal(t, 1, daysUntil(now.Add(time.Minute), now))
+	assert.Equal(t, 1, daysUntil(now.Add(23*time.Hour), now))
+	assert.Equal(t, 2, daysUntil(now.Add(25*time.Hour), now))
+	assert.Equal(t, 3, daysUntil(now.Add(71*time.Hour), now))
+}
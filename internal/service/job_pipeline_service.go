@@ -0,0 +1,98 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// JobPipelineService 职位招聘流程服务
+type JobPipelineService struct {
+	pipelineDAO *dao.JobPipelineDAO
+	jobApplyDAO *dao.JobApplyDAO
+	jobService  *JobService
+}
+
+// NewJobPipelineService 创建职位招聘流程服务实例
+func NewJobPipelineService(pipelineDAO *dao.JobPipelineDAO, jobApplyDAO *dao.JobApplyDAO, jobService *JobService) *JobPipelineService {
+	return &JobPipelineService{
+		pipelineDAO: pipelineDAO,
+		jobApplyDAO: jobApplyDAO,
+		jobService:  jobService,
+	}
+}
+
+// GetForJob 获取职位生效的招聘流程，未自定义时返回默认流程
+// custom 表示是否为职位自定义的流程
+func (s *JobPipelineService) GetForJob(jobID uint) (definition *model.PipelineDefinition, custom bool, err error) {
+	pipeline, err := s.pipelineDAO.GetByJobID(jobID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return model.DefaultPipeline(), false, nil
+		}
+		return nil, false, errors.Wrap(err, errors.InternalServerError)
+	}
+	return &pipeline.Definition, true, nil
+}
+
+// Save 保存职位自定义的招聘流程，仅职位所属公司的所有者或招聘者可以操作
+// 职位下已有申请所处的状态必须保留在新流程中，避免申请停留在未定义的阶段
+func (s *JobPipelineService) Save(jobID, userID uint, definition *model.PipelineDefinition) error {
+	job, err := s.jobService.authorizeJob(jobID, userID, model.CompanyHirers...)
+	if err != nil {
+		return err
+	}
+	if err := definition.Validate(); err != nil {
+		return errors.New(errors.InvalidPipeline).WithMessage(err.Error())
+	}
+
+	statuses, err := s.jobApplyDAO.ListStatusesByJob(jobID)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	var missing []string
+	for _, status := range statuses {
+		if _, ok := definition.Stage(enums.JobApplyEnum(status)); !ok {
+			missing = append(missing, enums.GetStatusText(status))
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New(errors.InvalidPipeline).
+			WithMessage(fmt.Sprintf("职位下仍有申请处于以下阶段，不能移除: %s", strings.Join(missing, "、")))
+	}
+
+	pipeline := &model.JobPipeline{
+		JobID:      jobID,
+		CompanyID:  job.CompanyID,
+		Definition: *definition,
+		UpdatedBy:  userID,
+	}
+	if err := s.pipelineDAO.Save(pipeline); err != nil {
+		logger.L.Error("保存招聘流程失败",
+			zap.Error(err),
+			zap.Uint("jobId", jobID),
+			zap.Uint("userId", userID))
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// Reset 删除职位自定义的招聘流程，恢复使用默认流程
+// 默认流程包含所有申请状态，已有申请不受影响
+func (s *JobPipelineService) Reset(jobID, userID uint) error {
+	if err := s.jobService.VerifyCompanyOwner(jobID, userID); err != nil {
+		return err
+	}
+	if err := s.pipelineDAO.DeleteByJobID(jobID); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/recommend"
+	"org.thinkinai.com/recruit-center/pkg/config"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// 新职位推荐通知模板
+const TemplateNewJobRecommend = "new_job_recommend"
+
+// 推荐和相似职位的数量
+const (
+	DefaultRecommendLimit = 20
+	MaxRecommendLimit     = 50
+)
+
+const (
+	recommendCandidates    = 1000           // 参与打分的最新职位数
+	recommendHistory       = 50             // 参与打分的最近收藏和投递的职位数，各自计算
+	recommendNotifyWindow  = 24 * time.Hour // 只为该时间内发布的职位发送推荐，避免上线后为存量职位发送
+	recommendNotifyBatch   = 20             // 每轮最多处理的新职位数，剩余的在下一轮处理
+	recommendResumeBatch   = 500            // 发送推荐时每次读取的简历数
+	defaultNotifyThreshold = 0.6            // 默认的推荐通知最低匹配度
+	defaultNotifyLimit     = 50             // 默认的每个职位最多通知的求职者数
+)
+
+// JobRecommendService 职位推荐服务，按求职者的简历、收藏和投递记录推荐职位，并在新职位发布后通知匹配的求职者
+type JobRecommendService struct {
+	jobDao              *dao.JobDAO
+	resumeDao           *dao.ResumeDAO
+	favoriteDAO         *dao.JobFavoriteDAO
+	jobApplyDAO         *dao.JobApplyDAO
+	jobService          *JobService
+	companyService      *CompanyService
+	regionService       *RegionService
+	notificationService *NotificationService
+	cfg                 config.RecommendConfig
+}
+
+// NewJobRecommendService 创建职位推荐服务实例，未配置的通知阈值和数量使用默认值
+func NewJobRecommendService(jobDao *dao.JobDAO, resumeDao *dao.ResumeDAO, favoriteDAO *dao.JobFavoriteDAO, jobApplyDAO *dao.JobApplyDAO,
+	jobService *JobService, companyService *CompanyService, regionService *RegionService, notificationService *NotificationService,
+	cfg config.RecommendConfig) *JobRecommendService {
+	if cfg.NotifyThreshold <= 0 {
+		cfg.NotifyThreshold = defaultNotifyThreshold
+	}
+	if cfg.NotifyLimit <= 0 {
+		cfg.NotifyLimit = defaultNotifyLimit
+	}
+	return &JobRecommendService{
+		jobDao:              jobDao,
+		resumeDao:           resumeDao,
+		favoriteDAO:         favoriteDAO,
+		jobApplyDAO:         jobApplyDAO,
+		jobService:          jobService,
+		companyService:      companyService,
+		regionService:       regionService,
+		notificationService: notificationService,
+		cfg:                 cfg,
+	}
+}
+
+// Recommend 为求职者推荐职位，按匹配度倒序，不包括已收藏和已投递的职位
+// 没有简历和记录的求职者按发布时间倒序获得最新的职位
+func (s *JobRecommendService) Recommend(userID uint, limit int) (*response.JobRecommendResponse, error) {
+	resume, err := s.resumeDao.GetByUser(userID)
+	if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if resume != nil && resume.Status != 1 {
+		resume = nil
+	}
+	history, err := s.history(userID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.candidates()
+	if err != nil {
+		return nil, err
+	}
+	matches := recommend.Default.Rank(s.profile(resume, history), candidates, limit)
+	return s.toResponse(matches, userID), nil
+}
+
+// Similar 获取与指定职位相似的有效职位，供职位详情页展示
+func (s *JobRecommendService) Similar(jobID, userID uint, limit int) (*response.JobRecommendResponse, error) {
+	target, err := s.jobDao.GetByID(jobID)
+	if err != nil || target.DeleteStatus != 0 {
+		return nil, errors.New(errors.JobNotFound)
+	}
+	candidates, err := s.candidates()
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(recommend.Similar(target, candidates, limit), userID), nil
+}
+
+// NotifyNewJobs 向匹配度达到阈值的正在求职的求职者发送新发布职位的推荐，每个职位只推荐一次，返回处理的职位数
+func (s *JobRecommendService) NotifyNewJobs(now time.Time) (int, error) {
+	jobs, err := s.jobDao.ListUnrecommended(now.Add(-recommendNotifyWindow), now, recommendNotifyBatch)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	pending := make([]*model.Job, 0, len(jobs))
+	for i := range jobs {
+		// 先记录再发送，多实例切换或重试时不会重复推荐
+		marked, err := s.jobDao.MarkRecommended(jobs[i].ID, now)
+		if err != nil {
+			logger.L.Error("记录新职位推荐失败", zap.Error(err), zap.Uint("jobId", jobs[i].ID))
+			continue
+		}
+		if !marked {
+			continue
+		}
+		if _, err := s.companyService.EnsureActive(jobs[i].CompanyID); err != nil {
+			continue
+		}
+		pending = append(pending, &jobs[i])
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	recipients, err := s.matchSeekers(pending)
+	if err != nil {
+		return 0, err
+	}
+	for i, job := range pending {
+		s.notify(job, recipients[i])
+	}
+	return len(pending), nil
+}
+
+// recipient 推荐通知的接收者
+type recipient struct {
+	userID uint
+	score  float64
+}
+
+// matchSeekers 逐批读取正在求职的简历，为每个职位找出匹配度最高的求职者
+func (s *JobRecommendService) matchSeekers(jobs []*model.Job) ([][]recipient, error) {
+	recipients := make([][]recipient, len(jobs))
+	for after := uint(0); ; {
+		resumes, err := s.resumeDao.ListSeeking(after, recommendResumeBatch)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.InternalServerError)
+		}
+		for i := range resumes {
+			p := s.profile(&resumes[i], nil)
+			for j, job := range jobs {
+				if m := recommend.Default.Score(p, job); m.Score >= s.cfg.NotifyThreshold {
+					recipients[j] = append(recipients[j], recipient{userID: resumes[i].UserID, score: m.Score})
+				}
+			}
+		}
+		if len(resumes) < recommendResumeBatch {
+			break
+		}
+		after = resumes[len(resumes)-1].ID
+	}
+
+	for j := range recipients {
+		list := recipients[j]
+		sort.SliceStable(list, func(a, b int) bool { return list[a].score > list[b].score })
+		// 同一求职者有多份简历时只通知一次
+		seen := make(map[uint]bool, len(list))
+		unique := list[:0]
+		for _, r := range list {
+			if !seen[r.userID] && len(unique) < s.cfg.NotifyLimit {
+				seen[r.userID] = true
+				unique = append(unique, r)
+			}
+		}
+		recipients[j] = unique
+	}
+	return recipients, nil
+}
+
+// notify 发送新职位推荐，模板不可用时改用站内通知，失败只记录日志
+func (s *JobRecommendService) notify(job *model.Job, recipients []recipient) {
+	for _, r := range recipients {
+		err := s.notificationService.SendNotification(r.userID, model.UserTypeJobSeeker, TemplateNewJobRecommend, map[string]interface{}{
+			"jobName": job.Name,
+			"jobId":   job.ID,
+		})
+		if err == nil {
+			continue
+		}
+		logger.L.Warn("新职位推荐模板发送失败，改用站内通知", zap.Error(err), zap.Uint("jobId", job.ID))
+		if err := s.notificationService.Create(&model.Notification{
+			UserID:   r.userID,
+			UserType: model.UserTypeJobSeeker,
+			Type:     model.NotificationTypeSystem,
+			Title:    "为您推荐新职位",
+			Content:  fmt.Sprintf("根据您的简历，为您推荐%s职位，快来看看吧！", job.Name),
+			Channels: model.ChannelInApp,
+		}); err != nil {
+			logger.L.Error("发送新职位推荐失败", zap.Error(err), zap.Uint("jobId", job.ID), zap.Uint("userId", r.userID))
+		}
+	}
+}
+
+// profile 构建求职者画像，期望城市能解析为行政区划时按行政区划匹配工作地点
+func (s *JobRecommendService) profile(resume *model.Resume, history []*model.Job) *recommend.Profile {
+	p := recommend.NewProfile(resume, history)
+	if p.ExpectedCity != "" {
+		loc, err := s.regionService.Locate("", p.ExpectedCity)
+		if err != nil {
+			logger.L.Warn("解析期望城市失败", zap.Error(err), zap.String("city", p.ExpectedCity))
+		}
+		p.Location = loc
+	}
+	return p
+}
+
+// history 获取求职者最近收藏和投递的职位
+func (s *JobRecommendService) history(userID uint) ([]*model.Job, error) {
+	favorites, err := s.favoriteDAO.ListRecentJobIDs(userID, recommendHistory)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	applied, err := s.jobApplyDAO.ListRecentJobIDs(userID, recommendHistory)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	ids := uniqueIDs(append(favorites, applied...))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	jobMap, err := s.jobService.GetJobMap(ids)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*model.Job, 0, len(jobMap))
+	for _, id := range ids {
+		if job, ok := jobMap[id]; ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// candidates 获取参与推荐的最新有效职位，不包括已停用公司的职位
+func (s *JobRecommendService) candidates() ([]*model.Job, error) {
+	jobs, err := s.jobDao.GetActiveJobs(0, recommendCandidates)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	ids := make([]uint, 0, len(jobs))
+	for i := range jobs {
+		ids = append(ids, jobs[i].CompanyID)
+	}
+	companies, err := s.companyService.GetCompanyMap(ids)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*model.Job, 0, len(jobs))
+	for i := range jobs {
+		if company, ok := companies[jobs[i].CompanyID]; ok && company.IsActive() {
+			candidates = append(candidates, &jobs[i])
+		}
+	}
+	return candidates, nil
+}
+
+// toResponse 转换为推荐职位列表，匹配度保留三位小数
+func (s *JobRecommendService) toResponse(matches []recommend.Match, userID uint) *response.JobRecommendResponse {
+	resp := &response.JobRecommendResponse{Records: make([]response.JobRecommendRecord, 0, len(matches))}
+	for _, m := range matches {
+		resp.Records = append(resp.Records, response.JobRecommendRecord{
+			JobResponse: *s.jobService.ConvertToJobResponse(m.Job, userID),
+			Score:       math.Round(m.Score*1000) / 1000,
+			Reasons:     m.Reasons,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/moderation"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
+)
+
+// autoApproveReason 内容检查通过后自动审核的审核意见
+const autoApproveReason = "内容检查未发现问题，自动审核通过"
+
+// JobReviewService 职位审核服务
+// 职位创建后为草稿，提交审核后由管理员审核，审核通过后发布(或按计划发布时间定时发布)，驳回的职位修改后可重新提交
+type JobReviewService struct {
+	reviewDao           *dao.JobReviewDAO
+	jobDao              *dao.JobDAO
+	jobService          *JobService
+	companyService      *CompanyService
+	notificationService *NotificationService
+	checker             moderation.Checker
+	autoApprove         bool
+}
+
+// NewJobReviewService 创建职位审核服务实例，autoApprove 为 true 时内容检查未发现问题的职位自动通过审核
+func NewJobReviewService(reviewDao *dao.JobReviewDAO, jobDao *dao.JobDAO, jobService *JobService, companyService *CompanyService,
+	notificationService *NotificationService, checker moderation.Checker, autoApprove bool) *JobReviewService {
+	return &JobReviewService{
+		reviewDao:           reviewDao,
+		jobDao:              jobDao,
+		jobService:          jobService,
+		companyService:      companyService,
+		notificationService: notificationService,
+		checker:             checker,
+		autoApprove:         autoApprove,
+	}
+}
+
+// Submit 提交职位审核，只有草稿或被驳回的职位可以提交，提交时检查职位内容，发现的问题供管理员参考
+func (s *JobReviewService) Submit(jobID, userID uint) (*response.JobReviewResponse, error) {
+	job, err := s.jobService.authorizeJob(jobID, userID, model.CompanyHirers...)
+	if err != nil {
+		return nil, err
+	}
+	submittable := []enums.JobStatus{enums.JobStatusDraft, enums.JobStatusRejected}
+	if !slices.Contains(submittable, enums.JobStatus(job.Status)) {
+		return nil, errors.New(errors.InvalidJobStatus).WithMessage("只有草稿或被驳回的职位可以提交审核")
+	}
+
+	revision, err := s.jobDao.EnsureRevision(jobID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	review := &model.JobReview{
+		JobID:       jobID,
+		CompanyID:   job.CompanyID,
+		Revision:    revision,
+		SubmitterID: userID,
+		Status:      model.JobReviewPending,
+		Issues:      s.checker.Check(job),
+	}
+	submitted, err := s.reviewDao.Submit(review, submittable)
+	if err != nil {
+		logger.L.Error("提交职位审核失败", zap.Error(err), zap.Uint("jobId", jobID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if !submitted {
+		return nil, errors.New(errors.InvalidJobStatus).WithMessage("职位状态已变化，请刷新后重试")
+	}
+
+	if s.autoApprove && len(review.Issues) == 0 {
+		if _, err := s.resolve(review, model.JobReviewApproved, 0, autoApproveReason); err != nil {
+			// 自动审核失败时保留待审核状态，由管理员审核
+			logger.L.Error("自动审核职位失败", zap.Error(err), zap.Uint("jobId", jobID))
+		}
+	}
+	resp := response.NewJobReviewResponse(review)
+	return &resp, nil
+}
+
+// Approve 审核通过，职位按计划发布时间发布，未设置或已过发布时间的立即发布
+func (s *JobReviewService) Approve(reviewID, reviewerID uint, reason string) (*response.JobReviewResponse, error) {
+	review, err := s.getPending(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolve(review, model.JobReviewApproved, reviewerID, reason)
+}
+
+// Reject 审核驳回，须填写驳回原因，职位修改后可重新提交
+func (s *JobReviewService) Reject(reviewID, reviewerID uint, reason string) (*response.JobReviewResponse, error) {
+	if reason == "" {
+		return nil, errors.New(errors.InvalidParams).WithMessage("请填写驳回原因")
+	}
+	review, err := s.getPending(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolve(review, model.JobReviewRejected, reviewerID, reason)
+}
+
+// List 分页获取审核队列，先提交的在前，status 为空时不限审核结果
+func (s *JobReviewService) List(status model.JobReviewStatus, page pagination.Page) (*response.JobReviewListResponse, error) {
+	if status != "" && !status.IsValid() {
+		return nil, errors.New(errors.InvalidParams).WithMessage("无效的审核状态")
+	}
+	result, err := s.reviewDao.List(status, page)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	jobIDs := make([]uint, len(result.Records))
+	for i := range result.Records {
+		jobIDs[i] = result.Records[i].JobID
+	}
+	jobs, err := s.jobService.GetJobMap(jobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.JobReviewListResponse{
+		Total:      result.Total,
+		Records:    make([]response.JobReviewResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
+	}
+	for i := range result.Records {
+		resp.Records[i] = response.NewJobReviewResponse(&result.Records[i])
+		if job, ok := jobs[result.Records[i].JobID]; ok {
+			resp.Records[i].JobName = job.Name
+		}
+	}
+	return resp, nil
+}
+
+// ListByJob 获取职位的审核记录，按提交时间倒序
+func (s *JobReviewService) ListByJob(jobID uint) ([]response.JobReviewResponse, error) {
+	reviews, err := s.reviewDao.ListByJob(jobID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	resp := make([]response.JobReviewResponse, len(reviews))
+	for i := range reviews {
+		resp[i] = response.NewJobReviewResponse(&reviews[i])
+	}
+	return resp, nil
+}
+
+// getPending 获取待审核的审核记录
+func (s *JobReviewService) getPending(reviewID uint) (*model.JobReview, error) {
+	review, err := s.reviewDao.GetByID(reviewID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobReviewNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if review.Status != model.JobReviewPending {
+		return nil, errors.New(errors.InvalidJobStatus).WithMessage("该审核已处理")
+	}
+	return review, nil
+}
+
+// resolve 记录审核结果并更新职位状态，完成后通知公司的所有者和招聘者
+func (s *JobReviewService) resolve(review *model.JobReview, status model.JobReviewStatus, reviewerID uint, reason string) (*response.JobReviewResponse, error) {
+	job, err := s.jobDao.GetByID(review.JobID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.JobNotFound)
+	}
+	now := time.Now()
+	updates := map[string]interface{}{"status": enums.JobStatusRejected}
+	if status == model.JobReviewApproved {
+		if err := schedulePublish(job, now); err != nil {
+			return nil, err
+		}
+		updates = map[string]interface{}{"status": job.Status, "publish_time": job.PublishTime}
+	}
+
+	review.Status = status
+	review.ReviewerID = reviewerID
+	review.Reason = reason
+	review.ReviewTime = &now
+	resolved, err := s.reviewDao.Resolve(review, updates)
+	if err != nil {
+		logger.L.Error("处理职位审核失败", zap.Error(err), zap.Uint("reviewId", review.ID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if !resolved {
+		return nil, errors.New(errors.InvalidJobStatus).WithMessage("该审核已处理")
+	}
+
+	s.notifyResult(job, review)
+	resp := response.NewJobReviewResponse(review)
+	resp.JobName = job.Name
+	return &resp, nil
+}
+
+// notifyResult 通知公司的所有者和招聘者审核结果，失败只记录日志
+func (s *JobReviewService) notifyResult(job *model.Job, review *model.JobReview) {
+	title, content := "职位审核通过", fmt.Sprintf("您提交的%s职位已通过审核。", job.Name)
+	if job.IsScheduled() {
+		content = fmt.Sprintf("您提交的%s职位已通过审核，将于%s发布。", job.Name, job.PublishTime.Local().Format(interviewTimeLayout))
+	}
+	if job.Status == int(enums.JobStatusExpired) {
+		content = fmt.Sprintf("您提交的%s职位已通过审核，但已超过过期时间，延长过期时间后即可上线。", job.Name)
+	}
+	if review.Status == model.JobReviewRejected {
+		title, content = "职位审核未通过", fmt.Sprintf("您提交的%s职位未通过审核，原因：%s。修改后可重新提交审核。", job.Name, review.Reason)
+	}
+
+	userIDs, err := s.companyService.ListMemberUserIDs(job.CompanyID, model.CompanyHirers...)
+	if err != nil {
+		logger.L.Error("获取公司成员失败", zap.Error(err), zap.Uint("companyId", job.CompanyID))
+		return
+	}
+	notifications := make([]model.Notification, 0, len(userIDs))
+	for _, id := range userIDs {
+		notifications = append(notifications, model.Notification{
+			UserID:   id,
+			UserType: model.UserTypeRecruiter,
+			Type:     model.NotificationTypeSystem,
+			Title:    title,
+			Content:  content,
+			Channels: model.ChannelInApp,
+		})
+	}
+	if err := s.notificationService.CreateBatch(notifications); err != nil {
+		logger.L.Error("发送职位审核通知失败", zap.Error(err), zap.Uint("jobId", job.ID))
+	}
+}
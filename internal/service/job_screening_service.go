@@ -0,0 +1,91 @@
+package service
+
+import (
+	stderrors "errors"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// JobScreeningService 职位筛选问题服务
+type JobScreeningService struct {
+	screeningDAO *dao.JobScreeningDAO
+	jobService   *JobService
+}
+
+// NewJobScreeningService 创建职位筛选问题服务实例
+func NewJobScreeningService(screeningDAO *dao.JobScreeningDAO, jobService *JobService) *JobScreeningService {
+	return &JobScreeningService{
+		screeningDAO: screeningDAO,
+		jobService:   jobService,
+	}
+}
+
+// GetForJob 获取职位的筛选问题，未配置时返回空定义
+// configured 表示职位是否配置了筛选问题
+func (s *JobScreeningService) GetForJob(jobID uint) (definition *model.ScreeningDefinition, configured bool, err error) {
+	screening, err := s.screeningDAO.GetByJobID(jobID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return &model.ScreeningDefinition{}, false, nil
+		}
+		return nil, false, errors.Wrap(err, errors.InternalServerError)
+	}
+	return &screening.Definition, true, nil
+}
+
+// Save 保存职位的筛选问题，仅职位所属公司的所有者或招聘者可以操作
+// 修改只影响之后的申请，已提交的回答保留提交时的问题内容
+func (s *JobScreeningService) Save(jobID, userID uint, definition *model.ScreeningDefinition) error {
+	job, err := s.jobService.authorizeJob(jobID, userID, model.CompanyHirers...)
+	if err != nil {
+		return err
+	}
+	if err := definition.Validate(); err != nil {
+		return errors.New(errors.InvalidScreening).WithMessage(err.Error())
+	}
+
+	screening := &model.JobScreening{
+		JobID:      jobID,
+		CompanyID:  job.CompanyID,
+		Definition: *definition,
+		UpdatedBy:  userID,
+	}
+	if err := s.screeningDAO.Save(screening); err != nil {
+		logger.L.Error("保存职位筛选问题失败",
+			zap.Error(err),
+			zap.Uint("jobId", jobID),
+			zap.Uint("userId", userID))
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// Delete 删除职位的筛选问题，之后的申请无需回答问题
+func (s *JobScreeningService) Delete(jobID, userID uint) error {
+	if err := s.jobService.VerifyCompanyOwner(jobID, userID); err != nil {
+		return err
+	}
+	if err := s.screeningDAO.DeleteByJobID(jobID); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// Evaluate 校验申请人对职位筛选问题的回答并评估淘汰规则
+// 职位未配置筛选问题时不接受回答
+func (s *JobScreeningService) Evaluate(jobID uint, answers []model.ScreeningAnswer) (*model.ScreeningResult, error) {
+	definition, _, err := s.GetForJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := definition.Evaluate(answers)
+	if err != nil {
+		return nil, errors.New(errors.InvalidScreening).WithMessage(err.Error())
+	}
+	return result, nil
+}
@@ -1,36 +1,59 @@
 package service
 
 import (
+	stderrors "errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/api/dto/response"
 	"org.thinkinai.com/recruit-center/internal/dao"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/moderation"
 	"org.thinkinai.com/recruit-center/pkg/enums"
 	"org.thinkinai.com/recruit-center/pkg/errors"
 	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
+	"org.thinkinai.com/recruit-center/pkg/utils"
 )
 
+// jobSnippetLength 搜索结果中职位描述高亮片段的最大长度
+const jobSnippetLength = 120
+
 // JobService 职位服务
 type JobService struct {
-	jobDao      *dao.JobDAO
-	favoriteDAO *dao.JobFavoriteDAO
-	jobApplyDAO *dao.JobApplyDAO
+	jobDao         *dao.JobDAO
+	favoriteDAO    *dao.JobFavoriteDAO
+	jobApplyDAO    *dao.JobApplyDAO
+	companyService *CompanyService
+	regionService  *RegionService
+	checker        moderation.Checker // 修改已通过审核的职位时的内容检查，为空时不检查
 }
 
 // NewJobService 创建职位服务实例
-func NewJobService(jobDao *dao.JobDAO, favoriteDAO *dao.JobFavoriteDAO, jobApplyDAO *dao.JobApplyDAO) *JobService {
+func NewJobService(jobDao *dao.JobDAO, favoriteDAO *dao.JobFavoriteDAO, jobApplyDAO *dao.JobApplyDAO, companyService *CompanyService,
+	regionService *RegionService) *JobService {
 	return &JobService{
-		jobDao:      jobDao,
-		favoriteDAO: favoriteDAO,
-		jobApplyDAO: jobApplyDAO,
+		jobDao:         jobDao,
+		favoriteDAO:    favoriteDAO,
+		jobApplyDAO:    jobApplyDAO,
+		companyService: companyService,
+		regionService:  regionService,
 	}
 }
 
-// Create 创建职位
-func (s *JobService) Create(job *model.Job) error {
+// SetContentChecker 设置修改已通过审核的职位时使用的内容检查器
+func (s *JobService) SetContentChecker(checker moderation.Checker) {
+	s.checker = checker
+}
+
+// Create 创建职位，操作人必须是公司的所有者或招聘者，新职位为草稿，提交审核并通过后发布
+// 指定行政区划代码时按代码设置结构化地点，否则从工作地点文本中解析
+func (s *JobService) Create(job *model.Job, regionCode string, userID uint) error {
 	// 参数校验
 	if job.Name == "" {
 		return fmt.Errorf("职位名称不能为空")
@@ -40,90 +63,358 @@ func (s *JobService) Create(job *model.Job) error {
 	}
 
 	// 验证公司是否存在且有效
-	// company, err := s.companyDAO.GetByID(job.CompanyID)
-	// if err != nil {
-	// 	return errors.Wrap(err, enums.CompanyNotFound)
-	// }
-	// if !company.IsActive() {
-	// 	return errors.New(enums.CompanyInactive)
-	// }
+	if _, err := s.companyService.EnsureActive(job.CompanyID); err != nil {
+		return err
+	}
+	// 验证操作人属于该公司
+	if _, err := s.companyService.CheckMember(job.CompanyID, userID, model.CompanyHirers...); err != nil {
+		return err
+	}
 
+	if err := s.prepareCreate(job, regionCode); err != nil {
+		return err
+	}
+
+	// 创建职位
+	if err := s.jobDao.Create(job, userID); err != nil {
+		logger.L.Error("创建职位失败",
+			zap.Error(err),
+			zap.String("job_name", job.Name),
+			zap.Uint("company_id", job.CompanyID))
+		return err
+	}
+	return nil
+}
+
+// prepareCreate 校验新职位，解析结构化地点并设置默认值，新职位为草稿，审核通过时按计划发布时间发布
+func (s *JobService) prepareCreate(job *model.Job, regionCode string) error {
 	// 验证职位类型
 	if !job.ValidateJobType() {
 		return errors.New(errors.BadRequest).WithMessage("无效的职位类型")
 	}
 
-	// 设置默认值
-	if job.Status == 0 {
-		job.Status = int(enums.JobStatusNormal)
+	// 解析结构化地点
+	location, err := s.regionService.Locate(regionCode, job.JobLocation)
+	if err != nil {
+		return err
 	}
+	job.GeoLocation = location
+
+	job.Status = int(enums.JobStatusDraft)
 	if job.JobExpireTime.IsZero() {
 		job.JobExpireTime = time.Now().AddDate(0, 1, 0) // 默认一个月后过期
 	}
+	return validatePublishTime(job)
+}
 
-	// 创建职位
-	err := s.jobDao.Create(job)
+// JobImportItem 待导入的职位，Row 为表格中的行号，Errors 为解析阶段发现的错误，有错误时 Job 为空
+type JobImportItem struct {
+	Row        int
+	Job        *model.Job
+	RegionCode string
+	Errors     []response.JobImportError
+}
+
+// Import 校验并在同一事务中批量创建公司的职位，操作人必须是公司的所有者或招聘者
+// 导入的职位均为草稿；任一行有错误或 dryRun 时不保存任何职位，返回全部行的错误
+func (s *JobService) Import(companyID, userID uint, items []JobImportItem, dryRun bool) (*response.JobImportResponse, error) {
+	if _, err := s.companyService.EnsureActive(companyID); err != nil {
+		return nil, err
+	}
+	if _, err := s.companyService.CheckMember(companyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+
+	resp := &response.JobImportResponse{
+		DryRun: dryRun,
+		Total:  len(items),
+		JobIDs: []uint{},
+		Errors: []response.JobImportError{},
+	}
+	jobs := make([]*model.Job, 0, len(items))
+	for _, item := range items {
+		if len(item.Errors) > 0 {
+			resp.Errors = append(resp.Errors, item.Errors...)
+			continue
+		}
+		item.Job.CompanyID = companyID
+		if err := s.prepareCreate(item.Job, item.RegionCode); err != nil {
+			var bizErr *errors.Error
+			if !stderrors.As(err, &bizErr) || bizErr.Code == errors.InternalServerError {
+				return nil, err
+			}
+			resp.Errors = append(resp.Errors, response.JobImportError{Row: item.Row, Message: bizErr.Message})
+			continue
+		}
+		jobs = append(jobs, item.Job)
+	}
+	if dryRun || len(resp.Errors) > 0 || len(jobs) == 0 {
+		return resp, nil
+	}
+
+	if err := s.jobDao.CreateBatch(jobs, userID); err != nil {
+		logger.L.Error("批量导入职位失败", zap.Error(err), zap.Uint("company_id", companyID), zap.Int("count", len(jobs)))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	for _, job := range jobs {
+		resp.JobIDs = append(resp.JobIDs, job.ID)
+	}
+	resp.Imported = len(jobs)
+	return resp, nil
+}
+
+// ListForExport 获取公司的全部职位用于导出，包括未发布和已过期的职位，公司访问权限由路由校验
+func (s *JobService) ListForExport(companyID uint) ([]model.Job, error) {
+	jobs, err := s.jobDao.ListByCompany(companyID)
 	if err != nil {
-		logger.L.Error("创建职位失败",
-			zap.Error(err),
-			zap.String("job_name", job.Name),
-			zap.Uint("company_id", job.CompanyID))
-		return err
+		return nil, errors.Wrap(err, errors.InternalServerError)
 	}
-	return nil
+	return jobs, nil
+}
+
+// VerifyCompanyOwner 验证操作人是职位所属公司的所有者或招聘者
+func (s *JobService) VerifyCompanyOwner(jobID, userID uint) error {
+	_, err := s.authorizeJob(jobID, userID, model.CompanyHirers...)
+	return err
+}
+
+// VerifyCompanyMember 验证操作人是职位所属公司的成员，用于只读访问
+func (s *JobService) VerifyCompanyMember(jobID, userID uint) error {
+	_, err := s.authorizeJob(jobID, userID, model.CompanyReaders...)
+	return err
 }
 
-// VerifyCompanyOwner 验证职位是否属于指定公司
-func (s *JobService) VerifyCompanyOwner(jobID, companyID uint) error {
+// authorizeJob 获取职位并验证操作人在职位所属公司中拥有指定角色
+func (s *JobService) authorizeJob(jobID, userID uint, roles ...model.CompanyRole) (*model.Job, error) {
 	job, err := s.jobDao.GetByID(jobID)
 	if err != nil {
 		logger.L.Error("获取职位失败",
 			zap.Error(err),
 			zap.Uint("jobId", jobID))
-		return errors.Wrap(err, errors.JobNotFound)
+		return nil, errors.Wrap(err, errors.JobNotFound)
+	}
+
+	if _, err := s.companyService.CheckMember(job.CompanyID, userID, roles...); err != nil {
+		var bizErr *errors.Error
+		if stderrors.As(err, &bizErr) && bizErr.Code == errors.CompanyAccessDenied {
+			logger.L.Warn("职位不属于操作人所在公司",
+				zap.Uint("jobId", jobID),
+				zap.Uint("userId", userID),
+				zap.Uint("ownerCompanyId", job.CompanyID))
+			return nil, errors.New(errors.JobNotBelongToCompany)
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// Update 更新职位信息，职位所属公司不可修改，结构化地点按行政区划代码或工作地点文本重新解析
+func (s *JobService) Update(job *model.Job, regionCode string, userID uint) error {
+	existing, err := s.authorizeJob(job.ID, userID, model.CompanyHirers...)
+	if err != nil {
+		return err
+	}
+	job.CompanyID = existing.CompanyID
+	if job.GeoLocation, err = s.regionService.Locate(regionCode, job.JobLocation); err != nil {
+		return err
+	}
+	if err := updateLifecycle(job, existing, time.Now()); err != nil {
+		return err
+	}
+	if err := s.checkEditable(job, existing); err != nil {
+		return err
 	}
+	return s.jobDao.Update(job, &model.JobRevision{Action: model.JobRevisionUpdate, EditorID: userID})
+}
 
-	if job.CompanyID != companyID {
-		logger.L.Warn("职位不属于该公司",
-			zap.Uint("jobId", jobID),
-			zap.Uint("companyId", companyID),
-			zap.Uint("ownerCompanyId", job.CompanyID))
-		return errors.New(errors.JobNotBelongToCompany)
+// checkEditable 检查职位能否修改为 job 的内容：审核中的职位不能修改，已通过审核的职位修改后的内容须通过检查
+func (s *JobService) checkEditable(job, existing *model.Job) error {
+	status := enums.JobStatus(existing.Status)
+	if status == enums.JobStatusPendingReview {
+		return errors.New(errors.JobUpdateNotAllowed).WithMessage("职位审核中，不能修改")
+	}
+	if !status.IsApproved() || s.checker == nil {
+		return nil
+	}
+	if issues := s.checker.Check(job); len(issues) > 0 {
+		return errors.New(errors.JobContentViolation).WithMessage(model.JoinContentIssues(issues))
 	}
 	return nil
 }
 
-// Update 更新职位信息
-func (s *JobService) Update(job *model.Job) error {
-	if err := s.VerifyCompanyOwner(job.ID, job.CompanyID); err != nil {
+// schedulePublish 按计划发布时间设置职位状态，发布时间晚于当前时间的职位为待发布，否则立即发布
+// 审核期间已超过过期时间的职位发布后即为已过期，延长过期时间后重新上线
+func schedulePublish(job *model.Job, now time.Time) error {
+	if job.PublishTime == nil || !job.PublishTime.After(now) {
+		job.PublishTime = &now
+		job.Status = int(enums.JobStatusNormal)
+		if !job.JobExpireTime.After(now) {
+			job.Status = int(enums.JobStatusExpired)
+		}
+		return nil
+	}
+	if err := validatePublishTime(job); err != nil {
 		return err
 	}
-	return s.jobDao.Update(job)
+	job.Status = int(enums.JobStatusScheduled)
+	return nil
+}
+
+// validatePublishTime 校验计划发布时间早于过期时间
+func validatePublishTime(job *model.Job) error {
+	if job.PublishTime != nil && !job.PublishTime.Before(job.JobExpireTime) {
+		return errors.New(errors.InvalidParams).WithMessage("发布时间须早于过期时间")
+	}
+	return nil
+}
+
+// updateLifecycle 更新职位时保留状态和发布周期字段，只有尚未发布的职位可以调整发布时间，已过期的职位延长过期时间后重新上线
+func updateLifecycle(job, existing *model.Job, now time.Time) error {
+	if job.JobExpireTime.IsZero() {
+		job.JobExpireTime = existing.JobExpireTime
+	}
+	publishTime := job.PublishTime
+	job.Status = existing.Status
+	job.PublishTime = existing.PublishTime
+	job.RemindedExpireTime = existing.RemindedExpireTime
+
+	if !enums.JobStatus(existing.Status).IsApproved() {
+		// 未通过审核的职位审核通过时才按计划发布时间发布
+		if publishTime != nil {
+			job.PublishTime = publishTime
+		}
+		return validatePublishTime(job)
+	}
+	if existing.IsScheduled() {
+		if publishTime != nil {
+			job.PublishTime = publishTime
+		}
+		return schedulePublish(job, now)
+	}
+	if publishTime != nil && (existing.PublishTime == nil || !publishTime.Equal(*existing.PublishTime)) {
+		return errors.New(errors.InvalidJobStatus).WithMessage("职位已发布，不能修改发布时间")
+	}
+	if existing.Status == int(enums.JobStatusExpired) && job.JobExpireTime.After(now) {
+		job.Status = int(enums.JobStatusNormal)
+	}
+	return nil
+}
+
+// ListRevisions 获取职位的修订版本列表，按版本号倒序
+func (s *JobService) ListRevisions(jobID uint) ([]response.JobRevisionResponse, error) {
+	job, err := s.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := s.jobDao.ListRevisions(jobID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	resp := make([]response.JobRevisionResponse, len(revisions))
+	for i := range revisions {
+		resp[i] = response.NewJobRevisionResponse(&revisions[i], job.Revision)
+	}
+	return resp, nil
+}
+
+// DiffRevisions 比较职位两个修订版本的内容，to 为0时与当前版本比较
+// 候选人可以用申请时的版本号查看职位此后的变化，调用方负责校验职位对操作人是否可见
+func (s *JobService) DiffRevisions(job *model.Job, from, to int) (*response.JobRevisionDiffResponse, error) {
+	if to == 0 {
+		to = job.Revision
+	}
+	older, err := s.getRevision(job.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := s.getRevision(job.ID, to)
+	if err != nil {
+		return nil, err
+	}
+	return &response.JobRevisionDiffResponse{
+		JobID:   job.ID,
+		From:    from,
+		To:      to,
+		Changes: older.Snapshot.Diff(newer.Snapshot),
+	}, nil
+}
+
+// RestoreRevision 将职位内容恢复到指定的历史版本，恢复本身作为新版本保存，不改变职位状态和发布周期
+func (s *JobService) RestoreRevision(jobID uint, revision int, userID uint) (*response.JobResponse, error) {
+	job, err := s.authorizeJob(jobID, userID, model.CompanyHirers...)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getRevision(jobID, revision)
+	if err != nil {
+		return nil, err
+	}
+	target.Snapshot.ApplyTo(job)
+	if err := s.checkEditable(job, job); err != nil {
+		return nil, err
+	}
+	if err := s.jobDao.Update(job, &model.JobRevision{Action: model.JobRevisionRestore, RestoredFrom: revision, EditorID: userID}); err != nil {
+		logger.L.Error("恢复职位版本失败", zap.Error(err), zap.Uint("jobId", jobID), zap.Int("revision", revision))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return s.ConvertToJobResponse(job, userID), nil
+}
+
+// CurrentRevision 获取职位当前的修订版本号，升级前已存在的职位尚无版本时先保存当前内容为第一个版本
+func (s *JobService) CurrentRevision(job *response.JobResponse) (int, error) {
+	if job.Revision > 0 {
+		return job.Revision, nil
+	}
+	revision, err := s.jobDao.EnsureRevision(job.ID)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	return revision, nil
+}
+
+// getRevision 获取职位的指定修订版本
+func (s *JobService) getRevision(jobID uint, revision int) (*model.JobRevision, error) {
+	r, err := s.jobDao.GetRevision(jobID, revision)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobRevisionNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return r, nil
 }
 
 // Delete 删除职位
-func (s *JobService) Delete(id uint, companyID uint) error {
-	if err := s.VerifyCompanyOwner(id, companyID); err != nil {
+func (s *JobService) Delete(id uint, userID uint) error {
+	if err := s.VerifyCompanyOwner(id, userID); err != nil {
 		return err
 	}
 	return s.jobDao.Delete(id)
 }
 
 // UpdateStatus 更新职位状态
-func (s *JobService) UpdateStatus(id uint, status int, companyID uint) error {
+func (s *JobService) UpdateStatus(id uint, status int, userID uint) error {
 	logger.L.Info("更新职位状态",
 		zap.Uint("job_id", id),
 		zap.Int("status", status),
-		zap.Uint("company_id", companyID))
+		zap.Uint("user_id", userID))
 
-	if err := s.VerifyCompanyOwner(id, companyID); err != nil {
+	job, err := s.authorizeJob(id, userID, model.CompanyHirers...)
+	if err != nil {
 		return err
 	}
 
-	// 检查状态是否有效
-	if !enums.JobStatus(status).IsValid() {
+	// 检查状态是否有效，只能在正常和已过期之间切换，其余状态由审核流程和定时任务维护
+	target := enums.JobStatus(status)
+	if !target.IsValid() {
 		return errors.New(errors.InvalidJobStatus)
 	}
+	if target != enums.JobStatusNormal && target != enums.JobStatusExpired {
+		return errors.New(errors.InvalidJobStatus).WithMessage("只能将职位设置为正常或已过期")
+	}
+	if current := enums.JobStatus(job.Status); current != enums.JobStatusNormal && current != enums.JobStatusExpired {
+		return errors.New(errors.InvalidJobStatus).WithMessage("职位尚未发布，不能修改状态")
+	}
 
 	return s.jobDao.UpdateStatus(id, status)
 }
@@ -143,6 +434,8 @@ func (s *JobService) ConvertToJobResponse(job *model.Job, userID uint) *response
 		JobDescribe:   job.JobDescribe,
 		JobLocation:   job.JobLocation,
 		JobExpireTime: job.JobExpireTime,
+		PublishTime:   job.PublishTime,
+		Revision:      job.Revision,
 		Status:        job.Status,
 		JobType:       job.JobType,
 		JobCategory:   job.JobCategory,
@@ -155,6 +448,7 @@ func (s *JobService) ConvertToJobResponse(job *model.Job, userID uint) *response
 		ApplyCount:    job.ApplyCount,
 		Priority:      job.Priority,
 		Tags:          job.Tags,
+		GeoLocation:   job.GeoLocation,
 	}
 
 	// 如果提供了用户ID，查询用户状态
@@ -182,61 +476,229 @@ func (s *JobService) GetByID(id uint, userID uint) (*response.JobResponse, error
 	return s.ConvertToJobResponse(job, userID), nil
 }
 
-// List 获取职位列表
-func (s *JobService) List(page, size int, userID uint) (*response.JobListResponse, error) {
-	jobs, total, err := s.jobDao.List(page, size)
+// GetJob 获取职位，区分职位不存在和查询失败
+func (s *JobService) GetJob(id uint) (*model.Job, error) {
+	job, err := s.jobDao.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return job, nil
+}
+
+// GetJobMap 批量获取职位，以职位ID为键
+func (s *JobService) GetJobMap(ids []uint) (map[uint]*model.Job, error) {
+	jobs, err := s.jobDao.GetByIDs(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	jobMap := make(map[uint]*model.Job, len(jobs))
+	for i := range jobs {
+		jobMap[jobs[i].ID] = &jobs[i]
+	}
+	return jobMap, nil
+}
+
+// List 获取职位列表，按发布时间倒序，支持页码和游标分页
+func (s *JobService) List(page pagination.Page, userID uint) (*response.JobListResponse, error) {
+	result, err := s.jobDao.List(page)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.JobListResponse{
-		Total:   total,
-		Records: make([]response.JobResponse, len(jobs)),
+		Total:      result.Total,
+		Records:    make([]response.JobResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
 	}
 
-	for i, job := range jobs {
+	for i, job := range result.Records {
 		resp.Records[i] = *s.ConvertToJobResponse(&job, userID)
 	}
 
 	return resp, nil
 }
 
-// SearchByKeyword 关键词搜索职位
-func (s *JobService) SearchByKeyword(keyword string) (*response.JobListResponse, error) {
-	jobs, err := s.jobDao.SearchByKeyword(keyword)
+// Search 搜索有效职位，指定关键词时按名称、技能要求和描述的全文检索相关度排序并返回高亮片段
+func (s *JobService) Search(cond model.JobSearchCondition, page, size int, userID uint) (*response.JobSearchResponse, error) {
+	if cond.RegionCode != "" {
+		if _, err := s.regionService.Get(cond.RegionCode); err != nil {
+			return nil, err
+		}
+	}
+	hits, total, err := s.jobDao.Search(cond, page, size)
 	if err != nil {
-		return nil, err
+		logger.L.Error("搜索职位失败", zap.Error(err), zap.String("keyword", cond.Keyword))
+		return nil, errors.Wrap(err, errors.InternalServerError)
 	}
 
-	resp := &response.JobListResponse{
-		Total:   int64(len(jobs)),
-		Records: make([]response.JobResponse, len(jobs)),
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.JobID
+	}
+	jobs, err := s.jobDao.GetByIDs(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	byID := make(map[uint]*model.Job, len(jobs))
+	for i := range jobs {
+		byID[jobs[i].ID] = &jobs[i]
 	}
 
-	for i, job := range jobs {
-		resp.Records[i] = *s.ConvertToJobResponse(&job, 0)
+	facets, err := s.jobDao.Facets(cond)
+	if err != nil {
+		logger.L.Error("统计职位搜索分面失败", zap.Error(err), zap.String("keyword", cond.Keyword))
+		return nil, errors.Wrap(err, errors.InternalServerError)
 	}
 
+	keywords := strings.Fields(cond.Keyword)
+	resp := &response.JobSearchResponse{
+		Total:   total,
+		Records: make([]response.JobSearchRecord, 0, len(hits)),
+		Facets:  jobFacetResponses(cond, facets),
+	}
+	for _, hit := range hits {
+		job, ok := byID[hit.JobID]
+		if !ok {
+			continue
+		}
+		record := response.JobSearchRecord{
+			JobResponse: *s.ConvertToJobResponse(job, userID),
+			Rank:        hit.Rank,
+			Highlights: response.JobHighlightResponse{
+				Name:        utils.HighlightSnippet(job.Name, keywords, 0),
+				JobSkill:    utils.HighlightSnippet(job.JobSkill, keywords, 0),
+				JobDescribe: utils.HighlightSnippet(job.JobDescribe, keywords, jobSnippetLength),
+			},
+		}
+		if cond.Near != nil && job.HasCoordinates() {
+			distance := utils.DistanceKm(cond.Near.Latitude, cond.Near.Longitude, *job.Latitude, *job.Longitude)
+			distance = math.Round(distance*100) / 100
+			record.Distance = &distance
+		}
+		resp.Records = append(resp.Records, record)
+	}
 	return resp, nil
 }
 
-// SearchByCondition 多条件搜索职位
-func (s *JobService) SearchByCondition(conditions map[string]interface{}, page, size int) (*response.JobListResponse, error) {
-	jobs, total, err := s.jobDao.SearchByCondition(conditions, page, size)
+// jobFacetResponses 转换分面统计，补充展示名称并标记已选中的取值
+// 薪资区间按固定顺序返回全部区间；其他分面中已选中但没有统计到的取值以0补齐，地点为模糊匹配不补齐
+func jobFacetResponses(cond model.JobSearchCondition, facets map[model.JobFacet][]dao.FacetCount) []response.JobFacetResponse {
+	result := make([]response.JobFacetResponse, 0, len(model.JobFacets))
+	for _, facet := range model.JobFacets {
+		selected := selectedFacetValues(cond, facet)
+		isSelected := make(map[string]bool, len(selected))
+		for _, value := range selected {
+			isSelected[value] = true
+		}
+
+		values := make([]response.JobFacetValueResponse, 0, len(facets[facet]))
+		if facet == model.JobFacetSalary {
+			counts := make(map[string]int64, len(facets[facet]))
+			for _, count := range facets[facet] {
+				counts[count.Value] = count.Count
+			}
+			for _, r := range model.SalaryRanges {
+				values = append(values, response.JobFacetValueResponse{
+					Value:    r.Key,
+					Name:     r.Name,
+					Count:    counts[r.Key],
+					Selected: isSelected[r.Key],
+				})
+			}
+		} else {
+			seen := make(map[string]bool, len(facets[facet]))
+			for _, count := range facets[facet] {
+				seen[count.Value] = true
+				values = append(values, response.JobFacetValueResponse{
+					Value:    count.Value,
+					Name:     facetValueName(facet, count.Value),
+					Count:    count.Count,
+					Selected: isSelected[count.Value],
+				})
+			}
+			for _, value := range selected {
+				if seen[value] || facet == model.JobFacetLocation {
+					continue
+				}
+				seen[value] = true
+				values = append(values, response.JobFacetValueResponse{
+					Value:    value,
+					Name:     facetValueName(facet, value),
+					Selected: true,
+				})
+			}
+		}
+
+		result = append(result, response.JobFacetResponse{
+			Field:  string(facet),
+			Name:   facet.DisplayName(),
+			Values: values,
+		})
+	}
+	return result
+}
+
+// selectedFacetValues 搜索条件中某个分面已选中的取值
+func selectedFacetValues(cond model.JobSearchCondition, facet model.JobFacet) []string {
+	var values []string
+	switch facet {
+	case model.JobFacetJobType:
+		for _, jobType := range cond.JobTypes {
+			values = append(values, strconv.Itoa(jobType))
+		}
+	case model.JobFacetJobCategory:
+		values = cond.JobCategories
+	case model.JobFacetRemoteType:
+		for _, remoteType := range cond.RemoteTypes {
+			values = append(values, strconv.Itoa(int(remoteType)))
+		}
+	case model.JobFacetBenefit:
+		for _, benefit := range cond.Benefits {
+			values = append(values, strconv.Itoa(int(benefit)))
+		}
+	case model.JobFacetEducation:
+		values = cond.Educations
+	case model.JobFacetExperience:
+		values = cond.Experiences
+	case model.JobFacetSalary:
+		values = cond.SalaryRanges
+	case model.JobFacetLocation:
+		values = cond.Locations
+	}
+	return values
+}
+
+// facetValueName 分面取值的展示名称，枚举类分面转换为文本，其他分面原样返回
+func facetValueName(facet model.JobFacet, value string) string {
+	n, err := strconv.Atoi(value)
 	if err != nil {
-		return nil, err
+		return value
+	}
+	switch facet {
+	case model.JobFacetJobType:
+		return enums.JobType(n).String()
+	case model.JobFacetRemoteType:
+		return model.RemoteType(n).Text()
+	case model.JobFacetBenefit:
+		return model.JobBenefitType(n).Text()
+	default:
+		return value
 	}
+}
 
-	resp := &response.JobListResponse{
-		Total:   total,
-		Records: make([]response.JobResponse, len(jobs)),
+// BackfillSearchVectors 为尚未建立全文检索列的职位回填，用于升级后的首次启动
+func (s *JobService) BackfillSearchVectors() {
+	count, err := s.jobDao.BackfillSearchVectors()
+	if err != nil {
+		logger.L.Error("回填职位全文检索列失败", zap.Error(err), zap.Int("count", count))
+		return
 	}
-
-	for i, job := range jobs {
-		resp.Records[i] = *s.ConvertToJobResponse(&job, 0)
+	if count > 0 {
+		logger.L.Info("回填职位全文检索列完成", zap.Int("count", count))
 	}
-
-	return resp, nil
 }
 
 // GetExpiredJobs 获取已过期职位
@@ -246,8 +708,9 @@ func (s *JobService) GetExpiredJobs() (*response.JobListResponse, error) {
 		return nil, err
 	}
 
+	total := int64(len(jobs))
 	resp := &response.JobListResponse{
-		Total:   int64(len(jobs)),
+		Total:   &total,
 		Records: make([]response.JobResponse, len(jobs)),
 	}
 
@@ -266,7 +729,7 @@ func (s *JobService) SearchByCompany(companyID uint, page, size int) (*response.
 	}
 
 	resp := &response.JobListResponse{
-		Total:   total,
+		Total:   &total,
 		Records: make([]response.JobResponse, len(jobs)),
 	}
 
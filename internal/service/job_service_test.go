@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+)
+
+func TestSchedulePublish(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+	expire := now.AddDate(0, 1, 0)
+
+	// 未指定或已过的发布时间立即发布
+	job := &model.Job{JobExpireTime: expire}
+	assert.NoError(t, schedulePublish(job, now))
+	assert.Equal(t, int(enums.JobStatusNormal), job.Status)
+	assert.True(t, job.PublishTime.Equal(now))
+
+	past := now.Add(-time.Hour)
+	job = &model.Job{JobExpireTime: expire, PublishTime: &past}
+	assert.NoError(t, schedulePublish(job, now))
+	assert.Equal(t, int(enums.JobStatusNormal), job.Status)
+	assert.True(t, job.PublishTime.Equal(now))
+
+	// 未来的发布时间保存为待发布
+	future := now.AddDate(0, 0, 7)
+	job = &model.Job{JobExpireTime: expire, PublishTime: &future}
+	assert.NoError(t, schedulePublish(job, now))
+	assert.Equal(t, int(enums.JobStatusScheduled), job.Status)
+	assert.True(t, job.PublishTime.Equal(future))
+
+	// 审核期间已超过过期时间的职位发布后即为已过期
+	job = &model.Job{JobExpireTime: now.Add(-time.Hour)}
+	assert.NoError(t, schedulePublish(job, now))
+	assert.Equal(t, int(enums.JobStatusExpired), job.Status)
+	assert.True(t, job.PublishTime.Equal(now))
+
+	job = &model.Job{JobExpireTime: now.Add(-time.Hour), PublishTime: &past}
+	assert.NoError(t, schedulePublish(job, now))
+	assert.Equal(t, int(enums.JobStatusExpired), job.Status)
+
+	// 发布时间须早于过期时间
+	late := expire.Add(time.Hour)
+	job = &model.Job{JobExpireTime: expire, PublishTime: &late}
+	assert.Error(t, schedulePublish(job, now))
+}
+
+func TestUpdateLifecycle(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.Local)
+	published := now.AddDate(0, 0, -10)
+	scheduled := now.AddDate(0, 0, 3)
+	expire := now.AddDate(0, 1, 0)
+
+	// 未传过期时间时保留原值，状态和发布时间不被请求覆盖
+	existing := &model.Job{Status: int(enums.JobStatusNormal), PublishTime: &published, JobExpireTime: expire}
+	job := &model.Job{}
+	assert.NoError(t, updateLifecycle(job, existing, now))
+	assert.Equal(t, int(enums.JobStatusNormal), job.Status)
+	assert.True(t, job.JobExpireTime.Equal(expire))
+	assert.True(t, job.PublishTime.Equal(published))
+
+	// 已发布的职位不能修改发布时间
+	job = &model.Job{PublishTime: &scheduled}
+	assert.Error(t, updateLifecycle(job, existing, now))
+
+	// 待发布的职位可以调整发布时间，提前到当前时间即发布
+	existing = &model.Job{Status: int(enums.JobStatusScheduled), PublishTime: &scheduled, JobExpireTime: expire}
+	later := scheduled.AddDate(0, 0, 1)
+	job = &model.Job{PublishTime: &later}
+	assert.NoError(t, updateLifecycle(job, existing, now))
+	assert.Equal(t, int(enums.JobStatusScheduled), job.Status)
+	assert.True(t, job.PublishTime.Equal(later))
+
+	job = &model.Job{PublishTime: &now}
+	assert.NoError(t, updateLifecycle(job, existing, now))
+	assert.Equal(t, int(enums.JobStatusNormal), job.Status)
+
+	// 已过期的职位延长过期时间后重新上线
+	existing = &model.Job{Status: int(enums.JobStatusExpired), PublishTime: &published, JobExpireTime: now.AddDate(0, 0, -1)}
+	job = &model.Job{JobExpireTime: expire}
+	assert.NoError(t, updateLifecycle(job, existing, now))
+	assert.Equal(t, int(enums.JobStatusNormal), job.Status)
+
+	job = &model.Job{}
+	assert.NoError(t, updateLifecycle(job, existing, now))
+	assert.Equal(t, int(enums.JobStatusExpired), job.Status)
+
+	// 未通过审核的职位保留状态，可以调整计划发布时间，但须早于过期时间
+	existing = &model.Job{Status: int(enums.JobStatusRejected), JobExpireTime: expire}
+	job = &model.Job{PublishTime: &scheduled}
+	assert.NoError(t, updateLifecycle(job, existing, now))
+	assert.Equal(t, int(enums.JobStatusRejected), job.Status)
+	assert.True(t, job.PublishTime.Equal(scheduled))
+
+	tooLate := expire.AddDate(0, 0, 1)
+	job = &model.Job{PublishTime: &tooLate}
+	assert.Error(t, updateLifecycle(job, existing, now))
+}
@@ -3,14 +3,20 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"org.thinkinai.com/recruit-center/internal/dao"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/mail"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 )
 
 type NotificationService struct {
 	notificationDAO *dao.NotificationDAO
 	templateDAO     *dao.NotificationTemplateDAO
+	userDAO         *dao.UserDAO
+	mailer          mail.Sender
 }
 
 func NewNotificationService(notificationDAO *dao.NotificationDAO, templateDAO *dao.NotificationTemplateDAO) *NotificationService {
@@ -20,19 +26,42 @@ func NewNotificationService(notificationDAO *dao.NotificationDAO, templateDAO *d
 	}
 }
 
+// SetMailer 设置邮件渠道的发送器，收件地址为用户的注册邮箱
+func (s *NotificationService) SetMailer(mailer mail.Sender, userDAO *dao.UserDAO) {
+	s.mailer = mailer
+	s.userDAO = userDAO
+}
+
 // Create 创建通知
 func (s *NotificationService) Create(notification *model.Notification) error {
 	return s.notificationDAO.Create(notification)
 }
 
+// CreateBatch 批量创建通知
+func (s *NotificationService) CreateBatch(notifications []model.Notification) error {
+	return s.notificationDAO.CreateBatch(notifications)
+}
+
 // MarkAsRead 标记通知为已读
 func (s *NotificationService) MarkAsRead(id uint) error {
 	return s.notificationDAO.UpdateReadStatus(id, true)
 }
 
-// ListUserNotifications 获取用户的通知列表
-func (s *NotificationService) ListUserNotifications(userID uint, page, size int) ([]model.Notification, int64, error) {
-	return s.notificationDAO.ListByUser(userID, page, size)
+// MarkUserNotificationAsRead 标记当前用户的通知为已读，不能操作他人的通知
+func (s *NotificationService) MarkUserNotificationAsRead(id, userID uint) error {
+	affected, err := s.notificationDAO.UpdateUserReadStatus(id, userID, true)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(errors.NotFound)
+	}
+	return nil
+}
+
+// ListUserNotifications 获取用户的通知列表，按创建时间倒序，支持页码和游标分页
+func (s *NotificationService) ListUserNotifications(userID uint, page pagination.Page) (*pagination.Result[model.Notification], error) {
+	return s.notificationDAO.ListByUser(userID, page)
 }
 
 // GetUnreadCount 获取用户未读通知数量
@@ -82,7 +111,9 @@ func (s *NotificationService) sendToChannels(notification *model.Notification) e
 		s.notificationDAO.Create(notification)
 	}
 	if notification.IsChannelEnabled(model.ChannelEmail) {
-		// 发送邮件
+		if err := s.sendEmail(notification); err != nil {
+			return err
+		}
 	}
 	if notification.IsChannelEnabled(model.ChannelSMS) {
 		// 发送短信
@@ -91,19 +122,29 @@ func (s *NotificationService) sendToChannels(notification *model.Notification) e
 	return nil
 }
 
-// renderTemplate 渲染模板
+// sendEmail 将通知发送到用户的注册邮箱
+func (s *NotificationService) sendEmail(notification *model.Notification) error {
+	if s.mailer == nil || s.userDAO == nil {
+		return fmt.Errorf("mail sender not configured")
+	}
+	user, err := s.userDAO.GetByID(notification.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return fmt.Errorf("user %d has no email", user.ID)
+	}
+	return s.mailer.Send(user.Email, notification.Title, notification.Content)
+}
+
+// renderTemplate 渲染模板，将标题和内容中的 {{变量名}} 替换为变量值，未提供的变量保持原样
 func (s *NotificationService) renderTemplate(tmpl *model.NotificationTemplate, vars map[string]interface{}) (string, string, error) {
-	// titleTmpl, err := template.New("title").Parse(tmpl.Title)
-	// if err != nil {
-	// 	return "", "", err
-	// }
-	// contentTmpl, err := template.New("content").Parse(tmpl.Content)
-	// if err != nil {
-	// 	return "", "", err
-	// }
-
-	// ... 渲染逻辑
-	return "rendered title", "rendered content", nil
+	pairs := make([]string, 0, len(vars)*2)
+	for key, value := range vars {
+		pairs = append(pairs, "{{"+key+"}}", fmt.Sprint(value))
+	}
+	replacer := strings.NewReplacer(pairs...)
+	return replacer.Replace(tmpl.Title), replacer.Replace(tmpl.Content), nil
 }
 
 // containsUserType 检查用户类型是否在列表中
@@ -0,0 +1,800 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+const (
+	maxOfferApprovers  = 5             // 审批链最多审批人数
+	offerExpireBatch   = 100           // 每轮最多处理的过期Offer数量
+	offerDateLayout    = "2006年01月02日" // Offer函中的日期格式
+	offerExpiredReason = "Offer已过期"    // Offer过期时申请流转的原因
+	offerLetterMime    = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+// offerPlaceholder Offer函模板中的变量，形如 {{candidateName}}
+var offerPlaceholder = regexp.MustCompile(`{{\s*(\w+)\s*}}`)
+
+// OfferService Offer管理服务
+type OfferService struct {
+	offerDAO            *dao.OfferDAO
+	templateDAO         *dao.OfferTemplateDAO
+	jobApplyDAO         *dao.JobApplyDAO
+	jobApplyService     *JobApplyService
+	pipelineService     *JobPipelineService
+	jobService          *JobService
+	companyService      *CompanyService
+	userService         *UserService
+	notificationService *NotificationService
+}
+
+// NewOfferService 创建Offer管理服务实例
+func NewOfferService(offerDAO *dao.OfferDAO, templateDAO *dao.OfferTemplateDAO, jobApplyDAO *dao.JobApplyDAO, jobApplyService *JobApplyService,
+	pipelineService *JobPipelineService, jobService *JobService, companyService *CompanyService, userService *UserService,
+	notificationService *NotificationService) *OfferService {
+	return &OfferService{
+		offerDAO:            offerDAO,
+		templateDAO:         templateDAO,
+		jobApplyDAO:         jobApplyDAO,
+		jobApplyService:     jobApplyService,
+		pipelineService:     pipelineService,
+		jobService:          jobService,
+		companyService:      companyService,
+		userService:         userService,
+		notificationService: notificationService,
+	}
+}
+
+// OfferLetter 生成的Offer函文件
+type OfferLetter struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Create 为申请创建Offer草稿，仅申请所属公司的所有者或招聘者可以操作
+// 已结束的申请不能创建Offer，同一申请同时只能有一个进行中的Offer
+func (s *OfferService) Create(applyID, userID uint, offer *model.Offer) (*response.OfferResponse, error) {
+	apply, err := s.jobApplyDAO.GetByID(applyID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobApplicationNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+
+	pipeline, _, err := s.pipelineService.GetForJob(apply.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline.IsTerminal(enums.JobApplyEnum(apply.Status)) {
+		return nil, errors.New(errors.OfferNotAllowed).
+			WithMessage(fmt.Sprintf("申请已处于「%s」，不能创建Offer", enums.GetStatusText(apply.Status)))
+	}
+	active, err := s.offerDAO.HasActive(applyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if active {
+		return nil, errors.New(errors.OfferNotAllowed).WithMessage("该申请已有进行中的Offer")
+	}
+	if err := s.validateOffer(apply.CompanyID, userID, offer); err != nil {
+		return nil, err
+	}
+
+	offer.ApplyID = apply.ID
+	offer.JobID = apply.JobID
+	offer.CompanyID = apply.CompanyID
+	offer.CandidateID = apply.UserID
+	offer.Status = model.OfferDraft
+	offer.CreatedBy = userID
+	if err := s.offerDAO.Create(offer); err != nil {
+		logger.L.Error("创建Offer失败",
+			zap.Error(err),
+			zap.Uint("applyId", applyID),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return s.toResponse(offer, true)
+}
+
+// Update 修改Offer草稿，审批被驳回的Offer回到草稿后可修改再提交
+func (s *OfferService) Update(id, userID uint, offer *model.Offer) (*response.OfferResponse, error) {
+	current, err := s.getForWrite(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != model.OfferDraft {
+		return nil, offerStatusError(current, "修改")
+	}
+	if err := s.validateOffer(current.CompanyID, current.CreatedBy, offer); err != nil {
+		return nil, err
+	}
+
+	offer.ID = current.ID
+	affected, err := s.offerDAO.UpdateDraft(offer)
+	if err != nil {
+		logger.L.Error("修改Offer失败",
+			zap.Error(err),
+			zap.Uint("offerId", id),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return nil, errors.New(errors.Conflict).WithMessage("Offer状态已变更，请刷新后重试")
+	}
+	return s.getResponse(id)
+}
+
+// Submit 提交Offer审批，审批链为空时直接审批通过，否则通知第一位审批人
+func (s *OfferService) Submit(id, userID uint) (*response.OfferResponse, error) {
+	offer, err := s.getForWrite(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != model.OfferDraft {
+		return nil, offerStatusError(offer, "提交审批")
+	}
+	if offer.IsExpired(time.Now()) {
+		return nil, errors.New(errors.OfferNotAllowed).WithMessage("答复截止时间已过，请先修改Offer")
+	}
+
+	status := model.OfferPendingApproval
+	if len(offer.Approvals) == 0 {
+		status = model.OfferApproved
+	}
+	affected, err := s.offerDAO.Submit(id, status)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return nil, errors.New(errors.Conflict).WithMessage("Offer状态已变更，请刷新后重试")
+	}
+
+	offer, err = s.getOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if current := offer.CurrentApproval(); current != nil {
+		s.notifyUser(current.ApproverID, model.UserTypeRecruiter, "Offer待审批", fmt.Sprintf("%s 的Offer等待您审批。", offer.Position))
+	}
+	return s.toResponse(offer, true)
+}
+
+// Approve 当前审批人通过Offer，最后一位审批人通过后Offer进入待发送
+func (s *OfferService) Approve(id, userID uint, comment string) (*response.OfferResponse, error) {
+	return s.decide(id, userID, model.OfferApprovalApproved, comment)
+}
+
+// Reject 当前审批人驳回Offer，Offer回到草稿，修改后可重新提交
+func (s *OfferService) Reject(id, userID uint, comment string) (*response.OfferResponse, error) {
+	return s.decide(id, userID, model.OfferApprovalRejected, comment)
+}
+
+// decide 记录当前审批节点的审批结果，只有轮到的审批人可以操作
+func (s *OfferService) decide(id, userID uint, result model.OfferApprovalStatus, comment string) (*response.OfferResponse, error) {
+	offer, err := s.getOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.companyService.CheckMember(offer.CompanyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+	if offer.Status != model.OfferPendingApproval {
+		return nil, offerStatusError(offer, "审批")
+	}
+	current := offer.CurrentApproval()
+	if current == nil || current.ApproverID != userID {
+		return nil, errors.New(errors.Forbidden).WithMessage("当前不是您审批的节点")
+	}
+
+	now := time.Now()
+	current.Status = result
+	current.Comment = comment
+	current.DecidedAt = &now
+
+	var offerStatus model.OfferStatus
+	switch {
+	case result == model.OfferApprovalRejected:
+		offerStatus = model.OfferDraft
+	case offer.CurrentApproval() == nil:
+		offerStatus = model.OfferApproved
+	}
+	affected, err := s.offerDAO.Decide(current, offerStatus)
+	if err != nil {
+		logger.L.Error("审批Offer失败",
+			zap.Error(err),
+			zap.Uint("offerId", id),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return nil, errors.New(errors.Conflict).WithMessage("Offer状态已变更，请刷新后重试")
+	}
+
+	switch offerStatus {
+	case model.OfferDraft:
+		s.notifyUser(offer.CreatedBy, model.UserTypeRecruiter, "Offer审批被驳回", fmt.Sprintf("%s 的Offer被驳回：%s", offer.Position, comment))
+	case model.OfferApproved:
+		s.notifyUser(offer.CreatedBy, model.UserTypeRecruiter, "Offer审批通过", fmt.Sprintf("%s 的Offer已审批通过，可以发送给候选人。", offer.Position))
+	default:
+		if next := offer.CurrentApproval(); next != nil {
+			s.notifyUser(next.ApproverID, model.UserTypeRecruiter, "Offer待审批", fmt.Sprintf("%s 的Offer等待您审批。", offer.Position))
+		}
+	}
+	return s.getResponse(id)
+}
+
+// Send 将审批通过的Offer发送给候选人，申请同步流转到「已发Offer」，流转失败时Offer恢复为审批通过
+func (s *OfferService) Send(id, userID uint) (*response.OfferResponse, error) {
+	offer, err := s.getForWrite(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != model.OfferApproved {
+		return nil, offerStatusError(offer, "发送")
+	}
+	now := time.Now()
+	if offer.IsExpired(now) {
+		return nil, errors.New(errors.OfferNotAllowed).WithMessage("答复截止时间已过，请撤回后重新创建Offer")
+	}
+
+	apply, err := s.jobApplyService.getApply(offer.ApplyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先以审批通过状态为条件更新Offer，再流转申请，申请流转失败时恢复Offer状态
+	affected, err := s.offerDAO.UpdateStatus(id, model.OfferApproved, model.OfferSent, map[string]interface{}{"sent_at": now})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return nil, errors.New(errors.Conflict).WithMessage("Offer状态已变更，请刷新后重试")
+	}
+	if apply.Status != int(enums.JobApplyOfferSent) {
+		if err := s.jobApplyService.Transition(apply.ID, userID, model.PipelineActorCompany, StatusChange{Status: enums.JobApplyOfferSent}); err != nil {
+			if _, revertErr := s.offerDAO.UpdateStatus(id, model.OfferSent, model.OfferApproved, map[string]interface{}{"sent_at": nil}); revertErr != nil {
+				logger.L.Error("恢复Offer状态失败", zap.Error(revertErr), zap.Uint("offerId", id))
+			}
+			return nil, err
+		}
+	}
+
+	s.notifyUser(offer.CandidateID, model.UserTypeJobSeeker, "您收到一份Offer",
+		fmt.Sprintf("您收到了 %s 的Offer，请于 %s 前确认。Offer函：/api/v1/offers/%d/letter",
+			offer.Position, offer.ExpireAt.Local().Format(interviewTimeLayout), offer.ID))
+	return s.getResponse(id)
+}
+
+// Withdraw 撤回尚未发送给候选人的Offer
+func (s *OfferService) Withdraw(id, userID uint) error {
+	offer, err := s.getForWrite(id, userID)
+	if err != nil {
+		return err
+	}
+	switch offer.Status {
+	case model.OfferDraft, model.OfferPendingApproval, model.OfferApproved:
+	default:
+		return offerStatusError(offer, "撤回")
+	}
+	affected, err := s.offerDAO.UpdateStatus(id, offer.Status, model.OfferWithdrawn, nil)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return errors.New(errors.Conflict).WithMessage("Offer状态已变更，请刷新后重试")
+	}
+	return nil
+}
+
+// Accept 候选人接受Offer，申请流转到「已接受Offer」
+func (s *OfferService) Accept(id, userID uint) (*response.OfferResponse, error) {
+	return s.respond(id, userID, model.OfferAccepted, "")
+}
+
+// Decline 候选人拒绝Offer，申请流转到「已拒绝Offer」
+func (s *OfferService) Decline(id, userID uint, reason string) (*response.OfferResponse, error) {
+	return s.respond(id, userID, model.OfferDeclined, reason)
+}
+
+// respond 候选人答复Offer
+// 先以待确认状态为条件更新Offer，再按招聘流程流转申请，申请流转失败时恢复Offer状态
+func (s *OfferService) respond(id, userID uint, to model.OfferStatus, reason string) (*response.OfferResponse, error) {
+	offer, err := s.getOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if offer.CandidateID != userID {
+		return nil, errors.New(errors.Forbidden)
+	}
+	if offer.Status != model.OfferSent {
+		return nil, offerStatusError(offer, "答复")
+	}
+	now := time.Now()
+	if offer.IsExpired(now) {
+		s.expire(offer)
+		return nil, errors.New(errors.OfferExpired)
+	}
+
+	affected, err := s.offerDAO.UpdateStatus(id, model.OfferSent, to, map[string]interface{}{
+		"responded_at":   now,
+		"decline_reason": reason,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if affected == 0 {
+		return nil, errors.New(errors.Conflict).WithMessage("Offer状态已变更，请刷新后重试")
+	}
+
+	status := enums.JobApplyOfferAccept
+	if to == model.OfferDeclined {
+		status = enums.JobApplyOfferReject
+	}
+	if err := s.jobApplyService.Transition(offer.ApplyID, userID, model.PipelineActorCandidate, StatusChange{Status: status, Reason: reason}); err != nil {
+		if _, revertErr := s.offerDAO.UpdateStatus(id, to, model.OfferSent, map[string]interface{}{
+			"responded_at":   nil,
+			"decline_reason": "",
+		}); revertErr != nil {
+			logger.L.Error("恢复Offer状态失败", zap.Error(revertErr), zap.Uint("offerId", id))
+		}
+		return nil, err
+	}
+
+	title, content := "候选人已接受Offer", fmt.Sprintf("候选人已接受 %s 的Offer。", offer.Position)
+	if to == model.OfferDeclined {
+		title, content = "候选人已拒绝Offer", fmt.Sprintf("候选人已拒绝 %s 的Offer。%s", offer.Position, reason)
+	}
+	s.notifyHirers(offer, title, content)
+
+	offer.Status = to
+	offer.RespondedAt = &now
+	offer.DeclineReason = reason
+	return s.toResponse(offer, false)
+}
+
+// ExpireOverdue 将超过答复截止时间仍未确认的Offer置为已过期，申请流转到「已拒绝Offer」
+// 返回本轮处理的Offer数量
+func (s *OfferService) ExpireOverdue(now time.Time) (int, error) {
+	offers, err := s.offerDAO.ListOverdue(now, offerExpireBatch)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.InternalServerError)
+	}
+	expired := 0
+	for i := range offers {
+		if s.expire(&offers[i]) {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// expire 将待确认的Offer置为已过期并流转申请，失败只记录日志，返回是否已处理
+func (s *OfferService) expire(offer *model.Offer) bool {
+	affected, err := s.offerDAO.UpdateStatus(offer.ID, model.OfferSent, model.OfferExpired, nil)
+	if err != nil {
+		logger.L.Error("Offer过期处理失败", zap.Error(err), zap.Uint("offerId", offer.ID))
+		return false
+	}
+	if affected == 0 {
+		return false
+	}
+	if _, err := s.jobApplyService.SystemTransition(offer.ApplyID, enums.JobApplyOfferSent, enums.JobApplyOfferReject, offerExpiredReason); err != nil {
+		logger.L.Error("Offer过期后流转申请失败", zap.Error(err), zap.Uint("offerId", offer.ID), zap.Uint("applyId", offer.ApplyID))
+	}
+	s.notifyHirers(offer, "Offer已过期", fmt.Sprintf("%s 的Offer已超过答复截止时间，候选人未确认。", offer.Position))
+	return true
+}
+
+// Get 获取Offer详情
+// 管理员可查看所有Offer，企业侧须为公司成员，候选人只能查看已发送给本人的Offer且不含审批链
+func (s *OfferService) Get(id, userID uint, userType model.UserType) (*response.OfferResponse, error) {
+	offer, err := s.getForRead(id, userID, userType)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(offer, userType == model.UserTypeAdmin || userType.IsCompanySide())
+}
+
+// ListByApply 获取申请的所有Offer，候选人只能看到已发送的Offer
+func (s *OfferService) ListByApply(applyID, userID uint, userType model.UserType) ([]*response.OfferResponse, error) {
+	apply, err := s.jobApplyService.getApply(applyID)
+	if err != nil {
+		return nil, err
+	}
+	companySide := userType == model.UserTypeAdmin || userType.IsCompanySide()
+	switch {
+	case userType == model.UserTypeAdmin:
+	case userType.IsCompanySide():
+		if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyReaders...); err != nil {
+			return nil, err
+		}
+	case apply.UserID != userID:
+		return nil, errors.New(errors.Forbidden)
+	}
+
+	offers, err := s.offerDAO.ListByApply(applyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	visible := offers[:0]
+	for _, offer := range offers {
+		if companySide || offer.Status >= model.OfferSent && offer.Status != model.OfferWithdrawn {
+			visible = append(visible, offer)
+		}
+	}
+	return s.toResponses(visible, companySide)
+}
+
+// ListPendingApproval 获取轮到当前用户审批的Offer
+func (s *OfferService) ListPendingApproval(userID uint) ([]*response.OfferResponse, error) {
+	offers, err := s.offerDAO.ListAwaitingApprover(userID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	pending := offers[:0]
+	for _, offer := range offers {
+		if current := offer.CurrentApproval(); current != nil && current.ApproverID == userID {
+			pending = append(pending, offer)
+		}
+	}
+	return s.toResponses(pending, true)
+}
+
+// Letter 按Offer函模板生成 Word 格式的Offer函
+func (s *OfferService) Letter(id, userID uint, userType model.UserType) (*OfferLetter, error) {
+	offer, err := s.getForRead(id, userID, userType)
+	if err != nil {
+		return nil, err
+	}
+
+	template := model.DefaultOfferTemplate()
+	if offer.TemplateID != nil {
+		custom, err := s.templateDAO.GetByID(*offer.TemplateID)
+		switch {
+		case err == nil && custom.CompanyID == offer.CompanyID:
+			template = custom
+		case err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.Wrap(err, errors.InternalServerError)
+		default:
+			logger.L.Warn("Offer函模板不存在，使用默认模板", zap.Uint("offerId", id), zap.Uint("templateId", *offer.TemplateID))
+		}
+	}
+
+	vars, err := s.letterVariables(offer)
+	if err != nil {
+		return nil, err
+	}
+	lines := RenderOfferLetter(template.Content, vars)
+	data, err := utils.BuildDocx(template.Title, lines, 2)
+	if err != nil {
+		logger.L.Error("生成Offer函失败", zap.Error(err), zap.Uint("offerId", id))
+		if strings.Contains(err.Error(), "license") {
+			return nil, errors.Wrap(err, errors.ServiceUnavailable).WithMessage("文档服务未授权，请配置 office.license_key")
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return &OfferLetter{
+		Filename:    fmt.Sprintf("offer-%d.docx", offer.ID),
+		ContentType: offerLetterMime,
+		Data:        data,
+	}, nil
+}
+
+// letterVariables Offer函模板变量
+func (s *OfferService) letterVariables(offer *model.Offer) (map[string]string, error) {
+	company, err := s.companyService.GetByID(offer.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	candidateName := ""
+	if candidate, err := s.userService.GetByID(offer.CandidateID); err == nil {
+		candidateName = candidate.DisplayName()
+	}
+	signingBonus := ""
+	if offer.SigningBonus > 0 {
+		signingBonus = fmt.Sprintf("%d %s", offer.SigningBonus, offer.Currency)
+	}
+	return map[string]string{
+		"candidateName": candidateName,
+		"companyName":   company.Name,
+		"department":    offer.Department,
+		"position":      offer.Position,
+		"baseSalary":    strconv.FormatInt(offer.BaseSalary, 10),
+		"currency":      offer.Currency,
+		"salaryMonths":  strconv.Itoa(offer.SalaryMonths),
+		"annualSalary":  strconv.FormatInt(offer.AnnualSalary(), 10),
+		"signingBonus":  signingBonus,
+		"benefits":      offer.Benefits,
+		"location":      offer.Location,
+		"startDate":     offer.StartDate.Format(offerDateLayout),
+		"remark":        offer.Remark,
+		"expireAt":      offer.ExpireAt.Local().Format(offerDateLayout),
+		"today":         time.Now().Format(offerDateLayout),
+	}, nil
+}
+
+// RenderOfferLetter 替换Offer函模板变量，返回每个段落的文本
+// 未知变量替换为空；某行包含的变量全部为空时整行省略，如未设置签字费时不输出签字费一行
+func RenderOfferLetter(content string, vars map[string]string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		matches := offerPlaceholder.FindAllStringSubmatch(line, -1)
+		filled := len(matches) == 0
+		for _, m := range matches {
+			if vars[m[1]] != "" {
+				filled = true
+			}
+		}
+		if !filled {
+			continue
+		}
+		line = offerPlaceholder.ReplaceAllStringFunc(line, func(s string) string {
+			return vars[offerPlaceholder.FindStringSubmatch(s)[1]]
+		})
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ListTemplates 获取公司的Offer函模板，公司成员均可查看
+func (s *OfferService) ListTemplates(companyID, userID uint) ([]*response.OfferTemplateResponse, error) {
+	if _, err := s.companyService.CheckMember(companyID, userID, model.CompanyReaders...); err != nil {
+		return nil, err
+	}
+	templates, err := s.templateDAO.ListByCompany(companyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	resp := make([]*response.OfferTemplateResponse, len(templates))
+	for i := range templates {
+		resp[i] = response.NewOfferTemplateResponse(&templates[i])
+	}
+	return resp, nil
+}
+
+// CreateTemplate 创建Offer函模板，仅公司的所有者或招聘者可以操作
+func (s *OfferService) CreateTemplate(companyID, userID uint, template *model.OfferTemplate) (*response.OfferTemplateResponse, error) {
+	if _, err := s.companyService.CheckMember(companyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+	template.CompanyID = companyID
+	template.CreatedBy = userID
+	if err := s.templateDAO.Create(template); err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return response.NewOfferTemplateResponse(template), nil
+}
+
+// UpdateTemplate 修改Offer函模板，已发送的Offer下载时使用修改后的模板
+func (s *OfferService) UpdateTemplate(companyID, id, userID uint, template *model.OfferTemplate) (*response.OfferTemplateResponse, error) {
+	current, err := s.getTemplate(companyID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = current.ID
+	template.CompanyID = companyID
+	if err := s.templateDAO.Update(template); err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	updated, err := s.templateDAO.GetByID(id)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return response.NewOfferTemplateResponse(updated), nil
+}
+
+// DeleteTemplate 删除Offer函模板，使用该模板的Offer改用默认模板
+func (s *OfferService) DeleteTemplate(companyID, id, userID uint) error {
+	if _, err := s.getTemplate(companyID, id, userID); err != nil {
+		return err
+	}
+	if err := s.templateDAO.Delete(companyID, id); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// getTemplate 获取公司的Offer函模板并校验操作人是公司的所有者或招聘者
+func (s *OfferService) getTemplate(companyID, id, userID uint) (*model.OfferTemplate, error) {
+	if _, err := s.companyService.CheckMember(companyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+	template, err := s.templateDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.OfferTemplateNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if template.CompanyID != companyID {
+		return nil, errors.New(errors.OfferTemplateNotFound)
+	}
+	return template, nil
+}
+
+// validateOffer 校验Offer内容：入职日期不早于答复截止日期，模板属于本公司，审批人为公司的所有者或招聘者且不重复，创建人不能审批自己的Offer
+func (s *OfferService) validateOffer(companyID, creatorID uint, offer *model.Offer) error {
+	if !offer.ExpireAt.After(time.Now()) {
+		return errors.New(errors.InvalidParams).WithMessage("答复截止时间须晚于当前时间")
+	}
+	if offer.StartDate.Before(offer.ExpireAt.Truncate(24 * time.Hour)) {
+		return errors.New(errors.InvalidParams).WithMessage("入职日期不能早于答复截止时间")
+	}
+	if offer.TemplateID != nil {
+		template, err := s.templateDAO.GetByID(*offer.TemplateID)
+		if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, errors.InternalServerError)
+		}
+		if err != nil || template.CompanyID != companyID {
+			return errors.New(errors.OfferTemplateNotFound)
+		}
+	}
+
+	if len(offer.Approvals) > maxOfferApprovers {
+		return errors.New(errors.InvalidParams).WithMessage(fmt.Sprintf("审批人最多%d位", maxOfferApprovers))
+	}
+	seen := make(map[uint]bool, len(offer.Approvals))
+	for _, a := range offer.Approvals {
+		if seen[a.ApproverID] {
+			return errors.New(errors.InvalidParams).WithMessage("审批人不能重复")
+		}
+		seen[a.ApproverID] = true
+		if a.ApproverID == creatorID {
+			return errors.New(errors.InvalidParams).WithMessage("Offer创建人不能作为审批人")
+		}
+		if _, err := s.companyService.CheckMember(companyID, a.ApproverID, model.CompanyHirers...); err != nil {
+			return errors.New(errors.InvalidParams).WithMessage(fmt.Sprintf("用户 %d 不是公司的所有者或招聘者，不能审批Offer", a.ApproverID))
+		}
+	}
+	return nil
+}
+
+// getOffer 获取Offer及其审批链
+func (s *OfferService) getOffer(id uint) (*model.Offer, error) {
+	offer, err := s.offerDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.OfferNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return offer, nil
+}
+
+// getForRead 获取Offer并校验查看权限，候选人看不到尚未发送的Offer
+func (s *OfferService) getForRead(id, userID uint, userType model.UserType) (*model.Offer, error) {
+	offer, err := s.getOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case userType == model.UserTypeAdmin:
+	case userType.IsCompanySide():
+		if _, err := s.companyService.CheckMember(offer.CompanyID, userID, model.CompanyReaders...); err != nil {
+			return nil, err
+		}
+	case offer.CandidateID == userID && offer.Status >= model.OfferSent && offer.Status != model.OfferWithdrawn:
+	default:
+		return nil, errors.New(errors.OfferNotFound)
+	}
+	return offer, nil
+}
+
+// getForWrite 获取Offer并校验操作人是公司的所有者或招聘者
+func (s *OfferService) getForWrite(id, userID uint) (*model.Offer, error) {
+	offer, err := s.getOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.companyService.CheckMember(offer.CompanyID, userID, model.CompanyHirers...); err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+// offerStatusError 当前状态不允许操作的错误
+func offerStatusError(offer *model.Offer, action string) error {
+	return errors.New(errors.OfferNotAllowed).
+		WithMessage(fmt.Sprintf("Offer%s，不能%s", offer.Status.String(), action))
+}
+
+// notifyUser 发送站内Offer通知，发送失败只记录日志
+func (s *OfferService) notifyUser(userID uint, userType model.UserType, title, content string) {
+	if err := s.notificationService.Create(&model.Notification{
+		UserID:   userID,
+		UserType: userType,
+		Type:     model.NotificationTypeOffer,
+		Title:    title,
+		Content:  content,
+		Channels: model.ChannelInApp,
+	}); err != nil {
+		logger.L.Error("发送Offer通知失败", zap.Error(err), zap.Uint("userId", userID))
+	}
+}
+
+// notifyHirers 通知公司的所有者和招聘者
+func (s *OfferService) notifyHirers(offer *model.Offer, title, content string) {
+	userIDs, err := s.companyService.ListMemberUserIDs(offer.CompanyID, model.CompanyHirers...)
+	if err != nil {
+		logger.L.Error("获取公司成员失败", zap.Error(err), zap.Uint("companyId", offer.CompanyID))
+		return
+	}
+	notifications := make([]model.Notification, 0, len(userIDs))
+	for _, id := range userIDs {
+		notifications = append(notifications, model.Notification{
+			UserID:   id,
+			UserType: model.UserTypeRecruiter,
+			Type:     model.NotificationTypeOffer,
+			Title:    title,
+			Content:  content,
+			Channels: model.ChannelInApp,
+		})
+	}
+	if err := s.notificationService.CreateBatch(notifications); err != nil {
+		logger.L.Error("发送Offer通知失败", zap.Error(err), zap.Uint("offerId", offer.ID))
+	}
+}
+
+// getResponse 重新查询Offer并转换为响应
+func (s *OfferService) getResponse(id uint) (*response.OfferResponse, error) {
+	offer, err := s.getOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(offer, true)
+}
+
+// toResponse 转换为Offer响应
+func (s *OfferService) toResponse(offer *model.Offer, withApprovals bool) (*response.OfferResponse, error) {
+	responses, err := s.toResponses([]model.Offer{*offer}, withApprovals)
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
+}
+
+// toResponses 批量转换为Offer响应，审批人姓名一次性查询
+func (s *OfferService) toResponses(offers []model.Offer, withApprovals bool) ([]*response.OfferResponse, error) {
+	users := map[uint]*model.User{}
+	if withApprovals {
+		var ids []uint
+		for i := range offers {
+			for _, a := range offers[i].Approvals {
+				ids = append(ids, a.ApproverID)
+			}
+		}
+		if len(ids) > 0 {
+			var err error
+			if users, err = s.userService.GetUserMap(uniqueIDs(ids)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	resp := make([]*response.OfferResponse, len(offers))
+	for i := range offers {
+		resp[i] = response.NewOfferResponse(&offers[i], users, withApprovals)
+	}
+	return resp, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/testutil"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+func TestRenderOfferLetter(t *testing.T) {
+	content := "尊敬的 {{candidateName}}：\n签字费：{{signingBonus}}\n{{department}}{{position}}\n{{ remark }}\n\n{{unknown}}\n此致\n{{companyName}}"
+	lines := RenderOfferLetter(content, map[string]string{
+		"candidateName": "张三",
+		"position":      "后端工程师",
+		"companyName":   "示例科技",
+	})
+	// 签字费、备注和未知变量所在行整行省略，部分变量为空的行保留
+	assert.Equal(t, []string{"尊敬的 张三：", "后端工程师", "此致", "示例科技"}, lines)
+}
+
+func newTestOfferService(t *testing.T) (*OfferService, *gorm.DB) {
+	db := testutil.SetupTestDB(t)
+	logger.L = zap.NewNop()
+	jobApplyDao := dao.NewJobApplyDAO(db)
+	companyService := NewCompanyService(dao.NewCompanyDAO(db), dao.NewCompanyMemberDAO(db), dao.NewUserDAO(db))
+	jobService := NewJobService(dao.NewJobDAO(db), dao.NewJobFavoriteDAO(db), jobApplyDao, companyService, NewRegionService(dao.NewDictDAO(db)))
+	pipelineService := NewJobPipelineService(dao.NewJobPipelineDAO(db), jobApplyDao, jobService)
+	notificationService := NewNotificationService(dao.NewNotificationDAO(db), dao.NewNotificationTemplateDAO(db))
+	authService := NewAuthService(dao.NewTokenSessionDAO(db), dao.NewRevokedTokenDAO(db), dao.NewUserDAO(db))
+	userService := NewUserService(dao.NewUserDAO(db), dao.NewUserTokenDAO(db), authService, notificationService)
+	scorecardService := NewScorecardService(dao.NewScorecardDAO(db), dao.NewInterviewFeedbackDAO(db), dao.NewInterviewDAO(db), jobApplyDao, jobService, companyService, userService)
+	jobApplyService := NewJobApplyService(jobApplyDao, jobService, companyService, pipelineService, scorecardService, NewJobScreeningService(dao.NewJobScreeningDAO(db), jobService), notificationService)
+	service := NewOfferService(dao.NewOfferDAO(db), dao.NewOfferTemplateDAO(db), jobApplyDao, jobApplyService, pipelineService, jobService, companyService, userService, notificationService)
+	return service, db
+}
+
+// createTestOffer 创建公司、处于 applyStatus 的申请和处于 offerStatus 的Offer，公司所有者为Offer的创建人
+func createTestOffer(t *testing.T, service *OfferService, applyStatus enums.JobApplyEnum, offerStatus model.OfferStatus, expireAt time.Time) *model.Offer {
+	seed := uint(time.Now().UnixNano() % 1000000000)
+	company := &model.Company{Name: fmt.Sprintf("测试公司%d", seed)}
+	require.NoError(t, service.companyService.Create(company, seed))
+
+	apply := &model.JobApply{
+		JobID:         seed,
+		UserID:        seed + 1,
+		CompanyID:     company.ID,
+		ResumeID:      1,
+		Status:        int(applyStatus),
+		ApplyProgress: applyStatus.String(),
+	}
+	require.NoError(t, service.jobApplyDAO.Create(apply))
+
+	offer := &model.Offer{
+		ApplyID:     apply.ID,
+		JobID:       apply.JobID,
+		CompanyID:   company.ID,
+		CandidateID: apply.UserID,
+		Position:    "后端工程师",
+		BaseSalary:  20000,
+		StartDate:   time.Now().AddDate(0, 1, 0),
+		ExpireAt:    expireAt,
+		Status:      offerStatus,
+		CreatedBy:   seed,
+	}
+	require.NoError(t, service.offerDAO.Create(offer))
+	return offer
+}
+
+// setApplyStatus 直接修改申请状态
+func setApplyStatus(t *testing.T, db *gorm.DB, applyID uint, status enums.JobApplyEnum) {
+	require.NoError(t, db.Model(&model.JobApply{}).Where("id = ?", applyID).Update("status", status).Error)
+}
+
+// offerNotifications 获取用户收到的Offer通知
+func offerNotifications(t *testing.T, db *gorm.DB, userID uint) []model.Notification {
+	var notifications []model.Notification
+	require.NoError(t, db.Where("user_id = ? AND type = ?", userID, model.NotificationTypeOffer).Find(&notifications).Error)
+	return notifications
+}
+
+// TestOfferService_Send 测试发送Offer，申请不能流转时Offer恢复为审批通过
+func TestOfferService_Send(t *testing.T) {
+	service, db := newTestOfferService(t)
+	offer := createTestOffer(t, service, enums.JobApplyPending, model.OfferApproved, time.Now().AddDate(0, 0, 7))
+
+	// 待处理的申请不能直接流转到已发Offer
+	_, err := service.Send(offer.ID, offer.CreatedBy)
+	assert.Equal(t, errors.InvalidStatusTransition, err.(*errors.Error).Code)
+	current, err := service.offerDAO.GetByID(offer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OfferApproved, current.Status)
+	assert.Nil(t, current.SentAt)
+	assert.Empty(t, offerNotifications(t, db, offer.CandidateID))
+
+	// 非公司成员不能发送
+	_, err = service.Send(offer.ID, offer.CandidateID)
+	assert.Equal(t, errors.CompanyAccessDenied, err.(*errors.Error).Code)
+
+	setApplyStatus(t, db, offer.ApplyID, enums.JobApplyInterviewPass)
+	resp, err := service.Send(offer.ID, offer.CreatedBy)
+	require.NoError(t, err)
+	assert.Equal(t, int(model.OfferSent), resp.Status)
+	apply, err := service.jobApplyDAO.GetByID(offer.ApplyID)
+	require.NoError(t, err)
+	assert.Equal(t, int(enums.JobApplyOfferSent), apply.Status)
+	notifications := offerNotifications(t, db, offer.CandidateID)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, model.UserTypeJobSeeker, notifications[0].UserType)
+
+	// 已发送的Offer不能重复发送
+	_, err = service.Send(offer.ID, offer.CreatedBy)
+	assert.Equal(t, errors.OfferNotAllowed, err.(*errors.Error).Code)
+}
+
+// TestOfferService_Respond 测试候选人答复Offer，申请不能流转时Offer恢复为待确认
+func TestOfferService_Respond(t *testing.T) {
+	service, db := newTestOfferService(t)
+	offer := createTestOffer(t, service, enums.JobApplyInterviewPass, model.OfferSent, time.Now().AddDate(0, 0, 7))
+
+	// 申请尚未流转到已发Offer时不能答复
+	_, err := service.Decline(offer.ID, offer.CandidateID, "薪资不符")
+	assert.Equal(t, errors.InvalidStatusTransition, err.(*errors.Error).Code)
+	current, err := service.offerDAO.GetByID(offer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OfferSent, current.Status)
+	assert.Nil(t, current.RespondedAt)
+	assert.Empty(t, current.DeclineReason)
+
+	// 非候选人本人不能答复
+	_, err = service.Accept(offer.ID, offer.CreatedBy)
+	assert.Equal(t, errors.Forbidden, err.(*errors.Error).Code)
+
+	setApplyStatus(t, db, offer.ApplyID, enums.JobApplyOfferSent)
+	resp, err := service.Decline(offer.ID, offer.CandidateID, "薪资不符")
+	require.NoError(t, err)
+	assert.Equal(t, int(model.OfferDeclined), resp.Status)
+	current, err = service.offerDAO.GetByID(offer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "薪资不符", current.DeclineReason)
+	apply, err := service.jobApplyDAO.GetByID(offer.ApplyID)
+	require.NoError(t, err)
+	assert.Equal(t, int(enums.JobApplyOfferReject), apply.Status)
+	notifications := offerNotifications(t, db, offer.CreatedBy)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, model.UserTypeRecruiter, notifications[0].UserType)
+}
+
+// TestOfferService_ExpireOverdue 测试超过答复截止时间的Offer置为已过期
+func TestOfferService_ExpireOverdue(t *testing.T) {
+	service, db := newTestOfferService(t)
+	now := time.Now()
+	overdue := createTestOffer(t, service, enums.JobApplyOfferSent, model.OfferSent, now.Add(-time.Hour))
+	pending := createTestOffer(t, service, enums.JobApplyOfferSent, model.OfferSent, now.AddDate(0, 0, 7))
+
+	expired, err := service.ExpireOverdue(now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, expired, 1)
+
+	current, err := service.offerDAO.GetByID(overdue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OfferExpired, current.Status)
+	apply, err := service.jobApplyDAO.GetByID(overdue.ApplyID)
+	require.NoError(t, err)
+	assert.Equal(t, int(enums.JobApplyOfferReject), apply.Status)
+	notifications := offerNotifications(t, db, overdue.CreatedBy)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, model.UserTypeRecruiter, notifications[0].UserType)
+
+	// 未到截止时间的Offer不受影响
+	current, err = service.offerDAO.GetByID(pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OfferSent, current.Status)
+
+	// 已过期的Offer候选人不能再答复
+	_, err = service.Accept(overdue.ID, overdue.CandidateID)
+	assert.Equal(t, errors.OfferNotAllowed, err.(*errors.Error).Code)
+}
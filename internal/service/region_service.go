@@ -0,0 +1,108 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// regionCacheTTL 行政区划字典缓存有效期，字典变更后最多经过该时间生效
+const regionCacheTTL = 10 * time.Minute
+
+// RegionService 行政区划服务，基于本地行政区划字典解析结构化地点
+type RegionService struct {
+	dictDAO *dao.DictDAO
+
+	mu       sync.RWMutex
+	index    *model.RegionIndex
+	loadedAt time.Time
+}
+
+// NewRegionService 创建行政区划服务实例
+func NewRegionService(dictDAO *dao.DictDAO) *RegionService {
+	return &RegionService{dictDAO: dictDAO}
+}
+
+// Get 根据行政区划代码获取区划
+func (s *RegionService) Get(code string) (*model.Region, error) {
+	index, err := s.regions()
+	if err != nil {
+		return nil, err
+	}
+	region, ok := index.Get(strings.TrimSpace(code))
+	if !ok {
+		return nil, errors.New(errors.RegionNotFound)
+	}
+	return region, nil
+}
+
+// Names 获取结构化地点中省、市、区县的名称，未解析或不存在的级别为空
+func (s *RegionService) Names(loc model.GeoLocation) (province, city, district string, err error) {
+	index, err := s.regions()
+	if err != nil {
+		return "", "", "", err
+	}
+	name := func(code string) string {
+		if region, ok := index.Get(code); ok {
+			return region.Name
+		}
+		return ""
+	}
+	return name(loc.ProvinceCode), name(loc.CityCode), name(loc.DistrictCode), nil
+}
+
+// Locate 解析结构化地点：指定了行政区划代码时按代码解析，否则从地址文本中解析
+// 地址文本无法解析时返回空地点，不视为错误
+func (s *RegionService) Locate(code, address string) (model.GeoLocation, error) {
+	if code != "" {
+		region, err := s.Get(code)
+		if err != nil {
+			return model.GeoLocation{}, err
+		}
+		return region.Location(), nil
+	}
+	if strings.TrimSpace(address) == "" {
+		return model.GeoLocation{}, nil
+	}
+	index, err := s.regions()
+	if err != nil {
+		return model.GeoLocation{}, err
+	}
+	if region, ok := index.Match(address); ok {
+		return region.Location(), nil
+	}
+	return model.GeoLocation{}, nil
+}
+
+// regions 获取行政区划索引，缓存过期后重新加载，加载失败时继续使用旧的索引
+func (s *RegionService) regions() (*model.RegionIndex, error) {
+	s.mu.RLock()
+	index, loadedAt := s.index, s.loadedAt
+	s.mu.RUnlock()
+	if index != nil && time.Since(loadedAt) < regionCacheTTL {
+		return index, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index != nil && time.Since(s.loadedAt) < regionCacheTTL {
+		return s.index, nil
+	}
+	dicts, err := s.dictDAO.ListEnabledByCategory(model.RegionDictCategory)
+	if err != nil {
+		if s.index != nil {
+			logger.L.Warn("加载行政区划字典失败，使用缓存", zap.Error(err))
+			return s.index, nil
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	s.index = model.NewRegionIndex(dicts)
+	s.loadedAt = time.Now()
+	return s.index, nil
+}
@@ -18,15 +18,17 @@ import (
 	"org.thinkinai.com/recruit-center/pkg/errors"
 	"org.thinkinai.com/recruit-center/pkg/logger"
 	"org.thinkinai.com/recruit-center/pkg/oss"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
 	"org.thinkinai.com/recruit-center/pkg/utils"
 )
 
 type ResumeService struct {
-	resumeDao *dao.ResumeDAO
+	resumeDao     *dao.ResumeDAO
+	regionService *RegionService
 }
 
-func NewResumeService(resumeDao *dao.ResumeDAO) *ResumeService {
-	return &ResumeService{resumeDao: resumeDao}
+func NewResumeService(resumeDao *dao.ResumeDAO, regionService *RegionService) *ResumeService {
+	return &ResumeService{resumeDao: resumeDao, regionService: regionService}
 }
 
 // convertToResumeResponse 将 model.Resume 转换为 response.ResumeResponse
@@ -55,6 +57,7 @@ func (s *ResumeService) convertToResumeResponse(resume *model.Resume) *response.
 		AccessStatus:  resume.AccessStatus,
 		WorkingStatus: resume.WorkingStatus,
 		Status:        resume.Status,
+		GeoLocation:   resume.GeoLocation,
 	}
 
 	// 转换教育经历
@@ -144,6 +147,12 @@ func (s *ResumeService) Create(userID uint, req *request.CreateResumeRequest) (*
 		Introduction: req.Introduction,
 		Skills:       req.Skills,
 	}
+	// 解析结构化所在地
+	location, err := s.regionService.Locate(req.RegionCode, req.Location)
+	if err != nil {
+		return nil, err
+	}
+	resume.GeoLocation = location
 
 	// 添加教育经历
 	for _, edu := range req.Educations {
@@ -229,6 +238,14 @@ func (s *ResumeService) UpdateBasic(resumeID uint, req *request.UpdateResumeBasi
 		Location:     req.Location,
 		Introduction: req.Introduction,
 	}
+	// 修改了所在地时重新解析结构化所在地，否则保留原有数据
+	if req.RegionCode != "" || req.Location != "" {
+		location, err := s.regionService.Locate(req.RegionCode, req.Location)
+		if err != nil {
+			return err
+		}
+		resume.GeoLocation = location
+	}
 	return s.resumeDao.UpdateBasic(resume)
 }
 
@@ -378,6 +395,12 @@ func (s *ResumeService) UploadResumeFile(userID uint, file io.Reader, filename s
 		Introduction: parseResult.BasicInfo.Introduction,
 		Skills:       parseResult.BasicInfo.Skills,
 	}
+	// 从解析出的所在地中解析结构化所在地，失败不影响简历创建
+	if location, err := s.regionService.Locate("", resume.Location); err != nil {
+		logger.L.Warn("解析简历所在地失败", zap.Uint("userID", userID), zap.Error(err))
+	} else {
+		resume.GeoLocation = location
+	}
 
 	// 添加教育经历
 	for _, edu := range parseResult.Education {
@@ -462,19 +485,20 @@ func (s *ResumeService) GetResumeByID(id uint, userID int) (*response.ResumeResp
 	return s.convertToResumeResponse(resume), nil
 }
 
-// ListResumes 获取简历列表
-func (s *ResumeService) ListResumes(page, pageSize int) (*response.ResumeListResponse, error) {
-	resumes, total, err := s.resumeDao.List(page, pageSize)
+// ListResumes 获取简历列表，按创建时间倒序，支持页码和游标分页
+func (s *ResumeService) ListResumes(page pagination.Page) (*response.ResumeListResponse, error) {
+	result, err := s.resumeDao.List(page)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &response.ResumeListResponse{
-		Total:   total,
-		Records: make([]response.ResumeResponse, len(resumes)),
+		Total:      result.Total,
+		Records:    make([]response.ResumeResponse, len(result.Records)),
+		NextCursor: result.NextCursor,
 	}
 
-	for i, resume := range resumes {
+	for i, resume := range result.Records {
 		resp.Records[i] = *s.convertToResumeResponse(&resume)
 	}
 
@@ -14,7 +14,7 @@ func TestResumeService_Create_Success(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	mockDao := dao.NewResumeDAO(db)
-	service := &ResumeService{resumeDao: mockDao}
+	service := NewResumeService(mockDao, NewRegionService(dao.NewDictDAO(db)))
 	birthday, err := time.Parse("2006-01-02", "1990-01-01")
 	assert.NoError(t, err)
 	eduStart, err := time.Parse("2006", "2008")
@@ -67,7 +67,7 @@ func TestResumeService_Create_Success(t *testing.T) {
 func TestResumeService_Create_AlreadyExists(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDao := dao.NewResumeDAO(db)
-	service := &ResumeService{resumeDao: mockDao}
+	service := NewResumeService(mockDao, NewRegionService(dao.NewDictDAO(db)))
 	userID := uint(10012)
 	req := &request.CreateResumeRequest{}
 
@@ -80,7 +80,7 @@ func TestResumeService_Create_AlreadyExists(t *testing.T) {
 func TestResumeService_Create_GenerateNanoIDError(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDao := dao.NewResumeDAO(db)
-	service := &ResumeService{resumeDao: mockDao}
+	service := NewResumeService(mockDao, NewRegionService(dao.NewDictDAO(db)))
 	userID := uint(10012)
 	req := &request.CreateResumeRequest{}
 	resume, err := service.Create(userID, req)
@@ -92,7 +92,7 @@ func TestResumeService_Create_GenerateNanoIDError(t *testing.T) {
 func TestResumeService_Create_DaoCreateError(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDao := dao.NewResumeDAO(db)
-	service := &ResumeService{resumeDao: mockDao}
+	service := NewResumeService(mockDao, NewRegionService(dao.NewDictDAO(db)))
 	userID := uint(10012)
 	req := &request.CreateResumeRequest{}
 	resume, err := service.Create(userID, req)
@@ -104,7 +104,7 @@ func TestResumeService_Create_DaoCreateError(t *testing.T) {
 func TestResumeService_GetByShareToken(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	mockDao := dao.NewResumeDAO(db)
-	service := &ResumeService{resumeDao: mockDao}
+	service := NewResumeService(mockDao, NewRegionService(dao.NewDictDAO(db)))
 	token := "aM6tR3KU2W"
 	// 获取分享令牌
 	resume, err := service.GetByShareToken(token)
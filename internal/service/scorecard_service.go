@@ -0,0 +1,335 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/enums"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// ScorecardService 面试评分卡与面试反馈服务
+type ScorecardService struct {
+	scorecardDAO   *dao.ScorecardDAO
+	feedbackDAO    *dao.InterviewFeedbackDAO
+	interviewDAO   *dao.InterviewDAO
+	jobApplyDAO    *dao.JobApplyDAO
+	jobService     *JobService
+	companyService *CompanyService
+	userService    *UserService
+}
+
+// NewScorecardService 创建面试评分卡服务实例
+func NewScorecardService(scorecardDAO *dao.ScorecardDAO, feedbackDAO *dao.InterviewFeedbackDAO, interviewDAO *dao.InterviewDAO, jobApplyDAO *dao.JobApplyDAO,
+	jobService *JobService, companyService *CompanyService, userService *UserService) *ScorecardService {
+	return &ScorecardService{
+		scorecardDAO:   scorecardDAO,
+		feedbackDAO:    feedbackDAO,
+		interviewDAO:   interviewDAO,
+		jobApplyDAO:    jobApplyDAO,
+		jobService:     jobService,
+		companyService: companyService,
+		userService:    userService,
+	}
+}
+
+// getTemplate 获取职位的评分卡模板，未配置时返回 nil
+func (s *ScorecardService) getTemplate(jobID uint) (*model.ScorecardTemplate, error) {
+	template, err := s.scorecardDAO.GetByJobID(jobID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return template, nil
+}
+
+// GetTemplate 获取职位的评分卡模板，管理员可查看所有职位，企业侧须为职位所属公司的成员
+func (s *ScorecardService) GetTemplate(jobID, userID uint, userType model.UserType) (*response.ScorecardTemplateResponse, error) {
+	if userType != model.UserTypeAdmin {
+		if err := s.jobService.VerifyCompanyMember(jobID, userID); err != nil {
+			return nil, err
+		}
+	}
+	template, err := s.getTemplate(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return response.NewScorecardTemplateResponse(jobID, template), nil
+}
+
+// SaveTemplate 设置职位的评分卡模板，仅职位所属公司的所有者或招聘者可以操作
+// 修改考察项不影响已提交的反馈，汇总时只统计当前评分卡中的考察项
+func (s *ScorecardService) SaveTemplate(jobID, userID uint, definition *model.ScorecardDefinition, requireFeedback bool) (*response.ScorecardTemplateResponse, error) {
+	job, err := s.jobService.authorizeJob(jobID, userID, model.CompanyHirers...)
+	if err != nil {
+		return nil, err
+	}
+	if err := definition.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidScorecard).WithMessage(err.Error())
+	}
+
+	template := &model.ScorecardTemplate{
+		JobID:           jobID,
+		CompanyID:       job.CompanyID,
+		Definition:      *definition,
+		RequireFeedback: requireFeedback,
+		UpdatedBy:       userID,
+	}
+	if err := s.scorecardDAO.Save(template); err != nil {
+		logger.L.Error("保存评分卡失败",
+			zap.Error(err),
+			zap.Uint("jobId", jobID),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return response.NewScorecardTemplateResponse(jobID, template), nil
+}
+
+// DeleteTemplate 删除职位的评分卡模板，之后的反馈只记录录用建议，也不再要求提交反馈
+func (s *ScorecardService) DeleteTemplate(jobID, userID uint) error {
+	if err := s.jobService.VerifyCompanyOwner(jobID, userID); err != nil {
+		return err
+	}
+	if err := s.scorecardDAO.DeleteByJobID(jobID); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	return nil
+}
+
+// SubmitFeedback 面试官提交面试反馈，重复提交时覆盖之前的反馈
+// 只有该场面试的面试官可以提交，面试须已开始且未取消；职位配置了评分卡时每个考察项都须评分
+func (s *ScorecardService) SubmitFeedback(interviewID, userID uint, feedback *model.InterviewFeedback) (*response.InterviewFeedbackResponse, error) {
+	interview, err := s.getInterview(interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if !interview.HasInterviewer(userID) {
+		return nil, errors.New(errors.Forbidden).WithMessage("只有该场面试的面试官可以提交反馈")
+	}
+	if _, err := s.companyService.CheckMember(interview.CompanyID, userID, model.CompanyReaders...); err != nil {
+		return nil, err
+	}
+	switch {
+	case interview.Status == model.InterviewCancelled:
+		return nil, errors.New(errors.InterviewNotAllowed).WithMessage("面试已取消，不能提交反馈")
+	case interview.IsScheduled() && interview.StartTime.After(time.Now()):
+		return nil, errors.New(errors.InterviewNotAllowed).WithMessage("面试尚未开始，不能提交反馈")
+	}
+
+	if !feedback.Recommendation.IsValid() {
+		return nil, errors.New(errors.InvalidParams).WithMessage("无效的录用建议")
+	}
+	template, err := s.getTemplate(interview.JobID)
+	if err != nil {
+		return nil, err
+	}
+	var definition *model.ScorecardDefinition
+	if template != nil {
+		definition = &template.Definition
+		if err := definition.ValidateRatings(feedback.Ratings); err != nil {
+			return nil, errors.New(errors.InvalidScorecard).WithMessage(err.Error())
+		}
+	} else if len(feedback.Ratings) > 0 {
+		return nil, errors.New(errors.InvalidScorecard).WithMessage("职位未配置评分卡，只需提交录用建议")
+	}
+
+	feedback.InterviewID = interview.ID
+	feedback.InterviewerID = userID
+	feedback.ApplyID = interview.ApplyID
+	feedback.JobID = interview.JobID
+	if err := s.feedbackDAO.Save(feedback); err != nil {
+		logger.L.Error("保存面试反馈失败",
+			zap.Error(err),
+			zap.Uint("interviewId", interviewID),
+			zap.Uint("userId", userID))
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+
+	// 面试官姓名仅用于展示，查询失败时忽略
+	interviewer, _ := s.userService.GetByID(userID)
+	return response.NewInterviewFeedbackResponse(feedback, definition, interviewer), nil
+}
+
+// ListByInterview 获取一场面试的所有反馈，仅面试所属公司的成员可以查看
+func (s *ScorecardService) ListByInterview(interviewID, userID uint) ([]*response.InterviewFeedbackResponse, error) {
+	interview, err := s.getInterview(interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.companyService.CheckMember(interview.CompanyID, userID, model.CompanyReaders...); err != nil {
+		return nil, err
+	}
+
+	feedbacks, err := s.feedbackDAO.ListByInterview(interviewID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	template, err := s.getTemplate(interview.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponses(feedbacks, template)
+}
+
+// ApplySummary 汇总申请的所有面试反馈，给出综合录用建议
+// 管理员可查看所有申请，企业侧须为申请所属公司的成员
+func (s *ScorecardService) ApplySummary(applyID, userID uint, userType model.UserType) (*response.ApplyScorecardResponse, error) {
+	apply, err := s.jobApplyDAO.GetByID(applyID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.JobApplicationNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	if userType != model.UserTypeAdmin {
+		if _, err := s.companyService.CheckMember(apply.CompanyID, userID, model.CompanyReaders...); err != nil {
+			return nil, err
+		}
+	}
+
+	feedbacks, err := s.feedbackDAO.ListByApply(applyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	interviews, err := s.interviewDAO.ListByApply(applyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	template, err := s.getTemplate(apply.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var definition *model.ScorecardDefinition
+	requireFeedback := false
+	if template != nil {
+		definition = &template.Definition
+		requireFeedback = template.RequireFeedback
+	}
+	resp := response.NewApplyScorecardResponse(applyID, model.SummarizeFeedback(definition, feedbacks), requireFeedback)
+
+	items, err := s.toResponses(feedbacks, template)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		resp.Feedbacks = append(resp.Feedbacks, *item)
+	}
+
+	// 已完成的面试中尚未提交反馈的面试官
+	submitted := make(map[[2]uint]bool, len(feedbacks))
+	for _, f := range feedbacks {
+		submitted[[2]uint{f.InterviewID, f.InterviewerID}] = true
+	}
+	var pendingIDs []uint
+	for _, interview := range interviews {
+		if interview.Status != model.InterviewCompleted {
+			continue
+		}
+		for _, v := range interview.Interviewers {
+			if !submitted[[2]uint{interview.ID, v.UserID}] {
+				resp.Pending = append(resp.Pending, response.PendingFeedbackResponse{InterviewID: interview.ID, InterviewerID: v.UserID})
+				pendingIDs = append(pendingIDs, v.UserID)
+			}
+		}
+	}
+	if len(pendingIDs) > 0 {
+		users, err := s.userService.GetUserMap(uniqueIDs(pendingIDs))
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Pending {
+			if u, ok := users[resp.Pending[i].InterviewerID]; ok {
+				resp.Pending[i].InterviewerName = u.DisplayName()
+			}
+		}
+	}
+	return resp, nil
+}
+
+// RequiresFeedback 流转到指定状态前职位是否要求已提交面试反馈
+// 仅对面试通过、面试未通过两个状态生效
+func (s *ScorecardService) RequiresFeedback(jobID uint, status enums.JobApplyEnum) (bool, error) {
+	if status != enums.JobApplyInterviewPass && status != enums.JobApplyInterviewFail {
+		return false, nil
+	}
+	template, err := s.getTemplate(jobID)
+	if err != nil {
+		return false, err
+	}
+	return template != nil && template.RequireFeedback, nil
+}
+
+// FeedbackSubmitted 从给定申请中筛选出已有面试反馈的申请
+func (s *ScorecardService) FeedbackSubmitted(applyIDs []uint) (map[uint]bool, error) {
+	submitted, err := s.feedbackDAO.ApplyIDsWithFeedback(applyIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return submitted, nil
+}
+
+// CheckFeedback 校验申请流转到指定状态前是否已按职位要求提交面试反馈
+func (s *ScorecardService) CheckFeedback(apply *model.JobApply, status enums.JobApplyEnum) error {
+	required, err := s.RequiresFeedback(apply.JobID, status)
+	if err != nil || !required {
+		return err
+	}
+	submitted, err := s.FeedbackSubmitted([]uint{apply.ID})
+	if err != nil {
+		return err
+	}
+	if !submitted[apply.ID] {
+		return feedbackRequiredError(status)
+	}
+	return nil
+}
+
+// feedbackRequiredError 缺少面试反馈时的错误
+func feedbackRequiredError(status enums.JobApplyEnum) error {
+	return errors.New(errors.FeedbackRequired).
+		WithMessage(fmt.Sprintf("职位要求提交面试反馈后才能将申请流转到「%s」", status.String()))
+}
+
+// getInterview 获取面试
+func (s *ScorecardService) getInterview(id uint) (*model.Interview, error) {
+	interview, err := s.interviewDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.InterviewNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return interview, nil
+}
+
+// toResponses 批量转换为面试反馈响应，面试官姓名一次性查询
+func (s *ScorecardService) toResponses(feedbacks []model.InterviewFeedback, template *model.ScorecardTemplate) ([]*response.InterviewFeedbackResponse, error) {
+	userIDs := make([]uint, len(feedbacks))
+	for i, f := range feedbacks {
+		userIDs[i] = f.InterviewerID
+	}
+	users, err := s.userService.GetUserMap(uniqueIDs(userIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	var definition *model.ScorecardDefinition
+	if template != nil {
+		definition = &template.Definition
+	}
+	result := make([]*response.InterviewFeedbackResponse, len(feedbacks))
+	for i := range feedbacks {
+		result[i] = response.NewInterviewFeedbackResponse(&feedbacks[i], definition, users[feedbacks[i].InterviewerID])
+	}
+	return result, nil
+}
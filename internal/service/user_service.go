@@ -0,0 +1,272 @@
+package service
+
+import (
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// 账号相关通知模板编码
+const (
+	TemplateUserEmailVerify   = "user_email_verify"   // 邮箱验证
+	TemplateUserPasswordReset = "user_password_reset" // 重置密码
+)
+
+const (
+	emailVerifyTokenTTL   = 24 * time.Hour   // 邮箱验证令牌有效期
+	passwordResetTokenTTL = 30 * time.Minute // 重置密码令牌有效期
+	userTokenLength       = 32               // 一次性令牌长度
+)
+
+// dummyPasswordHash 账号不存在时用于校验的固定哈希，与默认代价一致，使登录耗时不暴露账号是否存在
+const dummyPasswordHash = "$2a$10$aUy/EXOyGIBnffPBdqoP3efe1zrlATU8E8nFmVa0cBxCprVj6npMm"
+
+// UserService 用户账号服务
+type UserService struct {
+	userDAO             *dao.UserDAO
+	userTokenDAO        *dao.UserTokenDAO
+	authService         *AuthService
+	notificationService *NotificationService
+}
+
+// NewUserService 创建用户服务实例
+func NewUserService(userDAO *dao.UserDAO, userTokenDAO *dao.UserTokenDAO, authService *AuthService, notificationService *NotificationService) *UserService {
+	return &UserService{
+		userDAO:             userDAO,
+		userTokenDAO:        userTokenDAO,
+		authService:         authService,
+		notificationService: notificationService,
+	}
+}
+
+// Register 注册用户，注册成功后发送邮箱验证通知
+func (s *UserService) Register(user *model.User, password string) error {
+	// 管理员账号不允许自助注册
+	if user.UserType == model.UserTypeAdmin || user.UserType.Code() == "" {
+		return errors.New(errors.InvalidParams)
+	}
+
+	user.Email = strings.ToLower(strings.TrimSpace(user.Email))
+	exists, err := s.userDAO.ExistsByUsernameOrEmail(user.Username, user.Email)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if exists {
+		return errors.New(errors.UserAlreadyExists)
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	user.PasswordHash = hash
+	user.Status = model.UserStatusActive
+	user.EmailVerified = false
+
+	if err := s.userDAO.Create(user); err != nil {
+		logger.L.Error("创建用户失败", zap.Error(err), zap.String("username", user.Username))
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+
+	// 验证通知发送失败不影响注册，用户可以重新发送
+	if err := s.sendUserToken(user, model.UserTokenEmailVerify); err != nil {
+		logger.L.Warn("发送邮箱验证通知失败", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+	return nil
+}
+
+// Login 使用用户名或邮箱登录，返回用户信息及令牌
+// 账号不存在和密码错误返回相同的错误，避免泄露账号是否存在
+func (s *UserService) Login(account, password string, client TokenClient) (*model.User, *utils.TokenPair, error) {
+	user, err := s.findByAccount(account, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !utils.CheckPassword(user.PasswordHash, password) {
+		return nil, nil, errors.New(errors.InvalidCredentials)
+	}
+	if !user.IsActive() {
+		return nil, nil, errors.New(errors.UserDisabled)
+	}
+
+	pair, err := s.authService.IssueTokens(user.ID, user.CompanyID, user.Username, user.UserType.Code(), client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if err := s.userDAO.UpdateLastLogin(user.ID, now); err != nil {
+		logger.L.Warn("更新最后登录时间失败", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+	user.LastLoginAt = &now
+	return user, pair, nil
+}
+
+// GetByID 获取用户
+func (s *UserService) GetByID(id uint) (*model.User, error) {
+	user, err := s.userDAO.GetByID(id)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(errors.UserNotFound)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return user, nil
+}
+
+// GetUserMap 批量获取用户，返回以用户ID为键的映射，供其他服务解析姓名和联系方式
+func (s *UserService) GetUserMap(ids []uint) (map[uint]*model.User, error) {
+	users, err := s.userDAO.GetByIDs(ids)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	userMap := make(map[uint]*model.User, len(users))
+	for i := range users {
+		userMap[users[i].ID] = &users[i]
+	}
+	return userMap, nil
+}
+
+// ResendEmailVerification 重新发送邮箱验证通知
+func (s *UserService) ResendEmailVerification(userID uint) error {
+	user, err := s.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return errors.New(errors.EmailAlreadyVerified)
+	}
+	return s.sendUserToken(user, model.UserTokenEmailVerify)
+}
+
+// VerifyEmail 使用邮箱验证令牌完成验证
+func (s *UserService) VerifyEmail(token string) error {
+	userToken, err := s.getUsableToken(model.UserTokenEmailVerify, token)
+	if err != nil {
+		return err
+	}
+	if err := s.userDAO.MarkEmailVerified(userToken); err != nil {
+		return userTokenError(err)
+	}
+	return nil
+}
+
+// ForgotPassword 发送重置密码邮件
+// 邮箱未注册、账号已停用或发送失败时同样返回成功，避免泄露账号是否存在
+func (s *UserService) ForgotPassword(email string) error {
+	user, err := s.userDAO.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+			logger.L.Error("查询重置密码用户失败", zap.Error(err))
+		}
+		return nil
+	}
+	if !user.IsActive() {
+		return nil
+	}
+	if err := s.sendUserToken(user, model.UserTokenPasswordReset); err != nil {
+		logger.L.Error("发送重置密码邮件失败", zap.Error(err), zap.Uint("user_id", user.ID))
+	}
+	return nil
+}
+
+// ResetPassword 使用重置密码令牌设置新密码，并吊销该用户所有已登录会话
+func (s *UserService) ResetPassword(token, newPassword string) error {
+	userToken, err := s.getUsableToken(model.UserTokenPasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+	if err := s.userDAO.ResetPassword(userToken, hash); err != nil {
+		return userTokenError(err)
+	}
+
+	if _, err := s.authService.LogoutAll(userToken.UserID); err != nil {
+		logger.L.Error("重置密码后吊销会话失败", zap.Error(err), zap.Uint("user_id", userToken.UserID))
+	}
+	return nil
+}
+
+// findByAccount 根据用户名或邮箱查找用户，不存在时同样执行一次密码校验后返回账号或密码错误
+func (s *UserService) findByAccount(account, password string) (*model.User, error) {
+	account = strings.TrimSpace(account)
+	var (
+		user *model.User
+		err  error
+	)
+	if strings.Contains(account, "@") {
+		user, err = s.userDAO.GetByEmail(strings.ToLower(account))
+	} else {
+		user, err = s.userDAO.GetByUsername(account)
+	}
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			utils.CheckPassword(dummyPasswordHash, password)
+			return nil, errors.New(errors.InvalidCredentials)
+		}
+		return nil, errors.Wrap(err, errors.InternalServerError)
+	}
+	return user, nil
+}
+
+// sendUserToken 生成一次性令牌并通过通知模板发送到用户的注册邮箱
+func (s *UserService) sendUserToken(user *model.User, purpose model.UserTokenPurpose) error {
+	token, err := utils.GenerateNanoID(userTokenLength)
+	if err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+
+	ttl, templateCode := emailVerifyTokenTTL, TemplateUserEmailVerify
+	if purpose == model.UserTokenPasswordReset {
+		ttl, templateCode = passwordResetTokenTTL, TemplateUserPasswordReset
+	}
+
+	userToken := &model.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.userTokenDAO.Replace(userToken); err != nil {
+		return errors.Wrap(err, errors.InternalServerError)
+	}
+
+	return s.notificationService.SendNotification(user.ID, user.UserType, templateCode, map[string]interface{}{
+		"username":  user.DisplayName(),
+		"email":     user.Email,
+		"token":     token,
+		"expiresAt": userToken.ExpiresAt.Format(time.DateTime),
+	})
+}
+
+// getUsableToken 获取未使用且未过期的一次性令牌
+func (s *UserService) getUsableToken(purpose model.UserTokenPurpose, token string) (*model.UserToken, error) {
+	userToken, err := s.userTokenDAO.GetByHash(purpose, utils.HashToken(token))
+	if err != nil {
+		return nil, userTokenError(err)
+	}
+	if !userToken.IsUsable() {
+		return nil, errors.New(errors.VerifyTokenInvalid)
+	}
+	return userToken, nil
+}
+
+// userTokenError 将一次性令牌相关的数据库错误转换为业务错误
+func userTokenError(err error) error {
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New(errors.VerifyTokenInvalid)
+	}
+	return errors.Wrap(err, errors.InternalServerError)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/internal/testutil"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+func newTestUserService(t *testing.T) *UserService {
+	db := testutil.SetupTestDB(t)
+	authService := NewAuthService(dao.NewTokenSessionDAO(db), dao.NewRevokedTokenDAO(db), dao.NewUserDAO(db))
+	notificationService := NewNotificationService(dao.NewNotificationDAO(db), dao.NewNotificationTemplateDAO(db))
+	return NewUserService(dao.NewUserDAO(db), dao.NewUserTokenDAO(db), authService, notificationService)
+}
+
+// TestUserService_RegisterAndLogin 测试注册与登录
+func TestUserService_RegisterAndLogin(t *testing.T) {
+	service := newTestUserService(t)
+
+	username := fmt.Sprintf("user%d", time.Now().UnixNano())
+	user := &model.User{
+		Username: username,
+		Email:    username + "@example.com",
+		UserType: model.UserTypeJobSeeker,
+	}
+	assert.NoError(t, service.Register(user, "P@ssw0rd"))
+	assert.NotZero(t, user.ID)
+	assert.NotEqual(t, "P@ssw0rd", user.PasswordHash)
+
+	// 重复注册
+	err := service.Register(&model.User{Username: username, Email: user.Email, UserType: model.UserTypeJobSeeker}, "P@ssw0rd")
+	assert.Equal(t, errors.UserAlreadyExists, err.(*errors.Error).Code)
+
+	// 密码错误和账号不存在返回相同的错误
+	_, _, err = service.Login(username, "wrong", TokenClient{})
+	assert.Equal(t, errors.InvalidCredentials, err.(*errors.Error).Code)
+	_, _, err = service.Login(username+"x", "P@ssw0rd", TokenClient{})
+	assert.Equal(t, errors.InvalidCredentials, err.(*errors.Error).Code)
+
+	// 使用邮箱登录
+	loggedIn, pair, err := service.Login(user.Email, "P@ssw0rd", TokenClient{})
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, loggedIn.ID)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+}
+
+// TestDummyPasswordHash 测试账号不存在时校验用的哈希与真实密码哈希代价一致
+func TestDummyPasswordHash(t *testing.T) {
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.DefaultCost, cost)
+}
+
+// TestUserService_RegisterAdmin 测试禁止注册管理员
+func TestUserService_RegisterAdmin(t *testing.T) {
+	service := newTestUserService(t)
+
+	err := service.Register(&model.User{Username: "admin", Email: "admin@example.com", UserType: model.UserTypeAdmin}, "P@ssw0rd")
+	assert.Equal(t, errors.InvalidParams, err.(*errors.Error).Code)
+}
+
+// TestAuthService_Refresh 测试刷新令牌按用户当前的角色和企业签发，禁用的用户不能刷新
+func TestAuthService_Refresh(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	authService := NewAuthService(dao.NewTokenSessionDAO(db), dao.NewRevokedTokenDAO(db), dao.NewUserDAO(db))
+	notificationService := NewNotificationService(dao.NewNotificationDAO(db), dao.NewNotificationTemplateDAO(db))
+	service := NewUserService(dao.NewUserDAO(db), dao.NewUserTokenDAO(db), authService, notificationService)
+
+	username := fmt.Sprintf("user%d", time.Now().UnixNano())
+	user := &model.User{Username: username, Email: username + "@example.com", UserType: model.UserTypeJobSeeker}
+	assert.NoError(t, service.Register(user, "P@ssw0rd"))
+	_, pair, err := service.Login(username, "P@ssw0rd", TokenClient{})
+	assert.NoError(t, err)
+
+	// 登录后角色和所属企业发生变化
+	assert.NoError(t, db.Model(user).Updates(map[string]interface{}{"user_type": model.UserTypeRecruiter, "company_id": 42}).Error)
+	refreshed, err := authService.Refresh(pair.RefreshToken, TokenClient{})
+	assert.NoError(t, err)
+	claims, err := utils.ParseToken(refreshed.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, model.UserTypeRecruiter.Code(), claims.Role)
+	assert.Equal(t, uint(42), claims.CompanyID)
+
+	// 禁用后不能刷新
+	assert.NoError(t, db.Model(user).Update("status", model.UserStatusDisabled).Error)
+	_, err = authService.Refresh(refreshed.RefreshToken, TokenClient{})
+	assert.Equal(t, errors.UserDisabled, err.(*errors.Error).Code)
+}
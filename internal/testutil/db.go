@@ -7,6 +7,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"org.thinkinai.com/recruit-center/internal/model"
+	"org.thinkinai.com/recruit-center/pkg/database"
 )
 
 // SetupTestDB 创建测试数据库连接
@@ -28,8 +29,29 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&model.NotificationTemplate{},
 		&model.Dict{},
 		&model.JobFavorite{},
+		&model.TokenSession{},
+		&model.RevokedToken{},
+		&model.User{},
+		&model.UserToken{},
+		&model.Company{},
+		&model.CompanyMember{},
+		&model.JobPipeline{},
+		&model.JobApplyEvent{},
+		&model.Interview{},
+		&model.InterviewInterviewer{},
+		&model.ScorecardTemplate{},
+		&model.InterviewFeedback{},
+		&model.Offer{},
+		&model.OfferApproval{},
+		&model.OfferTemplate{},
+		&model.JobApplyTag{},
+		&model.JobApplyNote{},
+		&model.JobScreening{},
+		&model.JobRevision{},
+		&model.JobReview{},
 	)
 	assert.NoError(t, err)
+	assert.NoError(t, database.MigrateJobSearch(db))
 	return db
 }
 
@@ -30,10 +30,15 @@ import (
 	"org.thinkinai.com/recruit-center/api/handler"
 	_ "org.thinkinai.com/recruit-center/docs"
 	"org.thinkinai.com/recruit-center/internal/dao"
+	"org.thinkinai.com/recruit-center/internal/moderation"
 	"org.thinkinai.com/recruit-center/internal/service"
 	"org.thinkinai.com/recruit-center/pkg/config"
 	"org.thinkinai.com/recruit-center/pkg/database"
 	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/mail"
+	"org.thinkinai.com/recruit-center/pkg/middleware"
+	"org.thinkinai.com/recruit-center/pkg/pagination"
+	"org.thinkinai.com/recruit-center/pkg/scheduler"
 	"org.thinkinai.com/recruit-center/pkg/utils"
 )
 
@@ -51,10 +56,21 @@ func init() {
 
 // App 应用程序结构体
 type App struct {
-	cfg    *config.Config
-	server *http.Server
+	cfg        *config.Config
+	server     *http.Server
+	jobService *service.JobService
+	scheduler  *scheduler.Scheduler // 定时任务，多实例时只在当选的实例上执行
 }
 
+const (
+	offerExpiryInterval        = time.Minute      // 过期Offer的检查间隔
+	jobPublishInterval         = time.Minute      // 默认的职位定时发布和过期下线检查间隔
+	jobRemindInterval          = time.Hour        // 默认的职位到期提醒检查间隔
+	jobRemindDays              = 3                // 默认提前几天发送职位到期提醒
+	jobRecommendInterval       = 10 * time.Minute // 默认的新职位推荐通知检查间隔
+	schedulerLockKey     int64 = 20240601         // 默认的定时任务咨询锁键
+)
+
 // NewApp 创建新的应用实例
 func NewApp() *App {
 	return &App{}
@@ -140,14 +156,28 @@ func (a *App) initHTTPServer() error {
 	}
 	// 初始化jwt配置
 	utils.InitJwt(&a.cfg.JWTConfig)
-	// 初始化依赖
+	// 设置文档生成授权，未设置时无法生成Offer函
+	if a.cfg.Office.LicenseKey != "" {
+		if err := utils.SetOfficeLicense(a.cfg.Office.LicenseKey); err != nil {
+			logger.L.Warn("设置文档生成授权失败", zap.Error(err))
+		}
+	}
+	// 设置中文全文检索配置，未配置时使用二元切分
+	utils.SetTextSearchConfig(a.cfg.Search.TextSearchConfig)
+	// 设置分页游标签名密钥，未配置时使用JWT密钥
+	if a.cfg.Pagination.CursorSecret != "" {
+		pagination.SetSecret(a.cfg.Pagination.CursorSecret)
+	} else {
+		pagination.SetSecret(a.cfg.JWTConfig.Secret)
+	}
+	// 初始化依赖和定时任务
 	handlers, err := a.initializeDependencies(db)
 	if err != nil {
 		return fmt.Errorf("初始化依赖失败: %w", err)
 	}
 
 	// 设置路由
-	router := api.SetupRouter(handlers.job, handlers.jobApply, handlers.resume, handlers.notification, handlers.jobStats, handlers.jobFavorite)
+	router := api.SetupRouter(handlers.job, handlers.jobApply, handlers.resume, handlers.notification, handlers.jobStats, handlers.jobFavorite, handlers.auth, handlers.user, handlers.company, handlers.jobPipeline, handlers.interview, handlers.scorecard, handlers.offer, handlers.applyNote, handlers.jobScreening, handlers.jobReview, handlers.feed, handlers.jobRecommend)
 
 	// 创建HTTP服务器
 	a.server = &http.Server{
@@ -169,6 +199,18 @@ type Handlers struct {
 	notification *handler.NotificationHandler
 	jobStats     *handler.JobStatisticsHandler
 	jobFavorite  *handler.JobFavoriteHandler
+	auth         *handler.AuthHandler
+	user         *handler.UserHandler
+	company      *handler.CompanyHandler
+	jobPipeline  *handler.JobPipelineHandler
+	jobScreening *handler.JobScreeningHandler
+	interview    *handler.InterviewHandler
+	scorecard    *handler.ScorecardHandler
+	offer        *handler.OfferHandler
+	applyNote    *handler.JobApplyNoteHandler
+	jobReview    *handler.JobReviewHandler
+	feed         *handler.FeedHandler
+	jobRecommend *handler.JobRecommendHandler
 }
 
 // initializeDependencies 初始化所有依赖
@@ -182,27 +224,151 @@ func (a *App) initializeDependencies(db *gorm.DB) (*Handlers, error) {
 	notificationDao := dao.NewNotificationDAO(db)
 	notificationTemplateDap := dao.NewNotificationTemplateDAO(db)
 	jobFavoriteDao := dao.NewJobFavoriteDAO(db)
+	tokenSessionDao := dao.NewTokenSessionDAO(db)
+	revokedTokenDao := dao.NewRevokedTokenDAO(db)
+	userDao := dao.NewUserDAO(db)
+	userTokenDao := dao.NewUserTokenDAO(db)
+	companyDao := dao.NewCompanyDAO(db)
+	companyMemberDao := dao.NewCompanyMemberDAO(db)
+	jobPipelineDao := dao.NewJobPipelineDAO(db)
+	jobScreeningDao := dao.NewJobScreeningDAO(db)
+	interviewDao := dao.NewInterviewDAO(db)
+	scorecardDao := dao.NewScorecardDAO(db)
+	interviewFeedbackDao := dao.NewInterviewFeedbackDAO(db)
+	offerDao := dao.NewOfferDAO(db)
+	offerTemplateDao := dao.NewOfferTemplateDAO(db)
+	jobApplyNoteDao := dao.NewJobApplyNoteDAO(db)
+	dictDao := dao.NewDictDAO(db)
+	jobReviewDao := dao.NewJobReviewDAO(db)
+
+	// 已吊销的令牌在解析时即被拒绝
+	utils.SetTokenDenylist(revokedTokenDao)
 
 	// 初始化 Service 层
-	jobService := service.NewJobService(jobDao, jobFavoriteDao, jobApplyDao)
+	companyService := service.NewCompanyService(companyDao, companyMemberDao, userDao)
+	regionService := service.NewRegionService(dictDao)
+	jobService := service.NewJobService(jobDao, jobFavoriteDao, jobApplyDao, companyService, regionService)
+	a.jobService = jobService
+	// 职位内容检查，提交审核和修改已发布的职位时使用
+	contentChecker := moderation.New(a.cfg.Moderation)
+	jobService.SetContentChecker(contentChecker)
 	jobStatsService := service.NewJobStatisticsService(jobStatisticsDao)
 	notificationService := service.NewNotificationService(notificationDao, notificationTemplateDap)
-	jobApplyService := service.NewJobApplyService(jobApplyDao, jobService, notificationService)
-	resumeService := service.NewResumeService(resumeDao)
+	// 配置了SMTP服务器时通过邮件发送邮箱验证和重置密码等通知
+	if mailer := mail.New(a.cfg.Mail); mailer != nil {
+		notificationService.SetMailer(mailer, userDao)
+	} else {
+		logger.L.Warn("未配置邮件服务，邮件通知将无法发送")
+	}
+	jobPipelineService := service.NewJobPipelineService(jobPipelineDao, jobApplyDao, jobService)
+	authService := service.NewAuthService(tokenSessionDao, revokedTokenDao, userDao)
+	userService := service.NewUserService(userDao, userTokenDao, authService, notificationService)
+	scorecardService := service.NewScorecardService(scorecardDao, interviewFeedbackDao, interviewDao, jobApplyDao, jobService, companyService, userService)
+	jobScreeningService := service.NewJobScreeningService(jobScreeningDao, jobService)
+	jobApplyService := service.NewJobApplyService(jobApplyDao, jobService, companyService, jobPipelineService, scorecardService, jobScreeningService, notificationService)
+	resumeService := service.NewResumeService(resumeDao, regionService)
 	JobFavoriteService := service.NewJobFavoriteService(jobFavoriteDao, jobService)
 	resumeInteractionService := service.NewResumeInteractionService(resumeInteractionDao)
+	interviewService := service.NewInterviewService(interviewDao, jobApplyDao, jobService, companyService, jobPipelineService, userService, notificationService)
+	offerService := service.NewOfferService(offerDao, offerTemplateDao, jobApplyDao, jobApplyService, jobPipelineService, jobService, companyService, userService, notificationService)
+	jobApplyNoteService := service.NewJobApplyNoteService(jobApplyNoteDao, jobApplyDao, companyService, userService, notificationService)
+	jobLifecycleService := service.NewJobLifecycleService(jobDao, companyService, notificationService)
+	jobFeedService := service.NewJobFeedService(jobDao, companyService, regionService, a.cfg.Feed)
+	jobRecommendService := service.NewJobRecommendService(jobDao, resumeDao, jobFavoriteDao, jobApplyDao, jobService, companyService, regionService, notificationService, a.cfg.Recommend)
+	jobReviewService := service.NewJobReviewService(jobReviewDao, jobDao, jobService, companyService, notificationService, contentChecker, a.cfg.Moderation.AutoApprove)
+
+	// 初始化定时任务
+	if err := a.initScheduler(db, jobLifecycleService, offerService, jobRecommendService); err != nil {
+		return nil, err
+	}
+
+	// 公司资源访问校验基于成员关系
+	middleware.SetCompanyAccessChecker(companyService)
 
 	// 初始化 Handler 层
 	return &Handlers{
-		job:          handler.NewJobHandler(jobService),
+		job:          handler.NewJobHandler(jobService, jobReviewService),
 		jobApply:     handler.NewJobApplyHandler(jobApplyService, jobService),
 		resume:       handler.NewResumeHandler(resumeService, resumeInteractionService),
 		notification: handler.NewNotificationHandler(notificationService),
-		jobStats:     handler.NewJobStatisticsHandler(jobStatsService),
+		jobStats:     handler.NewJobStatisticsHandler(jobStatsService, jobService),
 		jobFavorite:  handler.NewJobFavoriteHandler(JobFavoriteService),
+		auth:         handler.NewAuthHandler(authService),
+		user:         handler.NewUserHandler(userService),
+		company:      handler.NewCompanyHandler(companyService, userService),
+		jobPipeline:  handler.NewJobPipelineHandler(jobPipelineService, jobService),
+		jobScreening: handler.NewJobScreeningHandler(jobScreeningService, jobService),
+		interview:    handler.NewInterviewHandler(interviewService),
+		scorecard:    handler.NewScorecardHandler(scorecardService),
+		offer:        handler.NewOfferHandler(offerService),
+		applyNote:    handler.NewJobApplyNoteHandler(jobApplyNoteService),
+		jobReview:    handler.NewJobReviewHandler(jobReviewService, jobService),
+		feed:         handler.NewFeedHandler(jobFeedService),
+		jobRecommend: handler.NewJobRecommendHandler(jobRecommendService),
 	}, nil
 }
 
+// initScheduler 初始化定时任务：按时发布职位、下线过期职位、发送职位到期提醒和新职位推荐、处理过期Offer
+func (a *App) initScheduler(db *gorm.DB, jobLifecycleService *service.JobLifecycleService, offerService *service.OfferService, jobRecommendService *service.JobRecommendService) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("获取数据库连接失败: %w", err)
+	}
+	cfg := a.cfg.Scheduler
+	lockKey := cfg.LockKey
+	if lockKey == 0 {
+		lockKey = schedulerLockKey
+	}
+	publishInterval := cfg.PublishInterval
+	if publishInterval <= 0 {
+		publishInterval = jobPublishInterval
+	}
+	remindInterval := cfg.RemindInterval
+	if remindInterval <= 0 {
+		remindInterval = jobRemindInterval
+	}
+	remindDays := cfg.RemindDays
+	if remindDays <= 0 {
+		remindDays = jobRemindDays
+	}
+	recommendInterval := cfg.RecommendInterval
+	if recommendInterval <= 0 {
+		recommendInterval = jobRecommendInterval
+	}
+
+	a.scheduler = scheduler.New(scheduler.NewAdvisoryLock(sqlDB, lockKey), cfg.ElectionInterval)
+	a.scheduler.Register(scheduler.Task{Name: "job_publish", Interval: publishInterval, Run: func(_ context.Context, now time.Time) error {
+		_, err := jobLifecycleService.PublishScheduled(now)
+		return err
+	}})
+	a.scheduler.Register(scheduler.Task{Name: "job_expire", Interval: publishInterval, Run: func(_ context.Context, now time.Time) error {
+		_, err := jobLifecycleService.ExpireOverdue(now)
+		return err
+	}})
+	a.scheduler.Register(scheduler.Task{Name: "job_expire_remind", Interval: remindInterval, Run: func(_ context.Context, now time.Time) error {
+		n, err := jobLifecycleService.RemindExpiring(now, remindDays)
+		if n > 0 {
+			logger.L.Info("已发送职位到期提醒", zap.Int("count", n))
+		}
+		return err
+	}})
+	a.scheduler.Register(scheduler.Task{Name: "job_recommend", Interval: recommendInterval, Run: func(_ context.Context, now time.Time) error {
+		n, err := jobRecommendService.NotifyNewJobs(now)
+		if n > 0 {
+			logger.L.Info("已发送新职位推荐", zap.Int("count", n))
+		}
+		return err
+	}})
+	a.scheduler.Register(scheduler.Task{Name: "offer_expire", Interval: offerExpiryInterval, Run: func(_ context.Context, now time.Time) error {
+		n, err := offerService.ExpireOverdue(now)
+		if n > 0 {
+			logger.L.Info("已处理过期Offer", zap.Int("count", n))
+		}
+		return err
+	}})
+	return nil
+}
+
 // Run 运行应用
 func (a *App) Run() error {
 	// 启动HTTP服务器
@@ -213,6 +379,10 @@ func (a *App) Run() error {
 		}
 	}()
 
+	// 启动后台任务
+	a.scheduler.Start()
+	go a.jobService.BackfillSearchVectors()
+
 	return a.waitForShutdown()
 }
 
@@ -227,9 +397,16 @@ func (a *App) waitForShutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// 停止接收请求的同时停止定时任务，等待执行中的任务结束并释放咨询锁
+	schedulerStopped := make(chan error, 1)
+	go func() { schedulerStopped <- a.scheduler.Stop(ctx) }()
+
 	if err := a.server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("服务关闭失败: %w", err)
 	}
+	if err := <-schedulerStopped; err != nil {
+		return fmt.Errorf("定时任务停止失败: %w", err)
+	}
 
 	logger.L.Info("服务已关闭")
 	return nil
@@ -37,6 +37,14 @@ type Config struct {
 	Version          string           `mapstructure:"version"`     // Application version
 	System           SystemConfig     `mapstructure:"system"`      // System configuration
 	AI               AIConfig         `mapstructure:"ai"`          // AI configuration
+	Office           OfficeConfig     `mapstructure:"office"`      // Office document configuration
+	Search           SearchConfig     `mapstructure:"search"`      // Full-text search configuration
+	Pagination       PaginationConfig `mapstructure:"pagination"`  // List pagination configuration
+	Scheduler        SchedulerConfig  `mapstructure:"scheduler"`   // Background job scheduler configuration
+	Moderation       ModerationConfig `mapstructure:"moderation"`  // Job content moderation configuration
+	Feed             FeedConfig       `mapstructure:"feed"`        // Job syndication feed configuration
+	Recommend        RecommendConfig  `mapstructure:"recommend"`   // Job recommendation configuration
+	Mail             MailConfig       `mapstructure:"mail"`        // SMTP mail configuration
 	v                *viper.Viper     `mapstructure:"-"`
 }
 
@@ -44,8 +52,66 @@ type SystemConfig struct {
 	UserTagLimit int `mapstructure:"user_tag_limit"` // 用户标签限制
 }
 
+// OfficeConfig 文档生成配置
+type OfficeConfig struct {
+	LicenseKey string `mapstructure:"license_key"` // unioffice 计量授权密钥，未配置时无法生成Offer函
+}
+
+// SearchConfig 全文检索配置
+type SearchConfig struct {
+	TextSearchConfig string `mapstructure:"text_search_config"` // 数据库中的中文全文检索配置(如基于 zhparser 创建)，为空时使用二元切分
+}
+
+// PaginationConfig 列表分页配置
+type PaginationConfig struct {
+	CursorSecret string `mapstructure:"cursor_secret"` // 分页游标签名密钥，多实例须相同，为空时使用JWT密钥
+}
+
+// SchedulerConfig 定时任务配置，多实例部署时通过数据库咨询锁选出一个实例执行
+type SchedulerConfig struct {
+	LockKey           int64         `mapstructure:"lock_key"`           // 领导者选举使用的咨询锁键，同一部署的实例须相同
+	ElectionInterval  time.Duration `mapstructure:"election_interval"`  // 选举间隔，默认30秒
+	PublishInterval   time.Duration `mapstructure:"publish_interval"`   // 定时发布和过期下线的检查间隔，默认1分钟
+	RemindInterval    time.Duration `mapstructure:"remind_interval"`    // 职位到期提醒的检查间隔，默认1小时
+	RemindDays        int           `mapstructure:"remind_days"`        // 提前几天发送职位到期提醒，默认3天
+	RecommendInterval time.Duration `mapstructure:"recommend_interval"` // 新职位推荐通知的检查间隔，默认10分钟
+}
+
+// ModerationConfig 职位内容审核配置
+type ModerationConfig struct {
+	AutoApprove    bool     `mapstructure:"auto_approve"`     // 内容检查未发现问题的职位自动通过审核，否则全部由管理员审核
+	SensitiveWords []string `mapstructure:"sensitive_words"`  // 敏感词，不区分大小写
+	MinSalary      int      `mapstructure:"min_salary"`       // 最低薪资下限，0 表示不检查
+	MaxSalary      int      `mapstructure:"max_salary"`       // 最高薪资上限，0 表示不检查
+	MaxSalaryRatio float64  `mapstructure:"max_salary_ratio"` // 最高薪资与最低薪资的最大倍数，0 表示不检查
+}
+
+// FeedConfig 职位订阅源配置
+type FeedConfig struct {
+	SiteURL     string `mapstructure:"site_url"`    // 求职站点地址，职位链接为 {site_url}/jobs/{id}
+	Title       string `mapstructure:"title"`       // 订阅源标题，同时作为发布方名称
+	Description string `mapstructure:"description"` // 订阅源描述
+}
+
+// RecommendConfig 职位推荐配置
+type RecommendConfig struct {
+	NotifyThreshold float64 `mapstructure:"notify_threshold"` // 发送新职位推荐通知的最低匹配度(0-1)，默认0.6
+	NotifyLimit     int     `mapstructure:"notify_limit"`     // 每个新职位最多通知的求职者数，默认50
+}
+
+// MailConfig 邮件发送配置，用于邮箱验证和重置密码等邮件通知
+type MailConfig struct {
+	Host     string `mapstructure:"host"`     // SMTP服务器地址，为空时不发送邮件
+	Port     int    `mapstructure:"port"`     // SMTP端口，默认587，服务器支持时使用STARTTLS
+	Username string `mapstructure:"username"` // 登录用户名
+	Password string `mapstructure:"password"` // 登录密码或授权码
+	From     string `mapstructure:"from"`     // 发件人地址，为空时使用登录用户名
+}
+
 type JWTConfig struct {
-	Secret string `mapstructure:"secret"` // JWT密钥
+	Secret          string        `mapstructure:"secret"`            // JWT密钥
+	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`  // 访问令牌有效期，默认2小时
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"` // 刷新令牌有效期，默认7天
 }
 
 type DB struct {
@@ -54,7 +54,7 @@ func Init(cfg *config.DB) (*gorm.DB, error) {
 
 // AutoMigrate 自动迁移数据库表结构
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&model.Resume{},
 		&model.Education{},
 		&model.WorkExperience{},
@@ -68,9 +68,48 @@ func AutoMigrate(db *gorm.DB) error {
 		&model.Notification{},
 		&model.NotificationTemplate{},
 		&model.Dict{},
+		&model.TokenSession{},
+		&model.RevokedToken{},
+		&model.User{},
+		&model.UserToken{},
+		&model.Company{},
+		&model.CompanyMember{},
+		&model.JobPipeline{},
+		&model.JobApplyEvent{},
+		&model.Interview{},
+		&model.InterviewInterviewer{},
+		&model.ScorecardTemplate{},
+		&model.InterviewFeedback{},
+		&model.Offer{},
+		&model.OfferApproval{},
+		&model.OfferTemplate{},
+		&model.JobApplyTag{},
+		&model.JobApplyNote{},
+		&model.JobScreening{},
+		&model.JobRevision{},
+		&model.JobReview{},
 
 	// 添加其他需要迁移的模型
 	)
+	if err != nil {
+		return err
+	}
+	return MigrateJobSearch(db)
+}
+
+// MigrateJobSearch 为职位表添加全文检索列及 GIN 索引
+// 检索列由 JobDAO 在保存职位时写入，gorm 不管理该列
+func MigrateJobSearch(db *gorm.DB) error {
+	statements := []string{
+		"ALTER TABLE t_rc_job ADD COLUMN IF NOT EXISTS search_vector tsvector",
+		"CREATE INDEX IF NOT EXISTS idx_job_search_vector ON t_rc_job USING GIN (search_vector)",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("迁移职位全文检索失败: %w", err)
+		}
+	}
+	return nil
 }
 
 // Close 关闭数据库连接
@@ -4,8 +4,14 @@ package enums
 type JobStatus int
 
 const (
-	JobStatusNormal  JobStatus = 1 // 正常
-	JobStatusExpired JobStatus = 0 // 已过期
+	JobStatusNormal    JobStatus = 1 // 正常
+	JobStatusExpired   JobStatus = 0 // 已过期
+	JobStatusScheduled JobStatus = 2 // 待发布，到发布时间后自动发布
+
+	// 审核状态，审核通过后进入正常或待发布状态
+	JobStatusDraft         JobStatus = 3 // 草稿
+	JobStatusPendingReview JobStatus = 4 // 待审核
+	JobStatusRejected      JobStatus = 5 // 审核驳回
 )
 
 // String 实现 String 接口
@@ -15,6 +21,14 @@ func (s JobStatus) String() string {
 		return "正常"
 	case JobStatusExpired:
 		return "已过期"
+	case JobStatusScheduled:
+		return "待发布"
+	case JobStatusDraft:
+		return "草稿"
+	case JobStatusPendingReview:
+		return "待审核"
+	case JobStatusRejected:
+		return "审核驳回"
 	default:
 		return "未知状态"
 	}
@@ -74,12 +88,27 @@ func GetJobStatusText(status int) string {
 
 func (s JobStatus) IsValid() bool {
 	switch s {
-	case JobStatusNormal, JobStatusExpired:
+	case JobStatusNormal, JobStatusExpired, JobStatusScheduled, JobStatusDraft, JobStatusPendingReview, JobStatusRejected:
 		return true
 	}
 	return false
 }
 
+// IsApproved 职位是否已通过审核，未通过审核的职位只有所属公司可见
+func (s JobStatus) IsApproved() bool {
+	return s == JobStatusNormal || s == JobStatusExpired || s == JobStatusScheduled
+}
+
+// IsPublished 职位是否已发布，只有已发布的职位对所有人可见，待发布的职位到发布时间前只有所属公司可见
+func (s JobStatus) IsPublished() bool {
+	return s == JobStatusNormal || s == JobStatusExpired
+}
+
+// UnapprovedJobStatuses 未通过审核的职位状态
+func UnapprovedJobStatuses() []JobStatus {
+	return []JobStatus{JobStatusDraft, JobStatusPendingReview, JobStatusRejected}
+}
+
 // JobType 相关方法
 func JobTypeFromInt(typeStr int) JobType {
 	return JobType(typeStr)
@@ -119,6 +148,10 @@ func ListJobStatus() []JobStatus {
 	return []JobStatus{
 		JobStatusNormal,
 		JobStatusExpired,
+		JobStatusScheduled,
+		JobStatusDraft,
+		JobStatusPendingReview,
+		JobStatusRejected,
 	}
 }
 
@@ -23,9 +23,13 @@ const (
 
 	// 业务错误码 (1001-8999)
 	// 用户模块 (6001-6999)
-	UserNotFound      ErrorCode = 6001 // 用户不存在
-	UserAlreadyExists ErrorCode = 6002 // 用户已存在
-	PasswordIncorrect ErrorCode = 6003 // 密码错误
+	UserNotFound         ErrorCode = 6001 // 用户不存在
+	UserAlreadyExists    ErrorCode = 6002 // 用户已存在
+	PasswordIncorrect    ErrorCode = 6003 // 密码错误
+	UserDisabled         ErrorCode = 6004 // 用户已禁用
+	VerifyTokenInvalid   ErrorCode = 6005 // 验证链接无效或已过期
+	EmailAlreadyVerified ErrorCode = 6006 // 邮箱已验证
+	InvalidCredentials   ErrorCode = 6007 // 账号或密码错误
 
 	// 职位模块 (2001-2999)
 	JobNotFound                   ErrorCode = 2001 // 职位不存在
@@ -39,10 +43,31 @@ const (
 	JobApplicationAlreadyReviewed ErrorCode = 2009 // 职位申请已被审核
 	JobNotBelongToCompany         ErrorCode = 2010 // 职位不属于该公司
 	InvalidJobStatus              ErrorCode = 2011 // 无效的职位状态
+	InvalidStatusTransition       ErrorCode = 2012 // 不允许的申请状态流转
+	InvalidPipeline               ErrorCode = 2013 // 无效的招聘流程定义
+	InterviewNotFound             ErrorCode = 2014 // 面试不存在
+	InterviewConflict             ErrorCode = 2015 // 面试时间冲突
+	InterviewNotAllowed           ErrorCode = 2016 // 当前状态不允许该面试操作
+	InvalidScorecard              ErrorCode = 2017 // 无效的面试评分卡或评分
+	FeedbackRequired              ErrorCode = 2018 // 需先提交面试反馈
+	OfferNotFound                 ErrorCode = 2019 // Offer不存在
+	OfferNotAllowed               ErrorCode = 2020 // 当前状态不允许该Offer操作
+	OfferExpired                  ErrorCode = 2021 // Offer已过期
+	OfferTemplateNotFound         ErrorCode = 2022 // Offer函模板不存在
+	ApplyNoteNotFound             ErrorCode = 2023 // 申请备注不存在
+	InvalidScreening              ErrorCode = 2024 // 无效的筛选问题或回答
+	RegionNotFound                ErrorCode = 2025 // 行政区划不存在
+	JobRevisionNotFound           ErrorCode = 2026 // 职位修订版本不存在
+	JobReviewNotFound             ErrorCode = 2027 // 职位审核记录不存在
+	JobContentViolation           ErrorCode = 2028 // 职位内容未通过检查
 
 	// 公司模块 (3001-3999)
-	CompanyNotFound ErrorCode = 3001 // 公司不存在
-	CompanyInactive ErrorCode = 3002 // 公司未激活
+	CompanyNotFound       ErrorCode = 3001 // 公司不存在
+	CompanyInactive       ErrorCode = 3002 // 公司未激活
+	CompanyMemberNotFound ErrorCode = 3003 // 公司成员不存在
+	CompanyMemberExists   ErrorCode = 3004 // 用户已是公司成员
+	CompanyAccessDenied   ErrorCode = 3005 // 无权操作该公司
+	CompanyLastOwner      ErrorCode = 3006 // 公司至少需要保留一名所有者
 
 	//文件上传模块 (4001-4999)
 	FileTooLarge       ErrorCode = 4001 // 文件过大
@@ -67,7 +92,10 @@ const (
 
 	// 外部模块 (8001-8999)
 
-	InvalidToken ErrorCode = 8001 // 无效的令牌
+	InvalidToken   ErrorCode = 8001 // 无效的令牌
+	TokenExpired   ErrorCode = 8002 // 令牌已过期
+	TokenMalformed ErrorCode = 8003 // 令牌格式错误
+	TokenRevoked   ErrorCode = 8004 // 令牌已吊销
 
 )
 
@@ -92,6 +120,14 @@ func (e ErrorCode) String() string {
 		return "用户已存在"
 	case PasswordIncorrect:
 		return "密码错误"
+	case UserDisabled:
+		return "用户已禁用"
+	case VerifyTokenInvalid:
+		return "验证链接无效或已过期"
+	case EmailAlreadyVerified:
+		return "邮箱已验证"
+	case InvalidCredentials:
+		return "账号或密码错误"
 	case JobNotFound:
 		return "职位不存在"
 	case JobExpired:
@@ -102,6 +138,14 @@ func (e ErrorCode) String() string {
 		return "公司不存在"
 	case CompanyInactive:
 		return "公司未激活"
+	case CompanyMemberNotFound:
+		return "公司成员不存在"
+	case CompanyMemberExists:
+		return "用户已是公司成员"
+	case CompanyAccessDenied:
+		return "无权操作该公司"
+	case CompanyLastOwner:
+		return "公司至少需要保留一名所有者"
 	case FileTooLarge:
 		return "文件过大"
 	case FileTypeNotAllowed:
@@ -130,6 +174,40 @@ func (e ErrorCode) String() string {
 		return "职位申请不存在"
 	case JobApplicationAlreadyReviewed:
 		return "职位申请已被审核"
+	case InvalidStatusTransition:
+		return "不允许的申请状态流转"
+	case InvalidPipeline:
+		return "无效的招聘流程定义"
+	case InterviewNotFound:
+		return "面试不存在"
+	case InterviewConflict:
+		return "面试时间冲突"
+	case InterviewNotAllowed:
+		return "当前状态不允许该面试操作"
+	case InvalidScorecard:
+		return "无效的面试评分卡或评分"
+	case FeedbackRequired:
+		return "需先提交面试反馈"
+	case OfferNotFound:
+		return "Offer不存在"
+	case OfferNotAllowed:
+		return "当前状态不允许该Offer操作"
+	case OfferExpired:
+		return "Offer已过期"
+	case OfferTemplateNotFound:
+		return "Offer函模板不存在"
+	case ApplyNoteNotFound:
+		return "申请备注不存在"
+	case InvalidScreening:
+		return "无效的筛选问题或回答"
+	case RegionNotFound:
+		return "行政区划不存在"
+	case JobRevisionNotFound:
+		return "职位修订版本不存在"
+	case JobReviewNotFound:
+		return "职位审核记录不存在"
+	case JobContentViolation:
+		return "职位内容未通过检查"
 	case ResumeNotFound:
 		return "简历不存在"
 	case ResumeInvalid:
@@ -156,6 +234,12 @@ func (e ErrorCode) String() string {
 		return "简历更新状态错误"
 	case InvalidToken:
 		return "无效的令牌"
+	case TokenExpired:
+		return "令牌已过期"
+	case TokenMalformed:
+		return "令牌格式错误"
+	case TokenRevoked:
+		return "令牌已吊销"
 	default:
 		return "未知错误"
 	}
@@ -0,0 +1,68 @@
+// Package mail 通过 SMTP 发送邮件通知
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"org.thinkinai.com/recruit-center/pkg/config"
+)
+
+// Sender 邮件发送器
+type Sender interface {
+	Send(to, subject, body string) error
+}
+
+// SMTPSender 使用 SMTP 发送纯文本邮件，服务器支持 STARTTLS 时自动加密
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// New 按配置创建 SMTP 邮件发送器，未配置服务器地址时返回 nil
+func New(cfg config.MailConfig) *SMTPSender {
+	if cfg.Host == "" {
+		return nil
+	}
+	port := cfg.Port
+	if port <= 0 {
+		port = 587
+	}
+	from := cfg.From
+	if from == "" {
+		from = cfg.Username
+	}
+	s := &SMTPSender{addr: net.JoinHostPort(cfg.Host, strconv.Itoa(port)), from: from}
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return s
+}
+
+// Send 发送邮件
+func (s *SMTPSender) Send(to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("invalid recipient: %q", to)
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, message(s.from, to, subject, body, time.Now()))
+}
+
+// message 构造 UTF-8 纯文本邮件，标题按 RFC 2047 编码
+func message(from, to, subject, body string, now time.Time) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
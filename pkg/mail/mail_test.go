@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"org.thinkinai.com/recruit-center/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, New(config.MailConfig{}))
+
+	s := New(config.MailConfig{Host: "smtp.example.com", Username: "noreply@example.com", Password: "secret"})
+	assert.Equal(t, "smtp.example.com:587", s.addr)
+	assert.Equal(t, "noreply@example.com", s.from)
+	assert.NotNil(t, s.auth)
+}
+
+func TestMessage(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg := string(message("noreply@example.com", "user@example.com", "重置密码", "第一行\n第二行", now))
+
+	assert.Contains(t, msg, "To: user@example.com\r\n")
+	assert.Contains(t, msg, "Subject: =?UTF-8?b?")
+	assert.NotContains(t, msg, "Subject: 重置密码")
+	assert.Contains(t, msg, "Date: Tue, 02 Jan 2024 03:04:05 +0000\r\n")
+	assert.True(t, strings.HasSuffix(msg, "\r\n\r\n第一行\r\n第二行"))
+}
+
+func TestSendRejectsHeaderInjection(t *testing.T) {
+	s := New(config.MailConfig{Host: "smtp.example.com"})
+	assert.Error(t, s.Send("user@example.com\r\nBcc: other@example.com", "subject", "body"))
+}
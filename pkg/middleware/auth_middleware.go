@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// 上下文中存储的用户信息键
+const (
+	ContextKeyUserID    = "userId"
+	ContextKeyUsername  = "username"
+	ContextKeyUserRole  = "userRole"
+	ContextKeyCompanyID = "companyId"
+	ContextKeyTokenID   = "tokenId"
+	ContextKeyTokenExp  = "tokenExpiresAt"
+)
+
+// AuthRequired 认证中间件，未携带或携带无效令牌时直接拒绝请求
+func AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, code := extractBearerToken(c)
+		if code != errors.Success {
+			c.AbortWithStatusJSON(200, response.NewError(code))
+			return
+		}
+
+		claims, err := utils.ParseToken(token)
+		if err != nil {
+			logger.L.Warn("Token解析失败", zap.String("path", c.Request.URL.Path), zap.Error(err))
+			c.AbortWithStatusJSON(200, response.NewError(tokenErrorCode(err)))
+			return
+		}
+		// 刷新令牌只能用于换取新令牌，不能访问业务接口
+		if claims.IsRefresh() {
+			c.AbortWithStatusJSON(200, response.NewError(errors.InvalidToken))
+			return
+		}
+
+		setClaims(c, claims)
+		c.Next()
+	}
+}
+
+// AuthOptional 可选认证中间件，用于公开接口
+// 携带有效令牌时写入用户信息，否则以匿名身份继续处理
+func AuthOptional() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, code := extractBearerToken(c); code == errors.Success {
+			if claims, err := utils.ParseToken(token); err == nil && !claims.IsRefresh() {
+				setClaims(c, claims)
+			}
+		}
+		c.Next()
+	}
+}
+
+// extractBearerToken 从Authorization请求头中提取Bearer令牌
+func extractBearerToken(c *gin.Context) (string, errors.ErrorCode) {
+	auth := c.GetHeader("Authorization")
+	if auth == "" {
+		return "", errors.Unauthorized
+	}
+
+	parts := strings.SplitN(auth, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || strings.TrimSpace(parts[1]) == "" {
+		return "", errors.TokenMalformed
+	}
+	return strings.TrimSpace(parts[1]), errors.Success
+}
+
+// setClaims 将用户信息存储到上下文
+func setClaims(c *gin.Context, claims *utils.Claims) {
+	c.Set(ContextKeyUserID, claims.UserID)
+	c.Set(ContextKeyUsername, claims.Username)
+	c.Set(ContextKeyUserRole, claims.Role)
+	c.Set(ContextKeyCompanyID, claims.CompanyID)
+	c.Set(ContextKeyTokenID, claims.Id)
+	c.Set(ContextKeyTokenExp, claims.ExpiresTime())
+}
+
+// tokenErrorCode 将令牌解析错误转换为错误码
+func tokenErrorCode(err error) errors.ErrorCode {
+	switch {
+	case stderrors.Is(err, utils.ErrTokenExpired):
+		return errors.TokenExpired
+	case stderrors.Is(err, utils.ErrTokenMalformed):
+		return errors.TokenMalformed
+	case stderrors.Is(err, utils.ErrTokenRevoked):
+		return errors.TokenRevoked
+	default:
+		return errors.InvalidToken
+	}
+}
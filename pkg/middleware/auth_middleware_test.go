@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	logger.L = zap.NewNop()
+}
+
+// newAuthTestRouter 创建挂载指定认证中间件的测试路由，处理函数回显上下文中的用户信息
+func newAuthTestRouter(auth gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.GET("/test", auth, func(c *gin.Context) {
+		c.JSON(http.StatusOK, response.NewSuccess(gin.H{
+			"userId":    c.GetUint(ContextKeyUserID),
+			"userRole":  c.GetString(ContextKeyUserRole),
+			"companyId": c.GetUint(ContextKeyCompanyID),
+		}))
+	})
+	return r
+}
+
+type authTestResult struct {
+	Code errors.ErrorCode `json:"code"`
+	Data struct {
+		UserID    uint   `json:"userId"`
+		UserRole  string `json:"userRole"`
+		CompanyID uint   `json:"companyId"`
+	} `json:"data"`
+}
+
+func doAuthRequest(t *testing.T, r *gin.Engine, header string) authTestResult {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var result authTestResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return result
+}
+
+func TestAuthRequired(t *testing.T) {
+	token, err := utils.GenerateCompanyToken(7, 3, "hr", "recruiter")
+	assert.NoError(t, err)
+	pair, err := utils.GenerateTokenPair(7, 3, "hr", "recruiter")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		header   string
+		wantCode errors.ErrorCode
+	}{
+		{name: "missing header", header: "", wantCode: errors.Unauthorized},
+		{name: "not bearer", header: "Basic abc", wantCode: errors.TokenMalformed},
+		{name: "malformed token", header: "Bearer abc", wantCode: errors.TokenMalformed},
+		{name: "refresh token", header: "Bearer " + pair.RefreshToken, wantCode: errors.InvalidToken},
+		{name: "valid token", header: "Bearer " + token, wantCode: errors.Success},
+	}
+
+	r := newAuthTestRouter(AuthRequired())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := doAuthRequest(t, r, tt.header)
+			assert.Equal(t, tt.wantCode, result.Code)
+			if tt.wantCode == errors.Success {
+				assert.Equal(t, uint(7), result.Data.UserID)
+				assert.Equal(t, "recruiter", result.Data.UserRole)
+				assert.Equal(t, uint(3), result.Data.CompanyID)
+			}
+		})
+	}
+}
+
+func TestAuthOptional(t *testing.T) {
+	token, err := utils.GenerateToken(9, "seeker", "job_seeker")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		header     string
+		wantUserID uint
+	}{
+		{name: "anonymous", header: "", wantUserID: 0},
+		{name: "invalid token falls back to anonymous", header: "Bearer abc", wantUserID: 0},
+		{name: "valid token", header: "Bearer " + token, wantUserID: 9},
+	}
+
+	r := newAuthTestRouter(AuthOptional())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := doAuthRequest(t, r, tt.header)
+			assert.Equal(t, errors.Success, result.Code)
+			assert.Equal(t, tt.wantUserID, result.Data.UserID)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -15,39 +16,6 @@ import (
 	"org.thinkinai.com/recruit-center/pkg/logger"
 )
 
-// AuthRequired 认证中间件
-func AuthRequired() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// auth := c.GetHeader("Authorization")
-		// if auth == "" {
-		// 	c.AbortWithStatusJSON(200, response.NewError(errors.Unauthorized))
-		// 	return
-		// }
-
-		// // 提取 token
-		// parts := strings.SplitN(auth, " ", 2)
-		// if !(len(parts) == 2 && parts[0] == "Bearer") {
-		// 	c.AbortWithStatusJSON(200, response.NewError(errors.InvalidToken))
-		// 	return
-		// }
-
-		// // 解析 token
-		// claims, err := utils.ParseToken(parts[1])
-		// if err != nil {
-		// 	logger.L.Error("Token解析失败", zap.Error(err))
-		// 	c.AbortWithStatusJSON(200, response.NewError(errors.InvalidToken))
-		// 	return
-		// }
-
-		// // 将用户信息存储到上下文
-		// c.Set("userId", claims.UserID)
-		// c.Set("username", claims.Username)
-		// c.Set("userRole", claims.Role)
-
-		c.Next()
-	}
-}
-
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 允许所有来源的请求
@@ -117,8 +85,8 @@ func LoggerMiddleware() gin.HandlerFunc {
 			zap.String("user-agent", c.Request.UserAgent()),
 			zap.Int("status", c.Writer.Status()),
 			zap.Duration("latency", latency),
-			zap.String("request", requestBody),
-			zap.String("response", blw.body.String()),
+			zap.String("request", redactBody(path, requestBody)),
+			zap.String("response", redactBody(path, blw.body.String())),
 		)
 
 		// 如果发生错误，记录错误日志
@@ -131,6 +99,65 @@ func LoggerMiddleware() gin.HandlerFunc {
 	}
 }
 
+// sensitiveFields 日志中脱敏的JSON字段，不区分大小写
+var sensitiveFields = map[string]bool{
+	"password":     true,
+	"newpassword":  true,
+	"oldpassword":  true,
+	"token":        true,
+	"accesstoken":  true,
+	"refreshtoken": true,
+}
+
+// redactedValue 脱敏后的字段值
+const redactedValue = "***"
+
+// redactBody 脱敏日志中的请求或响应内容：JSON 中的密码和令牌字段替换为 ***
+// 认证和修改密码接口的非 JSON 内容整体省略
+func redactBody(path, body string) string {
+	if body == "" {
+		return body
+	}
+	var v interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		if isCredentialPath(path) {
+			return redactedValue
+		}
+		return body
+	}
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redactedValue
+	}
+	return string(redacted)
+}
+
+// redactValue 递归替换敏感字段的值
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, item := range val {
+			if sensitiveFields[strings.ToLower(key)] {
+				val[key] = redactedValue
+			} else {
+				val[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+	}
+	return v
+}
+
+// isCredentialPath 是否为认证或修改密码接口
+func isCredentialPath(path string) bool {
+	return strings.Contains(path, "/auth/") || strings.Contains(path, "/users/password")
+}
+
 // FileUploadConfig 文件上传配置
 type FileUploadConfig struct {
 	MaxSize      int64    // 最大文件大小（字节）
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		path, body, want string
+	}{
+		{"/api/v1/auth/login", `{"account":"alice","password":"P@ssw0rd"}`, `{"account":"alice","password":"***"}`},
+		{"/api/v1/auth/password/reset", `{"token":"abc","newPassword":"secret123"}`, `{"newPassword":"***","token":"***"}`},
+		{"/api/v1/auth/login", `{"code":"0000","data":{"user":{"id":1},"token":{"accessToken":"a","refreshToken":"r"}}}`, `{"code":"0000","data":{"token":"***","user":{"id":1}}}`},
+		{"/api/v1/auth/refresh", `{"RefreshToken":"r"}`, `{"RefreshToken":"***"}`},
+		{"/api/v1/jobs/", `[{"name":"Go","salary":20000}]`, `[{"name":"Go","salary":20000}]`},
+		// 认证接口的非 JSON 内容整体省略，其他接口保持原样
+		{"/api/v1/auth/login", "account=alice&password=secret", "***"},
+		{"/api/v1/jobs/1", "plain text", "plain text"},
+		{"/api/v1/jobs/1", "", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, redactBody(tt.path, tt.body), "%s %s", tt.path, tt.body)
+	}
+}
+
+func TestLoggerMiddlewareRedactsCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.InfoLevel)
+	prev := logger.L
+	logger.L = zap.New(core)
+	defer func() { logger.L = prev }()
+
+	r := gin.New()
+	r.Use(LoggerMiddleware())
+	r.POST("/api/v1/auth/login", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"accessToken": "issued-access-token", "refreshToken": "issued-refresh-token"})
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"account":"alice","password":"P@ssw0rd"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.FilterMessage("HTTP请求").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	for _, secret := range []string{"P@ssw0rd", "issued-access-token", "issued-refresh-token"} {
+		assert.NotContains(t, fields["request"], secret)
+		assert.NotContains(t, fields["response"], secret)
+	}
+	assert.Contains(t, fields["request"], "alice")
+}
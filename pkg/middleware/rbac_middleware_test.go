@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"org.thinkinai.com/recruit-center/api/dto/response"
+	"org.thinkinai.com/recruit-center/pkg/errors"
+	"org.thinkinai.com/recruit-center/pkg/utils"
+)
+
+// fakeCompanyChecker 以 公司ID -> 成员用户ID 表示的成员关系
+type fakeCompanyChecker map[uint][]uint
+
+func (f fakeCompanyChecker) IsMember(companyID, userID uint) (bool, error) {
+	for _, id := range f[companyID] {
+		if id == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestRequireCompanyAccess(t *testing.T) {
+	r := gin.New()
+	r.GET("/companies/:companyId", AuthRequired(), RequireCompanyAccess("companyId"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, response.NewSuccess(nil))
+	})
+
+	// 用户7的令牌所属公司为3，同时是公司5的成员
+	token, err := utils.GenerateCompanyToken(7, 3, "hr", "recruiter")
+	assert.NoError(t, err)
+	adminToken, err := utils.GenerateToken(1, "admin", "admin")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		checker  CompanyAccessChecker
+		path     string
+		token    string
+		wantCode errors.ErrorCode
+	}{
+		{name: "token company without checker", path: "/companies/3", token: token, wantCode: errors.Success},
+		{name: "other company without checker", path: "/companies/5", token: token, wantCode: errors.Forbidden},
+		{name: "member of another company", checker: fakeCompanyChecker{5: {7}}, path: "/companies/5", token: token, wantCode: errors.Success},
+		{name: "removed from token company", checker: fakeCompanyChecker{5: {7}}, path: "/companies/3", token: token, wantCode: errors.Forbidden},
+		{name: "admin bypass", checker: fakeCompanyChecker{}, path: "/companies/3", token: adminToken, wantCode: errors.Success},
+		{name: "invalid company id", path: "/companies/abc", token: token, wantCode: errors.BadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetCompanyAccessChecker(tt.checker)
+			defer SetCompanyAccessChecker(nil)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var result authTestResult
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantCode, result.Code)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package pagination 列表分页，支持页码分页和基于签名游标的键集分页
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"org.thinkinai.com/recruit-center/pkg/errors"
+)
+
+const (
+	DefaultSize = 10  // 默认每页数量
+	MaxSize     = 100 // 每页最大数量
+)
+
+var (
+	// ErrInvalidCursor 游标格式错误、签名不匹配或不属于当前列表
+	ErrInvalidCursor = errors.New(errors.InvalidParams).WithMessage("无效的分页游标")
+	// ErrCursorUnsupported 当前排序方式不支持游标分页
+	ErrCursorUnsupported = errors.New(errors.InvalidParams).WithMessage("当前排序方式不支持游标分页，请使用页码分页")
+)
+
+var (
+	secretMu sync.RWMutex
+	secret   = randomSecret()
+)
+
+// randomSecret 未配置密钥时使用的随机密钥，游标只在本进程内有效
+func randomSecret() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// SetSecret 设置游标签名密钥，多实例部署时各实例须使用相同的密钥，为空时保持不变
+func SetSecret(key string) {
+	if key == "" {
+		return
+	}
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secret = []byte(key)
+}
+
+// Cursor 键集分页位置，记录上一页最后一条记录的排序键
+type Cursor struct {
+	Scope string    `json:"s"`           // 游标所属的列表及排序方式，不同列表的游标不能混用
+	Time  time.Time `json:"t"`           // 时间排序键
+	Value int64     `json:"v,omitempty"` // 时间之前的数值排序键，如评分
+	ID    uint      `json:"i"`           // 记录ID，排序键相同时按ID排序
+}
+
+// Encode 将分页位置编码为签名的不透明游标
+func Encode(c Cursor) string {
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(sign(encoded))
+}
+
+// Decode 校验游标签名并解析分页位置，游标不属于 scope 时返回 ErrInvalidCursor
+func Decode(token, scope string) (*Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, sign(encoded)) {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.Scope != scope {
+		return nil, ErrInvalidCursor
+	}
+	c.Time = c.Time.Local()
+	return &c, nil
+}
+
+// sign 计算游标内容的签名
+func sign(encoded string) []byte {
+	secretMu.RLock()
+	defer secretMu.RUnlock()
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// Page 分页参数，指定游标时从游标之后开始查询并忽略页码
+type Page struct {
+	Number    int    // 页码，从1开始
+	Size      int    // 每页数量
+	Cursor    string // 上一页返回的游标
+	WithTotal bool   // 是否统计总数
+}
+
+// NewPage 创建分页参数，页码和每页数量超出范围时使用默认值
+// withTotal 为空时只在第一次请求(未指定游标)时统计总数，翻页时不再重复统计
+func NewPage(number, size int, cursor string, withTotal *bool) Page {
+	if number < 1 {
+		number = 1
+	}
+	if size < 1 {
+		size = DefaultSize
+	}
+	if size > MaxSize {
+		size = MaxSize
+	}
+	page := Page{Number: number, Size: size, Cursor: cursor, WithTotal: cursor == ""}
+	if withTotal != nil {
+		page.WithTotal = *withTotal
+	}
+	return page
+}
+
+// Offset 页码分页的偏移量，游标分页时为0
+func (p Page) Offset() int {
+	if p.Cursor != "" {
+		return 0
+	}
+	return (p.Number - 1) * p.Size
+}
+
+// Result 分页查询结果
+type Result[T any] struct {
+	Records    []T
+	Total      *int64 // 总数，未统计时为空
+	NextCursor string // 下一页的游标，没有下一页时为空
+}
@@ -0,0 +1,63 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncodeDecode(t *testing.T) {
+	SetSecret("test-secret")
+	want := Cursor{Scope: "jobs", Time: time.Date(2024, 5, 1, 8, 30, 0, 123456000, time.UTC), Value: 4, ID: 42}
+	token := Encode(want)
+
+	got, err := Decode(token, "jobs")
+	if assert.NoError(t, err) {
+		assert.True(t, got.Time.Equal(want.Time))
+		assert.Equal(t, want.Value, got.Value)
+		assert.Equal(t, want.ID, got.ID)
+	}
+
+	// 不属于当前列表的游标
+	_, err = Decode(token, "applies")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// 篡改内容后签名不匹配
+	payload, signature, _ := strings.Cut(token, ".")
+	tampered := Encode(Cursor{Scope: "jobs", Time: want.Time, ID: 1})
+	forged, _, _ := strings.Cut(tampered, ".")
+	_, err = Decode(forged+"."+signature, "jobs")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// 更换密钥后旧游标失效
+	SetSecret("another-secret")
+	_, err = Decode(payload+"."+signature, "jobs")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	for _, token := range []string{"", "not-a-cursor", "a.b", "."} {
+		_, err := Decode(token, "jobs")
+		assert.ErrorIs(t, err, ErrInvalidCursor, token)
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	page := NewPage(0, 0, "", nil)
+	assert.Equal(t, 1, page.Number)
+	assert.Equal(t, DefaultSize, page.Size)
+	assert.True(t, page.WithTotal)
+
+	page = NewPage(3, 500, "", nil)
+	assert.Equal(t, MaxSize, page.Size)
+	assert.Equal(t, 2*MaxSize, page.Offset())
+
+	// 按游标翻页时默认不统计总数，忽略页码
+	page = NewPage(3, 20, "cursor", nil)
+	assert.False(t, page.WithTotal)
+	assert.Zero(t, page.Offset())
+
+	withTotal := true
+	page = NewPage(1, 20, "cursor", &withTotal)
+	assert.True(t, page.WithTotal)
+}
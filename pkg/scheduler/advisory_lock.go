@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// releaseTimeout 放弃领导权时释放锁的超时时间
+const releaseTimeout = 5 * time.Second
+
+// AdvisoryLock 基于 Postgres 会话级咨询锁的领导者选举
+// 锁由一个独占的数据库连接持有，实例退出或连接断开时锁随会话自动释放，其他实例可在下一次竞选时当选
+type AdvisoryLock struct {
+	db   *sql.DB
+	key  int64
+	conn *sql.Conn
+}
+
+// NewAdvisoryLock 创建咨询锁，同一组竞选的实例须使用相同的 key
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+// Acquire 从连接池取出一个连接尝试加锁，成功时保留该连接直到放弃领导权
+func (l *AdvisoryLock) Acquire(ctx context.Context) (bool, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&locked); err != nil || !locked {
+		conn.Close()
+		return false, err
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Alive 确认持有锁的连接仍然可用，会话未断开则锁仍然有效
+func (l *AdvisoryLock) Alive(ctx context.Context) error {
+	var one int
+	return l.conn.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
+
+// Release 释放锁并将连接归还连接池
+func (l *AdvisoryLock) Release() {
+	if l.conn == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		// 无法确认锁已释放时丢弃该连接，由会话结束释放锁，避免持有锁的连接回到连接池
+		logger.L.Warn("释放定时任务锁失败", zap.Error(err))
+		_ = l.conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	l.conn.Close()
+	l.conn = nil
+}
@@ -0,0 +1,167 @@
+// Package scheduler 进程内定时任务调度，多实例部署时通过领导者选举保证同一时刻只有一个实例执行任务
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+// DefaultElectionInterval 默认的选举间隔，未当选的实例按该间隔重试，当选的实例按该间隔确认仍持有领导权
+const DefaultElectionInterval = 30 * time.Second
+
+// Task 定时任务
+type Task struct {
+	Name     string                                         // 任务名称，用于日志
+	Interval time.Duration                                  // 执行间隔，当选后立即执行一次
+	Run      func(ctx context.Context, now time.Time) error // 执行任务，ctx 在停止调度或失去领导权时取消
+}
+
+// Leader 领导者选举，只有当选的实例执行任务
+type Leader interface {
+	// Acquire 尝试当选，已被其他实例持有时返回 false
+	Acquire(ctx context.Context) (bool, error)
+	// Alive 确认仍持有领导权，返回错误表示已失去领导权
+	Alive(ctx context.Context) error
+	// Release 放弃领导权
+	Release()
+}
+
+// Scheduler 定时任务调度器
+type Scheduler struct {
+	leader   Leader
+	interval time.Duration
+	tasks    []Task
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+	leading bool
+}
+
+// New 创建调度器，electionInterval 为0时使用默认选举间隔
+func New(leader Leader, electionInterval time.Duration) *Scheduler {
+	if electionInterval <= 0 {
+		electionInterval = DefaultElectionInterval
+	}
+	return &Scheduler{leader: leader, interval: electionInterval}
+}
+
+// Register 注册任务，须在 Start 之前调用
+func (s *Scheduler) Register(task Task) {
+	s.tasks = append(s.tasks, task)
+}
+
+// IsLeader 当前实例是否为领导者
+func (s *Scheduler) IsLeader() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leading
+}
+
+// Start 在后台开始调度，重复调用无效
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx)
+}
+
+// Stop 停止调度，等待执行中的任务结束并放弃领导权，ctx 到期时不再等待
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 循环竞选，当选后执行任务直到停止调度或失去领导权
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+	for {
+		ok, err := s.leader.Acquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.L.Warn("定时任务竞选失败", zap.Error(err))
+		}
+		if ok {
+			s.lead(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.interval):
+		}
+	}
+}
+
+// lead 作为领导者执行所有任务，定期确认领导权，返回前等待任务结束并放弃领导权
+func (s *Scheduler) lead(ctx context.Context) {
+	logger.L.Info("当选定时任务执行实例", zap.Int("tasks", len(s.tasks)))
+	s.setLeading(true)
+	leadCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func(task Task) {
+			defer wg.Done()
+			runTask(leadCtx, task)
+		}(task)
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for leadCtx.Err() == nil {
+		select {
+		case <-leadCtx.Done():
+		case <-ticker.C:
+			if err := s.leader.Alive(leadCtx); err != nil && leadCtx.Err() == nil {
+				logger.L.Warn("失去定时任务执行权", zap.Error(err))
+				cancel()
+			}
+		}
+	}
+	cancel()
+	wg.Wait()
+	s.setLeading(false)
+	s.leader.Release()
+}
+
+// setLeading 记录是否为领导者
+func (s *Scheduler) setLeading(leading bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leading = leading
+}
+
+// runTask 立即执行一次任务，此后按间隔执行直到 ctx 取消，任务出错只记录日志
+func runTask(ctx context.Context, task Task) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+	for {
+		if err := task.Run(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logger.L.Error("定时任务执行失败", zap.String("task", task.Name), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
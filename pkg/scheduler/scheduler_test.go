@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"org.thinkinai.com/recruit-center/pkg/logger"
+)
+
+func init() {
+	logger.L = zap.NewNop()
+}
+
+// fakeLeader 测试用的领导者选举，acquire 决定能否当选，alive 返回错误时失去领导权
+type fakeLeader struct {
+	mu       sync.Mutex
+	acquire  bool
+	alive    error
+	acquired int
+	released int
+}
+
+func (l *fakeLeader) Acquire(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.acquire {
+		l.acquired++
+	}
+	return l.acquire, nil
+}
+
+func (l *fakeLeader) Alive(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.alive
+}
+
+func (l *fakeLeader) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.released++
+}
+
+func (l *fakeLeader) set(acquire bool, alive error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.acquire, l.alive = acquire, alive
+}
+
+func (l *fakeLeader) counts() (int, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.acquired, l.released
+}
+
+func TestSchedulerRunsTasksWhenLeading(t *testing.T) {
+	leader := &fakeLeader{acquire: true}
+	s := New(leader, 10*time.Millisecond)
+	var runs atomic.Int32
+	s.Register(Task{Name: "count", Interval: 5 * time.Millisecond, Run: func(context.Context, time.Time) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Start()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	assert.True(t, s.IsLeader())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.Stop(ctx))
+	assert.False(t, s.IsLeader())
+	_, released := leader.counts()
+	assert.Equal(t, 1, released)
+
+	// 停止后不再执行
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestSchedulerSkipsTasksWhenNotLeading(t *testing.T) {
+	leader := &fakeLeader{}
+	s := New(leader, 5*time.Millisecond)
+	var runs atomic.Int32
+	s.Register(Task{Name: "count", Interval: time.Millisecond, Run: func(context.Context, time.Time) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Start()
+	time.Sleep(30 * time.Millisecond)
+	assert.False(t, s.IsLeader())
+	assert.Zero(t, runs.Load())
+
+	// 其他实例放弃领导权后当选
+	leader.set(true, nil)
+	assert.Eventually(t, func() bool { return runs.Load() > 0 }, time.Second, time.Millisecond)
+	assert.NoError(t, s.Stop(context.Background()))
+}
+
+func TestSchedulerStepsDownWhenLeadershipLost(t *testing.T) {
+	leader := &fakeLeader{acquire: true}
+	s := New(leader, 5*time.Millisecond)
+	var runs atomic.Int32
+	s.Register(Task{Name: "count", Interval: time.Millisecond, Run: func(context.Context, time.Time) error {
+		runs.Add(1)
+		return errors.New("task failed")
+	}})
+	s.Start()
+	assert.Eventually(t, s.IsLeader, time.Second, time.Millisecond)
+
+	// 连接断开后放弃领导权，之后重新竞选
+	leader.set(false, errors.New("connection lost"))
+	assert.Eventually(t, func() bool {
+		_, released := leader.counts()
+		return released == 1 && !s.IsLeader()
+	}, time.Second, time.Millisecond)
+
+	leader.set(true, nil)
+	assert.Eventually(t, func() bool {
+		acquired, _ := leader.counts()
+		return acquired == 2
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, s.Stop(context.Background()))
+	assert.Positive(t, runs.Load())
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"bytes"
+
+	"github.com/unidoc/unioffice/common/license"
+	"github.com/unidoc/unioffice/document"
+	"github.com/unidoc/unioffice/measurement"
+	"github.com/unidoc/unioffice/schema/soo/wml"
+)
+
+// SetOfficeLicense 设置 unioffice 的计量授权密钥，未设置时无法生成 Word 文档
+func SetOfficeLicense(apiKey string) error {
+	return license.SetMeteredKey(apiKey)
+}
+
+// BuildDocx 生成 Word 文档，标题居中加粗，每个段落一行
+// 最后 signatureLines 个段落作为落款右对齐
+func BuildDocx(title string, paragraphs []string, signatureLines int) ([]byte, error) {
+	doc := document.New()
+	defer doc.Close()
+
+	heading := doc.AddParagraph()
+	heading.SetAlignment(wml.ST_JcCenter)
+	heading.Properties().Spacing().SetAfter(12 * measurement.Point)
+	run := heading.AddRun()
+	run.Properties().SetBold(true)
+	run.Properties().SetSize(18 * measurement.Point)
+	run.AddText(title)
+
+	for i, text := range paragraphs {
+		para := doc.AddParagraph()
+		para.Properties().Spacing().SetAfter(6 * measurement.Point)
+		if i >= len(paragraphs)-signatureLines {
+			para.SetAlignment(wml.ST_JcRight)
+		}
+		r := para.AddRun()
+		r.Properties().SetSize(11 * measurement.Point)
+		r.AddText(text)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Save(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,33 @@
+package utils
+
+import "math"
+
+// EarthRadiusKm 地球平均半径(公里)
+const EarthRadiusKm = 6371.0
+
+// kmPerDegree 每纬度对应的距离(公里)
+const kmPerDegree = math.Pi * EarthRadiusKm / 180
+
+// DistanceKm 按球面距离公式计算两点之间的距离(公里)
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLng := (lng2 - lng1) * math.Pi / 180
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox 以某点为中心、半径 km 的外接经纬度范围，用于在精确计算距离前缩小范围
+func BoundingBox(lat, lng, km float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := km / kmPerDegree
+	minLat, maxLat = math.Max(lat-dLat, -90), math.Min(lat+dLat, 90)
+	cos := math.Cos(lat * math.Pi / 180)
+	if maxLat >= 90 || minLat <= -90 || cos <= 0 {
+		return minLat, maxLat, -180, 180
+	}
+	dLng := km / (kmPerDegree * cos)
+	if dLng >= 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, lng - dLng, lng + dLng
+}
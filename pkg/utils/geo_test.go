@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceKm(t *testing.T) {
+	// 北京天安门到上海人民广场约1067公里
+	assert.InDelta(t, 1067, DistanceKm(39.9087, 116.3975, 31.2304, 121.4737), 5)
+	assert.Zero(t, DistanceKm(39.9, 116.4, 39.9, 116.4))
+}
+
+func TestBoundingBox(t *testing.T) {
+	lat, lng := 39.9087, 116.3975
+	minLat, maxLat, minLng, maxLng := BoundingBox(lat, lng, 10)
+
+	// 范围边界与中心的距离约等于半径
+	assert.InDelta(t, 10, DistanceKm(lat, lng, maxLat, lng), 0.01)
+	assert.InDelta(t, 10, DistanceKm(lat, lng, minLat, lng), 0.01)
+	assert.InDelta(t, 10, DistanceKm(lat, lng, lat, maxLng), 0.01)
+	assert.InDelta(t, 10, DistanceKm(lat, lng, lat, minLng), 0.01)
+
+	// 靠近极点时经度不限
+	_, _, minLng, maxLng = BoundingBox(89.99, 0, 10)
+	assert.Equal(t, -180.0, minLng)
+	assert.Equal(t, 180.0, maxLng)
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar 方法，见 RFC 5546
+const (
+	CalendarMethodRequest = "REQUEST" // 邀请或更新
+	CalendarMethodCancel  = "CANCEL"  // 取消
+)
+
+// icsProdID 日历产品标识
+const icsProdID = "-//ThinkInAI//Recruit Center//CN"
+
+// icsLineLimit 内容行最大字节数(不含换行)，见 RFC 5545 3.1
+const icsLineLimit = 75
+
+// CalendarAttendee 日历参与人
+type CalendarAttendee struct {
+	Name  string
+	Email string
+}
+
+// CalendarEvent 日历事件
+type CalendarEvent struct {
+	UID         string             // 事件唯一标识，同一事件的更新和取消必须保持不变
+	Sequence    int                // 修订序号，每次改期或取消递增
+	Method      string             // REQUEST 或 CANCEL
+	Start       time.Time          // 开始时间
+	End         time.Time          // 结束时间
+	Summary     string             // 标题
+	Description string             // 描述
+	Location    string             // 地点
+	URL         string             // 会议链接
+	Organizer   CalendarAttendee   // 组织者
+	Attendees   []CalendarAttendee // 参与人
+	Stamp       time.Time          // 生成时间，为空时使用当前时间
+}
+
+// BuildICS 按 RFC 5545 生成包含单个事件的 iCalendar 文件内容
+func BuildICS(event CalendarEvent) []byte {
+	method := event.Method
+	if method == "" {
+		method = CalendarMethodRequest
+	}
+	stamp := event.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	w := &icsWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icsProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:" + method)
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + event.UID)
+	w.line(fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+	w.line("DTSTAMP:" + icsTime(stamp))
+	w.line("DTSTART:" + icsTime(event.Start))
+	w.line("DTEND:" + icsTime(event.End))
+	w.line("SUMMARY:" + icsText(event.Summary))
+	if event.Description != "" {
+		w.line("DESCRIPTION:" + icsText(event.Description))
+	}
+	if event.Location != "" {
+		w.line("LOCATION:" + icsText(event.Location))
+	}
+	if event.URL != "" {
+		w.line("URL:" + event.URL)
+	}
+	if event.Organizer.Email != "" {
+		w.line("ORGANIZER" + icsCommonName(event.Organizer.Name) + ":mailto:" + event.Organizer.Email)
+	}
+	for _, a := range event.Attendees {
+		if a.Email == "" {
+			continue
+		}
+		w.line("ATTENDEE" + icsCommonName(a.Name) + ";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:" + a.Email)
+	}
+	if method == CalendarMethodCancel {
+		w.line("STATUS:CANCELLED")
+	} else {
+		w.line("STATUS:CONFIRMED")
+	}
+	w.line("END:VEVENT")
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// icsWriter 按 RFC 5545 折行并以 CRLF 结尾写入内容行
+type icsWriter struct {
+	buf bytes.Buffer
+}
+
+// line 写入一行，超过75字节时折行，续行以空格开头，且不拆分多字节字符
+func (w *icsWriter) line(s string) {
+	limit := icsLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// 续行的前导空格占用一个字节
+		limit = icsLineLimit - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
+
+// icsTime 格式化为 UTC 时间
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsText 转义 TEXT 类型的值，见 RFC 5545 3.3.11
+func icsText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// icsCommonName 生成 CN 参数，参数值不允许包含双引号，统一加引号以容纳冒号、分号等字符
+func icsCommonName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || r < ' ' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
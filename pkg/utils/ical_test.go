@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildICS(t *testing.T) {
+	start := time.Date(2026, 10, 20, 10, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	ics := string(BuildICS(CalendarEvent{
+		UID:         "interview-abc@recruit-center",
+		Sequence:    2,
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Summary:     "第1轮面试: Go开发工程师",
+		Description: "请准时参加;携带简历,谢谢\n第二行",
+		Location:    "北京市海淀区",
+		Organizer:   CalendarAttendee{Name: "某公司", Email: "hr@example.com"},
+		Attendees:   []CalendarAttendee{{Name: `张"三`, Email: "zhangsan@example.com"}, {Name: "无邮箱"}},
+		Stamp:       time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+	}))
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "METHOD:REQUEST\r\n")
+	assert.Contains(t, ics, "SEQUENCE:2\r\n")
+	assert.Contains(t, ics, "DTSTART:20261020T020000Z\r\n")
+	assert.Contains(t, ics, "DTEND:20261020T030000Z\r\n")
+	assert.Contains(t, ics, "DTSTAMP:20261017T000000Z\r\n")
+	assert.Contains(t, ics, `DESCRIPTION:请准时参加\;携带简历\,谢谢\n第二行`)
+	assert.Contains(t, ics, "STATUS:CONFIRMED\r\n")
+	assert.Contains(t, ics, `ORGANIZER;CN="某公司":mailto:hr@example.com`)
+	assert.NotContains(t, ics, "无邮箱")
+
+	// 展开折行后参与人参数中不应包含双引号
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, `ATTENDEE;CN="张三";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:zhangsan@example.com`)
+
+	// 每行不超过75字节，且只使用 CRLF 换行
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.NotContains(t, line, "\n")
+	}
+}
+
+func TestBuildICSCancel(t *testing.T) {
+	start := time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC)
+	ics := string(BuildICS(CalendarEvent{
+		UID:      "interview-abc@recruit-center",
+		Sequence: 3,
+		Method:   CalendarMethodCancel,
+		Start:    start,
+		End:      start.Add(time.Hour),
+		Summary:  "面试",
+	}))
+
+	assert.Contains(t, ics, "METHOD:CANCEL\r\n")
+	assert.Contains(t, ics, "STATUS:CANCELLED\r\n")
+	assert.Contains(t, ics, "SEQUENCE:3\r\n")
+}
+
+func TestICSLineFolding(t *testing.T) {
+	w := &icsWriter{}
+	w.line("SUMMARY:" + strings.Repeat("面试", 40))
+	out := w.buf.String()
+
+	lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	assert.Greater(t, len(lines), 1)
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), 75)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+	// 展开后与原文一致，多字节字符未被拆分
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("面试", 40), strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""))
+}
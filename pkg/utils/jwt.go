@@ -1,57 +1,201 @@
 package utils
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	"org.thinkinai.com/recruit-center/pkg/config"
 )
 
-var jwtSecret []byte
+// 令牌类型
+const (
+	TokenTypeAccess  = "access"  // 访问令牌
+	TokenTypeRefresh = "refresh" // 刷新令牌
+)
+
+const (
+	defaultAccessTokenTTL  = 2 * time.Hour
+	defaultRefreshTokenTTL = 7 * 24 * time.Hour
+	tokenIssuer            = "recruit-center"
+)
+
+var (
+	jwtSecret       []byte
+	accessTokenTTL  = defaultAccessTokenTTL
+	refreshTokenTTL = defaultRefreshTokenTTL
+	tokenDenylist   TokenDenylist
+)
+
+var (
+	// ErrTokenExpired token已过期
+	ErrTokenExpired = errors.New("token已过期")
+	// ErrTokenMalformed token格式错误
+	ErrTokenMalformed = errors.New("token格式错误")
+	// ErrTokenInvalid token签名或载荷无效
+	ErrTokenInvalid = errors.New("token无效")
+	// ErrTokenRevoked token已被吊销
+	ErrTokenRevoked = errors.New("token已吊销")
+)
+
+// TokenDenylist 已吊销令牌(jti)查询接口，由持久层实现
+type TokenDenylist interface {
+	IsRevoked(jti string) (bool, error)
+}
 
 func InitJwt(jwtConfig *config.JWTConfig) {
 	// 初始化JWT密钥
 	jwtSecret = []byte(jwtConfig.Secret)
+	// 初始化令牌有效期，未配置时使用默认值
+	accessTokenTTL = defaultAccessTokenTTL
+	if jwtConfig.AccessTokenTTL > 0 {
+		accessTokenTTL = jwtConfig.AccessTokenTTL
+	}
+	refreshTokenTTL = defaultRefreshTokenTTL
+	if jwtConfig.RefreshTokenTTL > 0 {
+		refreshTokenTTL = jwtConfig.RefreshTokenTTL
+	}
+}
+
+// SetTokenDenylist 设置令牌黑名单，ParseToken 会拒绝黑名单中的令牌
+func SetTokenDenylist(denylist TokenDenylist) {
+	tokenDenylist = denylist
 }
 
 // Claims JWT载荷
 type Claims struct {
-	UserID   uint   `json:"userId"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	UserID    uint   `json:"userId"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	CompanyID uint   `json:"companyId,omitempty"` // 企业用户/招聘者所属公司
+	TokenType string `json:"tokenType,omitempty"` // 令牌类型 access/refresh，为空视为访问令牌
 	jwt.StandardClaims
 }
 
+// IsRefresh 是否为刷新令牌
+func (c *Claims) IsRefresh() bool {
+	return c.TokenType == TokenTypeRefresh
+}
+
+// ExpiresTime 令牌过期时间
+func (c *Claims) ExpiresTime() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// TokenPair 访问令牌与刷新令牌
+type TokenPair struct {
+	AccessToken      string
+	AccessJTI        string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshJTI       string
+	RefreshExpiresAt time.Time
+}
+
 // GenerateToken 生成JWT token
 func GenerateToken(userID uint, username, role string) (string, error) {
+	return GenerateCompanyToken(userID, 0, username, role)
+}
+
+// GenerateCompanyToken 生成携带公司ID的JWT访问令牌
+func GenerateCompanyToken(userID, companyID uint, username, role string) (string, error) {
+	token, _, _, err := signToken(userID, companyID, username, role, TokenTypeAccess, accessTokenTTL)
+	return token, err
+}
+
+// GenerateTokenPair 生成访问令牌与刷新令牌
+func GenerateTokenPair(userID, companyID uint, username, role string) (*TokenPair, error) {
+	pair := &TokenPair{}
+	var err error
+
+	pair.AccessToken, pair.AccessJTI, pair.AccessExpiresAt, err =
+		signToken(userID, companyID, username, role, TokenTypeAccess, accessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	pair.RefreshToken, pair.RefreshJTI, pair.RefreshExpiresAt, err =
+		signToken(userID, companyID, username, role, TokenTypeRefresh, refreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// signToken 签发令牌，返回令牌字符串、jti与过期时间
+func signToken(userID, companyID uint, username, role, tokenType string, ttl time.Duration) (string, string, time.Time, error) {
+	jti, err := GenerateNanoID()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
 	claims := Claims{
-		UserID:   userID,
-		Username: username,
-		Role:     role,
+		UserID:    userID,
+		Username:  username,
+		Role:      role,
+		CompanyID: companyID,
+		TokenType: tokenType,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(24 * time.Hour).Unix(), // 24小时过期
-			IssuedAt:  time.Now().Unix(),
-			Issuer:    "recruit-center",
+			Id:        jti,
+			ExpiresAt: expiresAt.Unix(),
+			IssuedAt:  now.Unix(),
+			Issuer:    tokenIssuer,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return token, jti, expiresAt, nil
 }
 
-// ParseToken 解析JWT token
+// ParseToken 解析JWT token，并校验令牌是否已被吊销
 func ParseToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		// 只接受HMAC签名，防止算法替换攻击
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrTokenInvalid
+		}
 		return jwtSecret, nil
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, classifyTokenError(err)
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, ErrTokenInvalid
 	}
 
-	return nil, jwt.ErrSignatureInvalid
+	if tokenDenylist != nil && claims.Id != "" {
+		revoked, err := tokenDenylist.IsRevoked(claims.Id)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+// classifyTokenError 将jwt校验错误归类为过期、格式错误或无效
+func classifyTokenError(err error) error {
+	var ve *jwt.ValidationError
+	if !errors.As(err, &ve) {
+		return ErrTokenInvalid
+	}
+	switch {
+	case ve.Errors&jwt.ValidationErrorMalformed != 0:
+		return ErrTokenMalformed
+	case ve.Errors&jwt.ValidationErrorExpired != 0:
+		return ErrTokenExpired
+	default:
+		return ErrTokenInvalid
+	}
 }
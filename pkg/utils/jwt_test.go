@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -80,3 +81,88 @@ func TestParseInvalidToken(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateCompanyToken(t *testing.T) {
+	token, err := GenerateCompanyToken(3, 42, "recruiter", "recruiter")
+	assert.NoError(t, err)
+
+	claims, err := ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), claims.UserID)
+	assert.Equal(t, uint(42), claims.CompanyID)
+	assert.Equal(t, "recruiter", claims.Role)
+}
+
+func TestParseTokenErrors(t *testing.T) {
+	expired := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID: 1,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		},
+	})
+	expiredToken, err := expired.SignedString(jwtSecret)
+	assert.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: 1})
+	forgedToken, err := forged.SignedString([]byte("another-secret"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "expired token", token: expiredToken, wantErr: ErrTokenExpired},
+		{name: "malformed token", token: "not-a-jwt", wantErr: ErrTokenMalformed},
+		{name: "wrong signature", token: forgedToken, wantErr: ErrTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ParseToken(tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestGenerateTokenPair(t *testing.T) {
+	pair, err := GenerateTokenPair(5, 8, "hr", "recruiter")
+	assert.NoError(t, err)
+	assert.NotEqual(t, pair.AccessJTI, pair.RefreshJTI)
+	assert.True(t, pair.RefreshExpiresAt.After(pair.AccessExpiresAt))
+
+	access, err := ParseToken(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.False(t, access.IsRefresh())
+	assert.Equal(t, pair.AccessJTI, access.Id)
+	assert.Equal(t, uint(8), access.CompanyID)
+
+	refresh, err := ParseToken(pair.RefreshToken)
+	assert.NoError(t, err)
+	assert.True(t, refresh.IsRefresh())
+	assert.Equal(t, pair.RefreshJTI, refresh.Id)
+	assert.Equal(t, pair.RefreshExpiresAt.Unix(), refresh.ExpiresTime().Unix())
+}
+
+// fakeDenylist 内存令牌黑名单
+type fakeDenylist map[string]bool
+
+func (d fakeDenylist) IsRevoked(jti string) (bool, error) {
+	return d[jti], nil
+}
+
+func TestParseRevokedToken(t *testing.T) {
+	pair, err := GenerateTokenPair(1, 0, "seeker", "job_seeker")
+	assert.NoError(t, err)
+
+	SetTokenDenylist(fakeDenylist{pair.AccessJTI: true})
+	defer SetTokenDenylist(nil)
+
+	claims, err := ParseToken(pair.AccessToken)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	assert.Nil(t, claims)
+
+	_, err = ParseToken(pair.RefreshToken)
+	assert.NoError(t, err)
+}
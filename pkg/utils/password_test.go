@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("P@ssw0rd")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "P@ssw0rd", hash)
+
+	assert.True(t, CheckPassword(hash, "P@ssw0rd"))
+	assert.False(t, CheckPassword(hash, "wrong"))
+	assert.False(t, CheckPassword("not-a-hash", "P@ssw0rd"))
+}
+
+func TestHashToken(t *testing.T) {
+	assert.Equal(t, HashToken("abc"), HashToken("abc"))
+	assert.NotEqual(t, HashToken("abc"), HashToken("abd"))
+	assert.Len(t, HashToken("abc"), 64)
+}
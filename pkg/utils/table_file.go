@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/unidoc/unioffice/spreadsheet"
+)
+
+// 表格文件格式
+const (
+	TableCSV  = "csv"
+	TableXLSX = "xlsx"
+)
+
+// utf8BOM Excel 打开 CSV 时依据 BOM 识别 UTF-8 编码
+const utf8BOM = "\uFEFF"
+
+// TableFormatOf 根据文件名后缀识别表格格式
+func TableFormatOf(filename string) (string, bool) {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	return format, IsTableFormat(format)
+}
+
+// IsTableFormat 是否支持的表格格式
+func IsTableFormat(format string) bool {
+	return format == TableCSV || format == TableXLSX
+}
+
+// TableContentType 表格格式对应的 MIME 类型
+func TableContentType(format string) string {
+	if format == TableXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ReadTable 读取 CSV 或 XLSX 表格的全部行，XLSX 只读取第一个工作表
+// 读取 XLSX 需要先通过 SetOfficeLicense 设置授权
+func ReadTable(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case TableCSV:
+		return readCSV(r)
+	case TableXLSX:
+		return readXLSX(r)
+	default:
+		return nil, fmt.Errorf("不支持的表格格式: %s", format)
+	}
+}
+
+// WriteTable 将表格写为 CSV 或 XLSX，CSV 带 BOM 以便 Excel 正确识别中文
+// 生成 XLSX 需要先通过 SetOfficeLicense 设置授权
+func WriteTable(w io.Writer, format string, rows [][]string) error {
+	switch format {
+	case TableCSV:
+		return writeCSV(w, rows)
+	case TableXLSX:
+		return writeXLSX(w, rows)
+	default:
+		return fmt.Errorf("不支持的表格格式: %s", format)
+	}
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	reader.FieldsPerRecord = -1 // 允许各行列数不同，缺少的列按空值处理
+	return reader.ReadAll()
+}
+
+func writeCSV(w io.Writer, rows [][]string) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	wb, err := spreadsheet.Read(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	defer wb.Close()
+
+	sheets := wb.Sheets()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	sheet := sheets[0]
+	maxCol := sheet.MaxColumnIdx()
+	var rows [][]string
+	for _, row := range sheet.Rows() {
+		cells := row.CellsWithEmpty(maxCol)
+		values := make([]string, len(cells))
+		for i, cell := range cells {
+			values[i] = cell.GetFormattedValue()
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+func writeXLSX(w io.Writer, rows [][]string) error {
+	wb := spreadsheet.New()
+	defer wb.Close()
+
+	sheet := wb.AddSheet()
+	for _, values := range rows {
+		row := sheet.AddRow()
+		for _, value := range values {
+			row.AddCell().SetString(value)
+		}
+	}
+	return wb.Save(w)
+}
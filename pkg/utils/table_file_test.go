@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVTableRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"name", "jobDescribe", "tags"},
+		{"Go工程师", "负责后端开发，\n要求熟悉\"微服务\"", "急招,双休"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteTable(&buf, TableCSV, rows))
+	assert.True(t, strings.HasPrefix(buf.String(), utf8BOM))
+
+	read, err := ReadTable(&buf, TableCSV)
+	require.NoError(t, err)
+	assert.Equal(t, rows, read)
+
+	// 不带 BOM 且各行列数不同的文件也能读取
+	read, err = ReadTable(strings.NewReader("name,jobSalary\nGo工程师\n"), TableCSV)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"name", "jobSalary"}, {"Go工程师"}}, read)
+
+	_, err = ReadTable(strings.NewReader(""), "xls")
+	assert.Error(t, err)
+}
+
+func TestTableFormatOf(t *testing.T) {
+	format, ok := TableFormatOf("jobs.XLSX")
+	assert.True(t, ok)
+	assert.Equal(t, TableXLSX, format)
+
+	_, ok = TableFormatOf("jobs.xls")
+	assert.False(t, ok)
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// defaultTextSearchConfig 未配置中文分词时使用的全文检索配置，配合二元切分使用
+const defaultTextSearchConfig = "simple"
+
+// textSearchConfig 数据库中的中文全文检索配置(如基于 zhparser 创建的配置)，为空时使用二元切分
+var textSearchConfig string
+
+// SetTextSearchConfig 设置数据库中的中文全文检索配置，为空时回退到二元切分
+func SetTextSearchConfig(name string) {
+	textSearchConfig = strings.TrimSpace(name)
+}
+
+// TextSearchConfig 当前使用的全文检索配置名称
+func TextSearchConfig() string {
+	if textSearchConfig == "" {
+		return defaultTextSearchConfig
+	}
+	return textSearchConfig
+}
+
+// SearchText 将文本转换为写入 tsvector 或 tsquery 的内容
+// 配置了中文分词时原样交给数据库分词；否则英文和数字按单词小写，连续汉字切分为相邻的二元组
+func SearchText(text string) string {
+	if textSearchConfig != "" {
+		return text
+	}
+	return strings.Join(BigramTokens(text), " ")
+}
+
+// BigramTokens 二元切分：英文和数字按单词小写，连续汉字切分为相邻的两字词组，单个汉字保留
+func BigramTokens(text string) []string {
+	var tokens []string
+	var word []rune
+	var han []rune
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	flushHan := func() {
+		switch {
+		case len(han) == 1:
+			tokens = append(tokens, string(han))
+		case len(han) > 1:
+			for i := 0; i+1 < len(han); i++ {
+				tokens = append(tokens, string(han[i:i+2]))
+			}
+		}
+		han = han[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+	return tokens
+}
+
+// 高亮标记
+const (
+	highlightStart = "<em>"
+	highlightEnd   = "</em>"
+)
+
+// HighlightSnippet 截取文本中首个关键词附近最多 maxRunes 个字符，关键词用 <em> 标记，其余内容做 HTML 转义
+// 不区分大小写匹配，没有匹配的关键词时返回空字符串
+func HighlightSnippet(text string, keywords []string, maxRunes int) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, keyword := range keywords {
+		kw := []rune(strings.ToLower(strings.TrimSpace(keyword)))
+		if len(kw) == 0 {
+			continue
+		}
+		for i := 0; i+len(kw) <= len(lower); i++ {
+			if string(lower[i:i+len(kw)]) != string(kw) {
+				continue
+			}
+			for j := i; j < i+len(kw); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return ""
+	}
+
+	start, end := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		start = first - maxRunes/4
+		if start < 0 {
+			start = 0
+		}
+		end = start + maxRunes
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxRunes
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	open := false
+	for i := start; i < end; i++ {
+		if marked[i] && !open {
+			b.WriteString(highlightStart)
+			open = true
+		} else if !marked[i] && open {
+			b.WriteString(highlightEnd)
+			open = false
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+	}
+	if open {
+		b.WriteString(highlightEnd)
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
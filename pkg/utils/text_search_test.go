@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigramTokens(t *testing.T) {
+	assert.Equal(t, []string{"golang", "高级", "级开", "开发", "工程", "程师", "3", "年"}, BigramTokens("Golang高级开发 工程师,3年"))
+	assert.Empty(t, BigramTokens(" ,. "))
+}
+
+func TestSearchText(t *testing.T) {
+	defer SetTextSearchConfig("")
+
+	assert.Equal(t, "simple", TextSearchConfig())
+	assert.Equal(t, "go 开发", SearchText("Go开发"))
+
+	SetTextSearchConfig("chinese")
+	assert.Equal(t, "chinese", TextSearchConfig())
+	assert.Equal(t, "Go开发", SearchText("Go开发"))
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	assert.Equal(t, "熟悉<em>Go</em>语言与<em>go</em>-zero", HighlightSnippet("熟悉Go语言与go-zero", []string{"go"}, 0))
+	assert.Equal(t, "", HighlightSnippet("熟悉Java", []string{"go"}, 0))
+	assert.Equal(t, "<em>a&lt;b</em>", HighlightSnippet("a<b", []string{"a<b"}, 0))
+
+	long := strings.Repeat("甲", 50) + "分布式" + strings.Repeat("乙", 50)
+	snippet := HighlightSnippet(long, []string{"分布式"}, 20)
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "<em>分布式</em>")
+}